@@ -10,8 +10,10 @@ import (
 	"log"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/harper/gsuite-mcp/pkg/auth"
+	"github.com/harper/gsuite-mcp/pkg/config"
 	"github.com/harper/gsuite-mcp/pkg/gmail"
 	"github.com/harper/gsuite-mcp/pkg/server"
 )
@@ -35,6 +37,8 @@ func main() {
 		runTest()
 	case "whoami":
 		runWhoami()
+	case "send-scheduled":
+		runSendScheduled()
 	case "help", "--help", "-h":
 		printHelp()
 	case "version", "--version", "-v":
@@ -56,6 +60,7 @@ COMMANDS:
     setup       Interactive setup wizard (start here!)
     test        Test connection to Google APIs
     whoami      Show authenticated user info
+    send-scheduled  Send due drafts queued by gmail_schedule_send (e.g. from cron)
     mcp         Start the MCP server with stdio transport
     help        Show this help message
     version     Show version information
@@ -64,6 +69,9 @@ EXAMPLES:
     # Start the MCP server
     gsuite-mcp mcp
 
+    # Send due scheduled drafts every 5 minutes (crontab entry)
+    */5 * * * * /path/to/gsuite-mcp send-scheduled
+
     # Show help
     gsuite-mcp help
 
@@ -83,6 +91,48 @@ CONFIGURATION:
         2. $XDG_DATA_HOME/gsuite-mcp/token.json
         3. ~/.local/share/gsuite-mcp/token.json
 
+    Scheduled Sends Queue (checked in order):
+        1. GSUITE_MCP_SCHEDULE_PATH env var
+        2. $XDG_DATA_HOME/gsuite-mcp/scheduled_sends.json
+        3. ~/.local/share/gsuite-mcp/scheduled_sends.json
+
+        Gmail's API has no native scheduled send. gmail_schedule_send creates the
+        draft immediately; it is delivered only while 'gsuite-mcp mcp' is running
+        (checked every minute) or when 'gsuite-mcp send-scheduled' runs.
+
+    Config File (optional, checked in order):
+        1. GSUITE_MCP_CONFIG_PATH env var
+        2. $XDG_CONFIG_HOME/gsuite-mcp/config.toml
+        3. ~/.config/gsuite-mcp/config.toml
+
+        Keys: timezone, scopes, disabled_tools, log_level, delegate, from_name,
+              http_timeout, cache_ttl, recent_thread_contacts, templates_dir, work_start,
+              work_end, draft_only, audit_log, max_result_bytes, internal_domains, http_addr,
+              [retry] max_retries, base_delay,
+              [breaker] threshold, window, cooldown
+        A .json extension is parsed as JSON. Environment variables override file values:
+        GSUITE_MCP_TIMEZONE, GSUITE_MCP_SCOPES, GSUITE_MCP_DISABLED_TOOLS (comma-separated),
+        GSUITE_MCP_MAX_RETRIES, GSUITE_MCP_RETRY_BASE_DELAY, GSUITE_MCP_LOG_LEVEL,
+        GSUITE_MCP_BREAKER_THRESHOLD, GSUITE_MCP_BREAKER_WINDOW, GSUITE_MCP_BREAKER_COOLDOWN
+        (fail fast after repeated 429/5xx, default 5 within 1m, for 30s),
+        GSUITE_MCP_DELEGATE (Gmail mailbox to act on; requires domain-wide delegation),
+        GSUITE_MCP_FROM_NAME (display name on outgoing mail; default: the account's),
+        GSUITE_MCP_HTTP_TIMEOUT (per-request API timeout, default 30s, 0 = none),
+        GSUITE_MCP_CACHE_TTL (reuse of label and calendar lookups, default 10m, 0 = none),
+        GSUITE_MCP_RECENT_THREAD_CONTACTS, GSUITE_MCP_TEMPLATES_DIR,
+        GSUITE_MCP_WORK_START, GSUITE_MCP_WORK_END (working day as HH:MM, default 09:00-17:00),
+        GSUITE_MCP_DRAFT_ONLY (true removes the send tools; mail can only be drafted),
+        GSUITE_MCP_AUDIT_LOG (JSON lines file recording every mutating tool call),
+        GSUITE_MCP_MAX_RESULT_BYTES (trim larger tool results, flagging omitted items; 0 = no limit),
+        GSUITE_MCP_INTERNAL_DOMAINS (comma-separated; sends elsewhere need allow_external),
+        GSUITE_MCP_HTTP_ADDR (host:port; serves MCP over HTTP at /mcp with /healthz and
+        /readyz probes instead of stdio)
+
+    Rate Limiting:
+        GSUITE_MCP_MAX_CONCURRENCY  Max simultaneous API-backed calls (default: 4, 0 = unlimited)
+        GSUITE_MCP_RATE_LIMIT       Max calls started per second (default: 10, 0 = unlimited)
+        The auth_* tools and gsuite_selftest are not queued.
+
     Testing Mode (ish):
         Set environment variables:
             ISH_MODE=true
@@ -102,9 +152,9 @@ MCP CLIENT CONFIGURATION:
     }
 
 FEATURES:
-    • 19 MCP tools for Gmail, Calendar, and Contacts
-    • 8 MCP prompts for common workflows
-    • 8 MCP resources for dynamic data access
+    • MCP tools for Gmail, Calendar, and Contacts
+    • MCP prompts for common workflows
+    • MCP resources for dynamic data access
     • Automatic retry logic with exponential backoff
     • OAuth 2.0 authentication
 
@@ -115,15 +165,27 @@ DOCUMENTATION:
 	fmt.Println(help)
 }
 
+// loadConfig reads the config file and env overrides, exiting on invalid settings
+func loadConfig() *config.Config {
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+	return cfg
+}
+
 func startMCPServer() {
 	ctx := context.Background()
+	cfg := loadConfig()
 
-	srv, err := server.NewServer(ctx)
+	srv, err := server.NewServer(ctx, cfg)
 	if err != nil {
 		log.Fatalf("Failed to create server: %v", err)
 	}
 
-	log.Println("GSuite MCP Server starting...")
+	if level := strings.ToLower(cfg.LogLevel); level != "warn" && level != "error" {
+		log.Println("GSuite MCP Server starting...")
+	}
 
 	if err := srv.Serve(ctx); err != nil {
 		log.Fatalf("Server error: %v", err)
@@ -222,7 +284,7 @@ func runSetup() {
 			fmt.Println()
 
 			ctx := context.Background()
-			authenticator, err := auth.NewAuthenticator(credPath, tokenPath)
+			authenticator, err := auth.NewAuthenticator(credPath, tokenPath, loadConfig().Scopes...)
 			if err != nil {
 				fmt.Printf("Error: %v\n", err)
 				os.Exit(1)
@@ -293,7 +355,7 @@ func runTest() {
 
 	// Try to authenticate
 	ctx := context.Background()
-	authenticator, err := auth.NewAuthenticator(credPath, tokenPath)
+	authenticator, err := auth.NewAuthenticator(credPath, tokenPath, loadConfig().Scopes...)
 	if err != nil {
 		fmt.Printf("[FAIL] Could not load credentials: %v\n", err)
 		os.Exit(1)
@@ -340,7 +402,7 @@ func runWhoami() {
 	}
 
 	ctx := context.Background()
-	authenticator, err := auth.NewAuthenticator(credPath, tokenPath)
+	authenticator, err := auth.NewAuthenticator(credPath, tokenPath, loadConfig().Scopes...)
 	if err != nil {
 		fmt.Printf("Error: %v\n", err)
 		os.Exit(1)
@@ -368,3 +430,70 @@ func runWhoami() {
 	fmt.Printf("Messages: %d total\n", profile.MessagesTotal)
 	fmt.Printf("Threads:  %d total\n", profile.ThreadsTotal)
 }
+
+func runSendScheduled() {
+	credPath := auth.GetCredentialsPath()
+	tokenPath := auth.GetTokenPath()
+
+	if !fileExists(tokenPath) {
+		fmt.Println("Not authenticated. Run 'gsuite-mcp setup' to authenticate.")
+		os.Exit(1)
+	}
+
+	ctx := context.Background()
+	cfg := loadConfig()
+	if cfg.DraftOnly {
+		fmt.Println("Draft-only mode is on (draft_only); scheduled drafts stay in Drafts and are not sent.")
+		os.Exit(1)
+	}
+	authenticator, err := auth.NewAuthenticator(credPath, tokenPath, cfg.Scopes...)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	client, err := authenticator.GetClient(ctx)
+	if err != nil {
+		fmt.Printf("Authentication error: %v\n", err)
+		os.Exit(1)
+	}
+
+	svc, err := gmail.NewService(ctx, client)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	if err := svc.SetDelegate(cfg.Delegate); err != nil {
+		fmt.Printf("Invalid delegate: %v\n", err)
+		os.Exit(1)
+	}
+	if err := svc.SetFromName(cfg.FromName); err != nil {
+		fmt.Printf("Invalid from_name: %v\n", err)
+		os.Exit(1)
+	}
+
+	queue := gmail.NewScheduleQueue(auth.GetScheduledSendsPath())
+	results, err := queue.SendDue(ctx, svc, time.Now())
+	failed := 0
+	for _, result := range results {
+		switch {
+		case result.Error == "":
+			fmt.Printf("[OK]   Sent draft %s (message %s)\n", result.DraftID, result.MessageID)
+		case result.Dropped:
+			fmt.Printf("[DROP] Draft %s no longer exists: %s\n", result.DraftID, result.Error)
+		default:
+			failed++
+			fmt.Printf("[FAIL] Draft %s: %s (will retry)\n", result.DraftID, result.Error)
+		}
+	}
+	if err != nil {
+		fmt.Printf("Error updating %s: %v\n", queue.Path(), err)
+		os.Exit(1)
+	}
+	if len(results) == 0 {
+		fmt.Println("No scheduled sends are due.")
+	}
+	if failed > 0 {
+		os.Exit(1)
+	}
+}
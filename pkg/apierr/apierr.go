@@ -0,0 +1,70 @@
+// ABOUTME: Shared mapping of Google API errors to typed errors
+// ABOUTME: Turns 404/410 responses into NotFoundError carrying the resource kind and ID
+
+package apierr
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"google.golang.org/api/googleapi"
+)
+
+// ErrNotFound matches every NotFoundError with errors.Is
+var ErrNotFound = errors.New("not found")
+
+// NotFoundError reports that the requested resource doesn't exist
+type NotFoundError struct {
+	Kind string // Resource kind, e.g. "message", "event", "contact"
+	ID   string // ID or resource name that was requested
+	Err  error  // Underlying API error
+}
+
+func (e *NotFoundError) Error() string {
+	return fmt.Sprintf("%s %s not found", e.Kind, e.ID)
+}
+
+// Unwrap exposes the underlying API error
+func (e *NotFoundError) Unwrap() error {
+	return e.Err
+}
+
+// Is makes errors.Is(err, ErrNotFound) true for any NotFoundError
+func (e *NotFoundError) Is(target error) bool {
+	return target == ErrNotFound
+}
+
+// Wrap returns a *NotFoundError if err is a Google API 404 or 410 (Calendar
+// answers 410 Gone for deleted events); otherwise it wraps err as
+// "<action>: <err>" like the services' other errors
+func Wrap(err error, action, kind, id string) error {
+	if err == nil {
+		return nil
+	}
+	if isMissing(err) {
+		return &NotFoundError{Kind: kind, ID: id, Err: err}
+	}
+	return fmt.Errorf("%s: %w", action, err)
+}
+
+// IsNotFound reports whether err is a NotFoundError or an unmapped Google API 404/410
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrNotFound) || isMissing(err)
+}
+
+// IsConflict reports whether err is a Google API 409, e.g. from an insert
+// whose requested ID is already taken
+func IsConflict(err error) bool {
+	var apiErr *googleapi.Error
+	return errors.As(err, &apiErr) && apiErr.Code == http.StatusConflict
+}
+
+// isMissing reports whether err is a Google API 404 or 410
+func isMissing(err error) bool {
+	var apiErr *googleapi.Error
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	return apiErr.Code == http.StatusNotFound || apiErr.Code == http.StatusGone
+}
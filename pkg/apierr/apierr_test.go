@@ -0,0 +1,77 @@
+// ABOUTME: Tests for Google API error mapping
+// ABOUTME: Verifies 404/410 become NotFoundError, 409s are recognized, and other errors keep the service's wrapping
+
+package apierr
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/api/googleapi"
+)
+
+func TestWrap(t *testing.T) {
+	tests := []struct {
+		name         string
+		err          error
+		wantNotFound bool
+		wantMessage  string
+	}{
+		{
+			name:         "404 becomes not found",
+			err:          &googleapi.Error{Code: http.StatusNotFound, Message: "Requested entity was not found."},
+			wantNotFound: true,
+			wantMessage:  "message abc123 not found",
+		},
+		{
+			name:         "410 becomes not found",
+			err:          &googleapi.Error{Code: http.StatusGone, Message: "Resource has been deleted"},
+			wantNotFound: true,
+			wantMessage:  "message abc123 not found",
+		},
+		{
+			name:        "403 keeps service wrapping",
+			err:         &googleapi.Error{Code: http.StatusForbidden, Message: "Forbidden"},
+			wantMessage: "unable to get message: googleapi: Error 403: Forbidden",
+		},
+		{
+			name:        "non-API error keeps service wrapping",
+			err:         errors.New("connection reset"),
+			wantMessage: "unable to get message: connection reset",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Wrap(tt.err, "unable to get message", "message", "abc123")
+
+			assert.EqualError(t, err, tt.wantMessage)
+			assert.Equal(t, tt.wantNotFound, errors.Is(err, ErrNotFound))
+			assert.Equal(t, tt.wantNotFound, IsNotFound(err))
+			assert.ErrorIs(t, err, tt.err, "the API error stays reachable")
+
+			var nf *NotFoundError
+			if tt.wantNotFound {
+				assert.True(t, errors.As(err, &nf))
+				assert.Equal(t, "message", nf.Kind)
+				assert.Equal(t, "abc123", nf.ID)
+			}
+		})
+	}
+}
+
+func TestWrap_Nil(t *testing.T) {
+	assert.NoError(t, Wrap(nil, "unable to get message", "message", "abc123"))
+}
+
+func TestIsConflict(t *testing.T) {
+	conflict := &googleapi.Error{Code: http.StatusConflict, Message: "The requested identifier already exists."}
+	assert.True(t, IsConflict(conflict))
+	assert.True(t, IsConflict(fmt.Errorf("unable to create event: %w", conflict)), "wrapped errors are unwrapped")
+	assert.False(t, IsConflict(&googleapi.Error{Code: http.StatusNotFound}))
+	assert.False(t, IsConflict(errors.New("connection reset")))
+	assert.False(t, IsConflict(nil))
+}
@@ -0,0 +1,64 @@
+// ABOUTME: Detection of Google API errors caused by a token missing an OAuth scope
+// ABOUTME: Turns 403 insufficientPermissions responses into ScopeError naming the scope to grant
+
+package apierr
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"google.golang.org/api/googleapi"
+)
+
+// ErrInsufficientScope matches every ScopeError with errors.Is
+var ErrInsufficientScope = errors.New("insufficient OAuth scope")
+
+// ScopeError reports that the token lacks the scope an operation needs
+type ScopeError struct {
+	Scope string // OAuth scope the operation requires
+	Err   error  // Underlying API error
+}
+
+func (e *ScopeError) Error() string {
+	return fmt.Sprintf("this operation requires the %s scope; re-run auth_init with expanded scopes", e.Scope)
+}
+
+func (e *ScopeError) Unwrap() error {
+	return e.Err
+}
+
+// Is lets errors.Is(err, ErrInsufficientScope) match any ScopeError
+func (e *ScopeError) Is(target error) bool {
+	return target == ErrInsufficientScope
+}
+
+// ClassifyScopeError returns a ScopeError naming scope when err is a 403 caused
+// by the token's scopes, and err unchanged otherwise
+func ClassifyScopeError(err error, scope string) error {
+	if !isInsufficientScope(err) {
+		return err
+	}
+	return &ScopeError{Scope: scope, Err: err}
+}
+
+// isInsufficientScope reports whether err is a 403 whose reason is insufficientPermissions.
+// Newer APIs report it as an ACCESS_TOKEN_SCOPE_INSUFFICIENT detail instead.
+func isInsufficientScope(err error) bool {
+	var apiErr *googleapi.Error
+	if !errors.As(err, &apiErr) || apiErr.Code != http.StatusForbidden {
+		return false
+	}
+	for _, item := range apiErr.Errors {
+		if item.Reason == "insufficientPermissions" {
+			return true
+		}
+	}
+	for _, detail := range apiErr.Details {
+		if info, ok := detail.(map[string]interface{}); ok && info["reason"] == "ACCESS_TOKEN_SCOPE_INSUFFICIENT" {
+			return true
+		}
+	}
+	return strings.Contains(apiErr.Message, "insufficient authentication scopes")
+}
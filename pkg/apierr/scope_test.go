@@ -0,0 +1,74 @@
+// ABOUTME: Tests for insufficient-scope error detection
+// ABOUTME: Verifies 403 insufficientPermissions maps to guidance naming the missing scope
+
+package apierr
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/api/googleapi"
+)
+
+func TestClassifyScopeError(t *testing.T) {
+	const scope = "https://www.googleapis.com/auth/gmail.modify"
+
+	tests := []struct {
+		name      string
+		err       error
+		wantScope bool
+	}{
+		{
+			name: "insufficientPermissions reason",
+			err: &googleapi.Error{
+				Code:    http.StatusForbidden,
+				Message: "Request had insufficient authentication scopes.",
+				Errors:  []googleapi.ErrorItem{{Reason: "insufficientPermissions", Message: "Insufficient Permission"}},
+			},
+			wantScope: true,
+		},
+		{
+			name: "ACCESS_TOKEN_SCOPE_INSUFFICIENT detail",
+			err: &googleapi.Error{
+				Code:    http.StatusForbidden,
+				Message: "Permission denied",
+				Details: []interface{}{map[string]interface{}{"reason": "ACCESS_TOKEN_SCOPE_INSUFFICIENT"}},
+			},
+			wantScope: true,
+		},
+		{
+			name: "other 403 is unchanged",
+			err: &googleapi.Error{
+				Code:    http.StatusForbidden,
+				Message: "Rate Limit Exceeded",
+				Errors:  []googleapi.ErrorItem{{Reason: "rateLimitExceeded"}},
+			},
+		},
+		{
+			name: "401 is unchanged",
+			err:  &googleapi.Error{Code: http.StatusUnauthorized, Message: "Invalid Credentials"},
+		},
+		{
+			name: "non-API error is unchanged",
+			err:  errors.New("connection reset"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ClassifyScopeError(tt.err, scope)
+
+			assert.ErrorIs(t, err, tt.err, "the API error stays reachable")
+			assert.Equal(t, tt.wantScope, errors.Is(err, ErrInsufficientScope))
+			if tt.wantScope {
+				assert.EqualError(t, err, "this operation requires the "+scope+" scope; re-run auth_init with expanded scopes")
+			} else {
+				assert.Same(t, tt.err, err)
+			}
+		})
+	}
+
+	assert.Nil(t, ClassifyScopeError(nil, scope))
+}
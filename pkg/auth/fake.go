@@ -1,26 +1,164 @@
 // ABOUTME: Fake authentication for ish mode testing
-// ABOUTME: Provides Bearer token auth without real OAuth
+// ABOUTME: Provides Bearer token auth without real OAuth and records the MIME of mail sent or drafted
 
 package auth
 
 import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
 	"net/http"
 	"os"
+	"strings"
+	"sync"
 )
 
+// maxRecordedSends bounds how many raw messages a SentRecorder keeps
+const maxRecordedSends = 50
+
 // fakeTransport adds Bearer token authentication to requests
 type fakeTransport struct {
 	token string
 	base  http.RoundTripper
+	sent  *SentRecorder
 }
 
 func (t *fakeTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", t.token))
+	if t.sent != nil && isSendRequest(req) && req.Body != nil {
+		body, err := io.ReadAll(req.Body)
+		_ = req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+		if raw, ok := extractRaw(req.Header.Get("Content-Type"), body); ok {
+			t.sent.record(raw)
+		}
+	}
 	return t.base.RoundTrip(req)
 }
 
-// NewFakeClient creates an HTTP client with fake Bearer token auth
+// SentRecorder holds the decoded RFC 822 messages passed to messages.send and
+// drafts.create through a fake client, so tests can assert on the exact MIME
+type SentRecorder struct {
+	mu   sync.Mutex
+	raws []string
+}
+
+// LastSentRaw returns the most recently recorded message, or "" if none
+func (r *SentRecorder) LastSentRaw() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.raws) == 0 {
+		return ""
+	}
+	return r.raws[len(r.raws)-1]
+}
+
+// SentRaws returns the recorded messages, oldest first
+func (r *SentRecorder) SentRaws() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.raws...)
+}
+
+// Reset discards every recorded message
+func (r *SentRecorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.raws = nil
+}
+
+func (r *SentRecorder) record(raw string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.raws = append(r.raws, raw)
+	if len(r.raws) > maxRecordedSends {
+		r.raws = r.raws[len(r.raws)-maxRecordedSends:]
+	}
+}
+
+// SentMessages returns the recorder of a client made by NewFakeClient, or nil
+// for any other client
+func SentMessages(client *http.Client) *SentRecorder {
+	if client == nil {
+		return nil
+	}
+	if t, ok := client.Transport.(*fakeTransport); ok {
+		return t.sent
+	}
+	return nil
+}
+
+// isSendRequest reports whether req is a Gmail messages.send or drafts.create
+// call, including their media upload forms
+func isSendRequest(req *http.Request) bool {
+	if req.Method != http.MethodPost {
+		return false
+	}
+	path := strings.TrimSuffix(req.URL.Path, "/")
+	return strings.HasSuffix(path, "/messages/send") || strings.HasSuffix(path, "/drafts")
+}
+
+// extractRaw pulls the RFC 822 message out of a send or create request body.
+// JSON bodies carry it base64url-encoded in raw (or message.raw for drafts);
+// multipart/related media uploads carry it verbatim in the non-JSON part.
+func extractRaw(contentType string, body []byte) (string, bool) {
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err == nil && strings.HasPrefix(mediaType, "multipart/") {
+		reader := multipart.NewReader(bytes.NewReader(body), params["boundary"])
+		for {
+			part, err := reader.NextPart()
+			if err != nil {
+				return "", false
+			}
+			data, err := io.ReadAll(part)
+			if err != nil {
+				return "", false
+			}
+			partType, _, _ := mime.ParseMediaType(part.Header.Get("Content-Type"))
+			if partType == "application/json" {
+				if raw, ok := extractRaw(partType, data); ok {
+					return raw, true
+				}
+				continue
+			}
+			return string(data), true
+		}
+	}
+
+	var payload struct {
+		Raw     string `json:"raw"`
+		Message *struct {
+			Raw string `json:"raw"`
+		} `json:"message"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return "", false
+	}
+	encoded := payload.Raw
+	if encoded == "" && payload.Message != nil {
+		encoded = payload.Message.Raw
+	}
+	if encoded == "" {
+		return "", false
+	}
+	// Gmail accepts padded and unpadded base64url
+	for _, enc := range []*base64.Encoding{base64.URLEncoding, base64.RawURLEncoding} {
+		if decoded, err := enc.DecodeString(encoded); err == nil {
+			return string(decoded), true
+		}
+	}
+	return "", false
+}
+
+// NewFakeClient creates an HTTP client with fake Bearer token auth. Messages
+// it sends or drafts are recorded; see SentMessages.
 func NewFakeClient(user string) *http.Client {
 	if user == "" {
 		user = os.Getenv("ISH_USER")
@@ -35,6 +173,7 @@ func NewFakeClient(user string) *http.Client {
 		Transport: &fakeTransport{
 			token: token,
 			base:  http.DefaultTransport,
+			sent:  &SentRecorder{},
 		},
 	}
 }
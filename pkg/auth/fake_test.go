@@ -1,13 +1,21 @@
 // ABOUTME: Tests for fake credentials (ish mode)
-// ABOUTME: Validates Bearer token authentication for testing
+// ABOUTME: Validates Bearer token authentication and recording of sent MIME for testing
 
 package auth
 
 import (
+	"bytes"
+	"encoding/base64"
+	"io"
+	"mime/multipart"
 	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestFakeClient_WithUser(t *testing.T) {
@@ -87,3 +95,49 @@ func TestFakeClient_SpecialCharacters(t *testing.T) {
 		})
 	}
 }
+
+func TestFakeClient_RecordsSentRaw(t *testing.T) {
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		assert.NotEmpty(t, body, "the body still reaches the server")
+		_, _ = w.Write([]byte(`{"id": "m1"}`))
+	}))
+	defer api.Close()
+
+	client := NewFakeClient("")
+	sent := SentMessages(client)
+	require.NotNil(t, sent)
+	assert.Equal(t, "", sent.LastSentRaw())
+
+	post := func(path, contentType, body string) {
+		resp, err := client.Post(api.URL+path, contentType, strings.NewReader(body))
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+	}
+	encode := func(raw string) string { return base64.URLEncoding.EncodeToString([]byte(raw)) }
+
+	post("/gmail/v1/users/me/messages/send", "application/json", `{"raw": "`+encode("Subject: one\r\n\r\nfirst")+`"}`)
+	assert.Equal(t, "Subject: one\r\n\r\nfirst", sent.LastSentRaw())
+
+	post("/gmail/v1/users/me/drafts", "application/json", `{"message": {"raw": "`+base64.RawURLEncoding.EncodeToString([]byte("Subject: two"))+`"}}`)
+	assert.Equal(t, "Subject: two", sent.LastSentRaw())
+
+	// Media uploads send the message verbatim in a multipart/related body
+	var upload bytes.Buffer
+	mw := multipart.NewWriter(&upload)
+	meta, _ := mw.CreatePart(textproto.MIMEHeader{"Content-Type": {"application/json"}})
+	_, _ = meta.Write([]byte(`{"threadId": "t1"}`))
+	media, _ := mw.CreatePart(textproto.MIMEHeader{"Content-Type": {"message/rfc822"}})
+	_, _ = media.Write([]byte("Subject: three\r\n\r\nuploaded"))
+	_ = mw.Close()
+	post("/upload/gmail/v1/users/me/messages/send", "multipart/related; boundary="+mw.Boundary(), upload.String())
+	assert.Equal(t, "Subject: three\r\n\r\nuploaded", sent.LastSentRaw())
+
+	// Other calls aren't recorded
+	post("/gmail/v1/users/me/messages/m1/modify", "application/json", `{"raw": "`+encode("ignored")+`"}`)
+	assert.Len(t, sent.SentRaws(), 3)
+
+	sent.Reset()
+	assert.Empty(t, sent.SentRaws())
+	assert.Nil(t, SentMessages(http.DefaultClient))
+}
@@ -26,6 +26,7 @@ import (
 var DefaultScopes = []string{
 	gmail.GmailModifyScope,
 	gmail.GmailLabelsScope,
+	gmail.GmailSettingsBasicScope,
 	calendar.CalendarScope,
 	people.ContactsScope,
 }
@@ -38,7 +39,8 @@ type Authenticator struct {
 }
 
 // NewAuthenticator creates a new OAuth authenticator
-func NewAuthenticator(credentialsPath, tokenPath string) (*Authenticator, error) {
+// Scopes default to DefaultScopes when none are given.
+func NewAuthenticator(credentialsPath, tokenPath string, scopes ...string) (*Authenticator, error) {
 	// Check if credentials file exists
 	if _, err := os.Stat(credentialsPath); os.IsNotExist(err) {
 		return nil, fmt.Errorf("credentials.json not found at %s. Download from Google Cloud Console", credentialsPath)
@@ -51,7 +53,10 @@ func NewAuthenticator(credentialsPath, tokenPath string) (*Authenticator, error)
 	}
 
 	// Parse credentials
-	config, err := google.ConfigFromJSON(data, DefaultScopes...)
+	if len(scopes) == 0 {
+		scopes = DefaultScopes
+	}
+	config, err := google.ConfigFromJSON(data, scopes...)
 	if err != nil {
 		return nil, fmt.Errorf("unable to parse credentials: %w", err)
 	}
@@ -84,7 +89,15 @@ func (a *Authenticator) GetClient(ctx context.Context) (*http.Client, error) {
 	return oauth2.NewClient(ctx, persistentSource), nil
 }
 
+// storedToken is the on-disk token format. oauth2.Token drops its extra fields
+// when marshaled, so the granted scope string is persisted alongside it.
+type storedToken struct {
+	*oauth2.Token
+	Scope string `json:"scope,omitempty"`
+}
+
 // loadToken loads a cached token from disk
+// A persisted scope is reattached so token.Extra("scope") works after reload.
 func (a *Authenticator) loadToken() (token *oauth2.Token, err error) {
 	f, err := os.Open(a.tokenPath)
 	if err != nil {
@@ -96,9 +109,25 @@ func (a *Authenticator) loadToken() (token *oauth2.Token, err error) {
 		}
 	}()
 
-	token = &oauth2.Token{}
-	err = json.NewDecoder(f).Decode(token)
-	return token, err
+	stored := storedToken{Token: &oauth2.Token{}}
+	if err = json.NewDecoder(f).Decode(&stored); err != nil {
+		return stored.Token, err
+	}
+	if stored.Scope != "" {
+		return stored.Token.WithExtra(map[string]interface{}{"scope": stored.Scope}), nil
+	}
+	return stored.Token, nil
+}
+
+// tokenScope returns the space-separated scope string carried by a token
+func tokenScope(token *oauth2.Token) string {
+	scope, _ := token.Extra("scope").(string)
+	return scope
+}
+
+// ParseScopes splits a space-separated OAuth scope string
+func ParseScopes(scope string) []string {
+	return strings.Fields(scope)
 }
 
 // saveToken saves a token to disk using atomic write (write to temp, then rename).
@@ -137,7 +166,15 @@ func (a *Authenticator) saveToken(token *oauth2.Token) error {
 		return fmt.Errorf("failed to set temp file permissions: %w", err)
 	}
 
-	if err := json.NewEncoder(tmpFile).Encode(token); err != nil {
+	// Refresh responses may omit scope; keep what the original exchange granted
+	scope := tokenScope(token)
+	if scope == "" {
+		if previous, err := a.loadToken(); err == nil {
+			scope = tokenScope(previous)
+		}
+	}
+
+	if err := json.NewEncoder(tmpFile).Encode(storedToken{Token: token, Scope: scope}); err != nil {
 		_ = tmpFile.Close()
 		return fmt.Errorf("failed to encode token: %w", err)
 	}
@@ -260,6 +297,7 @@ type TokenInfo struct {
 	Expiry      time.Time     `json:"expiry"`
 	ExpiresIn   time.Duration `json:"expires_in"`
 	HasRefresh  bool          `json:"has_refresh"`
+	Scopes      []string      `json:"scopes,omitempty"` // Granted scopes recorded at exchange time
 }
 
 // TokenInfo returns metadata about the cached token without making API calls.
@@ -275,6 +313,7 @@ func (a *Authenticator) TokenInfo() (*TokenInfo, error) {
 		AccessToken: maskToken(token.AccessToken),
 		Expiry:      token.Expiry,
 		HasRefresh:  token.RefreshToken != "",
+		Scopes:      ParseScopes(tokenScope(token)),
 	}
 
 	if !token.Expiry.IsZero() {
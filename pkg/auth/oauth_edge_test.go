@@ -617,3 +617,66 @@ func createValidCredentialsFile(t *testing.T, dir string) string {
 
 	return credPath
 }
+
+func TestTokenInfo_ParsesScopes(t *testing.T) {
+	tmpDir := t.TempDir()
+	tokenPath := filepath.Join(tmpDir, "token.json")
+	credPath := createValidCredentialsFile(t, tmpDir)
+
+	data := `{"access_token":"ya29.scoped-token","expiry":"` + time.Now().Add(time.Hour).Format(time.RFC3339) + `",` +
+		`"scope":"https://www.googleapis.com/auth/gmail.readonly https://www.googleapis.com/auth/calendar"}`
+	require.NoError(t, os.WriteFile(tokenPath, []byte(data), 0600))
+
+	auth, err := NewAuthenticator(credPath, tokenPath)
+	require.NoError(t, err)
+
+	info, err := auth.TokenInfo()
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"https://www.googleapis.com/auth/gmail.readonly",
+		"https://www.googleapis.com/auth/calendar",
+	}, info.Scopes)
+}
+
+func TestSaveToken_PersistsScope(t *testing.T) {
+	tmpDir := t.TempDir()
+	tokenPath := filepath.Join(tmpDir, "token.json")
+	credPath := createValidCredentialsFile(t, tmpDir)
+
+	auth, err := NewAuthenticator(credPath, tokenPath)
+	require.NoError(t, err)
+
+	// Token as returned by the code exchange, carrying scope in its extras
+	exchanged := (&oauth2.Token{AccessToken: "first", Expiry: time.Now().Add(time.Hour)}).
+		WithExtra(map[string]interface{}{"scope": "https://www.googleapis.com/auth/gmail.modify"})
+	require.NoError(t, auth.saveToken(exchanged))
+
+	info, err := auth.TokenInfo()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"https://www.googleapis.com/auth/gmail.modify"}, info.Scopes)
+
+	// A refreshed token without scope keeps the previously granted scopes
+	require.NoError(t, auth.saveToken(&oauth2.Token{AccessToken: "second", Expiry: time.Now().Add(time.Hour)}))
+
+	info, err = auth.TokenInfo()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"https://www.googleapis.com/auth/gmail.modify"}, info.Scopes)
+}
+
+func TestTokenInfo_NoScopeRecorded(t *testing.T) {
+	tmpDir := t.TempDir()
+	tokenPath := filepath.Join(tmpDir, "token.json")
+	credPath := createValidCredentialsFile(t, tmpDir)
+
+	data, err := json.Marshal(&oauth2.Token{AccessToken: "legacy", Expiry: time.Now().Add(time.Hour)})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(tokenPath, data, 0600))
+
+	auth, err := NewAuthenticator(credPath, tokenPath)
+	require.NoError(t, err)
+
+	info, err := auth.TokenInfo()
+	require.NoError(t, err)
+	assert.Empty(t, info.Scopes)
+}
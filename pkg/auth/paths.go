@@ -12,6 +12,7 @@ const (
 	appName             = "gsuite-mcp"
 	defaultCredentials  = "credentials.json"
 	defaultToken        = "token.json"
+	defaultScheduled    = "scheduled_sends.json"
 	configSubdir        = ".config"
 	dataSubdir          = ".local/share"
 )
@@ -62,6 +63,26 @@ func GetTokenPath() string {
 	return filepath.Clean(filepath.Join(dataHome, appName, defaultToken))
 }
 
+// GetScheduledSendsPath returns the path to the scheduled-send queue file
+// Priority: GSUITE_MCP_SCHEDULE_PATH > XDG_DATA_HOME > ~/.local/share
+// The queue is per-user state like token.json, so it lives in the data dir.
+func GetScheduledSendsPath() string {
+	if override := os.Getenv("GSUITE_MCP_SCHEDULE_PATH"); override != "" {
+		return filepath.Clean(override)
+	}
+
+	dataHome := os.Getenv("XDG_DATA_HOME")
+	if dataHome == "" || !filepath.IsAbs(dataHome) {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return defaultScheduled // fallback to cwd
+		}
+		dataHome = filepath.Join(home, dataSubdir)
+	}
+
+	return filepath.Clean(filepath.Join(dataHome, appName, defaultScheduled))
+}
+
 // EnsureDir creates the parent directory for a file path if it doesn't exist.
 // Directories are created with 0700 permissions (owner read/write/execute only).
 func EnsureDir(filePath string) error {
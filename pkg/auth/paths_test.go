@@ -121,6 +121,27 @@ func TestGetTokenPath(t *testing.T) {
 	}
 }
 
+func TestGetScheduledSendsPath(t *testing.T) {
+	t.Run("explicit override takes priority", func(t *testing.T) {
+		t.Setenv("GSUITE_MCP_SCHEDULE_PATH", "/custom/queue.json")
+		t.Setenv("XDG_DATA_HOME", "/should/be/ignored")
+
+		if got := GetScheduledSendsPath(); got != "/custom/queue.json" {
+			t.Errorf("GetScheduledSendsPath() = %q, want %q", got, "/custom/queue.json")
+		}
+	})
+
+	t.Run("XDG_DATA_HOME when set", func(t *testing.T) {
+		t.Setenv("GSUITE_MCP_SCHEDULE_PATH", "")
+		t.Setenv("XDG_DATA_HOME", "/tmp/xdg-data")
+
+		want := "/tmp/xdg-data/gsuite-mcp/scheduled_sends.json"
+		if got := GetScheduledSendsPath(); got != want {
+			t.Errorf("GetScheduledSendsPath() = %q, want %q", got, want)
+		}
+	})
+}
+
 func TestEnsureDir(t *testing.T) {
 	tmpDir := t.TempDir()
 
@@ -0,0 +1,80 @@
+// ABOUTME: HTML agenda for a day's events, suitable for an email body
+// ABOUTME: Lists each event's time range, summary, and location in the configured timezone
+
+package calendar
+
+import (
+	"fmt"
+	"html"
+	"sort"
+	"strings"
+	"time"
+
+	"google.golang.org/api/calendar/v3"
+)
+
+// agendaTimeLayout formats event times in the agenda, e.g. "9:30 AM"
+const agendaTimeLayout = "3:04 PM"
+
+// AgendaHTML renders day's events as an HTML document with one table row per
+// event, all-day events first, then timed events by start. Times are shown
+// in loc; cancelled events are left out.
+func AgendaHTML(day time.Time, events []*calendar.Event, loc *time.Location) string {
+	type agendaItem struct {
+		start    time.Time
+		allDay   bool
+		when     string
+		summary  string
+		location string
+	}
+
+	var items []agendaItem
+	for _, event := range events {
+		if event == nil || event.Status == "cancelled" {
+			continue
+		}
+		start, err := reminderStart(event.Start, loc)
+		if err != nil {
+			continue
+		}
+		item := agendaItem{start: start, summary: event.Summary, location: event.Location}
+		if item.summary == "" {
+			item.summary = "(No title)"
+		}
+		if event.Start.DateTime == "" {
+			item.allDay = true
+			item.when = "All day"
+		} else if end, err := reminderStart(event.End, loc); err == nil {
+			item.when = start.Format(agendaTimeLayout) + " – " + end.Format(agendaTimeLayout)
+		} else {
+			item.when = start.Format(agendaTimeLayout)
+		}
+		items = append(items, item)
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		if items[i].allDay != items[j].allDay {
+			return items[i].allDay
+		}
+		return items[i].start.Before(items[j].start)
+	})
+
+	var b strings.Builder
+	title := "Agenda for " + day.In(loc).Format("Monday, January 2, 2006")
+	fmt.Fprintf(&b, "<html><body>\n<h2>%s</h2>\n", html.EscapeString(title))
+	if len(items) == 0 {
+		b.WriteString("<p>No events scheduled.</p>\n")
+	} else {
+		b.WriteString("<table cellpadding=\"6\" style=\"border-collapse:collapse\">\n")
+		for _, item := range items {
+			fmt.Fprintf(&b, "<tr><td style=\"white-space:nowrap;vertical-align:top\"><b>%s</b></td><td>%s",
+				html.EscapeString(item.when), html.EscapeString(item.summary))
+			if item.location != "" {
+				fmt.Fprintf(&b, "<br><small>%s</small>", html.EscapeString(item.location))
+			}
+			b.WriteString("</td></tr>\n")
+		}
+		b.WriteString("</table>\n")
+	}
+	fmt.Fprintf(&b, "<p><small>Times are in %s.</small></p>\n</body></html>", html.EscapeString(loc.String()))
+	return b.String()
+}
@@ -0,0 +1,47 @@
+// ABOUTME: Tests for the HTML agenda
+// ABOUTME: Checks ordering, time formatting in the configured zone, escaping, and the empty day
+
+package calendar
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/calendar/v3"
+)
+
+func TestAgendaHTML(t *testing.T) {
+	loc, err := time.LoadLocation("America/Chicago")
+	require.NoError(t, err)
+	day := time.Date(2025, 1, 6, 0, 0, 0, 0, loc)
+
+	review := timedEvent("2025-01-06T20:00:00Z", "2025-01-06T21:00:00Z")
+	review.Summary = "Budget review"
+	review.Location = "Room <4>"
+	standup := timedEvent("2025-01-06T15:00:00Z", "2025-01-06T15:15:00Z")
+	standup.Summary = "Standup"
+	holiday := &calendar.Event{
+		Summary: "Team offsite",
+		Start:   &calendar.EventDateTime{Date: "2025-01-06"},
+		End:     &calendar.EventDateTime{Date: "2025-01-07"},
+	}
+	cancelled := timedEvent("2025-01-06T16:00:00Z", "2025-01-06T17:00:00Z")
+	cancelled.Summary = "Cancelled sync"
+	cancelled.Status = "cancelled"
+
+	got := AgendaHTML(day, []*calendar.Event{review, standup, holiday, cancelled}, loc)
+
+	assert.Contains(t, got, "<h2>Agenda for Monday, January 6, 2025</h2>")
+	assert.Contains(t, got, "<b>9:00 AM – 9:15 AM</b></td><td>Standup</td>")
+	assert.Contains(t, got, "<b>2:00 PM – 3:00 PM</b></td><td>Budget review<br><small>Room &lt;4&gt;</small></td>")
+	assert.Contains(t, got, "<b>All day</b></td><td>Team offsite</td>")
+	assert.NotContains(t, got, "Cancelled sync")
+	assert.Less(t, strings.Index(got, "Team offsite"), strings.Index(got, "Standup"), "all-day events come first")
+	assert.Less(t, strings.Index(got, "Standup"), strings.Index(got, "Budget review"))
+	assert.Contains(t, got, "Times are in America/Chicago.")
+
+	assert.Contains(t, AgendaHTML(day, nil, loc), "<p>No events scheduled.</p>")
+}
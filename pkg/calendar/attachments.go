@@ -0,0 +1,41 @@
+// ABOUTME: Listing of the files attached to calendar events
+// ABOUTME: Flattens Drive and other attachments to title, URL, and MIME type
+
+package calendar
+
+import (
+	"context"
+
+	"google.golang.org/api/calendar/v3"
+)
+
+// EventAttachment is a file attached to an event, usually a Drive document
+type EventAttachment struct {
+	Title    string `json:"title"`
+	FileURL  string `json:"file_url"`
+	MimeType string `json:"mime_type,omitempty"`
+	FileID   string `json:"file_id,omitempty"` // Drive file ID, for Drive attachments
+}
+
+// ListEventAttachments returns the attachments on an event, or an empty list if it has none
+func (s *Service) ListEventAttachments(ctx context.Context, eventID string) ([]EventAttachment, error) {
+	event, err := s.GetEvent(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	return Attachments(event), nil
+}
+
+// Attachments flattens an event's attachments, never returning nil
+func Attachments(event *calendar.Event) []EventAttachment {
+	result := make([]EventAttachment, 0, len(event.Attachments))
+	for _, a := range event.Attachments {
+		result = append(result, EventAttachment{
+			Title:    a.Title,
+			FileURL:  a.FileUrl,
+			MimeType: a.MimeType,
+			FileID:   a.FileId,
+		})
+	}
+	return result
+}
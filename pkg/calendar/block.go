@@ -0,0 +1,74 @@
+// ABOUTME: Whole-day time blocks such as vacations
+// ABOUTME: Converts an inclusive date range to the exclusive end the Calendar API expects
+
+package calendar
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harper/gsuite-mcp/pkg/apierr"
+	"google.golang.org/api/calendar/v3"
+)
+
+// maxBlockDays bounds a block so a mistyped year doesn't fill the calendar
+const maxBlockDays = 366
+
+// BlockRange returns midnight on firstDay and midnight after lastDay in loc.
+// lastDay is inclusive, as people say "Monday to Friday"; the returned end is
+// exclusive, as the API wants, so a Mon-Fri block ends on Saturday.
+func BlockRange(firstDay, lastDay time.Time, loc *time.Location) (start, end time.Time, err error) {
+	y, m, d := firstDay.Date()
+	start = time.Date(y, m, d, 0, 0, 0, 0, loc)
+	y, m, d = lastDay.Date()
+	end = time.Date(y, m, d+1, 0, 0, 0, 0, loc)
+
+	if !end.After(start) {
+		return time.Time{}, time.Time{}, fmt.Errorf("end_date %s is before start_date %s", lastDay.Format("2006-01-02"), firstDay.Format("2006-01-02"))
+	}
+	if days := start.AddDate(0, 0, maxBlockDays); end.After(days) {
+		return time.Time{}, time.Time{}, fmt.Errorf("a block can span at most %d days", maxBlockDays)
+	}
+	return start, end, nil
+}
+
+// BlockTime creates an event covering firstDay through lastDay inclusive, as
+// calendar days in loc. A regular block is an all-day event shown as busy. An
+// out-of-office block declines conflicting invitations with declineMessage; the
+// API rejects all-day outOfOffice events, so it runs from midnight to midnight.
+func (s *Service) BlockTime(ctx context.Context, summary string, firstDay, lastDay time.Time, loc *time.Location, outOfOffice bool, declineMessage string) (*calendar.Event, error) {
+	start, end, err := BlockRange(firstDay, lastDay, loc)
+	if err != nil {
+		return nil, err
+	}
+
+	if outOfOffice {
+		return s.CreateEvent(ctx, summary, "", start, end, nil, nil, SendUpdatesNone, &EventOptions{
+			EventType:      EventTypeOutOfOffice,
+			DeclineMessage: declineMessage,
+		})
+	}
+
+	if err := ValidateEventText(summary, ""); err != nil {
+		return nil, err
+	}
+	event := &calendar.Event{
+		Summary:      summary,
+		Start:        &calendar.EventDateTime{Date: start.Format("2006-01-02")},
+		End:          &calendar.EventDateTime{Date: end.Format("2006-01-02")},
+		Transparency: "opaque", // All-day events otherwise show as free
+	}
+
+	var created *calendar.Event
+	err = s.breaker.Do(func() error {
+		var err error
+		created, err = s.svc.Events.Insert("primary", event).Context(ctx).Do()
+		return err
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to create event: %w", apierr.ClassifyScopeError(err, calendar.CalendarScope))
+	}
+	return created, nil
+}
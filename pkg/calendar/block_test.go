@@ -0,0 +1,86 @@
+// ABOUTME: Tests for whole-day time blocks
+// ABOUTME: Verifies the inclusive-to-exclusive date conversion and the event each block creates
+
+package calendar
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/calendar/v3"
+)
+
+func TestBlockRange(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	day := func(s string) time.Time {
+		d, err := time.ParseInLocation("2006-01-02", s, loc)
+		require.NoError(t, err)
+		return d
+	}
+
+	// Monday through Friday ends at the start of Saturday
+	start, end, err := BlockRange(day("2025-03-03"), day("2025-03-07"), loc)
+	require.NoError(t, err)
+	assert.Equal(t, "2025-03-03T00:00:00-05:00", start.Format(time.RFC3339))
+	assert.Equal(t, "2025-03-08T00:00:00-05:00", end.Format(time.RFC3339))
+	assert.Equal(t, time.Saturday, end.Weekday())
+
+	// A single day and a range crossing the DST change stay on midnights
+	_, end, err = BlockRange(day("2025-03-07"), day("2025-03-07"), loc)
+	require.NoError(t, err)
+	assert.Equal(t, "2025-03-08T00:00:00-05:00", end.Format(time.RFC3339))
+	_, end, err = BlockRange(day("2025-03-07"), day("2025-03-10"), loc)
+	require.NoError(t, err)
+	assert.Equal(t, "2025-03-11T00:00:00-04:00", end.Format(time.RFC3339))
+
+	_, _, err = BlockRange(day("2025-03-07"), day("2025-03-03"), loc)
+	assert.EqualError(t, err, "end_date 2025-03-03 is before start_date 2025-03-07")
+	_, _, err = BlockRange(day("2025-03-03"), day("2026-03-04"), loc)
+	assert.Error(t, err)
+}
+
+func TestBlockTime(t *testing.T) {
+	var inserted []calendar.Event
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var event calendar.Event
+		_ = json.NewDecoder(r.Body).Decode(&event)
+		inserted = append(inserted, event)
+		_ = json.NewEncoder(w).Encode(event)
+	}))
+	defer api.Close()
+
+	t.Setenv("ISH_MODE", "true")
+	t.Setenv("ISH_BASE_URL", api.URL)
+
+	svc, err := NewService(context.Background(), nil)
+	require.NoError(t, err)
+
+	monday := time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC)
+	friday := time.Date(2025, 3, 7, 0, 0, 0, 0, time.UTC)
+
+	_, err = svc.BlockTime(context.Background(), "Vacation", monday, friday, time.UTC, false, "")
+	require.NoError(t, err)
+	_, err = svc.BlockTime(context.Background(), "Vacation", monday, friday, time.UTC, true, "Back on the 10th")
+	require.NoError(t, err)
+	require.Len(t, inserted, 2)
+
+	allDay := inserted[0]
+	assert.Equal(t, "2025-03-03", allDay.Start.Date)
+	assert.Equal(t, "2025-03-08", allDay.End.Date, "the API's end date is exclusive")
+	assert.Equal(t, "opaque", allDay.Transparency)
+	assert.Empty(t, allDay.EventType)
+
+	ooo := inserted[1]
+	assert.Equal(t, EventTypeOutOfOffice, ooo.EventType)
+	assert.Equal(t, "2025-03-03T00:00:00Z", ooo.Start.DateTime)
+	assert.Equal(t, "2025-03-08T00:00:00Z", ooo.End.DateTime)
+	require.NotNil(t, ooo.OutOfOfficeProperties)
+	assert.Equal(t, "Back on the 10th", ooo.OutOfOfficeProperties.DeclineMessage)
+}
@@ -0,0 +1,54 @@
+// ABOUTME: Renders structured meeting details into a consistent event description
+// ABOUTME: Lays out agenda bullets, a meeting notes link, and the attendee list as plain text
+
+package calendar
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// RenderMeetingDescription returns a plain-text description with an agenda
+// section of "- " bullets, a notes link, and the attendees, optional ones
+// marked. Blank agenda items are dropped. It returns "" when there is neither
+// an agenda nor a notes link, so plain events keep an empty description.
+func RenderMeetingDescription(agenda []string, notesLink string, attendees, optionalAttendees []string) (string, error) {
+	var items []string
+	for _, item := range agenda {
+		if item = strings.Join(strings.Fields(item), " "); item != "" {
+			items = append(items, item)
+		}
+	}
+	notesLink = strings.TrimSpace(notesLink)
+	if len(items) == 0 && notesLink == "" {
+		return "", nil
+	}
+	if notesLink != "" {
+		u, err := url.Parse(notesLink)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return "", fmt.Errorf("meeting_notes_link must be an absolute http or https URL (got %q)", notesLink)
+		}
+	}
+
+	var sections []string
+	if len(items) > 0 {
+		sections = append(sections, "Agenda:\n- "+strings.Join(items, "\n- "))
+	}
+	if notesLink != "" {
+		sections = append(sections, "Meeting notes: "+notesLink)
+	}
+
+	var people []string
+	for _, email := range attendees {
+		people = append(people, "- "+email)
+	}
+	for _, email := range optionalAttendees {
+		people = append(people, "- "+email+" (optional)")
+	}
+	if len(people) > 0 {
+		sections = append(sections, "Attendees:\n"+strings.Join(people, "\n"))
+	}
+
+	return strings.Join(sections, "\n\n"), nil
+}
@@ -0,0 +1,74 @@
+// ABOUTME: Tests for rendering structured meeting descriptions
+// ABOUTME: Checks the agenda, notes link, and attendee layout and that explicit descriptions win
+
+package calendar
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/calendar/v3"
+)
+
+func TestRenderMeetingDescription(t *testing.T) {
+	description, err := RenderMeetingDescription(
+		[]string{"Budget review", "  ", "Hiring\n  plan"},
+		"https://docs.google.com/document/d/abc",
+		[]string{"alice@example.com"},
+		[]string{"bob@example.com"},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, `Agenda:
+- Budget review
+- Hiring plan
+
+Meeting notes: https://docs.google.com/document/d/abc
+
+Attendees:
+- alice@example.com
+- bob@example.com (optional)`, description)
+
+	description, err = RenderMeetingDescription(nil, "https://notes.example.com/sync", nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "Meeting notes: https://notes.example.com/sync", description)
+
+	description, err = RenderMeetingDescription([]string{" "}, "", []string{"alice@example.com"}, nil)
+	require.NoError(t, err)
+	assert.Empty(t, description, "attendees alone don't produce a description")
+
+	_, err = RenderMeetingDescription([]string{"Intro"}, "docs/notes", nil, nil)
+	assert.ErrorContains(t, err, "meeting_notes_link must be an absolute http or https URL")
+}
+
+func TestCreateEvent_RendersAgenda(t *testing.T) {
+	var inserted *calendar.Event
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		inserted = &calendar.Event{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(inserted))
+		_ = json.NewEncoder(w).Encode(inserted)
+	}))
+	defer api.Close()
+
+	t.Setenv("ISH_MODE", "true")
+	t.Setenv("ISH_BASE_URL", api.URL)
+
+	svc, err := NewService(context.Background(), nil)
+	require.NoError(t, err)
+
+	start := time.Date(2025, 1, 6, 9, 0, 0, 0, time.UTC)
+	opts := &EventOptions{Agenda: []string{"Roadmap"}, NotesLink: "https://docs.example.com/q1"}
+
+	_, err = svc.CreateEvent(context.Background(), "Planning", "", start, start.Add(time.Hour), []string{"alice@example.com"}, nil, SendUpdatesNone, opts)
+	require.NoError(t, err)
+	assert.Equal(t, "Agenda:\n- Roadmap\n\nMeeting notes: https://docs.example.com/q1\n\nAttendees:\n- alice@example.com", inserted.Description)
+
+	_, err = svc.CreateEvent(context.Background(), "Planning", "My own words", start, start.Add(time.Hour), nil, nil, SendUpdatesNone, opts)
+	require.NoError(t, err)
+	assert.Equal(t, "My own words", inserted.Description, "an explicit description overrides the agenda")
+}
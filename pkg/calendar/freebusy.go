@@ -0,0 +1,249 @@
+// ABOUTME: Free/busy queries and meeting slot suggestions
+// ABOUTME: Finds times free for every attendee within business hours, and free meeting rooms
+
+package calendar
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"google.golang.org/api/calendar/v3"
+)
+
+// maxSlotSuggestions caps how many candidate slots SuggestMeetingSlots returns
+const maxSlotSuggestions = 5
+
+// slotStep is the granularity at which candidate start times are generated
+const slotStep = 30 * time.Minute
+
+// TimeSlot is a candidate meeting time
+type TimeSlot struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+// WorkHours is the daily working window as offsets from midnight
+type WorkHours struct {
+	Start time.Duration
+	End   time.Duration
+}
+
+// ParseWorkHours parses HH:MM start and end times of the working day
+func ParseWorkHours(start, end string) (WorkHours, error) {
+	startClock, err := parseClock(start)
+	if err != nil {
+		return WorkHours{}, fmt.Errorf("invalid work start: %w", err)
+	}
+	endClock, err := parseClock(end)
+	if err != nil {
+		return WorkHours{}, fmt.Errorf("invalid work end: %w", err)
+	}
+	if endClock <= startClock {
+		return WorkHours{}, fmt.Errorf("work end must be after work start")
+	}
+	return WorkHours{Start: startClock, End: endClock}, nil
+}
+
+// busyInterval is a half-open [start, end) period when someone is unavailable
+type busyInterval struct {
+	start time.Time
+	end   time.Time
+}
+
+// SuggestMeetingSlots queries free/busy for the user and attendees and returns
+// up to five slots of the requested duration that are free for everyone.
+// Slots fall within workStart-workEnd (HH:MM, in windowStart's location) on weekdays.
+func (s *Service) SuggestMeetingSlots(ctx context.Context, attendees []string, durationMinutes int, windowStart, windowEnd time.Time, workStart, workEnd string) ([]TimeSlot, error) {
+	if durationMinutes <= 0 {
+		return nil, fmt.Errorf("duration must be positive")
+	}
+	if !windowEnd.After(windowStart) {
+		return nil, fmt.Errorf("window end must be after window start")
+	}
+
+	hours, err := ParseWorkHours(workStart, workEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	items := []*calendar.FreeBusyRequestItem{{Id: "primary"}}
+	for _, email := range attendees {
+		if email == "" {
+			continue
+		}
+		items = append(items, &calendar.FreeBusyRequestItem{Id: email})
+	}
+
+	req := &calendar.FreeBusyRequest{
+		TimeMin: windowStart.Format(time.RFC3339),
+		TimeMax: windowEnd.Format(time.RFC3339),
+		Items:   items,
+	}
+
+	var resp *calendar.FreeBusyResponse
+	err = s.breaker.Do(func() error {
+		var err error
+		resp, err = s.svc.Freebusy.Query(req).Context(ctx).Do()
+		return err
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to query free/busy: %w", err)
+	}
+
+	var busy []busyInterval
+	for id, cal := range resp.Calendars {
+		if len(cal.Errors) > 0 {
+			return nil, fmt.Errorf("free/busy unavailable for %s: %s", id, cal.Errors[0].Reason)
+		}
+		busy = append(busy, parseBusy(cal.Busy)...)
+	}
+
+	duration := time.Duration(durationMinutes) * time.Minute
+	return findFreeSlots(busy, duration, windowStart, windowEnd, hours.Start, hours.End, maxSlotSuggestions), nil
+}
+
+// maxFreeBusyCalendars is the most calendars one free/busy query may name
+const maxFreeBusyCalendars = 50
+
+// RoomAvailability is whether a room is free for a requested time
+type RoomAvailability struct {
+	Email string `json:"email"`
+	Free  bool   `json:"free"`
+	Error string `json:"error,omitempty"` // Why free/busy was unavailable, e.g. notFound; the room counts as not free
+}
+
+// FindAvailableRoom queries free/busy for the given room resource calendars
+// and reports, in the order given, whether each is free for all of
+// [start, end). Rooms whose free/busy can't be read are reported as not free.
+func (s *Service) FindAvailableRoom(ctx context.Context, roomEmails []string, start, end time.Time) ([]RoomAvailability, error) {
+	if !end.After(start) {
+		return nil, fmt.Errorf("end must be after start")
+	}
+
+	var rooms []string
+	seen := map[string]bool{}
+	for _, email := range roomEmails {
+		email = strings.TrimSpace(email)
+		if email != "" && !seen[strings.ToLower(email)] {
+			seen[strings.ToLower(email)] = true
+			rooms = append(rooms, email)
+		}
+	}
+	if len(rooms) == 0 {
+		return nil, fmt.Errorf("at least one room is required")
+	}
+	if len(rooms) > maxFreeBusyCalendars {
+		return nil, fmt.Errorf("at most %d rooms can be checked at once (got %d)", maxFreeBusyCalendars, len(rooms))
+	}
+
+	items := make([]*calendar.FreeBusyRequestItem, 0, len(rooms))
+	for _, email := range rooms {
+		items = append(items, &calendar.FreeBusyRequestItem{Id: email})
+	}
+	req := &calendar.FreeBusyRequest{
+		TimeMin: start.Format(time.RFC3339),
+		TimeMax: end.Format(time.RFC3339),
+		Items:   items,
+	}
+
+	var resp *calendar.FreeBusyResponse
+	err := s.breaker.Do(func() error {
+		var err error
+		resp, err = s.svc.Freebusy.Query(req).Context(ctx).Do()
+		return err
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to query free/busy: %w", err)
+	}
+
+	result := make([]RoomAvailability, 0, len(rooms))
+	for _, email := range rooms {
+		availability := RoomAvailability{Email: email}
+		cal, ok := resp.Calendars[email]
+		switch {
+		case !ok:
+			availability.Error = "missing from free/busy response"
+		case len(cal.Errors) > 0:
+			availability.Error = cal.Errors[0].Reason
+		default:
+			availability.Free = !overlapsBusy(parseBusy(cal.Busy), start, end)
+		}
+		result = append(result, availability)
+	}
+	return result, nil
+}
+
+// parseBusy converts free/busy periods to intervals, skipping unparseable ones
+func parseBusy(periods []*calendar.TimePeriod) []busyInterval {
+	var busy []busyInterval
+	for _, period := range periods {
+		start, err := time.Parse(time.RFC3339, period.Start)
+		if err != nil {
+			continue
+		}
+		end, err := time.Parse(time.RFC3339, period.End)
+		if err != nil {
+			continue
+		}
+		busy = append(busy, busyInterval{start: start, end: end})
+	}
+	return busy
+}
+
+// findFreeSlots walks each weekday in the window and returns the earliest
+// slots of the given duration that overlap no busy interval
+func findFreeSlots(busy []busyInterval, duration time.Duration, windowStart, windowEnd time.Time, workStart, workEnd time.Duration, limit int) []TimeSlot {
+	loc := windowStart.Location()
+
+	var slots []TimeSlot
+	day := time.Date(windowStart.Year(), windowStart.Month(), windowStart.Day(), 0, 0, 0, 0, loc)
+	for day.Before(windowEnd) && len(slots) < limit {
+		if day.Weekday() != time.Saturday && day.Weekday() != time.Sunday {
+			dayStart := atClock(day, workStart)
+			dayEnd := atClock(day, workEnd)
+			for start := dayStart; !start.Add(duration).After(dayEnd) && len(slots) < limit; start = start.Add(slotStep) {
+				end := start.Add(duration)
+				if start.Before(windowStart) || end.After(windowEnd) {
+					continue
+				}
+				if overlapsBusy(busy, start, end) {
+					continue
+				}
+				slots = append(slots, TimeSlot{Start: start, End: end})
+			}
+		}
+		day = day.AddDate(0, 0, 1)
+	}
+
+	return slots
+}
+
+// overlapsBusy reports whether [start, end) intersects any busy interval
+func overlapsBusy(busy []busyInterval, start, end time.Time) bool {
+	for _, b := range busy {
+		if b.start.Before(end) && b.end.After(start) {
+			return true
+		}
+	}
+	return false
+}
+
+// atClock returns the given time of day on day's date, in day's location
+func atClock(day time.Time, clock time.Duration) time.Time {
+	hours := int(clock / time.Hour)
+	minutes := int((clock % time.Hour) / time.Minute)
+	return time.Date(day.Year(), day.Month(), day.Day(), hours, minutes, 0, 0, day.Location())
+}
+
+// parseClock parses an HH:MM time of day into an offset from midnight
+func parseClock(value string) (time.Duration, error) {
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, fmt.Errorf("expected HH:MM, got %q", value)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
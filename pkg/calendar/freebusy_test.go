@@ -0,0 +1,189 @@
+// ABOUTME: Tests for free/busy queries and meeting slot suggestions
+// ABOUTME: Uses a local HTTP server in place of the Calendar API
+
+package calendar
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindFreeSlots_RespectsDurationAndWindow(t *testing.T) {
+	// Monday 2025-01-06
+	windowStart := time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC)
+	windowEnd := time.Date(2025, 1, 6, 23, 59, 0, 0, time.UTC)
+
+	slots := findFreeSlots(nil, 60*time.Minute, windowStart, windowEnd, 9*time.Hour, 12*time.Hour, 10)
+
+	require.NotEmpty(t, slots)
+	for _, slot := range slots {
+		assert.Equal(t, 60*time.Minute, slot.End.Sub(slot.Start))
+		assert.False(t, slot.Start.Before(time.Date(2025, 1, 6, 9, 0, 0, 0, time.UTC)))
+		assert.False(t, slot.End.After(time.Date(2025, 1, 6, 12, 0, 0, 0, time.UTC)))
+	}
+	// 09:00, 09:30, 10:00, 10:30, 11:00
+	assert.Len(t, slots, 5)
+}
+
+func TestFindFreeSlots_SkipsWeekends(t *testing.T) {
+	// Saturday 2025-01-04 through Sunday 2025-01-05
+	windowStart := time.Date(2025, 1, 4, 0, 0, 0, 0, time.UTC)
+	windowEnd := time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC)
+
+	slots := findFreeSlots(nil, 30*time.Minute, windowStart, windowEnd, 9*time.Hour, 17*time.Hour, 5)
+
+	assert.Empty(t, slots)
+}
+
+func TestFindFreeSlots_ExcludesBusy(t *testing.T) {
+	windowStart := time.Date(2025, 1, 6, 9, 0, 0, 0, time.UTC)
+	windowEnd := time.Date(2025, 1, 6, 11, 0, 0, 0, time.UTC)
+	busy := []busyInterval{
+		{start: time.Date(2025, 1, 6, 9, 15, 0, 0, time.UTC), end: time.Date(2025, 1, 6, 10, 0, 0, 0, time.UTC)},
+	}
+
+	slots := findFreeSlots(busy, 30*time.Minute, windowStart, windowEnd, 9*time.Hour, 17*time.Hour, 5)
+
+	require.Len(t, slots, 2)
+	assert.Equal(t, time.Date(2025, 1, 6, 10, 0, 0, 0, time.UTC), slots[0].Start)
+	assert.Equal(t, time.Date(2025, 1, 6, 10, 30, 0, 0, time.UTC), slots[1].Start)
+}
+
+func TestSuggestMeetingSlots_ExcludesAttendeeConflict(t *testing.T) {
+	var requested []string
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.True(t, strings.HasSuffix(r.URL.Path, "freeBusy"))
+
+		var body struct {
+			Items []struct {
+				ID string `json:"id"`
+			} `json:"items"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		for _, item := range body.Items {
+			requested = append(requested, item.ID)
+		}
+
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"calendars": map[string]interface{}{
+				"primary": map[string]interface{}{"busy": []interface{}{}},
+				"alice@example.com": map[string]interface{}{
+					"busy": []map[string]string{
+						{"start": "2025-01-06T09:00:00Z", "end": "2025-01-06T10:00:00Z"},
+					},
+				},
+			},
+		})
+	}))
+	defer api.Close()
+
+	t.Setenv("ISH_MODE", "true")
+	t.Setenv("ISH_BASE_URL", api.URL)
+
+	svc, err := NewService(context.Background(), nil)
+	require.NoError(t, err)
+
+	windowStart := time.Date(2025, 1, 6, 9, 0, 0, 0, time.UTC)
+	windowEnd := time.Date(2025, 1, 6, 17, 0, 0, 0, time.UTC)
+
+	slots, err := svc.SuggestMeetingSlots(context.Background(), []string{"alice@example.com"}, 30, windowStart, windowEnd, "09:00", "17:00")
+	require.NoError(t, err)
+
+	assert.ElementsMatch(t, []string{"primary", "alice@example.com"}, requested)
+	require.Len(t, slots, 5)
+	assert.Equal(t, time.Date(2025, 1, 6, 10, 0, 0, 0, time.UTC), slots[0].Start)
+	for _, slot := range slots {
+		assert.False(t, slot.Start.Before(time.Date(2025, 1, 6, 10, 0, 0, 0, time.UTC)),
+			"slot at %s conflicts with attendee", slot.Start)
+	}
+}
+
+func TestSuggestMeetingSlots_InvalidInput(t *testing.T) {
+	t.Setenv("ISH_MODE", "true")
+	t.Setenv("ISH_BASE_URL", "http://localhost:9000")
+
+	svc, err := NewService(context.Background(), nil)
+	require.NoError(t, err)
+
+	start := time.Date(2025, 1, 6, 9, 0, 0, 0, time.UTC)
+	end := start.Add(8 * time.Hour)
+
+	_, err = svc.SuggestMeetingSlots(context.Background(), nil, 0, start, end, "09:00", "17:00")
+	assert.Error(t, err)
+
+	_, err = svc.SuggestMeetingSlots(context.Background(), nil, 30, end, start, "09:00", "17:00")
+	assert.Error(t, err)
+
+	_, err = svc.SuggestMeetingSlots(context.Background(), nil, 30, start, end, "9am", "17:00")
+	assert.Error(t, err)
+
+	_, err = svc.SuggestMeetingSlots(context.Background(), nil, 30, start, end, "17:00", "09:00")
+	assert.Error(t, err)
+}
+
+func TestFindAvailableRoom(t *testing.T) {
+	var requested []string
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Items []struct {
+				ID string `json:"id"`
+			} `json:"items"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		for _, item := range body.Items {
+			requested = append(requested, item.ID)
+		}
+
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"calendars": map[string]interface{}{
+				"room-a@resource.calendar.google.com": map[string]interface{}{
+					"busy": []map[string]string{{"start": "2025-01-06T09:30:00Z", "end": "2025-01-06T10:30:00Z"}},
+				},
+				"room-b@resource.calendar.google.com": map[string]interface{}{
+					"busy": []map[string]string{{"start": "2025-01-06T10:00:00Z", "end": "2025-01-06T11:00:00Z"}},
+				},
+				"room-c@resource.calendar.google.com": map[string]interface{}{
+					"errors": []map[string]string{{"domain": "calendar", "reason": "notFound"}},
+				},
+			},
+		})
+	}))
+	defer api.Close()
+
+	t.Setenv("ISH_MODE", "true")
+	t.Setenv("ISH_BASE_URL", api.URL)
+
+	svc, err := NewService(context.Background(), nil)
+	require.NoError(t, err)
+
+	start := time.Date(2025, 1, 6, 9, 0, 0, 0, time.UTC)
+	rooms := []string{
+		"room-a@resource.calendar.google.com",
+		"room-b@resource.calendar.google.com",
+		"room-c@resource.calendar.google.com",
+		"ROOM-A@resource.calendar.google.com",
+	}
+
+	got, err := svc.FindAvailableRoom(context.Background(), rooms, start, start.Add(time.Hour))
+	require.NoError(t, err)
+
+	assert.Equal(t, rooms[:3], requested, "duplicates are queried once")
+	assert.Equal(t, []RoomAvailability{
+		{Email: "room-a@resource.calendar.google.com", Free: false},
+		{Email: "room-b@resource.calendar.google.com", Free: true},
+		{Email: "room-c@resource.calendar.google.com", Error: "notFound"},
+	}, got, "a booking starting at the meeting's end leaves room-b free")
+
+	_, err = svc.FindAvailableRoom(context.Background(), nil, start, start.Add(time.Hour))
+	assert.ErrorContains(t, err, "at least one room")
+	_, err = svc.FindAvailableRoom(context.Background(), rooms, start, start)
+	assert.ErrorContains(t, err, "end must be after start")
+}
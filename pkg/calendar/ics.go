@@ -0,0 +1,228 @@
+// ABOUTME: RFC 5545 iCalendar export for Calendar API events
+// ABOUTME: Serializes events as VEVENTs with times, text fields, and attendees for non-Google calendars
+
+package calendar
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"google.golang.org/api/calendar/v3"
+)
+
+// icsLineLength is the folding limit in octets, excluding the CRLF
+const icsLineLength = 75
+
+// maxExportEvents bounds how many events can be exported by ID in one call
+const maxExportEvents = 50
+
+// Date-time formats for iCalendar DATE and UTC DATE-TIME values
+const (
+	icsDateLayout     = "20060102"
+	icsDateTimeLayout = "20060102T150405Z"
+)
+
+// GetEvents fetches several events from the primary calendar by ID, in order
+func (s *Service) GetEvents(ctx context.Context, eventIDs []string) ([]*calendar.Event, error) {
+	if len(eventIDs) == 0 {
+		return nil, fmt.Errorf("at least one event ID is required")
+	}
+	if len(eventIDs) > maxExportEvents {
+		return nil, fmt.Errorf("at most %d events can be fetched at once (got %d)", maxExportEvents, len(eventIDs))
+	}
+
+	events := make([]*calendar.Event, 0, len(eventIDs))
+	for _, id := range eventIDs {
+		event, err := s.GetEvent(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+// ListEventsInRange lists the expanded events on calendarID (empty means
+// primary) that overlap [timeMin, timeMax]
+func (s *Service) ListEventsInRange(ctx context.Context, timeMin, timeMax time.Time, calendarID string) ([]*calendar.Event, error) {
+	if timeMin.IsZero() || timeMax.IsZero() {
+		return nil, fmt.Errorf("both time_min and time_max are required")
+	}
+	if !timeMin.Before(timeMax) {
+		return nil, fmt.Errorf("time_min must be before time_max")
+	}
+	return s.listWindow(ctx, "", timeMin, timeMax, calendarID)
+}
+
+// EncodeICS serializes events as one VCALENDAR with a VEVENT per event.
+// Timed events are written in UTC and all-day events as DATE values.
+// DTSTAMP is the event's last update, or now if the API didn't report one.
+func EncodeICS(events []*calendar.Event) string {
+	var b strings.Builder
+	line := func(name string, params []string, value string) {
+		prop := name
+		for _, p := range params {
+			prop += ";" + p
+		}
+		b.WriteString(foldICSLine(prop + ":" + value))
+		b.WriteString("\r\n")
+	}
+
+	line("BEGIN", nil, "VCALENDAR")
+	line("VERSION", nil, "2.0")
+	line("PRODID", nil, "-//gsuite-mcp//Calendar Export//EN")
+	line("CALSCALE", nil, "GREGORIAN")
+	line("METHOD", nil, "PUBLISH")
+
+	for _, event := range events {
+		line("BEGIN", nil, "VEVENT")
+
+		uid := event.ICalUID
+		if uid == "" {
+			uid = event.Id
+		}
+		line("UID", nil, escapeText(uid))
+
+		stamp, err := time.Parse(time.RFC3339, event.Updated)
+		if err != nil {
+			stamp = time.Now()
+		}
+		line("DTSTAMP", nil, stamp.UTC().Format(icsDateTimeLayout))
+
+		if params, value, ok := icsTime(event.Start); ok {
+			line("DTSTART", params, value)
+		}
+		if params, value, ok := icsTime(event.End); ok {
+			line("DTEND", params, value)
+		}
+
+		if event.Summary != "" {
+			line("SUMMARY", nil, escapeText(event.Summary))
+		}
+		if event.Description != "" {
+			line("DESCRIPTION", nil, escapeText(event.Description))
+		}
+		if event.Location != "" {
+			line("LOCATION", nil, escapeText(event.Location))
+		}
+		if status := icsStatus(event.Status); status != "" {
+			line("STATUS", nil, status)
+		}
+		if event.Organizer != nil && event.Organizer.Email != "" {
+			line("ORGANIZER", cnParam(event.Organizer.DisplayName), "mailto:"+event.Organizer.Email)
+		}
+		for _, attendee := range event.Attendees {
+			if attendee.Email == "" {
+				continue
+			}
+			params := cnParam(attendee.DisplayName)
+			role := "REQ-PARTICIPANT"
+			if attendee.Optional {
+				role = "OPT-PARTICIPANT"
+			}
+			params = append(params, "ROLE="+role)
+			if partstat := icsPartstat(attendee.ResponseStatus); partstat != "" {
+				params = append(params, "PARTSTAT="+partstat)
+			}
+			line("ATTENDEE", params, "mailto:"+attendee.Email)
+		}
+
+		line("END", nil, "VEVENT")
+	}
+
+	line("END", nil, "VCALENDAR")
+	return b.String()
+}
+
+// icsTime renders an event boundary: a UTC DATE-TIME for timed events, or
+// VALUE=DATE for all-day events. ok is false if the boundary is missing.
+func icsTime(dt *calendar.EventDateTime) (params []string, value string, ok bool) {
+	if dt == nil {
+		return nil, "", false
+	}
+	if dt.DateTime != "" {
+		t, err := time.Parse(time.RFC3339, dt.DateTime)
+		if err != nil {
+			return nil, "", false
+		}
+		return nil, t.UTC().Format(icsDateTimeLayout), true
+	}
+	d, err := time.Parse("2006-01-02", dt.Date)
+	if err != nil {
+		return nil, "", false
+	}
+	return []string{"VALUE=DATE"}, d.Format(icsDateLayout), true
+}
+
+// icsStatus maps an event status to the VEVENT STATUS values
+func icsStatus(status string) string {
+	switch status {
+	case "confirmed", "tentative", "cancelled":
+		return strings.ToUpper(status)
+	}
+	return ""
+}
+
+// icsPartstat maps an attendee response status to a PARTSTAT value
+func icsPartstat(status string) string {
+	switch status {
+	case "accepted":
+		return "ACCEPTED"
+	case "declined":
+		return "DECLINED"
+	case "tentative":
+		return "TENTATIVE"
+	case "needsAction":
+		return "NEEDS-ACTION"
+	}
+	return ""
+}
+
+// cnParam renders a CN parameter, quoting names that contain separators.
+// Double quotes can't appear in a parameter value, so they are dropped.
+func cnParam(name string) []string {
+	name = strings.ReplaceAll(name, `"`, "")
+	if name == "" {
+		return nil
+	}
+	if strings.ContainsAny(name, ",;:") {
+		name = `"` + name + `"`
+	}
+	return []string{"CN=" + name}
+}
+
+// escapeText escapes backslashes, commas, semicolons, and newlines per RFC 5545 section 3.3.11
+func escapeText(value string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		",", `\,`,
+		";", `\;`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	).Replace(value)
+}
+
+// foldICSLine splits lines longer than 75 octets, continuing with a leading
+// space. Splits never fall inside a multi-byte UTF-8 sequence.
+func foldICSLine(line string) string {
+	if len(line) <= icsLineLength {
+		return line
+	}
+
+	var b strings.Builder
+	limit := icsLineLength
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && line[cut]&0xC0 == 0x80 {
+			cut--
+		}
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n ")
+		line = line[cut:]
+		limit = icsLineLength - 1 // the leading space counts toward the limit
+	}
+	b.WriteString(line)
+	return b.String()
+}
@@ -0,0 +1,83 @@
+// ABOUTME: Tests for iCalendar export
+// ABOUTME: Verifies VEVENT fields, all-day dates, text escaping, and 75-octet line folding
+
+package calendar
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/api/calendar/v3"
+)
+
+func TestEncodeICS(t *testing.T) {
+	ics := EncodeICS([]*calendar.Event{
+		{
+			Id:          "evt1",
+			ICalUID:     "evt1@google.com",
+			Summary:     "Budget review; Q3, final",
+			Description: "Agenda:\n1. Numbers\n2. Next steps \\ owners",
+			Location:    "Room 4, Building B",
+			Status:      "confirmed",
+			Updated:     "2025-03-01T09:00:00.000Z",
+			Start:       &calendar.EventDateTime{DateTime: "2025-03-03T10:00:00-08:00"},
+			End:         &calendar.EventDateTime{DateTime: "2025-03-03T11:00:00-08:00"},
+			Organizer:   &calendar.EventOrganizer{Email: "alice@example.com", DisplayName: "Alice"},
+			Attendees: []*calendar.EventAttendee{
+				{Email: "bob@example.com", DisplayName: "Smith, Bob", ResponseStatus: "accepted"},
+				{Email: "carol@example.com", Optional: true, ResponseStatus: "needsAction"},
+			},
+		},
+		{
+			Id:      "evt2",
+			Summary: "Offsite",
+			Updated: "2025-03-01T09:00:00Z",
+			Start:   &calendar.EventDateTime{Date: "2025-03-04"},
+			End:     &calendar.EventDateTime{Date: "2025-03-06"},
+		},
+	})
+
+	assert.True(t, strings.HasPrefix(ics, "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n"))
+	assert.True(t, strings.HasSuffix(ics, "END:VCALENDAR\r\n"))
+	assert.Equal(t, 2, strings.Count(ics, "BEGIN:VEVENT\r\n"))
+
+	assert.Contains(t, ics, "\r\nUID:evt1@google.com\r\n")
+	assert.Contains(t, ics, "\r\nDTSTAMP:20250301T090000Z\r\n")
+	assert.Contains(t, ics, "\r\nDTSTART:20250303T180000Z\r\n", "timed events are written in UTC")
+	assert.Contains(t, ics, "\r\nDTEND:20250303T190000Z\r\n")
+	assert.Contains(t, ics, "\r\nSUMMARY:Budget review\\; Q3\\, final\r\n")
+	assert.Contains(t, ics, "\r\nDESCRIPTION:Agenda:\\n1. Numbers\\n2. Next steps \\\\ owners\r\n")
+	assert.Contains(t, ics, "\r\nLOCATION:Room 4\\, Building B\r\n")
+	assert.Contains(t, ics, "\r\nSTATUS:CONFIRMED\r\n")
+	assert.Contains(t, ics, "\r\nORGANIZER;CN=Alice:mailto:alice@example.com\r\n")
+	assert.Contains(t, ics, "\r\nATTENDEE;CN=\"Smith, Bob\";ROLE=REQ-PARTICIPANT;PARTSTAT=ACCEPTED:mailto:bob@\r\n example.com\r\n", "long lines fold at 75 octets")
+	assert.Contains(t, ics, "\r\nATTENDEE;ROLE=OPT-PARTICIPANT;PARTSTAT=NEEDS-ACTION:mailto:carol@example.co\r\n m\r\n")
+
+	assert.Contains(t, ics, "\r\nUID:evt2\r\n", "the event ID stands in for a missing iCalUID")
+	assert.Contains(t, ics, "\r\nDTSTART;VALUE=DATE:20250304\r\n")
+	assert.Contains(t, ics, "\r\nDTEND;VALUE=DATE:20250306\r\n")
+
+	for _, line := range strings.Split(strings.TrimSuffix(ics, "\r\n"), "\r\n") {
+		assert.LessOrEqual(t, len(line), icsLineLength, line)
+	}
+}
+
+func TestFoldICSLine(t *testing.T) {
+	long := "DESCRIPTION:" + strings.Repeat("a", 150)
+	folded := foldICSLine(long)
+	lines := strings.Split(folded, "\r\n")
+	assert.Len(t, lines, 3)
+	assert.Len(t, lines[0], 75)
+	assert.Len(t, lines[1], 75)
+	assert.True(t, strings.HasPrefix(lines[1], " "))
+	assert.Equal(t, long, strings.ReplaceAll(folded, "\r\n ", ""), "unfolding restores the line")
+
+	// A multi-byte character straddling the limit moves to the next line whole
+	utf := "SUMMARY:" + strings.Repeat("x", 66) + "é"
+	lines = strings.Split(foldICSLine(utf), "\r\n")
+	assert.Equal(t, "SUMMARY:"+strings.Repeat("x", 66), lines[0])
+	assert.Equal(t, " é", lines[1])
+
+	assert.Equal(t, "SUMMARY:short", foldICSLine("SUMMARY:short"))
+}
@@ -0,0 +1,93 @@
+// ABOUTME: Pending invitation detection for the user's calendar
+// ABOUTME: Picks out events the user was invited to but hasn't answered
+
+package calendar
+
+import (
+	"google.golang.org/api/calendar/v3"
+)
+
+// ResponseNeedsAction is the attendee response status of an unanswered invitation
+const ResponseNeedsAction = "needsAction"
+
+// Invite is an event awaiting the user's RSVP
+type Invite struct {
+	EventID   string           `json:"event_id"`
+	Summary   string           `json:"summary"`
+	Organizer string           `json:"organizer"`
+	Start     string           `json:"start"`
+	End       string           `json:"end"`
+	HTMLLink  string           `json:"html_link,omitempty"`
+	Attendees []InviteResponse `json:"attendees"` // Everyone else's responses
+}
+
+// InviteResponse is another attendee's response to an invitation
+type InviteResponse struct {
+	Email          string `json:"email"`
+	Name           string `json:"name,omitempty"`
+	ResponseStatus string `json:"response_status"`
+}
+
+// PendingInvites returns the events where the user's own attendee entry is
+// still needsAction. Events the user organized are skipped.
+func PendingInvites(events []*calendar.Event) []Invite {
+	invites := []Invite{}
+	for _, event := range events {
+		if event == nil || event.Status == "cancelled" {
+			continue
+		}
+		if event.Organizer != nil && event.Organizer.Self {
+			continue
+		}
+
+		self := selfAttendee(event)
+		if self == nil || self.Organizer || self.ResponseStatus != ResponseNeedsAction {
+			continue
+		}
+
+		invite := Invite{
+			EventID:   event.Id,
+			Summary:   event.Summary,
+			Start:     inviteTime(event.Start),
+			End:       inviteTime(event.End),
+			HTMLLink:  event.HtmlLink,
+			Attendees: []InviteResponse{},
+		}
+		if event.Organizer != nil {
+			invite.Organizer = event.Organizer.Email
+		}
+		for _, attendee := range event.Attendees {
+			if attendee.Self {
+				continue
+			}
+			invite.Attendees = append(invite.Attendees, InviteResponse{
+				Email:          attendee.Email,
+				Name:           attendee.DisplayName,
+				ResponseStatus: attendee.ResponseStatus,
+			})
+		}
+		invites = append(invites, invite)
+	}
+	return invites
+}
+
+// selfAttendee returns the user's own attendee entry, or nil if they aren't listed
+func selfAttendee(event *calendar.Event) *calendar.EventAttendee {
+	for _, attendee := range event.Attendees {
+		if attendee.Self {
+			return attendee
+		}
+	}
+	return nil
+}
+
+// inviteTime returns the RFC3339 time of a timed event or the date of an all-day one
+func inviteTime(t *calendar.EventDateTime) string {
+	if t == nil {
+		return ""
+	}
+	if t.DateTime != "" {
+		return t.DateTime
+	}
+	return t.Date
+}
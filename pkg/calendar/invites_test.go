@@ -0,0 +1,60 @@
+// ABOUTME: Tests for pending invitation detection
+// ABOUTME: Verifies only the user's needsAction invites from other organizers are returned
+
+package calendar
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/calendar/v3"
+)
+
+func TestPendingInvites(t *testing.T) {
+	invite := func(id string, selfStatus string, organizerSelf bool) *calendar.Event {
+		return &calendar.Event{
+			Id:        id,
+			Summary:   "Event " + id,
+			Organizer: &calendar.EventOrganizer{Email: "boss@example.com", Self: organizerSelf},
+			Start:     &calendar.EventDateTime{DateTime: "2025-01-06T10:00:00Z"},
+			End:       &calendar.EventDateTime{DateTime: "2025-01-06T11:00:00Z"},
+			Attendees: []*calendar.EventAttendee{
+				{Email: "boss@example.com", Organizer: true, ResponseStatus: "accepted"},
+				{Email: "me@example.com", Self: true, ResponseStatus: selfStatus},
+				{Email: "peer@example.com", DisplayName: "Peer", ResponseStatus: "tentative"},
+			},
+		}
+	}
+
+	cancelled := invite("cancelled", ResponseNeedsAction, false)
+	cancelled.Status = "cancelled"
+
+	events := []*calendar.Event{
+		invite("pending", ResponseNeedsAction, false),
+		invite("accepted", "accepted", false),
+		invite("declined", "declined", false),
+		invite("mine", ResponseNeedsAction, true),
+		cancelled,
+		{Id: "not-invited", Summary: "Solo", Start: &calendar.EventDateTime{Date: "2025-01-07"}},
+	}
+
+	invites := PendingInvites(events)
+	require.Len(t, invites, 1)
+
+	pending := invites[0]
+	assert.Equal(t, "pending", pending.EventID)
+	assert.Equal(t, "Event pending", pending.Summary)
+	assert.Equal(t, "boss@example.com", pending.Organizer)
+	assert.Equal(t, "2025-01-06T10:00:00Z", pending.Start)
+	assert.Equal(t, []InviteResponse{
+		{Email: "boss@example.com", ResponseStatus: "accepted"},
+		{Email: "peer@example.com", Name: "Peer", ResponseStatus: "tentative"},
+	}, pending.Attendees, "the user's own entry is omitted")
+}
+
+func TestPendingInvites_None(t *testing.T) {
+	invites := PendingInvites(nil)
+	assert.NotNil(t, invites, "an empty list encodes as [] rather than null")
+	assert.Empty(t, invites)
+}
@@ -0,0 +1,261 @@
+// ABOUTME: Meeting-load analytics computed from calendar events
+// ABOUTME: Totals meeting time per day and finds focus blocks within work hours
+
+package calendar
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"google.golang.org/api/calendar/v3"
+)
+
+// nonMeetingEventTypes are event types that don't represent time spent in meetings
+var nonMeetingEventTypes = map[string]bool{
+	"focusTime":       true,
+	"outOfOffice":     true,
+	"workingLocation": true,
+}
+
+// MeetingLoad summarizes how much of a period is spent in meetings
+type MeetingLoad struct {
+	PeriodStart       time.Time   `json:"period_start"`
+	PeriodEnd         time.Time   `json:"period_end"`
+	MeetingCount      int         `json:"meeting_count"`
+	TotalMeetingHours float64     `json:"total_meeting_hours"`
+	WorkHours         float64     `json:"work_hours"`                    // Weekday work hours in the period
+	MeetingPercent    float64     `json:"meeting_percent_of_work_hours"` // Share of work hours covered by meetings
+	LongestFocusBlock *FocusBlock `json:"longest_focus_block"`           // Nil if every work hour is booked
+	BusiestDay        *DayLoad    `json:"busiest_day"`                   // Nil if there are no meetings
+	Days              []DayLoad   `json:"days"`
+}
+
+// FocusBlock is an uninterrupted stretch of work hours with no meetings
+type FocusBlock struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+	Hours float64   `json:"hours"`
+}
+
+// DayLoad is the meeting load for one calendar day
+type DayLoad struct {
+	Date         string  `json:"date"`
+	Weekday      string  `json:"weekday"`
+	MeetingCount int     `json:"meeting_count"`
+	MeetingHours float64 `json:"meeting_hours"`
+	WorkHours    float64 `json:"work_hours"` // Zero on weekends
+}
+
+// meeting is a timed event that blocks the attendee's time
+type meeting struct {
+	busyInterval
+	day string // Date of the start, in the period's location
+}
+
+// ComputeMeetingLoad analyzes events between periodStart and periodEnd.
+// Meetings are timed events that aren't transparent, cancelled, declined, or
+// focus/out-of-office/working-location blocks. Each meeting counts toward the
+// day it starts on; days are taken in periodStart's location.
+func ComputeMeetingLoad(events []*calendar.Event, periodStart, periodEnd time.Time, hours WorkHours) *MeetingLoad {
+	loc := periodStart.Location()
+	meetings := meetingsInPeriod(events, periodStart, periodEnd, loc)
+
+	load := &MeetingLoad{
+		PeriodStart:  periodStart,
+		PeriodEnd:    periodEnd,
+		MeetingCount: len(meetings),
+		Days:         []DayLoad{},
+	}
+
+	busy := make([]busyInterval, len(meetings))
+	byDay := make(map[string]int) // Date -> index in load.Days
+	var total time.Duration
+	for i, m := range meetings {
+		busy[i] = m.busyInterval
+		total += m.end.Sub(m.start)
+	}
+	busy = mergeIntervals(busy)
+
+	var workTotal, bookedTotal time.Duration
+	day := time.Date(periodStart.Year(), periodStart.Month(), periodStart.Day(), 0, 0, 0, 0, loc)
+	for ; day.Before(periodEnd); day = day.AddDate(0, 0, 1) {
+		dayLoad := DayLoad{Date: day.Format("2006-01-02"), Weekday: day.Weekday().String()}
+
+		if day.Weekday() != time.Saturday && day.Weekday() != time.Sunday {
+			workStart := latest(atClock(day, hours.Start), periodStart)
+			workEnd := earliest(atClock(day, hours.End), periodEnd)
+			if workEnd.After(workStart) {
+				workTotal += workEnd.Sub(workStart)
+				bookedTotal += overlapDuration(busy, workStart, workEnd)
+				dayLoad.WorkHours = roundHours(workEnd.Sub(workStart))
+				load.LongestFocusBlock = longerBlock(load.LongestFocusBlock, longestGap(busy, workStart, workEnd))
+			}
+		}
+
+		byDay[dayLoad.Date] = len(load.Days)
+		load.Days = append(load.Days, dayLoad)
+	}
+
+	dayTotals := make(map[string]time.Duration)
+	for _, m := range meetings {
+		i, ok := byDay[m.day]
+		if !ok {
+			continue
+		}
+		dayLoad := &load.Days[i]
+		dayLoad.MeetingCount++
+		dayTotals[m.day] += m.end.Sub(m.start)
+		dayLoad.MeetingHours = roundHours(dayTotals[m.day])
+	}
+
+	for i := range load.Days {
+		if load.Days[i].MeetingCount == 0 {
+			continue
+		}
+		if load.BusiestDay == nil || dayTotals[load.Days[i].Date] > dayTotals[load.BusiestDay.Date] {
+			busiest := load.Days[i]
+			load.BusiestDay = &busiest
+		}
+	}
+
+	load.TotalMeetingHours = roundHours(total)
+	load.WorkHours = roundHours(workTotal)
+	if workTotal > 0 {
+		load.MeetingPercent = math.Round(float64(bookedTotal)/float64(workTotal)*1000) / 10
+	}
+	return load
+}
+
+// meetingsInPeriod extracts meetings from events, clipped to [periodStart, periodEnd)
+func meetingsInPeriod(events []*calendar.Event, periodStart, periodEnd time.Time, loc *time.Location) []meeting {
+	var meetings []meeting
+	for _, event := range events {
+		if !isMeeting(event) {
+			continue
+		}
+		start, err := time.Parse(time.RFC3339, event.Start.DateTime)
+		if err != nil {
+			continue
+		}
+		end, err := time.Parse(time.RFC3339, event.End.DateTime)
+		if err != nil {
+			continue
+		}
+		start = latest(start, periodStart)
+		end = earliest(end, periodEnd)
+		if !end.After(start) {
+			continue
+		}
+		meetings = append(meetings, meeting{
+			busyInterval: busyInterval{start: start, end: end},
+			day:          start.In(loc).Format("2006-01-02"),
+		})
+	}
+	return meetings
+}
+
+// isMeeting reports whether event is a timed event that occupies the user's time
+func isMeeting(event *calendar.Event) bool {
+	if event == nil || event.Start == nil || event.End == nil {
+		return false
+	}
+	// All-day events have only a date
+	if event.Start.DateTime == "" || event.End.DateTime == "" {
+		return false
+	}
+	if event.Transparency == "transparent" || event.Status == "cancelled" || nonMeetingEventTypes[event.EventType] {
+		return false
+	}
+	for _, attendee := range event.Attendees {
+		if attendee.Self && attendee.ResponseStatus == "declined" {
+			return false
+		}
+	}
+	return true
+}
+
+// mergeIntervals sorts intervals and joins any that overlap or touch
+func mergeIntervals(intervals []busyInterval) []busyInterval {
+	sorted := append([]busyInterval(nil), intervals...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].start.Before(sorted[j].start) })
+
+	var merged []busyInterval
+	for _, interval := range sorted {
+		last := len(merged) - 1
+		if last >= 0 && !interval.start.After(merged[last].end) {
+			merged[last].end = latest(merged[last].end, interval.end)
+			continue
+		}
+		merged = append(merged, interval)
+	}
+	return merged
+}
+
+// overlapDuration returns how much of [start, end) the merged intervals cover
+func overlapDuration(merged []busyInterval, start, end time.Time) time.Duration {
+	var covered time.Duration
+	for _, b := range merged {
+		s := latest(b.start, start)
+		e := earliest(b.end, end)
+		if e.After(s) {
+			covered += e.Sub(s)
+		}
+	}
+	return covered
+}
+
+// longestGap returns the longest stretch of [start, end) that no merged interval covers
+func longestGap(merged []busyInterval, start, end time.Time) *FocusBlock {
+	var best *FocusBlock
+	cursor := start
+	for _, b := range merged {
+		if !b.end.After(cursor) {
+			continue
+		}
+		if !b.start.Before(end) {
+			break
+		}
+		if b.start.After(cursor) {
+			best = longerBlock(best, &FocusBlock{Start: cursor, End: b.start})
+		}
+		cursor = b.end
+	}
+	if end.After(cursor) {
+		best = longerBlock(best, &FocusBlock{Start: cursor, End: end})
+	}
+	if best != nil {
+		best.Hours = roundHours(best.End.Sub(best.Start))
+	}
+	return best
+}
+
+// longerBlock returns whichever block is longer, preferring the earlier one on a tie
+func longerBlock(current, candidate *FocusBlock) *FocusBlock {
+	if candidate == nil {
+		return current
+	}
+	if current == nil || candidate.End.Sub(candidate.Start) > current.End.Sub(current.Start) {
+		return candidate
+	}
+	return current
+}
+
+// roundHours converts d to hours rounded to two decimal places
+func roundHours(d time.Duration) float64 {
+	return math.Round(d.Hours()*100) / 100
+}
+
+func latest(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
+
+func earliest(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,110 @@
+// ABOUTME: Tests for meeting-load analytics
+// ABOUTME: Checks totals, focus blocks, busiest day, and which events count as meetings
+
+package calendar
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/calendar/v3"
+)
+
+// timedEvent builds an event between two RFC3339 times
+func timedEvent(start, end string) *calendar.Event {
+	return &calendar.Event{
+		Start: &calendar.EventDateTime{DateTime: start},
+		End:   &calendar.EventDateTime{DateTime: end},
+	}
+}
+
+func TestComputeMeetingLoad(t *testing.T) {
+	// Monday 2025-01-06 through Sunday 2025-01-12
+	periodStart := time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC)
+	periodEnd := periodStart.AddDate(0, 0, 7)
+	hours := WorkHours{Start: 9 * time.Hour, End: 17 * time.Hour}
+
+	transparent := timedEvent("2025-01-06T12:00:00Z", "2025-01-06T13:00:00Z")
+	transparent.Transparency = "transparent"
+	declined := timedEvent("2025-01-07T15:00:00Z", "2025-01-07T16:00:00Z")
+	declined.Attendees = []*calendar.EventAttendee{{Self: true, ResponseStatus: "declined"}}
+	focus := timedEvent("2025-01-08T13:00:00Z", "2025-01-08T15:00:00Z")
+	focus.EventType = "focusTime"
+	allDay := &calendar.Event{
+		Start: &calendar.EventDateTime{Date: "2025-01-09"},
+		End:   &calendar.EventDateTime{Date: "2025-01-10"},
+	}
+
+	events := []*calendar.Event{
+		timedEvent("2025-01-06T09:00:00Z", "2025-01-06T10:00:00Z"),
+		timedEvent("2025-01-06T09:30:00Z", "2025-01-06T10:30:00Z"), // Overlaps the first
+		timedEvent("2025-01-06T14:00:00Z", "2025-01-06T16:00:00Z"),
+		timedEvent("2025-01-07T11:00:00Z", "2025-01-07T11:30:00Z"),
+		timedEvent("2025-01-11T10:00:00Z", "2025-01-11T11:00:00Z"), // Saturday
+		transparent, declined, focus, allDay,
+	}
+
+	load := ComputeMeetingLoad(events, periodStart, periodEnd, hours)
+
+	// Sum of the durations of the counted events: 1 + 1 + 2 + 0.5 + 1
+	assert.Equal(t, 5, load.MeetingCount)
+	assert.Equal(t, 5.5, load.TotalMeetingHours)
+	assert.Equal(t, 40.0, load.WorkHours)
+	// 3.5h of Monday plus 0.5h of Tuesday fall within work hours: 4/40
+	assert.Equal(t, 10.0, load.MeetingPercent)
+
+	require.NotNil(t, load.BusiestDay)
+	assert.Equal(t, "2025-01-06", load.BusiestDay.Date)
+	assert.Equal(t, "Monday", load.BusiestDay.Weekday)
+	assert.Equal(t, 3, load.BusiestDay.MeetingCount)
+	assert.Equal(t, 4.0, load.BusiestDay.MeetingHours)
+
+	// Wednesday has no meetings, so the whole working day is free
+	require.NotNil(t, load.LongestFocusBlock)
+	assert.Equal(t, time.Date(2025, 1, 8, 9, 0, 0, 0, time.UTC), load.LongestFocusBlock.Start)
+	assert.Equal(t, 8.0, load.LongestFocusBlock.Hours)
+
+	require.Len(t, load.Days, 7)
+	assert.Equal(t, 0.0, load.Days[5].WorkHours, "weekends have no work hours")
+	assert.Equal(t, 1.0, load.Days[5].MeetingHours)
+}
+
+func TestComputeMeetingLoad_FullyBooked(t *testing.T) {
+	// A single Monday with every work hour booked
+	periodStart := time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC)
+	periodEnd := periodStart.AddDate(0, 0, 1)
+	hours := WorkHours{Start: 9 * time.Hour, End: 12 * time.Hour}
+
+	load := ComputeMeetingLoad([]*calendar.Event{
+		timedEvent("2025-01-06T08:00:00Z", "2025-01-06T10:00:00Z"),
+		timedEvent("2025-01-06T10:00:00Z", "2025-01-06T12:30:00Z"),
+	}, periodStart, periodEnd, hours)
+
+	assert.Equal(t, 4.5, load.TotalMeetingHours)
+	assert.Equal(t, 100.0, load.MeetingPercent)
+	assert.Nil(t, load.LongestFocusBlock)
+}
+
+func TestComputeMeetingLoad_NoEvents(t *testing.T) {
+	periodStart := time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC)
+	load := ComputeMeetingLoad(nil, periodStart, periodStart.AddDate(0, 0, 7), WorkHours{Start: 9 * time.Hour, End: 17 * time.Hour})
+
+	assert.Zero(t, load.MeetingCount)
+	assert.Zero(t, load.MeetingPercent)
+	assert.Nil(t, load.BusiestDay)
+	require.NotNil(t, load.LongestFocusBlock)
+	assert.Equal(t, 8.0, load.LongestFocusBlock.Hours)
+}
+
+func TestParseWorkHours(t *testing.T) {
+	hours, err := ParseWorkHours("08:30", "17:00")
+	require.NoError(t, err)
+	assert.Equal(t, WorkHours{Start: 8*time.Hour + 30*time.Minute, End: 17 * time.Hour}, hours)
+
+	_, err = ParseWorkHours("9am", "17:00")
+	assert.Error(t, err)
+	_, err = ParseWorkHours("17:00", "09:00")
+	assert.Error(t, err)
+}
@@ -0,0 +1,62 @@
+// ABOUTME: Meetings shared with one person, for catch-up and CRM style views
+// ABOUTME: Searches events by attendee email and keeps those the person is actually invited to
+
+package calendar
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"google.golang.org/api/calendar/v3"
+)
+
+// MeetingsWith returns the events in [timeMin, timeMax) on the primary
+// calendar that email attends or organizes, in start order. Every page is
+// read so both ends of the window are complete. The API's free-text search
+// also matches summaries and descriptions, so its results are filtered to
+// events listing email as organizer or attendee.
+func (s *Service) MeetingsWith(ctx context.Context, email string, timeMin, timeMax time.Time) ([]*calendar.Event, error) {
+	events, err := s.listWindow(ctx, email, timeMin, timeMax, "")
+	if err != nil {
+		return nil, err
+	}
+
+	meetings := []*calendar.Event{}
+	for _, event := range events {
+		if involves(event, email) {
+			meetings = append(meetings, event)
+		}
+	}
+	sort.SliceStable(meetings, func(i, j int) bool {
+		return eventStartKey(meetings[i]).Before(eventStartKey(meetings[j]))
+	})
+	return meetings, nil
+}
+
+// eventStartKey is when event starts, for ordering. All-day events count from
+// midnight UTC; events without a parseable start sort first.
+func eventStartKey(event *calendar.Event) time.Time {
+	if event.Start == nil {
+		return time.Time{}
+	}
+	if t, err := time.Parse(time.RFC3339, event.Start.DateTime); err == nil {
+		return t
+	}
+	t, _ := time.Parse("2006-01-02", event.Start.Date)
+	return t
+}
+
+// involves reports whether email organizes or is invited to event
+func involves(event *calendar.Event, email string) bool {
+	if event.Organizer != nil && strings.EqualFold(event.Organizer.Email, email) {
+		return true
+	}
+	for _, attendee := range event.Attendees {
+		if strings.EqualFold(attendee.Email, email) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,53 @@
+// ABOUTME: Tests for finding the meetings shared with one person
+// ABOUTME: Verifies the attendee search query, paging, start ordering, and that mere mentions are filtered out
+
+package calendar
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMeetingsWith(t *testing.T) {
+	var query string
+	var pages int
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query().Get("q")
+		pages++
+		if r.URL.Query().Get("pageToken") == "" {
+			_, _ = w.Write([]byte(`{"nextPageToken": "p2", "items": [
+				{"id": "attends", "start": {"dateTime": "2025-01-02T10:00:00Z"}, "attendees": [{"email": "bob@example.com"}, {"email": "Alice@Acme.com"}]},
+				{"id": "mentions", "start": {"dateTime": "2025-01-03T10:00:00Z"}, "description": "Ask alice@acme.com about pricing"}
+			]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"items": [
+			{"id": "organizes", "start": {"dateTime": "2025-01-05T10:00:00Z"}, "organizer": {"email": "alice@acme.com"}},
+			{"id": "all-day", "start": {"date": "2025-01-04"}, "attendees": [{"email": "alice@acme.com"}]}
+		]}`))
+	}))
+	defer api.Close()
+
+	t.Setenv("ISH_MODE", "true")
+	t.Setenv("ISH_BASE_URL", api.URL)
+
+	svc, err := NewService(context.Background(), nil)
+	require.NoError(t, err)
+
+	now := time.Date(2025, 1, 6, 12, 0, 0, 0, time.UTC)
+	meetings, err := svc.MeetingsWith(context.Background(), "alice@acme.com", now.AddDate(0, 0, -30), now.AddDate(0, 0, 30))
+	require.NoError(t, err)
+
+	assert.Equal(t, "alice@acme.com", query)
+	assert.Equal(t, 2, pages, "every page is read so the newest meetings aren't cut off")
+	require.Len(t, meetings, 3)
+	assert.Equal(t, "attends", meetings[0].Id)
+	assert.Equal(t, "all-day", meetings[1].Id, "results are put in start order")
+	assert.Equal(t, "organizes", meetings[2].Id)
+}
@@ -0,0 +1,115 @@
+// ABOUTME: Reminder schedules for upcoming events
+// ABOUTME: Resolves each event's reminder overrides or calendar defaults into concrete fire times
+
+package calendar
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"google.golang.org/api/calendar/v3"
+)
+
+// EventReminders is an upcoming event and when its reminders fire
+type EventReminders struct {
+	EventID     string     `json:"event_id"`
+	Summary     string     `json:"summary"`
+	Start       time.Time  `json:"start"`
+	UsesDefault bool       `json:"uses_default"` // Reminders come from the calendar's defaults, not the event
+	Reminders   []Reminder `json:"reminders"`    // Ordered by fire time; empty if the event has none
+}
+
+// Reminder is one notification for an event
+type Reminder struct {
+	Method        string    `json:"method"` // popup or email
+	MinutesBefore int64     `json:"minutes_before"`
+	FireAt        time.Time `json:"fire_at"`
+	Fired         bool      `json:"fired"` // FireAt is already in the past
+}
+
+// DefaultReminders returns the reminders the primary calendar applies to
+// events that use its defaults. The answer is cached for the service's TTL.
+func (s *Service) DefaultReminders(ctx context.Context) ([]*calendar.EventReminder, error) {
+	s.remindersMu.Lock()
+	defer s.remindersMu.Unlock()
+
+	if !s.remindersFetched.IsZero() && s.now().Sub(s.remindersFetched) < s.cacheTTL {
+		return s.reminders, nil
+	}
+
+	var entry *calendar.CalendarListEntry
+
+	err := s.breaker.Do(func() error {
+		var err error
+		entry, err = s.svc.CalendarList.Get("primary").Context(ctx).Fields("defaultReminders").Do()
+		return err
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to get default reminders: %w", err)
+	}
+	s.reminders = entry.DefaultReminders
+	s.remindersFetched = s.now()
+	return s.reminders, nil
+}
+
+// UpcomingReminders computes reminder fire times (event start minus the
+// reminder's minutes) for each event. Events that use the calendar's defaults
+// get defaults. All-day events start at midnight in loc; times are reported
+// in loc. Cancelled events are skipped, and results are ordered by start.
+func UpcomingReminders(events []*calendar.Event, defaults []*calendar.EventReminder, loc *time.Location, now time.Time) []EventReminders {
+	result := []EventReminders{}
+	for _, event := range events {
+		if event == nil || event.Status == "cancelled" {
+			continue
+		}
+		start, err := reminderStart(event.Start, loc)
+		if err != nil {
+			continue
+		}
+
+		overrides := defaults
+		usesDefault := event.Reminders == nil || event.Reminders.UseDefault
+		if !usesDefault {
+			overrides = event.Reminders.Overrides
+		}
+
+		entry := EventReminders{
+			EventID:     event.Id,
+			Summary:     event.Summary,
+			Start:       start,
+			UsesDefault: usesDefault,
+			Reminders:   make([]Reminder, 0, len(overrides)),
+		}
+		for _, r := range overrides {
+			fireAt := start.Add(-time.Duration(r.Minutes) * time.Minute)
+			entry.Reminders = append(entry.Reminders, Reminder{
+				Method:        r.Method,
+				MinutesBefore: r.Minutes,
+				FireAt:        fireAt,
+				Fired:         fireAt.Before(now),
+			})
+		}
+		sort.SliceStable(entry.Reminders, func(i, j int) bool {
+			return entry.Reminders[i].FireAt.Before(entry.Reminders[j].FireAt)
+		})
+		result = append(result, entry)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool { return result[i].Start.Before(result[j].Start) })
+	return result
+}
+
+// reminderStart parses an event start in loc; all-day dates mean midnight there
+func reminderStart(dt *calendar.EventDateTime, loc *time.Location) (time.Time, error) {
+	if dt == nil {
+		return time.Time{}, fmt.Errorf("missing event time")
+	}
+	if dt.DateTime != "" {
+		t, err := time.Parse(time.RFC3339, dt.DateTime)
+		return t.In(loc), err
+	}
+	return time.ParseInLocation("2006-01-02", dt.Date, loc)
+}
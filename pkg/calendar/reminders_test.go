@@ -0,0 +1,103 @@
+// ABOUTME: Tests for reminder schedules
+// ABOUTME: Checks fire times relative to event start, calendar defaults, and all-day events
+
+package calendar
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/calendar/v3"
+)
+
+func TestUpcomingReminders(t *testing.T) {
+	loc, err := time.LoadLocation("America/Chicago")
+	require.NoError(t, err)
+	now := time.Date(2025, 1, 6, 8, 50, 0, 0, loc)
+
+	standup := timedEvent("2025-01-06T15:00:00Z", "2025-01-06T15:15:00Z") // 9:00 in Chicago
+	standup.Id = "standup"
+	standup.Reminders = &calendar.EventReminders{Overrides: []*calendar.EventReminder{
+		{Method: "email", Minutes: 60},
+		{Method: "popup", Minutes: 5},
+	}}
+
+	review := timedEvent("2025-01-06T20:00:00Z", "2025-01-06T21:00:00Z") // 14:00 in Chicago
+	review.Id = "review"
+	review.Reminders = &calendar.EventReminders{UseDefault: true}
+
+	holiday := &calendar.Event{
+		Id:        "holiday",
+		Start:     &calendar.EventDateTime{Date: "2025-01-07"},
+		End:       &calendar.EventDateTime{Date: "2025-01-08"},
+		Reminders: &calendar.EventReminders{Overrides: []*calendar.EventReminder{{Method: "popup", Minutes: 600}}},
+	}
+
+	noReminders := timedEvent("2025-01-06T17:00:00Z", "2025-01-06T17:30:00Z")
+	noReminders.Id = "quiet"
+	noReminders.Reminders = &calendar.EventReminders{}
+
+	cancelled := timedEvent("2025-01-06T16:00:00Z", "2025-01-06T17:00:00Z")
+	cancelled.Status = "cancelled"
+
+	defaults := []*calendar.EventReminder{{Method: "popup", Minutes: 10}}
+	got := UpcomingReminders([]*calendar.Event{review, holiday, standup, noReminders, cancelled}, defaults, loc, now)
+
+	require.Len(t, got, 4)
+	assert.Equal(t, []string{"standup", "quiet", "review", "holiday"}, []string{got[0].EventID, got[1].EventID, got[2].EventID, got[3].EventID})
+
+	// Overrides are ordered by fire time; the hour-ahead email already went out
+	standupReminders := got[0].Reminders
+	require.Len(t, standupReminders, 2)
+	assert.Equal(t, time.Date(2025, 1, 6, 8, 0, 0, 0, loc), standupReminders[0].FireAt)
+	assert.Equal(t, "email", standupReminders[0].Method)
+	assert.True(t, standupReminders[0].Fired)
+	assert.Equal(t, time.Date(2025, 1, 6, 8, 55, 0, 0, loc), standupReminders[1].FireAt)
+	assert.Equal(t, int64(5), standupReminders[1].MinutesBefore)
+	assert.False(t, standupReminders[1].Fired)
+	assert.False(t, got[0].UsesDefault)
+
+	assert.Empty(t, got[1].Reminders)
+
+	assert.True(t, got[2].UsesDefault)
+	require.Len(t, got[2].Reminders, 1)
+	assert.Equal(t, time.Date(2025, 1, 6, 13, 50, 0, 0, loc), got[2].Reminders[0].FireAt)
+
+	// All-day events start at midnight in the configured timezone
+	assert.Equal(t, time.Date(2025, 1, 6, 14, 0, 0, 0, loc), got[3].Reminders[0].FireAt)
+}
+
+func TestDefaultReminders_CachedForTTL(t *testing.T) {
+	calls := 0
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		_, _ = w.Write([]byte(`{"defaultReminders": [{"method": "popup", "minutes": 10}]}`))
+	}))
+	defer api.Close()
+
+	t.Setenv("ISH_MODE", "true")
+	t.Setenv("ISH_BASE_URL", api.URL)
+
+	svc, err := NewService(context.Background(), nil)
+	require.NoError(t, err)
+	now := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	svc.now = func() time.Time { return now }
+
+	for i := 0; i < 2; i++ {
+		reminders, err := svc.DefaultReminders(context.Background())
+		require.NoError(t, err)
+		require.Len(t, reminders, 1)
+		assert.Equal(t, int64(10), reminders[0].Minutes)
+	}
+	assert.Equal(t, 1, calls)
+
+	now = now.Add(DefaultCacheTTL)
+	_, err = svc.DefaultReminders(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, calls, "an expired entry is fetched again")
+}
@@ -0,0 +1,103 @@
+// ABOUTME: Moves an event by a relative offset, such as "push my 2pm back 30 minutes"
+// ABOUTME: Parses day-aware offsets and shifts start and end together, whole days for all-day events
+
+package calendar
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/api/calendar/v3"
+)
+
+// oneDay is the length of the d unit accepted by ParseOffset
+const oneDay = 24 * time.Hour
+
+// ParseOffset parses a Go duration extended with a leading day count, e.g.
+// 30m, -1h, 1d, or 1d2h. A sign before the days applies to the whole offset.
+// A zero offset is an error since it would leave the event unchanged.
+func ParseOffset(offset string) (time.Duration, error) {
+	value := strings.TrimSpace(offset)
+	sign := time.Duration(1)
+	if rest, ok := strings.CutPrefix(value, "-"); ok {
+		sign, value = -1, rest
+	} else {
+		value = strings.TrimPrefix(value, "+")
+	}
+
+	var total time.Duration
+	if days, rest, ok := strings.Cut(value, "d"); ok {
+		n, err := strconv.ParseUint(days, 10, 16)
+		if err != nil {
+			return 0, fmt.Errorf("invalid offset %q: expected a duration like 30m, -1h, or 1d", offset)
+		}
+		total, value = time.Duration(n)*oneDay, rest
+	}
+	if value != "" {
+		d, err := time.ParseDuration(value)
+		if err != nil || d < 0 || strings.HasPrefix(value, "+") {
+			return 0, fmt.Errorf("invalid offset %q: expected a duration like 30m, -1h, or 1d", offset)
+		}
+		total += d
+	}
+	if total == 0 {
+		return 0, fmt.Errorf("offset must not be zero")
+	}
+	return sign * total, nil
+}
+
+// ShiftEvent moves event's start and end by offset, keeping its duration.
+// Timed events keep their time zone, and whole days move by calendar day, so
+// 1d keeps the same local time across a DST change. All-day events move by
+// whole days and reject other offsets.
+func ShiftEvent(event *calendar.Event, offset time.Duration) error {
+	if event.Start == nil || event.End == nil {
+		return fmt.Errorf("event %s has no start or end time", event.Id)
+	}
+	if event.Start.Date != "" {
+		if offset%oneDay != 0 {
+			return fmt.Errorf("all-day events can only move by whole days (got %s)", offset)
+		}
+		for _, dt := range []*calendar.EventDateTime{event.Start, event.End} {
+			date, err := time.Parse("2006-01-02", dt.Date)
+			if err != nil {
+				return fmt.Errorf("unable to parse event date %q: %w", dt.Date, err)
+			}
+			dt.Date = date.AddDate(0, 0, int(offset/oneDay)).Format("2006-01-02")
+		}
+		return nil
+	}
+
+	for _, dt := range []*calendar.EventDateTime{event.Start, event.End} {
+		t, err := time.Parse(time.RFC3339, dt.DateTime)
+		if err != nil {
+			return fmt.Errorf("unable to parse event time %q: %w", dt.DateTime, err)
+		}
+		if dt.TimeZone != "" {
+			if loc, err := time.LoadLocation(dt.TimeZone); err == nil {
+				t = t.In(loc)
+			}
+		}
+		dt.DateTime = t.AddDate(0, 0, int(offset/oneDay)).Add(offset % oneDay).Format(time.RFC3339)
+	}
+	return nil
+}
+
+// RescheduleEvent moves eventID by offset and saves it. sendUpdates is one of
+// the SendUpdates* constants.
+func (s *Service) RescheduleEvent(ctx context.Context, eventID string, offset time.Duration, sendUpdates string) (*calendar.Event, error) {
+	if err := ValidateSendUpdates(sendUpdates); err != nil {
+		return nil, err
+	}
+	event, err := s.GetEvent(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	if err := ShiftEvent(event, offset); err != nil {
+		return nil, err
+	}
+	return s.UpdateEvent(ctx, eventID, event, sendUpdates)
+}
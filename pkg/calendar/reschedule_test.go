@@ -0,0 +1,105 @@
+// ABOUTME: Tests for moving events by a relative offset
+// ABOUTME: Covers offset parsing, timed and all-day shifts, and the fetch-and-update round trip
+
+package calendar
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/calendar/v3"
+)
+
+func TestParseOffset(t *testing.T) {
+	valid := map[string]time.Duration{
+		"30m":    30 * time.Minute,
+		"-1h":    -time.Hour,
+		"+90m":   90 * time.Minute,
+		"1d":     24 * time.Hour,
+		"-2d":    -48 * time.Hour,
+		"1d2h":   26 * time.Hour,
+		" 45s ":  45 * time.Second,
+		"-1h30m": -90 * time.Minute,
+	}
+	for input, want := range valid {
+		got, err := ParseOffset(input)
+		require.NoError(t, err, input)
+		assert.Equal(t, want, got, input)
+	}
+
+	for _, input := range []string{"", "0m", "0d", "soon", "30", "d", "1.5d", "1d-2h", "--1h", "1w"} {
+		_, err := ParseOffset(input)
+		assert.Error(t, err, input)
+	}
+}
+
+func TestShiftEvent(t *testing.T) {
+	event := &calendar.Event{
+		Start: &calendar.EventDateTime{DateTime: "2025-03-10T14:00:00-04:00", TimeZone: "America/New_York"},
+		End:   &calendar.EventDateTime{DateTime: "2025-03-10T15:00:00-04:00", TimeZone: "America/New_York"},
+	}
+	require.NoError(t, ShiftEvent(event, 30*time.Minute))
+	assert.Equal(t, "2025-03-10T14:30:00-04:00", event.Start.DateTime)
+	assert.Equal(t, "2025-03-10T15:30:00-04:00", event.End.DateTime)
+	assert.Equal(t, "America/New_York", event.Start.TimeZone)
+
+	// Moving back a day across the DST change keeps the local time
+	require.NoError(t, ShiftEvent(event, -oneDay))
+	assert.Equal(t, "2025-03-09T14:30:00-04:00", event.Start.DateTime)
+	require.NoError(t, ShiftEvent(event, -oneDay))
+	assert.Equal(t, "2025-03-08T14:30:00-05:00", event.Start.DateTime)
+	assert.Equal(t, "2025-03-08T15:30:00-05:00", event.End.DateTime)
+
+	allDay := &calendar.Event{
+		Start: &calendar.EventDateTime{Date: "2025-02-27"},
+		End:   &calendar.EventDateTime{Date: "2025-03-01"},
+	}
+	require.NoError(t, ShiftEvent(allDay, 2*oneDay))
+	assert.Equal(t, "2025-03-01", allDay.Start.Date)
+	assert.Equal(t, "2025-03-03", allDay.End.Date)
+	assert.ErrorContains(t, ShiftEvent(allDay, time.Hour), "all-day events can only move by whole days")
+	assert.Equal(t, "2025-03-01", allDay.Start.Date, "a rejected offset leaves the event alone")
+}
+
+func TestRescheduleEvent(t *testing.T) {
+	var updated *calendar.Event
+	var sendUpdates string
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			_ = json.NewEncoder(w).Encode(&calendar.Event{
+				Id:      "evt-1",
+				Summary: "Design review",
+				Start:   &calendar.EventDateTime{DateTime: "2025-01-06T14:00:00Z"},
+				End:     &calendar.EventDateTime{DateTime: "2025-01-06T14:45:00Z"},
+			})
+		case http.MethodPut:
+			sendUpdates = r.URL.Query().Get("sendUpdates")
+			updated = &calendar.Event{}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(updated))
+			_ = json.NewEncoder(w).Encode(updated)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer api.Close()
+
+	t.Setenv("ISH_MODE", "true")
+	t.Setenv("ISH_BASE_URL", api.URL)
+
+	svc, err := NewService(context.Background(), nil)
+	require.NoError(t, err)
+
+	_, err = svc.RescheduleEvent(context.Background(), "evt-1", 30*time.Minute, SendUpdatesNone)
+	require.NoError(t, err)
+	assert.Equal(t, "2025-01-06T14:30:00Z", updated.Start.DateTime)
+	assert.Equal(t, "2025-01-06T15:15:00Z", updated.End.DateTime)
+	assert.Equal(t, "Design review", updated.Summary)
+	assert.Equal(t, SendUpdatesNone, sendUpdates)
+}
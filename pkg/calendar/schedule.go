@@ -0,0 +1,115 @@
+// ABOUTME: Dry-run conflict checks for a batch of events before creating them
+// ABOUTME: Reports proposed slots that overlap existing meetings or each other
+
+package calendar
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"google.golang.org/api/calendar/v3"
+)
+
+// maxProposedSlots bounds how many slots one ValidateSchedule call checks
+const maxProposedSlots = 200
+
+// Conflict types reported by ValidateSchedule
+const (
+	ConflictExternal = "external" // The slot overlaps an event already on the calendar
+	ConflictInternal = "internal" // The slot overlaps another proposed slot
+)
+
+// ProposedSlot is an event that has not been created yet
+type ProposedSlot struct {
+	Start   time.Time `json:"start"`
+	End     time.Time `json:"end"`
+	Summary string    `json:"summary,omitempty"`
+}
+
+// ScheduleConflict is one overlap involving a proposed slot
+type ScheduleConflict struct {
+	Slot      int       `json:"slot"` // Index of the proposed slot
+	Summary   string    `json:"summary,omitempty"`
+	Type      string    `json:"type"`                 // ConflictExternal or ConflictInternal
+	EventID   string    `json:"event_id,omitempty"`   // The existing event, for external conflicts
+	OtherSlot *int      `json:"other_slot,omitempty"` // The slot listed later, for internal conflicts
+	With      string    `json:"with,omitempty"`       // Title of the event or slot overlapped
+	Start     time.Time `json:"start"`                // When the overlapped event or slot starts
+	End       time.Time `json:"end"`
+}
+
+// ValidateSchedule checks each slot against the primary calendar and the
+// other slots without creating anything. Existing events count when they
+// occupy the user's time, as in the meeting load: all-day, free, cancelled,
+// and declined events are ignored. Each overlapping pair of slots is
+// reported once, on the slot listed first.
+func (s *Service) ValidateSchedule(ctx context.Context, slots []ProposedSlot) ([]ScheduleConflict, error) {
+	if len(slots) == 0 {
+		return nil, fmt.Errorf("at least one slot is required")
+	}
+	if len(slots) > maxProposedSlots {
+		return nil, fmt.Errorf("at most %d slots can be checked at once (got %d)", maxProposedSlots, len(slots))
+	}
+
+	var windowStart, windowEnd time.Time
+	for i, slot := range slots {
+		if !slot.End.After(slot.Start) {
+			return nil, fmt.Errorf("slot %d: end must be after start", i)
+		}
+		if windowStart.IsZero() || slot.Start.Before(windowStart) {
+			windowStart = slot.Start
+		}
+		if slot.End.After(windowEnd) {
+			windowEnd = slot.End
+		}
+	}
+
+	events, err := s.ListEventsInRange(ctx, windowStart, windowEnd, "")
+	if err != nil {
+		return nil, err
+	}
+	var existing []scheduledEvent
+	for _, event := range events {
+		if !isMeeting(event) {
+			continue
+		}
+		start, err := time.Parse(time.RFC3339, event.Start.DateTime)
+		if err != nil {
+			continue
+		}
+		end, err := time.Parse(time.RFC3339, event.End.DateTime)
+		if err != nil {
+			continue
+		}
+		existing = append(existing, scheduledEvent{busyInterval: busyInterval{start: start, end: end}, event: event})
+	}
+
+	conflicts := []ScheduleConflict{}
+	for i, slot := range slots {
+		for _, e := range existing {
+			if overlapsBusy([]busyInterval{e.busyInterval}, slot.Start, slot.End) {
+				conflicts = append(conflicts, ScheduleConflict{
+					Slot: i, Summary: slot.Summary, Type: ConflictExternal,
+					EventID: e.event.Id, With: e.event.Summary, Start: e.start, End: e.end,
+				})
+			}
+		}
+		for j := i + 1; j < len(slots); j++ {
+			other := slots[j]
+			if overlapsBusy([]busyInterval{{start: other.Start, end: other.End}}, slot.Start, slot.End) {
+				conflicts = append(conflicts, ScheduleConflict{
+					Slot: i, Summary: slot.Summary, Type: ConflictInternal,
+					OtherSlot: &j, With: other.Summary, Start: other.Start, End: other.End,
+				})
+			}
+		}
+	}
+	return conflicts, nil
+}
+
+// scheduledEvent is an existing event and the time it occupies
+type scheduledEvent struct {
+	busyInterval
+	event *calendar.Event
+}
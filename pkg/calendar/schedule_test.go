@@ -0,0 +1,83 @@
+// ABOUTME: Tests for dry-run schedule validation
+// ABOUTME: Verifies overlaps with existing events and between proposed slots are both reported
+
+package calendar
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/calendar/v3"
+)
+
+func TestValidateSchedule(t *testing.T) {
+	var timeMin, timeMax string
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("validation must not modify the calendar: %s %s", r.Method, r.URL.Path)
+		}
+		timeMin, timeMax = r.URL.Query().Get("timeMin"), r.URL.Query().Get("timeMax")
+		_ = json.NewEncoder(w).Encode(&calendar.Events{Items: []*calendar.Event{
+			{
+				Id: "standup", Summary: "Standup",
+				Start: &calendar.EventDateTime{DateTime: "2025-01-06T09:00:00Z"},
+				End:   &calendar.EventDateTime{DateTime: "2025-01-06T09:30:00Z"},
+			},
+			{
+				Id: "lunch", Summary: "Lunch", Transparency: "transparent",
+				Start: &calendar.EventDateTime{DateTime: "2025-01-06T12:00:00Z"},
+				End:   &calendar.EventDateTime{DateTime: "2025-01-06T13:00:00Z"},
+			},
+			{
+				Id: "holiday", Summary: "Holiday",
+				Start: &calendar.EventDateTime{Date: "2025-01-06"},
+				End:   &calendar.EventDateTime{Date: "2025-01-07"},
+			},
+		}})
+	}))
+	defer api.Close()
+
+	t.Setenv("ISH_MODE", "true")
+	t.Setenv("ISH_BASE_URL", api.URL)
+
+	svc, err := NewService(context.Background(), nil)
+	require.NoError(t, err)
+
+	at := func(hour, minute int) time.Time { return time.Date(2025, 1, 6, hour, minute, 0, 0, time.UTC) }
+	slots := []ProposedSlot{
+		{Start: at(9, 15), End: at(10, 0), Summary: "Kickoff"},
+		{Start: at(10, 0), End: at(11, 0), Summary: "Design"},
+		{Start: at(10, 30), End: at(11, 30), Summary: "Budget"},
+		{Start: at(12, 0), End: at(12, 30), Summary: "Sync"},
+	}
+
+	conflicts, err := svc.ValidateSchedule(context.Background(), slots)
+	require.NoError(t, err)
+	assert.Equal(t, "2025-01-06T09:15:00Z", timeMin, "the window spans every slot")
+	assert.Equal(t, "2025-01-06T12:30:00Z", timeMax)
+
+	require.Len(t, conflicts, 2, "back-to-back slots, free events, and all-day events don't conflict")
+	assert.Equal(t, 0, conflicts[0].Slot)
+	assert.Equal(t, ConflictExternal, conflicts[0].Type)
+	assert.Equal(t, "standup", conflicts[0].EventID)
+	assert.Equal(t, "Standup", conflicts[0].With)
+	assert.Nil(t, conflicts[0].OtherSlot)
+
+	assert.Equal(t, 1, conflicts[1].Slot)
+	assert.Equal(t, ConflictInternal, conflicts[1].Type)
+	require.NotNil(t, conflicts[1].OtherSlot)
+	assert.Equal(t, 2, *conflicts[1].OtherSlot)
+	assert.Equal(t, "Budget", conflicts[1].With)
+	assert.Equal(t, at(10, 30), conflicts[1].Start)
+
+	_, err = svc.ValidateSchedule(context.Background(), []ProposedSlot{{Start: at(11, 0), End: at(10, 0)}})
+	assert.EqualError(t, err, "slot 0: end must be after start")
+	_, err = svc.ValidateSchedule(context.Background(), nil)
+	assert.EqualError(t, err, "at least one slot is required")
+}
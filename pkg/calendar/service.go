@@ -7,9 +7,16 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"net/mail"
+	"net/url"
 	"os"
+	"sort"
+	"strings"
+	"sync"
 	"time"
+	"unicode/utf8"
 
+	"github.com/harper/gsuite-mcp/pkg/apierr"
 	"github.com/harper/gsuite-mcp/pkg/retry"
 	"google.golang.org/api/calendar/v3"
 	"google.golang.org/api/option"
@@ -17,7 +24,17 @@ import (
 
 // Service wraps Calendar API operations
 type Service struct {
-	svc *calendar.Service
+	svc     *calendar.Service
+	breaker *retry.Breaker // Fails calls fast while the API is down
+
+	selfMu    sync.Mutex // Guards selfEmail
+	selfEmail string     // Authenticated user's address, looked up once
+
+	remindersMu      sync.Mutex // Guards the fields below
+	reminders        []*calendar.EventReminder
+	remindersFetched time.Time     // When reminders was fetched; zero if never
+	cacheTTL         time.Duration // How long the primary calendar's list entry is reused; 0 fetches it every time
+	now              func() time.Time
 }
 
 // NewService creates a new Calendar service
@@ -43,19 +60,89 @@ func NewService(ctx context.Context, client *http.Client) (*Service, error) {
 		return nil, fmt.Errorf("unable to create Calendar service: %w", err)
 	}
 
-	return &Service{svc: svc}, nil
+	return &Service{
+		svc:      svc,
+		breaker:  retry.NewBreaker(breakerName, retry.DefaultBreakerPolicy()),
+		cacheTTL: DefaultCacheTTL,
+		now:      time.Now,
+	}, nil
+}
+
+// DefaultCacheTTL is how long calendar list data is reused before it is fetched again
+const DefaultCacheTTL = 10 * time.Minute
+
+// SetCacheTTL sets how long calendar list data is reused. Zero disables caching.
+func (s *Service) SetCacheTTL(ttl time.Duration) {
+	s.remindersMu.Lock()
+	s.cacheTTL = ttl
+	s.remindersMu.Unlock()
+}
+
+// breakerName prefixes the error returned while the circuit is open
+const breakerName = "Calendar API"
+
+// SetBreakerPolicy replaces the circuit breaker shared by this service's calls
+func (s *Service) SetBreakerPolicy(policy retry.BreakerPolicy) {
+	s.breaker = retry.NewBreaker(breakerName, policy)
+}
+
+// Values accepted by the Calendar API's orderBy parameter
+const (
+	OrderByStartTime = "startTime" // Chronological; requires SingleEvents
+	OrderByUpdated   = "updated"   // Last modification time, ascending
+)
+
+// ListEventsOptions controls how recurring events are returned by ListEvents
+type ListEventsOptions struct {
+	SingleEvents bool   // Expand recurring series into individual instances
+	OrderBy      string // OrderByStartTime, OrderByUpdated, or empty for the API default
+	Query        string // Free-text search over summary, description, location, and attendees
+}
+
+// DefaultListEventsOptions expands recurring events in chronological order
+func DefaultListEventsOptions() *ListEventsOptions {
+	return &ListEventsOptions{SingleEvents: true, OrderBy: OrderByStartTime}
+}
+
+// Validate checks the combination of options the API accepts
+func (o *ListEventsOptions) Validate() error {
+	switch o.OrderBy {
+	case "", OrderByUpdated:
+	case OrderByStartTime:
+		if !o.SingleEvents {
+			return fmt.Errorf("order_by=startTime requires single_events=true")
+		}
+	default:
+		return fmt.Errorf("order_by must be startTime or updated (got %q)", o.OrderBy)
+	}
+	return nil
 }
 
 // ListEvents lists events from the primary calendar
-func (s *Service) ListEvents(ctx context.Context, maxResults int64, timeMin, timeMax time.Time) ([]*calendar.Event, error) {
+// A nil opts uses DefaultListEventsOptions.
+func (s *Service) ListEvents(ctx context.Context, maxResults int64, timeMin, timeMax time.Time, opts *ListEventsOptions) ([]*calendar.Event, error) {
+	if opts == nil {
+		opts = DefaultListEventsOptions()
+	}
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+
 	var events *calendar.Events
 
-	err := retry.WithRetry(func() error {
+	err := s.breaker.Do(func() error {
 		call := s.svc.Events.List("primary").
 			Context(ctx).
 			MaxResults(maxResults).
-			SingleEvents(true).
-			OrderBy("startTime")
+			SingleEvents(opts.SingleEvents)
+
+		if opts.OrderBy != "" {
+			call = call.OrderBy(opts.OrderBy)
+		}
+
+		if opts.Query != "" {
+			call = call.Q(opts.Query)
+		}
 
 		if !timeMin.IsZero() {
 			call = call.TimeMin(timeMin.Format(time.RFC3339))
@@ -68,7 +155,7 @@ func (s *Service) ListEvents(ctx context.Context, maxResults int64, timeMin, tim
 		var err error
 		events, err = call.Do()
 		return err
-	}, 3, time.Second)
+	})
 
 	if err != nil {
 		return nil, fmt.Errorf("unable to list events: %w", err)
@@ -77,8 +164,243 @@ func (s *Service) ListEvents(ctx context.Context, maxResults int64, timeMin, tim
 	return events.Items, nil
 }
 
+// Values accepted by the Calendar API's sendUpdates parameter
+const (
+	SendUpdatesAll          = "all"          // Notify every guest
+	SendUpdatesExternalOnly = "externalOnly" // Notify only guests outside the organizer's domain
+	SendUpdatesNone         = "none"         // Notify nobody
+)
+
+// ValidateSendUpdates checks that value is a sendUpdates mode the API accepts
+func ValidateSendUpdates(value string) error {
+	switch value {
+	case SendUpdatesAll, SendUpdatesExternalOnly, SendUpdatesNone:
+		return nil
+	default:
+		return fmt.Errorf("send_updates must be one of all, externalOnly, none (got %q)", value)
+	}
+}
+
+// Longest event text the API accepts, in characters. Longer values fail with
+// an unhelpful 400, so they are rejected before the request.
+const (
+	MaxSummaryLength     = 1024
+	MaxDescriptionLength = 8192
+)
+
+// ValidateEventText checks that summary and description are valid UTF-8 and
+// within the API's limits. Length is counted in runes, not bytes, so accented
+// and CJK text and emoji count as one character each.
+func ValidateEventText(summary, description string) error {
+	if err := validateTextField("summary", summary, MaxSummaryLength); err != nil {
+		return err
+	}
+	return validateTextField("description", description, MaxDescriptionLength)
+}
+
+// validateTextField checks one field against limit
+func validateTextField(field, value string, limit int) error {
+	if !utf8.ValidString(value) {
+		return fmt.Errorf("%s is not valid UTF-8", field)
+	}
+	if n := utf8.RuneCountInString(value); n > limit {
+		return fmt.Errorf("%s is %d characters; the limit is %d", field, n, limit)
+	}
+	return nil
+}
+
+// SendUpdatesFromBool maps the legacy send_notifications flag to a sendUpdates mode
+func SendUpdatesFromBool(sendNotifications bool) string {
+	if sendNotifications {
+		return SendUpdatesAll
+	}
+	return SendUpdatesNone
+}
+
+// Values accepted by the Calendar API's eventType field
+const (
+	EventTypeDefault         = "default"         // Regular event
+	EventTypeOutOfOffice     = "outOfOffice"     // Auto-declines conflicting invites
+	EventTypeFocusTime       = "focusTime"       // Auto-declines new invites and mutes chat
+	EventTypeWorkingLocation = "workingLocation" // Where the user works that day
+)
+
+// Values accepted for a working location's type
+const (
+	WorkingLocationHome   = "homeOffice"
+	WorkingLocationOffice = "officeLocation"
+	WorkingLocationCustom = "customLocation"
+)
+
+// maxReminderMinutes is the API's limit on how far ahead a reminder can fire (four weeks)
+const maxReminderMinutes = 40320
+
+// Auto-decline modes used for out-of-office and focus time events
+const (
+	autoDeclineAll = "declineAllConflictingInvitations"
+	autoDeclineNew = "declineOnlyNewConflictingInvitations"
+)
+
+// ValidateEventType checks that value is an event type the API lets clients create
+func ValidateEventType(value string) error {
+	switch value {
+	case EventTypeDefault, EventTypeOutOfOffice, EventTypeFocusTime, EventTypeWorkingLocation:
+		return nil
+	default:
+		return fmt.Errorf("event_type must be one of default, outOfOffice, focusTime, workingLocation (got %q)", value)
+	}
+}
+
+// EventOptions holds the optional settings for CreateEvent
+type EventOptions struct {
+	EventType            string              // One of the EventType* constants; empty means EventTypeDefault
+	DeclineMessage       string              // Reply sent when outOfOffice or focusTime auto-declines an invite
+	WorkingLocationType  string              // One of the WorkingLocation* constants, for workingLocation events
+	WorkingLocationLabel string              // Office or custom location name, for workingLocation events
+	ReminderMinutes      []int64             // Popup reminders, in minutes before start; nil keeps the calendar default
+	ExtendedProperties   *ExtendedProperties // Integration metadata stored on the event
+	Source               *EventSource        // Where the event was created from
+	EventID              string              // Caller-chosen event ID (see ValidateEventID); empty lets the API assign one
+	IncludeSelf          bool                // List the authenticated user as an accepted attendee
+	RoomEmail            string              // Room resource calendar to book; added as a resource attendee
+	Agenda               []string            // Agenda items rendered into an empty description
+	NotesLink            string              // Meeting notes URL rendered into an empty description
+}
+
+// Length limits the API places on caller-chosen event IDs
+const (
+	minEventIDLength = 5
+	maxEventIDLength = 1024
+)
+
+// ValidateEventID checks id against the API's rules for caller-chosen event IDs:
+// 5-1024 characters of base32hex (lowercase a-v and digits 0-9)
+func ValidateEventID(id string) error {
+	if len(id) < minEventIDLength || len(id) > maxEventIDLength {
+		return fmt.Errorf("event_id must be %d-%d characters (got %d)", minEventIDLength, maxEventIDLength, len(id))
+	}
+	for _, r := range id {
+		if (r < 'a' || r > 'v') && (r < '0' || r > '9') {
+			return fmt.Errorf("event_id may only contain lowercase letters a-v and digits 0-9 (got %q)", r)
+		}
+	}
+	return nil
+}
+
+// apply validates the options and sets the matching properties on event
+func (o *EventOptions) apply(event *calendar.Event) error {
+	eventType := o.EventType
+	if eventType == "" {
+		eventType = EventTypeDefault
+	}
+	if err := ValidateEventType(eventType); err != nil {
+		return err
+	}
+	if eventType != EventTypeDefault && len(event.Attendees) > 0 {
+		return fmt.Errorf("%s events cannot have attendees", eventType)
+	}
+
+	if o.EventID != "" {
+		if err := ValidateEventID(o.EventID); err != nil {
+			return err
+		}
+		event.Id = o.EventID
+	}
+
+	if o.ExtendedProperties != nil {
+		if err := MergeExtendedProperties(event, o.ExtendedProperties); err != nil {
+			return err
+		}
+	}
+
+	if o.Source != nil {
+		if err := o.Source.Validate(); err != nil {
+			return err
+		}
+		event.Source = &calendar.EventSource{Title: o.Source.Title, Url: o.Source.URL}
+	}
+
+	if o.ReminderMinutes != nil {
+		overrides := make([]*calendar.EventReminder, 0, len(o.ReminderMinutes))
+		for _, minutes := range o.ReminderMinutes {
+			if minutes < 0 || minutes > maxReminderMinutes {
+				return fmt.Errorf("reminder minutes must be between 0 and %d (got %d)", maxReminderMinutes, minutes)
+			}
+			overrides = append(overrides, &calendar.EventReminder{Method: "popup", Minutes: minutes})
+		}
+		event.Reminders = &calendar.EventReminders{
+			UseDefault:      false,
+			Overrides:       overrides,
+			ForceSendFields: []string{"UseDefault"},
+		}
+	}
+
+	event.EventType = eventType
+	switch eventType {
+	case EventTypeOutOfOffice:
+		event.OutOfOfficeProperties = &calendar.EventOutOfOfficeProperties{
+			AutoDeclineMode: autoDeclineAll,
+			DeclineMessage:  o.DeclineMessage,
+		}
+	case EventTypeFocusTime:
+		event.FocusTimeProperties = &calendar.EventFocusTimeProperties{
+			AutoDeclineMode: autoDeclineNew,
+			ChatStatus:      "doNotDisturb",
+			DeclineMessage:  o.DeclineMessage,
+		}
+	case EventTypeWorkingLocation:
+		props, err := workingLocationProperties(o.WorkingLocationType, o.WorkingLocationLabel)
+		if err != nil {
+			return err
+		}
+		event.WorkingLocationProperties = props
+		// The API requires working location events to be public and not block time
+		event.Visibility = "public"
+		event.Transparency = "transparent"
+	}
+	return nil
+}
+
+// workingLocationProperties builds the properties for a workingLocation event
+func workingLocationProperties(locationType, label string) (*calendar.EventWorkingLocationProperties, error) {
+	props := &calendar.EventWorkingLocationProperties{Type: locationType}
+	switch locationType {
+	case WorkingLocationHome:
+		props.HomeOffice = map[string]interface{}{}
+	case WorkingLocationOffice:
+		props.OfficeLocation = &calendar.EventWorkingLocationPropertiesOfficeLocation{Label: label}
+	case WorkingLocationCustom:
+		if label == "" {
+			return nil, fmt.Errorf("customLocation working location requires a label")
+		}
+		props.CustomLocation = &calendar.EventWorkingLocationPropertiesCustomLocation{Label: label}
+	default:
+		return nil, fmt.Errorf("working_location_type must be one of homeOffice, officeLocation, customLocation (got %q)", locationType)
+	}
+	return props, nil
+}
+
 // CreateEvent creates a new calendar event
-func (s *Service) CreateEvent(ctx context.Context, summary, description string, startTime, endTime time.Time, attendees []string, optionalAttendees []string, sendNotifications bool) (*calendar.Event, error) {
+// sendUpdates is one of the SendUpdates* constants. A nil opts creates a default event.
+func (s *Service) CreateEvent(ctx context.Context, summary, description string, startTime, endTime time.Time, attendees []string, optionalAttendees []string, sendUpdates string, opts *EventOptions) (*calendar.Event, error) {
+	if err := ValidateSendUpdates(sendUpdates); err != nil {
+		return nil, err
+	}
+	if opts == nil {
+		opts = &EventOptions{}
+	}
+	// An explicit description wins; otherwise build one from the agenda and notes link
+	if description == "" {
+		rendered, err := RenderMeetingDescription(opts.Agenda, opts.NotesLink, attendees, optionalAttendees)
+		if err != nil {
+			return nil, err
+		}
+		description = rendered
+	}
+	if err := ValidateEventText(summary, description); err != nil {
+		return nil, err
+	}
+
 	event := &calendar.Event{
 		Summary:     summary,
 		Description: description,
@@ -109,71 +431,415 @@ func (s *Service) CreateEvent(ctx context.Context, summary, description string,
 		})
 	}
 
+	if opts.IncludeSelf {
+		self, err := s.SelfEmail(ctx)
+		if err != nil {
+			return nil, err
+		}
+		eventAttendees = addSelfAttendee(eventAttendees, self)
+	}
+
+	if opts.RoomEmail != "" {
+		if err := ValidateRoomEmail(opts.RoomEmail); err != nil {
+			return nil, err
+		}
+		// Resource attendees are booked by Google, which accepts or declines
+		// on the room's behalf
+		eventAttendees = append(eventAttendees, &calendar.EventAttendee{Email: opts.RoomEmail, Resource: true})
+	}
+
 	// Only set attendees if we have any
 	if len(eventAttendees) > 0 {
 		event.Attendees = eventAttendees
 	}
 
+	if err := opts.apply(event); err != nil {
+		return nil, err
+	}
+
 	var created *calendar.Event
-	err := retry.WithRetry(func() error {
+	err := s.breaker.Do(func() error {
 		var err error
 		created, err = s.svc.Events.Insert("primary", event).
 			Context(ctx).
-			SendNotifications(sendNotifications).
+			SendUpdates(sendUpdates).
 			Do()
 		return err
-	}, 3, time.Second)
+	})
 
+	if opts.EventID != "" && apierr.IsConflict(err) {
+		return nil, fmt.Errorf("event with id %s already exists: %w", opts.EventID, err)
+	}
 	if err != nil {
-		return nil, fmt.Errorf("unable to create event: %w", err)
+		return nil, fmt.Errorf("unable to create event: %w", apierr.ClassifyScopeError(err, calendar.CalendarScope))
 	}
 
 	return created, nil
 }
 
+// SelfEmail returns the authenticated user's address, which is the ID of
+// their primary calendar, looked up once
+func (s *Service) SelfEmail(ctx context.Context) (string, error) {
+	s.selfMu.Lock()
+	defer s.selfMu.Unlock()
+	if s.selfEmail != "" {
+		return s.selfEmail, nil
+	}
+
+	var primary *calendar.Calendar
+	err := s.breaker.Do(func() error {
+		var err error
+		primary, err = s.svc.Calendars.Get("primary").Context(ctx).Fields("id").Do()
+		return err
+	})
+	if err != nil {
+		return "", fmt.Errorf("unable to look up your calendar address: %w", err)
+	}
+	s.selfEmail = primary.Id
+	return s.selfEmail, nil
+}
+
+// addSelfAttendee marks self as an accepted attendee, adding it unless an
+// entry for the same address is already in attendees
+func addSelfAttendee(attendees []*calendar.EventAttendee, self string) []*calendar.EventAttendee {
+	for _, attendee := range attendees {
+		if strings.EqualFold(attendee.Email, self) {
+			attendee.ResponseStatus = "accepted"
+			return attendees
+		}
+	}
+	return append(attendees, &calendar.EventAttendee{Email: self, ResponseStatus: "accepted"})
+}
+
+// ValidateRoomEmail checks that email is a bare address usable as a room's
+// resource calendar ID
+func ValidateRoomEmail(email string) error {
+	addr, err := mail.ParseAddress(email)
+	if err != nil || addr.Address != email {
+		return fmt.Errorf("room_email must be a plain email address (got %q)", email)
+	}
+	return nil
+}
+
+// RoomResponseStatus returns the response status of the resource attendee
+// room on event, or "" if the room isn't on it
+func RoomResponseStatus(event *calendar.Event, room string) string {
+	for _, attendee := range event.Attendees {
+		if attendee.Resource && strings.EqualFold(attendee.Email, room) {
+			return attendee.ResponseStatus
+		}
+	}
+	return ""
+}
+
 // GetEvent retrieves a specific event
 func (s *Service) GetEvent(ctx context.Context, eventID string) (*calendar.Event, error) {
 	var event *calendar.Event
 
-	err := retry.WithRetry(func() error {
+	err := s.breaker.Do(func() error {
 		var err error
 		event, err = s.svc.Events.Get("primary", eventID).Context(ctx).Do()
 		return err
-	}, 3, time.Second)
+	})
 
 	if err != nil {
-		return nil, fmt.Errorf("unable to get event: %w", err)
+		return nil, apierr.Wrap(err, "unable to get event", "event", eventID)
 	}
 	return event, nil
 }
 
 // UpdateEvent updates an existing event
-func (s *Service) UpdateEvent(ctx context.Context, eventID string, event *calendar.Event, sendNotifications bool) (*calendar.Event, error) {
+// sendUpdates is one of the SendUpdates* constants. Event text isn't checked
+// here, since most of it round-trips from the API; callers validate what they set.
+func (s *Service) UpdateEvent(ctx context.Context, eventID string, event *calendar.Event, sendUpdates string) (*calendar.Event, error) {
+	if err := ValidateSendUpdates(sendUpdates); err != nil {
+		return nil, err
+	}
+
 	var updated *calendar.Event
 
-	err := retry.WithRetry(func() error {
+	err := s.breaker.Do(func() error {
 		var err error
 		updated, err = s.svc.Events.Update("primary", eventID, event).
 			Context(ctx).
-			SendNotifications(sendNotifications).
+			SendUpdates(sendUpdates).
 			Do()
 		return err
-	}, 3, time.Second)
+	})
 
 	if err != nil {
-		return nil, fmt.Errorf("unable to update event: %w", err)
+		return nil, apierr.Wrap(apierr.ClassifyScopeError(err, calendar.CalendarScope), "unable to update event", "event", eventID)
 	}
 	return updated, nil
 }
 
 // DeleteEvent deletes an event
 func (s *Service) DeleteEvent(ctx context.Context, eventID string) error {
-	err := retry.WithRetry(func() error {
+	err := s.breaker.Do(func() error {
 		return s.svc.Events.Delete("primary", eventID).Context(ctx).Do()
-	}, 3, time.Second)
+	})
 
 	if err != nil {
-		return fmt.Errorf("unable to delete event: %w", err)
+		return apierr.Wrap(apierr.ClassifyScopeError(err, calendar.CalendarScope), "unable to delete event", "event", eventID)
 	}
 	return nil
 }
+
+// CancelEvent marks an event cancelled and notifies every guest. Unlike
+// DeleteEvent, the event stays visible as cancelled on attendees' calendars.
+// A non-empty note is appended to the description.
+func (s *Service) CancelEvent(ctx context.Context, eventID, note string) (*calendar.Event, error) {
+	if err := validateTextField("note", note, MaxDescriptionLength); err != nil {
+		return nil, err
+	}
+	event, err := s.GetEvent(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	event.Status = "cancelled"
+	if note = strings.TrimSpace(note); note != "" {
+		if event.Description == "" {
+			event.Description = note
+		} else {
+			event.Description = strings.TrimRight(event.Description, "\n") + "\n\n" + note
+		}
+	}
+
+	return s.UpdateEvent(ctx, eventID, event, SendUpdatesAll)
+}
+
+// FindEventsInWindow lists events on calendarID (empty means primary) matching the
+// free-text query that lie entirely within [timeMin, timeMax]. Recurring events are
+// expanded, so each returned event is a single occurrence.
+func (s *Service) FindEventsInWindow(ctx context.Context, query string, timeMin, timeMax time.Time, calendarID string) ([]*calendar.Event, error) {
+	if timeMin.IsZero() || timeMax.IsZero() {
+		return nil, fmt.Errorf("both time_min and time_max are required")
+	}
+	if !timeMin.Before(timeMax) {
+		return nil, fmt.Errorf("time_min must be before time_max")
+	}
+
+	events, err := s.listWindow(ctx, query, timeMin, timeMax, calendarID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The API returns events that merely overlap the window; keep only those inside it
+	var matches []*calendar.Event
+	for _, event := range events {
+		start, startErr := eventTime(event.Start)
+		end, endErr := eventTime(event.End)
+		if startErr != nil || endErr != nil {
+			continue
+		}
+		if !start.Before(timeMin) && !end.After(timeMax) {
+			matches = append(matches, event)
+		}
+	}
+	return matches, nil
+}
+
+// listWindow reads every page of expanded events on calendarID (empty means
+// primary) that overlap [timeMin, timeMax] and match query
+func (s *Service) listWindow(ctx context.Context, query string, timeMin, timeMax time.Time, calendarID string) ([]*calendar.Event, error) {
+	if calendarID == "" {
+		calendarID = "primary"
+	}
+
+	var events []*calendar.Event
+	pageToken := ""
+	for {
+		var page *calendar.Events
+		err := s.breaker.Do(func() error {
+			call := s.svc.Events.List(calendarID).
+				Context(ctx).
+				SingleEvents(true).
+				TimeMin(timeMin.Format(time.RFC3339)).
+				TimeMax(timeMax.Format(time.RFC3339))
+			if query != "" {
+				call = call.Q(query)
+			}
+			if pageToken != "" {
+				call = call.PageToken(pageToken)
+			}
+
+			var err error
+			page, err = call.Do()
+			return err
+		})
+		if err != nil {
+			return nil, fmt.Errorf("unable to list events: %w", err)
+		}
+
+		events = append(events, page.Items...)
+		if page.NextPageToken == "" {
+			return events, nil
+		}
+		pageToken = page.NextPageToken
+	}
+}
+
+// DeleteEventsByQuery deletes every event FindEventsInWindow returns and reports
+// how many were deleted. On failure the count covers the deletions that succeeded.
+func (s *Service) DeleteEventsByQuery(ctx context.Context, query string, timeMin, timeMax time.Time, calendarID string) (int, error) {
+	events, err := s.FindEventsInWindow(ctx, query, timeMin, timeMax, calendarID)
+	if err != nil {
+		return 0, err
+	}
+	if calendarID == "" {
+		calendarID = "primary"
+	}
+
+	deleted := 0
+	for _, event := range events {
+		err := s.breaker.Do(func() error {
+			return s.svc.Events.Delete(calendarID, event.Id).Context(ctx).Do()
+		})
+		if err != nil {
+			return deleted, fmt.Errorf("unable to delete event %s: %w", event.Id, apierr.ClassifyScopeError(err, calendar.CalendarScope))
+		}
+		deleted++
+	}
+	return deleted, nil
+}
+
+// eventTime parses a timed (dateTime) or all-day (date) event boundary
+func eventTime(dt *calendar.EventDateTime) (time.Time, error) {
+	if dt == nil {
+		return time.Time{}, fmt.Errorf("missing event time")
+	}
+	if dt.DateTime != "" {
+		return time.Parse(time.RFC3339, dt.DateTime)
+	}
+	return time.Parse("2006-01-02", dt.Date)
+}
+
+// Limits the Calendar API places on extended property keys and values
+const (
+	maxPropertyKeyLength   = 44
+	maxPropertyValueLength = 1024
+)
+
+// ExtendedProperties are integration-owned key/value pairs stored on an event
+type ExtendedProperties struct {
+	Private map[string]string `json:"private,omitempty"` // Visible only on this calendar's copy of the event
+	Shared  map[string]string `json:"shared,omitempty"`  // Visible on every attendee's copy
+}
+
+// Validate checks key and value lengths against the API limits
+func (p *ExtendedProperties) Validate() error {
+	for scope, props := range map[string]map[string]string{"private": p.Private, "shared": p.Shared} {
+		for key, value := range props {
+			if key == "" || len(key) > maxPropertyKeyLength {
+				return fmt.Errorf("%s extended property key %q must be 1-%d characters", scope, key, maxPropertyKeyLength)
+			}
+			if strings.Contains(key, "=") {
+				return fmt.Errorf("%s extended property key %q cannot contain '='", scope, key)
+			}
+			if len(value) > maxPropertyValueLength {
+				return fmt.Errorf("%s extended property %q value exceeds %d characters", scope, key, maxPropertyValueLength)
+			}
+		}
+	}
+	return nil
+}
+
+// EventSource links an event back to the system or page it was created from
+type EventSource struct {
+	Title string `json:"title,omitempty"`
+	URL   string `json:"url"`
+}
+
+// Validate checks that the URL is an absolute http or https URL, as the API requires
+func (src *EventSource) Validate() error {
+	u, err := url.Parse(src.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("source url must be an absolute http or https URL (got %q)", src.URL)
+	}
+	return nil
+}
+
+// MergeExtendedProperties adds props to the event's extended properties,
+// overwriting existing keys. An empty value removes the key.
+func MergeExtendedProperties(event *calendar.Event, props *ExtendedProperties) error {
+	if err := props.Validate(); err != nil {
+		return err
+	}
+	if event.ExtendedProperties == nil {
+		event.ExtendedProperties = &calendar.EventExtendedProperties{}
+	}
+	event.ExtendedProperties.Private = mergeProperties(event.ExtendedProperties.Private, props.Private)
+	event.ExtendedProperties.Shared = mergeProperties(event.ExtendedProperties.Shared, props.Shared)
+	return nil
+}
+
+// mergeProperties applies updates to existing, deleting keys whose new value is empty
+func mergeProperties(existing, updates map[string]string) map[string]string {
+	if len(updates) == 0 {
+		return existing
+	}
+	if existing == nil {
+		existing = make(map[string]string, len(updates))
+	}
+	for key, value := range updates {
+		if value == "" {
+			delete(existing, key)
+			continue
+		}
+		existing[key] = value
+	}
+	return existing
+}
+
+// propertyFilters formats properties as the API's key=value filter strings, sorted by key
+func propertyFilters(props map[string]string) []string {
+	filters := make([]string, 0, len(props))
+	for key, value := range props {
+		filters = append(filters, key+"="+value)
+	}
+	sort.Strings(filters)
+	return filters
+}
+
+// FindEventsByProperty lists primary-calendar events carrying every given
+// extended property. Zero times leave that side of the range open.
+func (s *Service) FindEventsByProperty(ctx context.Context, props *ExtendedProperties, timeMin, timeMax time.Time, maxResults int64) ([]*calendar.Event, error) {
+	if props == nil || len(props.Private)+len(props.Shared) == 0 {
+		return nil, fmt.Errorf("at least one private or shared extended property is required")
+	}
+	if err := props.Validate(); err != nil {
+		return nil, err
+	}
+
+	var events *calendar.Events
+	err := s.breaker.Do(func() error {
+		call := s.svc.Events.List("primary").
+			Context(ctx).
+			MaxResults(maxResults).
+			SingleEvents(true).
+			OrderBy(OrderByStartTime)
+
+		if len(props.Private) > 0 {
+			call = call.PrivateExtendedProperty(propertyFilters(props.Private)...)
+		}
+		if len(props.Shared) > 0 {
+			call = call.SharedExtendedProperty(propertyFilters(props.Shared)...)
+		}
+		if !timeMin.IsZero() {
+			call = call.TimeMin(timeMin.Format(time.RFC3339))
+		}
+		if !timeMax.IsZero() {
+			call = call.TimeMax(timeMax.Format(time.RFC3339))
+		}
+
+		var err error
+		events, err = call.Do()
+		return err
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to find events by property: %w", err)
+	}
+	return events.Items, nil
+}
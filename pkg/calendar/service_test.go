@@ -5,11 +5,19 @@ package calendar
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/harper/gsuite-mcp/pkg/apierr"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/api/calendar/v3"
 )
 
 func TestNewService_WithIshMode(t *testing.T) {
@@ -53,7 +61,7 @@ func TestService_CreateEvent_Basic(t *testing.T) {
 	end := start.Add(1 * time.Hour)
 
 	// Test that the method signature is correct (without attendees - backward compat)
-	_, err = svc.CreateEvent(context.Background(), "Test Event", "Test Description", start, end, []string{}, []string{}, false)
+	_, err = svc.CreateEvent(context.Background(), "Test Event", "Test Description", start, end, []string{}, []string{}, SendUpdatesNone, nil)
 
 	// We expect it to fail because there's no ish server running,
 	// but we're testing that the method exists and has the right signature
@@ -118,7 +126,8 @@ func TestService_CreateEvent_WithAttendees(t *testing.T) {
 			end,
 			attendees,
 			optionalAttendees,
-			sendNotifications,
+			SendUpdatesFromBool(sendNotifications),
+			nil,
 		)
 
 		// This test will FAIL until implementation is added
@@ -140,7 +149,8 @@ func TestService_CreateEvent_WithAttendees(t *testing.T) {
 			end,
 			attendees,
 			optionalAttendees,
-			sendNotifications,
+			SendUpdatesFromBool(sendNotifications),
+			nil,
 		)
 
 		// This test will FAIL until implementation is added
@@ -162,7 +172,8 @@ func TestService_CreateEvent_WithAttendees(t *testing.T) {
 			end,
 			attendees,
 			optionalAttendees,
-			sendNotifications,
+			SendUpdatesFromBool(sendNotifications),
+			nil,
 		)
 
 		// This test will FAIL until implementation is added
@@ -184,7 +195,8 @@ func TestService_CreateEvent_WithAttendees(t *testing.T) {
 			end,
 			attendees,
 			optionalAttendees,
-			sendNotifications,
+			SendUpdatesFromBool(sendNotifications),
+			nil,
 		)
 
 		// This test will FAIL until implementation is added
@@ -206,7 +218,8 @@ func TestService_CreateEvent_WithAttendees(t *testing.T) {
 			end,
 			attendees,
 			optionalAttendees,
-			sendNotifications,
+			SendUpdatesFromBool(sendNotifications),
+			nil,
 		)
 
 		// This test will FAIL until implementation is added
@@ -228,7 +241,8 @@ func TestService_CreateEvent_WithAttendees(t *testing.T) {
 			end,
 			attendees,
 			optionalAttendees,
-			sendNotifications,
+			SendUpdatesFromBool(sendNotifications),
+			nil,
 		)
 
 		// This should work like the old API (no attendees)
@@ -238,3 +252,511 @@ func TestService_CreateEvent_WithAttendees(t *testing.T) {
 		}
 	})
 }
+
+// recurringEventsHandler serves a daily standup as a single master event, or as
+// expanded instances when singleEvents=true, recording the query it received
+func recurringEventsHandler(seen *url.Values) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		*seen = r.URL.Query()
+
+		items := []map[string]interface{}{{
+			"id":         "standup",
+			"summary":    "Daily standup",
+			"recurrence": []string{"RRULE:FREQ=DAILY;COUNT=3"},
+			"start":      map[string]string{"dateTime": "2025-01-06T09:00:00Z"},
+		}}
+		if r.URL.Query().Get("singleEvents") == "true" {
+			items = nil
+			for _, day := range []string{"06", "07", "08"} {
+				items = append(items, map[string]interface{}{
+					"id":               "standup_202501" + day,
+					"summary":          "Daily standup",
+					"recurringEventId": "standup",
+					"start":            map[string]string{"dateTime": "2025-01-" + day + "T09:00:00Z"},
+				})
+			}
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"items": items})
+	}
+}
+
+func TestListEvents_ExpandsRecurringEvents(t *testing.T) {
+	var seen url.Values
+	api := httptest.NewServer(recurringEventsHandler(&seen))
+	defer api.Close()
+
+	t.Setenv("ISH_MODE", "true")
+	t.Setenv("ISH_BASE_URL", api.URL)
+
+	svc, err := NewService(context.Background(), nil)
+	require.NoError(t, err)
+
+	t.Run("default expands in start order", func(t *testing.T) {
+		events, err := svc.ListEvents(context.Background(), 10, time.Time{}, time.Time{}, nil)
+		require.NoError(t, err)
+
+		assert.Equal(t, "true", seen.Get("singleEvents"))
+		assert.Equal(t, "startTime", seen.Get("orderBy"))
+		require.Len(t, events, 3)
+		for _, event := range events {
+			assert.Equal(t, "standup", event.RecurringEventId)
+		}
+	})
+
+	t.Run("single_events false returns the series master", func(t *testing.T) {
+		events, err := svc.ListEvents(context.Background(), 10, time.Time{}, time.Time{},
+			&ListEventsOptions{SingleEvents: false, OrderBy: OrderByUpdated})
+		require.NoError(t, err)
+
+		assert.Equal(t, "false", seen.Get("singleEvents"))
+		assert.Equal(t, "updated", seen.Get("orderBy"))
+		require.Len(t, events, 1)
+		assert.NotEmpty(t, events[0].Recurrence)
+	})
+}
+
+func TestListEventsOptions_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    ListEventsOptions
+		wantErr bool
+	}{
+		{name: "expanded by start time", opts: ListEventsOptions{SingleEvents: true, OrderBy: OrderByStartTime}},
+		{name: "expanded by updated", opts: ListEventsOptions{SingleEvents: true, OrderBy: OrderByUpdated}},
+		{name: "unexpanded without order", opts: ListEventsOptions{SingleEvents: false}},
+		{name: "startTime requires single events", opts: ListEventsOptions{SingleEvents: false, OrderBy: OrderByStartTime}, wantErr: true},
+		{name: "unknown order", opts: ListEventsOptions{SingleEvents: true, OrderBy: "title"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.opts.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+// insertedEventHandler records the decoded body of every event insert and echoes it back
+func insertedEventHandler(inserted *[]map[string]interface{}) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		*inserted = append(*inserted, body)
+		body["id"] = "evt-1"
+		_ = json.NewEncoder(w).Encode(body)
+	}
+}
+
+func TestCreateEvent_EventTypes(t *testing.T) {
+	var inserted []map[string]interface{}
+	api := httptest.NewServer(insertedEventHandler(&inserted))
+	defer api.Close()
+
+	t.Setenv("ISH_MODE", "true")
+	t.Setenv("ISH_BASE_URL", api.URL)
+
+	svc, err := NewService(context.Background(), nil)
+	require.NoError(t, err)
+
+	start := time.Date(2025, 1, 6, 9, 0, 0, 0, time.UTC)
+	end := start.Add(8 * time.Hour)
+
+	t.Run("nil options create a default event", func(t *testing.T) {
+		event, err := svc.CreateEvent(context.Background(), "Sync", "", start, end, nil, nil, SendUpdatesNone, nil)
+		require.NoError(t, err)
+		assert.Equal(t, EventTypeDefault, event.EventType)
+	})
+
+	t.Run("out of office auto-declines invites", func(t *testing.T) {
+		event, err := svc.CreateEvent(context.Background(), "Vacation", "", start, end, nil, nil, SendUpdatesNone,
+			&EventOptions{EventType: EventTypeOutOfOffice, DeclineMessage: "Back Monday"})
+		require.NoError(t, err)
+
+		assert.Equal(t, EventTypeOutOfOffice, event.EventType)
+		require.NotNil(t, event.OutOfOfficeProperties)
+		assert.Equal(t, "declineAllConflictingInvitations", event.OutOfOfficeProperties.AutoDeclineMode)
+		assert.Equal(t, "Back Monday", event.OutOfOfficeProperties.DeclineMessage)
+	})
+
+	t.Run("focus time mutes chat", func(t *testing.T) {
+		event, err := svc.CreateEvent(context.Background(), "Deep work", "", start, end, nil, nil, SendUpdatesNone,
+			&EventOptions{EventType: EventTypeFocusTime})
+		require.NoError(t, err)
+
+		require.NotNil(t, event.FocusTimeProperties)
+		assert.Equal(t, "doNotDisturb", event.FocusTimeProperties.ChatStatus)
+	})
+
+	t.Run("working location is public and transparent", func(t *testing.T) {
+		event, err := svc.CreateEvent(context.Background(), "Office", "", start, end, nil, nil, SendUpdatesNone,
+			&EventOptions{EventType: EventTypeWorkingLocation, WorkingLocationType: WorkingLocationOffice, WorkingLocationLabel: "HQ"})
+		require.NoError(t, err)
+
+		require.NotNil(t, event.WorkingLocationProperties)
+		assert.Equal(t, "officeLocation", event.WorkingLocationProperties.Type)
+		assert.Equal(t, "HQ", event.WorkingLocationProperties.OfficeLocation.Label)
+		assert.Equal(t, "public", event.Visibility)
+		assert.Equal(t, "transparent", event.Transparency)
+	})
+
+	t.Run("invalid options are rejected before the API call", func(t *testing.T) {
+		invalid := []struct {
+			name      string
+			attendees []string
+			opts      *EventOptions
+		}{
+			{name: "unsupported type", opts: &EventOptions{EventType: "birthday"}},
+			{name: "out of office with attendees", attendees: []string{"a@example.com"}, opts: &EventOptions{EventType: EventTypeOutOfOffice}},
+			{name: "working location without type", opts: &EventOptions{EventType: EventTypeWorkingLocation}},
+			{name: "custom location without label", opts: &EventOptions{EventType: EventTypeWorkingLocation, WorkingLocationType: WorkingLocationCustom}},
+		}
+
+		for _, tt := range invalid {
+			before := len(inserted)
+			_, err := svc.CreateEvent(context.Background(), "Bad", "", start, end, tt.attendees, nil, SendUpdatesNone, tt.opts)
+			assert.Error(t, err, tt.name)
+			assert.Len(t, inserted, before, tt.name)
+		}
+	})
+}
+
+// windowEventsHandler serves a fixed event list and records deleted event IDs
+func windowEventsHandler(deleted *[]string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			*deleted = append(*deleted, r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:])
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		event := func(id, start, end string) map[string]interface{} {
+			return map[string]interface{}{
+				"id":    id,
+				"start": map[string]string{"dateTime": start},
+				"end":   map[string]string{"dateTime": end},
+			}
+		}
+		// The API returns anything overlapping the window, including events that spill out of it
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"items": []interface{}{
+			event("inside-1", "2025-01-06T09:00:00Z", "2025-01-06T10:00:00Z"),
+			event("inside-2", "2025-01-06T15:00:00Z", "2025-01-06T16:00:00Z"),
+			event("starts-before", "2025-01-05T23:00:00Z", "2025-01-06T01:00:00Z"),
+			event("ends-after", "2025-01-06T23:30:00Z", "2025-01-07T00:30:00Z"),
+		}})
+	}
+}
+
+func TestDeleteEventsByQuery_OnlyInsideWindow(t *testing.T) {
+	var deleted []string
+	api := httptest.NewServer(windowEventsHandler(&deleted))
+	defer api.Close()
+
+	t.Setenv("ISH_MODE", "true")
+	t.Setenv("ISH_BASE_URL", api.URL)
+
+	svc, err := NewService(context.Background(), nil)
+	require.NoError(t, err)
+
+	dayStart := time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC)
+	dayEnd := dayStart.Add(24 * time.Hour)
+
+	count, err := svc.DeleteEventsByQuery(context.Background(), "test", dayStart, dayEnd, "")
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, count)
+	assert.Equal(t, []string{"inside-1", "inside-2"}, deleted)
+}
+
+func TestDeleteEventsByQuery_RequiresWindow(t *testing.T) {
+	var deleted []string
+	api := httptest.NewServer(windowEventsHandler(&deleted))
+	defer api.Close()
+
+	t.Setenv("ISH_MODE", "true")
+	t.Setenv("ISH_BASE_URL", api.URL)
+
+	svc, err := NewService(context.Background(), nil)
+	require.NoError(t, err)
+
+	now := time.Now()
+	for _, window := range [][2]time.Time{{{}, now}, {now, {}}, {now, now.Add(-time.Hour)}} {
+		_, err := svc.DeleteEventsByQuery(context.Background(), "", window[0], window[1], "")
+		assert.Error(t, err)
+	}
+	assert.Empty(t, deleted)
+}
+
+func TestFindEventsByProperty_ForwardsFilters(t *testing.T) {
+	var seen url.Values
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = r.URL.Query()
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"items": []interface{}{}})
+	}))
+	defer api.Close()
+
+	t.Setenv("ISH_MODE", "true")
+	t.Setenv("ISH_BASE_URL", api.URL)
+
+	svc, err := NewService(context.Background(), nil)
+	require.NoError(t, err)
+
+	_, err = svc.FindEventsByProperty(context.Background(), &ExtendedProperties{
+		Private: map[string]string{"ticket": "OPS-12", "source": "jira"},
+		Shared:  map[string]string{"team": "platform"},
+	}, time.Time{}, time.Time{}, 10)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"source=jira", "ticket=OPS-12"}, seen["privateExtendedProperty"])
+	assert.Equal(t, []string{"team=platform"}, seen["sharedExtendedProperty"])
+
+	_, err = svc.FindEventsByProperty(context.Background(), &ExtendedProperties{}, time.Time{}, time.Time{}, 10)
+	assert.Error(t, err, "an empty filter would list every event")
+}
+
+func TestMergeExtendedProperties(t *testing.T) {
+	event := &calendar.Event{ExtendedProperties: &calendar.EventExtendedProperties{
+		Private: map[string]string{"keep": "1", "drop": "2", "change": "old"},
+	}}
+
+	err := MergeExtendedProperties(event, &ExtendedProperties{
+		Private: map[string]string{"drop": "", "change": "new"},
+		Shared:  map[string]string{"team": "platform"},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]string{"keep": "1", "change": "new"}, event.ExtendedProperties.Private)
+	assert.Equal(t, map[string]string{"team": "platform"}, event.ExtendedProperties.Shared)
+
+	err = MergeExtendedProperties(event, &ExtendedProperties{Private: map[string]string{"a=b": "c"}})
+	assert.Error(t, err)
+}
+
+func TestGetEvent_NotFound(t *testing.T) {
+	for _, status := range []int{http.StatusNotFound, http.StatusGone} {
+		t.Run(http.StatusText(status), func(t *testing.T) {
+			api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(status)
+				_, _ = fmt.Fprintf(w, `{"error": {"code": %d, "message": "Not Found"}}`, status)
+			}))
+			defer api.Close()
+
+			t.Setenv("ISH_MODE", "true")
+			t.Setenv("ISH_BASE_URL", api.URL)
+
+			svc, err := NewService(context.Background(), nil)
+			require.NoError(t, err)
+
+			_, err = svc.GetEvent(context.Background(), "evt123")
+			assert.ErrorIs(t, err, apierr.ErrNotFound)
+			assert.EqualError(t, err, "event evt123 not found")
+
+			err = svc.DeleteEvent(context.Background(), "evt123")
+			assert.ErrorIs(t, err, apierr.ErrNotFound)
+		})
+	}
+}
+
+func TestCreateEvent_CustomID(t *testing.T) {
+	taken := map[string]bool{"takenid1": true}
+	var inserted []map[string]interface{}
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		if id, _ := body["id"].(string); taken[id] {
+			w.WriteHeader(http.StatusConflict)
+			_, _ = w.Write([]byte(`{"error": {"code": 409, "message": "The requested identifier already exists."}}`))
+			return
+		}
+		inserted = append(inserted, body)
+		_ = json.NewEncoder(w).Encode(body)
+	}))
+	defer api.Close()
+
+	t.Setenv("ISH_MODE", "true")
+	t.Setenv("ISH_BASE_URL", api.URL)
+
+	svc, err := NewService(context.Background(), nil)
+	require.NoError(t, err)
+
+	start := time.Date(2025, 1, 6, 9, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+
+	event, err := svc.CreateEvent(context.Background(), "Sync", "", start, end, nil, nil, SendUpdatesNone,
+		&EventOptions{EventID: "crm0deal42"})
+	require.NoError(t, err)
+	assert.Equal(t, "crm0deal42", event.Id)
+	require.Len(t, inserted, 1)
+	assert.Equal(t, "crm0deal42", inserted[0]["id"])
+
+	_, err = svc.CreateEvent(context.Background(), "Sync", "", start, end, nil, nil, SendUpdatesNone,
+		&EventOptions{EventID: "takenid1"})
+	assert.ErrorContains(t, err, "event with id takenid1 already exists")
+
+	for _, id := range []string{"CRM0DEAL42", "abcd", "has-dash", "wxyz0", strings.Repeat("a", 1025)} {
+		_, err := svc.CreateEvent(context.Background(), "Sync", "", start, end, nil, nil, SendUpdatesNone,
+			&EventOptions{EventID: id})
+		assert.Error(t, err, "id %q", id)
+	}
+	assert.Len(t, inserted, 1, "invalid IDs are rejected before the API call")
+}
+
+func TestCreateEvent_IncludeSelf(t *testing.T) {
+	var inserted *calendar.Event
+	var lookups int
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			lookups++
+			assert.True(t, strings.HasSuffix(r.URL.Path, "/calendars/primary"))
+			_ = json.NewEncoder(w).Encode(&calendar.Calendar{Id: "me@example.com"})
+			return
+		}
+		inserted = &calendar.Event{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(inserted))
+		_ = json.NewEncoder(w).Encode(inserted)
+	}))
+	defer api.Close()
+
+	t.Setenv("ISH_MODE", "true")
+	t.Setenv("ISH_BASE_URL", api.URL)
+
+	svc, err := NewService(context.Background(), nil)
+	require.NoError(t, err)
+
+	start := time.Date(2025, 1, 6, 9, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+
+	_, err = svc.CreateEvent(context.Background(), "Sync", "", start, end, []string{"bob@example.com"}, nil, SendUpdatesNone,
+		&EventOptions{IncludeSelf: true})
+	require.NoError(t, err)
+	require.Len(t, inserted.Attendees, 2)
+	assert.Equal(t, "bob@example.com", inserted.Attendees[0].Email)
+	assert.Empty(t, inserted.Attendees[0].ResponseStatus)
+	assert.Equal(t, "me@example.com", inserted.Attendees[1].Email)
+	assert.Equal(t, "accepted", inserted.Attendees[1].ResponseStatus)
+
+	// Already listed: marked accepted rather than duplicated, and the address is cached
+	_, err = svc.CreateEvent(context.Background(), "Sync", "", start, end, []string{"Me@example.com"}, nil, SendUpdatesNone,
+		&EventOptions{IncludeSelf: true})
+	require.NoError(t, err)
+	require.Len(t, inserted.Attendees, 1)
+	assert.Equal(t, "accepted", inserted.Attendees[0].ResponseStatus)
+	assert.Equal(t, 1, lookups)
+
+	_, err = svc.CreateEvent(context.Background(), "Sync", "", start, end, []string{"bob@example.com"}, nil, SendUpdatesNone, nil)
+	require.NoError(t, err)
+	require.Len(t, inserted.Attendees, 1, "off by default")
+}
+
+func TestCreateEvent_RoomEmail(t *testing.T) {
+	var inserted *calendar.Event
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		inserted = &calendar.Event{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(inserted))
+		// Google answers for the room in the insert response
+		for _, attendee := range inserted.Attendees {
+			if attendee.Resource {
+				attendee.ResponseStatus = "accepted"
+			}
+		}
+		_ = json.NewEncoder(w).Encode(inserted)
+	}))
+	defer api.Close()
+
+	t.Setenv("ISH_MODE", "true")
+	t.Setenv("ISH_BASE_URL", api.URL)
+
+	svc, err := NewService(context.Background(), nil)
+	require.NoError(t, err)
+
+	start := time.Date(2025, 1, 6, 9, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+	room := "boardroom@resource.calendar.google.com"
+
+	event, err := svc.CreateEvent(context.Background(), "Sync", "", start, end, []string{"bob@example.com"}, nil, SendUpdatesNone,
+		&EventOptions{RoomEmail: room})
+	require.NoError(t, err)
+	require.Len(t, inserted.Attendees, 2)
+	assert.Equal(t, "bob@example.com", inserted.Attendees[0].Email)
+	assert.False(t, inserted.Attendees[0].Resource)
+	assert.Equal(t, room, inserted.Attendees[1].Email)
+	assert.True(t, inserted.Attendees[1].Resource)
+	assert.Equal(t, "accepted", RoomResponseStatus(event, room))
+	assert.Empty(t, RoomResponseStatus(event, "bob@example.com"), "only resource attendees count as the room")
+
+	inserted = nil
+	for _, bad := range []string{"boardroom", "Board Room <boardroom@resource.calendar.google.com>"} {
+		_, err = svc.CreateEvent(context.Background(), "Sync", "", start, end, nil, nil, SendUpdatesNone, &EventOptions{RoomEmail: bad})
+		assert.ErrorContains(t, err, "room_email must be a plain email address")
+	}
+	assert.Nil(t, inserted, "invalid rooms are rejected before the API call")
+}
+
+func TestCancelEvent(t *testing.T) {
+	var updated map[string]interface{}
+	var sendUpdates string
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			_ = json.NewEncoder(w).Encode(&calendar.Event{Id: "evt1", Summary: "Offsite", Description: "Agenda TBD\n", Status: "confirmed"})
+			return
+		}
+		assert.Equal(t, http.MethodPut, r.Method, "cancelling updates rather than deletes")
+		sendUpdates = r.URL.Query().Get("sendUpdates")
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&updated))
+		_ = json.NewEncoder(w).Encode(updated)
+	}))
+	defer api.Close()
+
+	t.Setenv("ISH_MODE", "true")
+	t.Setenv("ISH_BASE_URL", api.URL)
+
+	svc, err := NewService(context.Background(), nil)
+	require.NoError(t, err)
+
+	event, err := svc.CancelEvent(context.Background(), "evt1", "Moving to next quarter.")
+	require.NoError(t, err)
+	assert.Equal(t, "cancelled", event.Status)
+	assert.Equal(t, "cancelled", updated["status"])
+	assert.Equal(t, "Agenda TBD\n\nMoving to next quarter.", updated["description"])
+	assert.Equal(t, SendUpdatesAll, sendUpdates)
+
+	_, err = svc.CancelEvent(context.Background(), "evt1", "")
+	require.NoError(t, err)
+	assert.Equal(t, "Agenda TBD\n", updated["description"], "no note leaves the description alone")
+}
+
+func TestValidateEventText(t *testing.T) {
+	assert.NoError(t, ValidateEventText("Sync", "Agenda"))
+
+	// 1024 three-byte runes are 3072 bytes but still within the limit
+	assert.NoError(t, ValidateEventText(strings.Repeat("会", MaxSummaryLength), ""))
+	assert.NoError(t, ValidateEventText("", strings.Repeat("é", MaxDescriptionLength)))
+
+	assert.EqualError(t, ValidateEventText(strings.Repeat("a", MaxSummaryLength+1), ""), "summary is 1025 characters; the limit is 1024")
+	assert.EqualError(t, ValidateEventText("", strings.Repeat("🎉", MaxDescriptionLength+1)), "description is 8193 characters; the limit is 8192")
+	assert.EqualError(t, ValidateEventText("bad \xff byte", ""), "summary is not valid UTF-8")
+}
+
+func TestCreateAndCancelEvent_RejectOverLengthText(t *testing.T) {
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected API call %s %s", r.Method, r.URL.Path)
+	}))
+	defer api.Close()
+
+	t.Setenv("ISH_MODE", "true")
+	t.Setenv("ISH_BASE_URL", api.URL)
+
+	svc, err := NewService(context.Background(), nil)
+	require.NoError(t, err)
+
+	start := time.Date(2025, 1, 6, 9, 0, 0, 0, time.UTC)
+	long := strings.Repeat("x", MaxSummaryLength+1)
+
+	_, err = svc.CreateEvent(context.Background(), long, "", start, start.Add(time.Hour), nil, nil, SendUpdatesNone, nil)
+	assert.ErrorContains(t, err, "summary is 1025 characters")
+
+	_, err = svc.CancelEvent(context.Background(), "evt1", strings.Repeat("x", MaxDescriptionLength+1))
+	assert.ErrorContains(t, err, "note is 8193 characters")
+}
@@ -0,0 +1,152 @@
+// ABOUTME: Hashable snapshots of the events in a time window and diffs between them
+// ABOUTME: Lets callers detect calendar changes between checks without a sync token
+
+package calendar
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"google.golang.org/api/calendar/v3"
+)
+
+// Snapshot is a stable representation of the events in a window. Events are
+// sorted by ID and attendees by address, so equal calendars hash equally.
+type Snapshot struct {
+	TimeMin string          `json:"time_min"`
+	TimeMax string          `json:"time_max"`
+	Hash    string          `json:"hash"` // SHA-256 over Events
+	Events  []SnapshotEvent `json:"events"`
+}
+
+// SnapshotEvent is the part of an event a snapshot tracks
+type SnapshotEvent struct {
+	ID        string   `json:"id"`
+	Summary   string   `json:"summary,omitempty"`
+	Start     string   `json:"start"` // RFC3339 dateTime, or a date for all-day events
+	End       string   `json:"end"`
+	Attendees []string `json:"attendees,omitempty"` // Lowercased addresses
+	Updated   string   `json:"updated"`             // Last modification time, as reported by the API
+}
+
+// SnapshotEvents captures the events on calendarID (empty means primary) that
+// overlap [timeMin, timeMax]. Recurring events are expanded into occurrences.
+func (s *Service) SnapshotEvents(ctx context.Context, timeMin, timeMax time.Time, calendarID string) (*Snapshot, error) {
+	if timeMin.IsZero() || timeMax.IsZero() {
+		return nil, fmt.Errorf("both time_min and time_max are required")
+	}
+	if !timeMin.Before(timeMax) {
+		return nil, fmt.Errorf("time_min must be before time_max")
+	}
+
+	events, err := s.listWindow(ctx, "", timeMin, timeMax, calendarID)
+	if err != nil {
+		return nil, err
+	}
+	return NewSnapshot(timeMin, timeMax, events), nil
+}
+
+// NewSnapshot builds a snapshot of events for the window [timeMin, timeMax]
+func NewSnapshot(timeMin, timeMax time.Time, events []*calendar.Event) *Snapshot {
+	snapshot := &Snapshot{
+		TimeMin: timeMin.Format(time.RFC3339),
+		TimeMax: timeMax.Format(time.RFC3339),
+		Events:  make([]SnapshotEvent, 0, len(events)),
+	}
+	for _, event := range events {
+		snapshot.Events = append(snapshot.Events, snapshotEvent(event))
+	}
+	sort.Slice(snapshot.Events, func(i, j int) bool { return snapshot.Events[i].ID < snapshot.Events[j].ID })
+	snapshot.Hash = hashEvents(snapshot.Events)
+	return snapshot
+}
+
+func snapshotEvent(event *calendar.Event) SnapshotEvent {
+	snap := SnapshotEvent{
+		ID:      event.Id,
+		Summary: event.Summary,
+		Start:   snapshotTime(event.Start),
+		End:     snapshotTime(event.End),
+		Updated: event.Updated,
+	}
+	for _, attendee := range event.Attendees {
+		snap.Attendees = append(snap.Attendees, strings.ToLower(attendee.Email))
+	}
+	sort.Strings(snap.Attendees)
+	return snap
+}
+
+func snapshotTime(dt *calendar.EventDateTime) string {
+	if dt == nil {
+		return ""
+	}
+	if dt.DateTime != "" {
+		return dt.DateTime
+	}
+	return dt.Date
+}
+
+func hashEvents(events []SnapshotEvent) string {
+	data, _ := json.Marshal(events)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// SnapshotDiff lists how the events changed between two snapshots
+type SnapshotDiff struct {
+	Added     []SnapshotEvent `json:"added"`
+	Removed   []SnapshotEvent `json:"removed"`
+	Modified  []EventChange   `json:"modified"`
+	Unchanged int             `json:"unchanged"`
+}
+
+// EventChange is an event present in both snapshots whose updated time differs
+type EventChange struct {
+	ID     string        `json:"id"`
+	Before SnapshotEvent `json:"before"`
+	After  SnapshotEvent `json:"after"`
+}
+
+// DiffSnapshots compares two snapshots by event ID. An event in both is
+// modified when its updated timestamp changed. Results are sorted by ID.
+func DiffSnapshots(before, after *Snapshot) *SnapshotDiff {
+	diff := &SnapshotDiff{
+		Added:    []SnapshotEvent{},
+		Removed:  []SnapshotEvent{},
+		Modified: []EventChange{},
+	}
+
+	old := make(map[string]SnapshotEvent, len(before.Events))
+	for _, event := range before.Events {
+		old[event.ID] = event
+	}
+	seen := make(map[string]bool, len(after.Events))
+	for _, event := range after.Events {
+		seen[event.ID] = true
+		prev, ok := old[event.ID]
+		switch {
+		case !ok:
+			diff.Added = append(diff.Added, event)
+		case prev.Updated != event.Updated:
+			diff.Modified = append(diff.Modified, EventChange{ID: event.ID, Before: prev, After: event})
+		default:
+			diff.Unchanged++
+		}
+	}
+	for _, event := range before.Events {
+		if !seen[event.ID] {
+			diff.Removed = append(diff.Removed, event)
+		}
+	}
+
+	sort.Slice(diff.Added, func(i, j int) bool { return diff.Added[i].ID < diff.Added[j].ID })
+	sort.Slice(diff.Removed, func(i, j int) bool { return diff.Removed[i].ID < diff.Removed[j].ID })
+	sort.Slice(diff.Modified, func(i, j int) bool { return diff.Modified[i].ID < diff.Modified[j].ID })
+	return diff
+}
@@ -0,0 +1,118 @@
+// ABOUTME: Tests for calendar snapshots and diffs
+// ABOUTME: Verifies stable hashing and classification of added, removed, and modified events
+
+package calendar
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/calendar/v3"
+)
+
+func snapshotTestEvent(id, summary, updated string, attendees ...string) *calendar.Event {
+	event := &calendar.Event{
+		Id:      id,
+		Summary: summary,
+		Start:   &calendar.EventDateTime{DateTime: "2025-03-03T10:00:00Z"},
+		End:     &calendar.EventDateTime{DateTime: "2025-03-03T11:00:00Z"},
+		Updated: updated,
+	}
+	for _, email := range attendees {
+		event.Attendees = append(event.Attendees, &calendar.EventAttendee{Email: email})
+	}
+	return event
+}
+
+func TestNewSnapshot_StableHash(t *testing.T) {
+	timeMin := time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC)
+	timeMax := timeMin.AddDate(0, 0, 7)
+
+	a := NewSnapshot(timeMin, timeMax, []*calendar.Event{
+		snapshotTestEvent("evt1", "Standup", "2025-03-01T09:00:00Z", "Bob@example.com", "alice@example.com"),
+		snapshotTestEvent("evt2", "Review", "2025-03-01T09:00:00Z"),
+	})
+	b := NewSnapshot(timeMin, timeMax, []*calendar.Event{
+		snapshotTestEvent("evt2", "Review", "2025-03-01T09:00:00Z"),
+		snapshotTestEvent("evt1", "Standup", "2025-03-01T09:00:00Z", "alice@example.com", "bob@example.com"),
+	})
+
+	assert.Equal(t, a.Hash, b.Hash, "event and attendee order don't change the hash")
+	assert.Equal(t, []string{"alice@example.com", "bob@example.com"}, a.Events[0].Attendees)
+	assert.Equal(t, "2025-03-03T00:00:00Z", a.TimeMin)
+
+	c := NewSnapshot(timeMin, timeMax, []*calendar.Event{
+		snapshotTestEvent("evt1", "Standup (moved)", "2025-03-02T09:00:00Z", "alice@example.com", "bob@example.com"),
+		snapshotTestEvent("evt2", "Review", "2025-03-01T09:00:00Z"),
+	})
+	assert.NotEqual(t, a.Hash, c.Hash)
+}
+
+func TestDiffSnapshots(t *testing.T) {
+	timeMin := time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC)
+	timeMax := timeMin.AddDate(0, 0, 7)
+
+	before := NewSnapshot(timeMin, timeMax, []*calendar.Event{
+		snapshotTestEvent("evt1", "Standup", "2025-03-01T09:00:00Z"),
+		snapshotTestEvent("evt2", "Review", "2025-03-01T09:00:00Z"),
+		snapshotTestEvent("evt3", "Lunch", "2025-03-01T09:00:00Z"),
+	})
+	after := NewSnapshot(timeMin, timeMax, []*calendar.Event{
+		snapshotTestEvent("evt1", "Standup", "2025-03-01T09:00:00Z"),
+		snapshotTestEvent("evt2", "Design review", "2025-03-02T14:00:00Z"),
+		snapshotTestEvent("evt4", "1:1", "2025-03-02T15:00:00Z"),
+	})
+
+	diff := DiffSnapshots(before, after)
+
+	require.Len(t, diff.Added, 1)
+	assert.Equal(t, "evt4", diff.Added[0].ID)
+	require.Len(t, diff.Removed, 1)
+	assert.Equal(t, "evt3", diff.Removed[0].ID)
+	require.Len(t, diff.Modified, 1)
+	assert.Equal(t, "evt2", diff.Modified[0].ID)
+	assert.Equal(t, "Review", diff.Modified[0].Before.Summary)
+	assert.Equal(t, "Design review", diff.Modified[0].After.Summary)
+	assert.Equal(t, 1, diff.Unchanged)
+
+	same := DiffSnapshots(before, before)
+	assert.Empty(t, same.Added)
+	assert.Empty(t, same.Removed)
+	assert.Empty(t, same.Modified)
+	assert.Equal(t, 3, same.Unchanged)
+}
+
+func TestSnapshotEvents(t *testing.T) {
+	var query map[string][]string
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		_ = json.NewEncoder(w).Encode(&calendar.Events{
+			Items: []*calendar.Event{snapshotTestEvent("evt1", "Standup", "2025-03-01T09:00:00Z")},
+		})
+	}))
+	defer api.Close()
+
+	t.Setenv("ISH_MODE", "true")
+	t.Setenv("ISH_BASE_URL", api.URL)
+
+	svc, err := NewService(context.Background(), nil)
+	require.NoError(t, err)
+
+	timeMin := time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC)
+	snapshot, err := svc.SnapshotEvents(context.Background(), timeMin, timeMin.AddDate(0, 0, 7), "")
+	require.NoError(t, err)
+	require.Len(t, snapshot.Events, 1)
+	assert.Equal(t, "evt1", snapshot.Events[0].ID)
+	assert.Equal(t, "2025-03-03T10:00:00Z", snapshot.Events[0].Start)
+	assert.NotEmpty(t, snapshot.Hash)
+	assert.Equal(t, []string{"true"}, query["singleEvents"])
+
+	_, err = svc.SnapshotEvents(context.Background(), timeMin, timeMin, "")
+	assert.ErrorContains(t, err, "time_min must be before time_max")
+}
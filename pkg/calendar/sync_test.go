@@ -0,0 +1,62 @@
+// ABOUTME: Tests for incremental calendar sync
+// ABOUTME: Verifies sync token forwarding, paging, and the expired-token resync signal
+
+package calendar
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/calendar/v3"
+)
+
+func TestListEventsSince(t *testing.T) {
+	var queries []map[string]string
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		queries = append(queries, map[string]string{"syncToken": q.Get("syncToken"), "pageToken": q.Get("pageToken"), "path": r.URL.Path})
+		switch {
+		case q.Get("syncToken") == "stale":
+			w.WriteHeader(http.StatusGone)
+			_, _ = w.Write([]byte(`{"error": {"code": 410, "message": "Sync token is no longer valid, a full sync is required."}}`))
+		case q.Get("pageToken") == "":
+			_ = json.NewEncoder(w).Encode(&calendar.Events{
+				Items:         []*calendar.Event{{Id: "evt1", Status: "confirmed"}},
+				NextPageToken: "page-2",
+			})
+		default:
+			_ = json.NewEncoder(w).Encode(&calendar.Events{
+				Items:         []*calendar.Event{{Id: "evt2", Status: "cancelled"}},
+				NextSyncToken: "token-2",
+			})
+		}
+	}))
+	defer api.Close()
+
+	t.Setenv("ISH_MODE", "true")
+	t.Setenv("ISH_BASE_URL", api.URL)
+
+	svc, err := NewService(context.Background(), nil)
+	require.NoError(t, err)
+
+	page, err := svc.ListEventsSince(context.Background(), "token-1", "")
+	require.NoError(t, err)
+	require.Len(t, page.Events, 2)
+	assert.Equal(t, "cancelled", page.Events[1].Status, "deletions come through as cancelled events")
+	assert.Equal(t, "token-2", page.NextSyncToken)
+
+	require.Len(t, queries, 2)
+	assert.Equal(t, "token-1", queries[0]["syncToken"])
+	assert.Equal(t, "token-1", queries[1]["syncToken"], "the token is kept while paging")
+	assert.Equal(t, "page-2", queries[1]["pageToken"])
+	assert.Contains(t, queries[0]["path"], "/calendars/primary/events")
+
+	_, err = svc.ListEventsSince(context.Background(), "stale", "team@example.com")
+	assert.ErrorIs(t, err, ErrSyncTokenExpired)
+	assert.Contains(t, queries[2]["path"], "/calendars/team@example.com/events")
+}
@@ -0,0 +1,188 @@
+// ABOUTME: Meeting templates with agendas, default durations, and reminders
+// ABOUTME: Built-in templates can be extended or overridden by text files in a config directory
+
+package calendar
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// templateExt is the extension of user template files; the file name is the template name
+const templateExt = ".txt"
+
+// EventTemplate is a reusable meeting definition
+type EventTemplate struct {
+	Name            string  // Template name, e.g. "one_on_one"
+	Summary         string  // Default event title
+	DurationMinutes int     // Default length when no end time is given
+	ReminderMinutes []int64 // Default popup reminders
+	Agenda          string  // Structured agenda placed in the description
+}
+
+// Templates maps template names to their definitions
+type Templates map[string]EventTemplate
+
+// builtinTemplates are always available unless a user file of the same name replaces them
+var builtinTemplates = Templates{
+	"one_on_one": {
+		Name:            "one_on_one",
+		Summary:         "1:1",
+		DurationMinutes: 30,
+		ReminderMinutes: []int64{10},
+		Agenda: `Agenda:
+1. Wins since last time
+2. Blockers and concerns
+3. Priorities for the next two weeks
+4. Feedback (both directions)
+5. Action items`,
+	},
+	"standup": {
+		Name:            "standup",
+		Summary:         "Standup",
+		DurationMinutes: 15,
+		ReminderMinutes: []int64{5},
+		Agenda: `Agenda (each person):
+1. What I did yesterday
+2. What I'm doing today
+3. Blockers`,
+	},
+	"interview": {
+		Name:            "interview",
+		Summary:         "Interview",
+		DurationMinutes: 60,
+		ReminderMinutes: []int64{15},
+		Agenda: `Agenda:
+1. Introductions (5 min)
+2. Candidate background (10 min)
+3. Technical / role discussion (30 min)
+4. Candidate questions (10 min)
+5. Next steps (5 min)
+
+Scorecard due within 24 hours.`,
+	},
+}
+
+// Names returns the template names in sorted order
+func (t Templates) Names() []string {
+	names := make([]string, 0, len(t))
+	for name := range t {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Get returns the named template or an error listing the available ones
+func (t Templates) Get(name string) (EventTemplate, error) {
+	tmpl, ok := t[name]
+	if !ok {
+		return EventTemplate{}, fmt.Errorf("unknown template %q (available: %s)", name, strings.Join(t.Names(), ", "))
+	}
+	return tmpl, nil
+}
+
+// Description renders the event description: caller notes, if any, above the agenda
+func (t EventTemplate) Description(notes string) string {
+	notes = strings.TrimSpace(notes)
+	if notes == "" {
+		return t.Agenda
+	}
+	return notes + "\n\n" + t.Agenda
+}
+
+// LoadTemplates returns the built-in templates merged with *.txt files in dir.
+// A missing dir is not an error. Each file may start with "key: value" lines
+// (summary, duration_minutes, reminder_minutes) followed by a "---" line; the
+// rest of the file is the agenda. Files that can't be read or parsed are
+// skipped and reported in the returned errors, so one bad file doesn't hide
+// the rest.
+func LoadTemplates(dir string) (Templates, []error) {
+	templates := make(Templates, len(builtinTemplates))
+	for name, tmpl := range builtinTemplates {
+		templates[name] = tmpl
+	}
+	if dir == "" {
+		return templates, nil
+	}
+
+	paths, err := filepath.Glob(filepath.Join(dir, "*"+templateExt))
+	if err != nil {
+		return templates, []error{fmt.Errorf("unable to list templates: %w", err)}
+	}
+	var errs []error
+	for _, path := range paths {
+		tmpl, err := parseTemplateFile(path)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		templates[tmpl.Name] = tmpl
+	}
+	return templates, errs
+}
+
+// parseTemplateFile reads one user template
+func parseTemplateFile(path string) (EventTemplate, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return EventTemplate{}, fmt.Errorf("unable to read template %s: %w", path, err)
+	}
+
+	name := strings.TrimSuffix(filepath.Base(path), templateExt)
+	tmpl := EventTemplate{Name: name, Summary: name, DurationMinutes: 30}
+
+	content := string(data)
+	if header, agenda, ok := strings.Cut(content, "\n---\n"); ok {
+		if err := applyTemplateHeader(&tmpl, header); err != nil {
+			return EventTemplate{}, fmt.Errorf("invalid template %s: %w", path, err)
+		}
+		content = agenda
+	}
+	tmpl.Agenda = strings.TrimSpace(content)
+	return tmpl, nil
+}
+
+// applyTemplateHeader sets template fields from "key: value" lines
+func applyTemplateHeader(tmpl *EventTemplate, header string) error {
+	scanner := bufio.NewScanner(strings.NewReader(header))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			return fmt.Errorf("header line %q is not key: value", line)
+		}
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+
+		switch key {
+		case "summary":
+			tmpl.Summary = value
+		case "duration_minutes":
+			minutes, err := strconv.Atoi(value)
+			if err != nil || minutes <= 0 {
+				return fmt.Errorf("duration_minutes must be a positive integer (got %q)", value)
+			}
+			tmpl.DurationMinutes = minutes
+		case "reminder_minutes":
+			tmpl.ReminderMinutes = nil
+			for _, field := range strings.Split(value, ",") {
+				minutes, err := strconv.ParseInt(strings.TrimSpace(field), 10, 64)
+				if err != nil {
+					return fmt.Errorf("reminder_minutes must be comma-separated integers (got %q)", value)
+				}
+				tmpl.ReminderMinutes = append(tmpl.ReminderMinutes, minutes)
+			}
+		default:
+			return fmt.Errorf("unknown header key %q", key)
+		}
+	}
+	return scanner.Err()
+}
@@ -0,0 +1,67 @@
+// ABOUTME: Tests for meeting templates
+// ABOUTME: Validates built-ins, user template files, and header parsing
+
+package calendar
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadTemplates_BuiltinsWithoutDir(t *testing.T) {
+	templates, errs := LoadTemplates(filepath.Join(t.TempDir(), "missing"))
+	require.Empty(t, errs)
+
+	assert.Equal(t, []string{"interview", "one_on_one", "standup"}, templates.Names())
+
+	_, err := templates.Get("retro")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "available: interview, one_on_one, standup")
+}
+
+func TestLoadTemplates_UserFiles(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "retro.txt"), []byte(
+		"summary: Sprint Retro\nduration_minutes: 45\nreminder_minutes: 30, 5\n---\nWhat went well?\nWhat didn't?\n"), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "standup.txt"), []byte("Just blockers today.\n"), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "notes.md"), []byte("ignored"), 0600))
+
+	templates, errs := LoadTemplates(dir)
+	require.Empty(t, errs)
+
+	retro, err := templates.Get("retro")
+	require.NoError(t, err)
+	assert.Equal(t, "Sprint Retro", retro.Summary)
+	assert.Equal(t, 45, retro.DurationMinutes)
+	assert.Equal(t, []int64{30, 5}, retro.ReminderMinutes)
+	assert.Equal(t, "What went well?\nWhat didn't?", retro.Agenda)
+
+	standup, err := templates.Get("standup")
+	require.NoError(t, err)
+	assert.Equal(t, "Just blockers today.", standup.Agenda, "user files replace built-ins")
+	assert.NotContains(t, templates.Names(), "notes")
+}
+
+func TestLoadTemplates_InvalidHeaderSkipsFile(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "bad.txt"), []byte("duration_minutes: soon\n---\nAgenda\n"), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "retro.txt"), []byte("Agenda\n"), 0600))
+
+	templates, errs := LoadTemplates(dir)
+	require.Len(t, errs, 1)
+	assert.ErrorContains(t, errs[0], "bad.txt")
+	assert.NotContains(t, templates.Names(), "bad")
+	assert.Contains(t, templates.Names(), "retro", "valid files still load")
+	assert.Contains(t, templates.Names(), "one_on_one")
+}
+
+func TestEventTemplate_Description(t *testing.T) {
+	tmpl := builtinTemplates["one_on_one"]
+
+	assert.Equal(t, tmpl.Agenda, tmpl.Description(""))
+	assert.Equal(t, "Discuss promo packet\n\n"+tmpl.Agenda, tmpl.Description("  Discuss promo packet "))
+}
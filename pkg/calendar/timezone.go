@@ -0,0 +1,44 @@
+// ABOUTME: Calendar timezone lookup
+// ABOUTME: Reads a calendar's own zone, falling back to the account's timezone setting
+
+package calendar
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harper/gsuite-mcp/pkg/apierr"
+	"google.golang.org/api/calendar/v3"
+)
+
+// GetCalendarTimezone returns the IANA timezone of calendarID ("primary" if
+// empty). Calendars without their own zone use the account's timezone setting.
+func (s *Service) GetCalendarTimezone(ctx context.Context, calendarID string) (string, error) {
+	if calendarID == "" {
+		calendarID = "primary"
+	}
+
+	var entry *calendar.CalendarListEntry
+	err := s.breaker.Do(func() error {
+		var err error
+		entry, err = s.svc.CalendarList.Get(calendarID).Context(ctx).Fields("timeZone").Do()
+		return err
+	})
+	if err != nil {
+		return "", apierr.Wrap(err, "unable to get calendar timezone", "calendar", calendarID)
+	}
+	if entry.TimeZone != "" {
+		return entry.TimeZone, nil
+	}
+
+	var setting *calendar.Setting
+	err = s.breaker.Do(func() error {
+		var err error
+		setting, err = s.svc.Settings.Get("timezone").Context(ctx).Do()
+		return err
+	})
+	if err != nil {
+		return "", fmt.Errorf("unable to get account timezone: %w", err)
+	}
+	return setting.Value, nil
+}
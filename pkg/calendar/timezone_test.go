@@ -0,0 +1,53 @@
+// ABOUTME: Tests for calendar timezone lookup
+// ABOUTME: Verifies the calendar's zone is returned and the account setting is the fallback
+
+package calendar
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/harper/gsuite-mcp/pkg/apierr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetCalendarTimezone(t *testing.T) {
+	var paths []string
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/calendarList/primary"):
+			_, _ = w.Write([]byte(`{"timeZone": "Europe/Zurich"}`))
+		case strings.HasSuffix(r.URL.Path, "/calendarList/shared@group.calendar.google.com"):
+			_, _ = w.Write([]byte(`{}`))
+		case strings.HasSuffix(r.URL.Path, "/settings/timezone"):
+			_, _ = w.Write([]byte(`{"id": "timezone", "value": "America/New_York"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error": {"code": 404, "message": "Not Found"}}`))
+		}
+	}))
+	defer api.Close()
+
+	t.Setenv("ISH_MODE", "true")
+	t.Setenv("ISH_BASE_URL", api.URL)
+
+	svc, err := NewService(context.Background(), nil)
+	require.NoError(t, err)
+
+	zone, err := svc.GetCalendarTimezone(context.Background(), "")
+	require.NoError(t, err)
+	assert.Equal(t, "Europe/Zurich", zone)
+	assert.Len(t, paths, 1, "a calendar with its own zone needs no settings lookup")
+
+	zone, err = svc.GetCalendarTimezone(context.Background(), "shared@group.calendar.google.com")
+	require.NoError(t, err)
+	assert.Equal(t, "America/New_York", zone, "falls back to the account setting")
+
+	_, err = svc.GetCalendarTimezone(context.Background(), "missing")
+	assert.ErrorIs(t, err, apierr.ErrNotFound)
+}
@@ -0,0 +1,432 @@
+// ABOUTME: Server configuration loaded from an optional config file
+// ABOUTME: File values are overridden by GSUITE_MCP_* environment variables
+
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/harper/gsuite-mcp/pkg/calendar"
+	"github.com/harper/gsuite-mcp/pkg/gmail"
+	"github.com/harper/gsuite-mcp/pkg/retry"
+)
+
+const (
+	appName       = "gsuite-mcp"
+	defaultConfig = "config.toml"
+	configSubdir  = ".config"
+
+	// DefaultRecentThreadContacts keeps the recent-threads resource to a couple of dozen API calls
+	DefaultRecentThreadContacts = 10
+
+	// DefaultHTTPTimeout bounds each API request so a hung connection can't block a tool call forever
+	DefaultHTTPTimeout = 30 * time.Second
+
+	// DefaultWorkStart and DefaultWorkEnd bound the working day used for slot suggestions and meeting load
+	DefaultWorkStart = "09:00"
+	DefaultWorkEnd   = "17:00"
+)
+
+// validLogLevels are the accepted values for LogLevel
+var validLogLevels = map[string]bool{"debug": true, "info": true, "warn": true, "error": true}
+
+// Config holds user-tunable server settings
+type Config struct {
+	Timezone             string        `toml:"timezone" json:"timezone"`                             // IANA zone for date calculations (default: local)
+	Scopes               []string      `toml:"scopes" json:"scopes"`                                 // OAuth scopes to request (default: auth.DefaultScopes)
+	Retry                RetryConfig   `toml:"retry" json:"retry"`                                   // Retry policy for API calls
+	Breaker              BreakerConfig `toml:"breaker" json:"breaker"`                               // Circuit breaker for sustained API outages
+	DisabledTools        []string      `toml:"disabled_tools" json:"disabled_tools"`                 // Tool names to leave unregistered
+	LogLevel             string        `toml:"log_level" json:"log_level"`                           // debug, info, warn, or error
+	Delegate             string        `toml:"delegate" json:"delegate"`                             // Mailbox address Gmail calls act on instead of "me"
+	FromName             string        `toml:"from_name" json:"from_name"`                           // Display name on outgoing mail; empty uses the account default
+	HTTPTimeout          string        `toml:"http_timeout" json:"http_timeout"`                     // Go duration bounding each API request; "0" disables
+	RecentThreadContacts int           `toml:"recent_thread_contacts" json:"recent_thread_contacts"` // Contacts checked by gsuite://contacts/recent-threads
+	TemplatesDir         string        `toml:"templates_dir" json:"templates_dir"`                   // Directory of calendar template .txt files
+	WorkStart            string        `toml:"work_start" json:"work_start"`                         // Start of the working day as HH:MM
+	WorkEnd              string        `toml:"work_end" json:"work_end"`                             // End of the working day as HH:MM
+	DraftOnly            bool          `toml:"draft_only" json:"draft_only"`                         // Leave send tools unregistered so mail is only drafted
+	AuditLog             string        `toml:"audit_log" json:"audit_log"`                           // JSON lines file recording mutating tool calls; empty disables
+	MaxResultBytes       int           `toml:"max_result_bytes" json:"max_result_bytes"`             // Trim tool results larger than this; 0 means no limit
+	CacheTTL             string        `toml:"cache_ttl" json:"cache_ttl"`                           // Go duration the label list and calendar list entry are reused; "0" disables
+	InternalDomains      []string      `toml:"internal_domains" json:"internal_domains"`             // Domains gmail_send_message may reach without allow_external; empty allows all
+	HTTPAddr             string        `toml:"http_addr" json:"http_addr"`                           // host:port to serve MCP over HTTP instead of stdio; empty uses stdio
+}
+
+// RetryConfig controls retries of transient API failures
+type RetryConfig struct {
+	MaxRetries int    `toml:"max_retries" json:"max_retries"` // Retries after the first attempt
+	BaseDelay  string `toml:"base_delay" json:"base_delay"`   // Go duration before the first retry, e.g. "1s"
+}
+
+// BreakerConfig controls the per-service circuit breaker
+type BreakerConfig struct {
+	Threshold int    `toml:"threshold" json:"threshold"` // Consecutive 429/5xx failures that open the circuit; 0 disables it
+	Window    string `toml:"window" json:"window"`       // Go duration; failures further apart don't count as consecutive
+	Cooldown  string `toml:"cooldown" json:"cooldown"`   // Go duration the circuit stays open before a probe call
+}
+
+// Default returns the configuration used when no file or env overrides exist
+func Default() *Config {
+	return &Config{
+		Retry: RetryConfig{
+			MaxRetries: retry.DefaultMaxRetries,
+			BaseDelay:  retry.DefaultBaseDelay.String(),
+		},
+		Breaker: BreakerConfig{
+			Threshold: retry.DefaultBreakerThreshold,
+			Window:    retry.DefaultBreakerWindow.String(),
+			Cooldown:  retry.DefaultBreakerCooldown.String(),
+		},
+		LogLevel:             "info",
+		HTTPTimeout:          DefaultHTTPTimeout.String(),
+		CacheTTL:             gmail.DefaultCacheTTL.String(),
+		RecentThreadContacts: DefaultRecentThreadContacts,
+		WorkStart:            DefaultWorkStart,
+		WorkEnd:              DefaultWorkEnd,
+	}
+}
+
+// Path returns the path to the config file
+// Priority: GSUITE_MCP_CONFIG_PATH > XDG_CONFIG_HOME > ~/.config
+// Follows the same rules as auth.GetCredentialsPath.
+func Path() string {
+	if override := os.Getenv("GSUITE_MCP_CONFIG_PATH"); override != "" {
+		return filepath.Clean(override)
+	}
+
+	configHome := os.Getenv("XDG_CONFIG_HOME")
+	if configHome == "" || !filepath.IsAbs(configHome) {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return defaultConfig // fallback to cwd
+		}
+		configHome = filepath.Join(home, configSubdir)
+	}
+
+	return filepath.Clean(filepath.Join(configHome, appName, defaultConfig))
+}
+
+// Load reads the config file at Path and applies environment overrides
+func Load() (*Config, error) {
+	return LoadFile(Path())
+}
+
+// LoadFile reads the config file at path, if it exists, and applies environment
+// overrides. Files ending in .json are parsed as JSON; anything else as TOML.
+func LoadFile(path string) (*Config, error) {
+	cfg := Default()
+
+	data, err := os.ReadFile(path)
+	switch {
+	case err == nil:
+		if strings.EqualFold(filepath.Ext(path), ".json") {
+			err = json.Unmarshal(data, cfg)
+		} else {
+			err = toml.Unmarshal(data, cfg)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse config file %s: %w", path, err)
+		}
+	case os.IsNotExist(err):
+		// No config file - defaults plus env vars only
+	default:
+		return nil, fmt.Errorf("unable to read config file %s: %w", path, err)
+	}
+
+	if err := cfg.applyEnv(); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+
+	return cfg, nil
+}
+
+// applyEnv overrides file values with any GSUITE_MCP_* environment variables that are set
+func (c *Config) applyEnv() error {
+	if v := os.Getenv("GSUITE_MCP_TIMEZONE"); v != "" {
+		c.Timezone = v
+	}
+	if v := os.Getenv("GSUITE_MCP_SCOPES"); v != "" {
+		c.Scopes = splitList(v)
+	}
+	if v := os.Getenv("GSUITE_MCP_MAX_RETRIES"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return fmt.Errorf("invalid GSUITE_MCP_MAX_RETRIES %q: %w", v, err)
+		}
+		c.Retry.MaxRetries = n
+	}
+	if v := os.Getenv("GSUITE_MCP_RETRY_BASE_DELAY"); v != "" {
+		c.Retry.BaseDelay = v
+	}
+	if v := os.Getenv("GSUITE_MCP_BREAKER_THRESHOLD"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return fmt.Errorf("invalid GSUITE_MCP_BREAKER_THRESHOLD %q: %w", v, err)
+		}
+		c.Breaker.Threshold = n
+	}
+	if v := os.Getenv("GSUITE_MCP_BREAKER_WINDOW"); v != "" {
+		c.Breaker.Window = v
+	}
+	if v := os.Getenv("GSUITE_MCP_BREAKER_COOLDOWN"); v != "" {
+		c.Breaker.Cooldown = v
+	}
+	if v := os.Getenv("GSUITE_MCP_DISABLED_TOOLS"); v != "" {
+		c.DisabledTools = splitList(v)
+	}
+	if v := os.Getenv("GSUITE_MCP_LOG_LEVEL"); v != "" {
+		c.LogLevel = v
+	}
+	if v := os.Getenv("GSUITE_MCP_DELEGATE"); v != "" {
+		c.Delegate = strings.TrimSpace(v)
+	}
+	if v := os.Getenv("GSUITE_MCP_FROM_NAME"); v != "" {
+		c.FromName = strings.TrimSpace(v)
+	}
+	if v := os.Getenv("GSUITE_MCP_HTTP_TIMEOUT"); v != "" {
+		c.HTTPTimeout = v
+	}
+	if v := os.Getenv("GSUITE_MCP_CACHE_TTL"); v != "" {
+		c.CacheTTL = v
+	}
+	if v := os.Getenv("GSUITE_MCP_TEMPLATES_DIR"); v != "" {
+		c.TemplatesDir = v
+	}
+	if v := os.Getenv("GSUITE_MCP_WORK_START"); v != "" {
+		c.WorkStart = strings.TrimSpace(v)
+	}
+	if v := os.Getenv("GSUITE_MCP_WORK_END"); v != "" {
+		c.WorkEnd = strings.TrimSpace(v)
+	}
+	if v := os.Getenv("GSUITE_MCP_DRAFT_ONLY"); v != "" {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return fmt.Errorf("invalid GSUITE_MCP_DRAFT_ONLY %q: %w", v, err)
+		}
+		c.DraftOnly = b
+	}
+	if v := os.Getenv("GSUITE_MCP_INTERNAL_DOMAINS"); v != "" {
+		c.InternalDomains = splitList(v)
+	}
+	if v := os.Getenv("GSUITE_MCP_HTTP_ADDR"); v != "" {
+		c.HTTPAddr = strings.TrimSpace(v)
+	}
+	if v := os.Getenv("GSUITE_MCP_AUDIT_LOG"); v != "" {
+		c.AuditLog = v
+	}
+	if v := os.Getenv("GSUITE_MCP_MAX_RESULT_BYTES"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return fmt.Errorf("invalid GSUITE_MCP_MAX_RESULT_BYTES %q: %w", v, err)
+		}
+		c.MaxResultBytes = n
+	}
+	if v := os.Getenv("GSUITE_MCP_RECENT_THREAD_CONTACTS"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return fmt.Errorf("invalid GSUITE_MCP_RECENT_THREAD_CONTACTS %q: %w", v, err)
+		}
+		c.RecentThreadContacts = n
+	}
+	return nil
+}
+
+// Validate checks that every setting is usable
+func (c *Config) Validate() error {
+	if _, err := c.Location(); err != nil {
+		return err
+	}
+	if c.Retry.MaxRetries < 0 {
+		return fmt.Errorf("retry.max_retries cannot be negative")
+	}
+	if _, err := c.RetryPolicy(); err != nil {
+		return err
+	}
+	if c.Breaker.Threshold < 0 {
+		return fmt.Errorf("breaker.threshold cannot be negative")
+	}
+	if _, err := c.BreakerPolicy(); err != nil {
+		return err
+	}
+	if c.LogLevel != "" && !validLogLevels[strings.ToLower(c.LogLevel)] {
+		return fmt.Errorf("log_level must be one of debug, info, warn, error (got %q)", c.LogLevel)
+	}
+	if c.Delegate != "" {
+		if err := gmail.ValidateDelegate(c.Delegate); err != nil {
+			return err
+		}
+	}
+	if err := gmail.ValidateFromName(c.FromName); err != nil {
+		return err
+	}
+	if _, err := gmail.InternalDomains(c.InternalDomains); err != nil {
+		return err
+	}
+	if _, err := c.ClientTimeout(); err != nil {
+		return err
+	}
+	if _, err := c.LookupCacheTTL(); err != nil {
+		return err
+	}
+	if c.RecentThreadContacts < 0 {
+		return fmt.Errorf("recent_thread_contacts cannot be negative")
+	}
+	if c.MaxResultBytes < 0 {
+		return fmt.Errorf("max_result_bytes cannot be negative")
+	}
+	if c.HTTPAddr != "" {
+		if _, _, err := net.SplitHostPort(c.HTTPAddr); err != nil {
+			return fmt.Errorf("invalid http_addr %q: %w", c.HTTPAddr, err)
+		}
+	}
+	if _, err := calendar.ParseWorkHours(c.WorkDay()); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Location returns the configured timezone, or the local zone if unset
+func (c *Config) Location() (*time.Location, error) {
+	if c.Timezone == "" {
+		return time.Local, nil
+	}
+	loc, err := time.LoadLocation(c.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timezone %q: %w", c.Timezone, err)
+	}
+	return loc, nil
+}
+
+// RetryPolicy converts the retry settings to a retry.Policy
+func (c *Config) RetryPolicy() (retry.Policy, error) {
+	delay := retry.DefaultBaseDelay
+	if c.Retry.BaseDelay != "" {
+		parsed, err := time.ParseDuration(c.Retry.BaseDelay)
+		if err != nil {
+			return retry.Policy{}, fmt.Errorf("invalid retry.base_delay %q: %w", c.Retry.BaseDelay, err)
+		}
+		delay = parsed
+	}
+	return retry.Policy{MaxRetries: c.Retry.MaxRetries, BaseDelay: delay}, nil
+}
+
+// BreakerPolicy converts the breaker settings to a retry.BreakerPolicy,
+// using the retry package defaults for unset durations
+func (c *Config) BreakerPolicy() (retry.BreakerPolicy, error) {
+	policy := retry.BreakerPolicy{
+		Threshold: c.Breaker.Threshold,
+		Window:    retry.DefaultBreakerWindow,
+		Cooldown:  retry.DefaultBreakerCooldown,
+	}
+	if c.Breaker.Window != "" {
+		parsed, err := time.ParseDuration(c.Breaker.Window)
+		if err != nil || parsed <= 0 {
+			return retry.BreakerPolicy{}, fmt.Errorf("invalid breaker.window %q: must be a positive duration", c.Breaker.Window)
+		}
+		policy.Window = parsed
+	}
+	if c.Breaker.Cooldown != "" {
+		parsed, err := time.ParseDuration(c.Breaker.Cooldown)
+		if err != nil || parsed <= 0 {
+			return retry.BreakerPolicy{}, fmt.Errorf("invalid breaker.cooldown %q: must be a positive duration", c.Breaker.Cooldown)
+		}
+		policy.Cooldown = parsed
+	}
+	return policy, nil
+}
+
+// ClientTimeout returns the per-request HTTP timeout, or DefaultHTTPTimeout if unset
+// Zero means requests never time out.
+func (c *Config) ClientTimeout() (time.Duration, error) {
+	if c.HTTPTimeout == "" {
+		return DefaultHTTPTimeout, nil
+	}
+	timeout, err := time.ParseDuration(c.HTTPTimeout)
+	if err != nil {
+		return 0, fmt.Errorf("invalid http_timeout %q: %w", c.HTTPTimeout, err)
+	}
+	if timeout < 0 {
+		return 0, fmt.Errorf("http_timeout cannot be negative")
+	}
+	return timeout, nil
+}
+
+// LookupCacheTTL returns how long label and calendar list lookups are
+// cached, or gmail.DefaultCacheTTL if unset. Zero disables caching.
+func (c *Config) LookupCacheTTL() (time.Duration, error) {
+	if c.CacheTTL == "" {
+		return gmail.DefaultCacheTTL, nil
+	}
+	ttl, err := time.ParseDuration(c.CacheTTL)
+	if err != nil {
+		return 0, fmt.Errorf("invalid cache_ttl %q: %w", c.CacheTTL, err)
+	}
+	if ttl < 0 {
+		return 0, fmt.Errorf("cache_ttl cannot be negative")
+	}
+	return ttl, nil
+}
+
+// RecentThreadLimit returns how many contacts the recent-threads resource checks,
+// or DefaultRecentThreadContacts if unset
+func (c *Config) RecentThreadLimit() int {
+	if c.RecentThreadContacts == 0 {
+		return DefaultRecentThreadContacts
+	}
+	return c.RecentThreadContacts
+}
+
+// WorkDay returns the working day's HH:MM start and end, defaulting unset values
+func (c *Config) WorkDay() (start, end string) {
+	start, end = c.WorkStart, c.WorkEnd
+	if start == "" {
+		start = DefaultWorkStart
+	}
+	if end == "" {
+		end = DefaultWorkEnd
+	}
+	return start, end
+}
+
+// TemplateDir returns the calendar templates directory, defaulting to a
+// "templates" directory next to the config file
+func (c *Config) TemplateDir() string {
+	if c.TemplatesDir != "" {
+		return c.TemplatesDir
+	}
+	return filepath.Join(filepath.Dir(Path()), "templates")
+}
+
+// IsToolDisabled reports whether name appears in DisabledTools
+func (c *Config) IsToolDisabled(name string) bool {
+	for _, disabled := range c.DisabledTools {
+		if disabled == name {
+			return true
+		}
+	}
+	return false
+}
+
+// splitList parses a comma-separated env value, dropping empty entries
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
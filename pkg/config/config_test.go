@@ -0,0 +1,283 @@
+// ABOUTME: Tests for config file loading
+// ABOUTME: Validates TOML/JSON parsing, defaults, env overrides, and validation
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/harper/gsuite-mcp/pkg/retry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// clearConfigEnv unsets every env var that overrides config file values
+func clearConfigEnv(t *testing.T) {
+	t.Helper()
+	for _, key := range []string{
+		"GSUITE_MCP_TIMEZONE",
+		"GSUITE_MCP_SCOPES",
+		"GSUITE_MCP_MAX_RETRIES",
+		"GSUITE_MCP_RETRY_BASE_DELAY",
+		"GSUITE_MCP_BREAKER_THRESHOLD",
+		"GSUITE_MCP_BREAKER_WINDOW",
+		"GSUITE_MCP_BREAKER_COOLDOWN",
+		"GSUITE_MCP_DISABLED_TOOLS",
+		"GSUITE_MCP_LOG_LEVEL",
+		"GSUITE_MCP_DELEGATE",
+		"GSUITE_MCP_FROM_NAME",
+		"GSUITE_MCP_HTTP_TIMEOUT",
+		"GSUITE_MCP_CACHE_TTL",
+		"GSUITE_MCP_RECENT_THREAD_CONTACTS",
+		"GSUITE_MCP_TEMPLATES_DIR",
+		"GSUITE_MCP_WORK_START",
+		"GSUITE_MCP_WORK_END",
+		"GSUITE_MCP_DRAFT_ONLY",
+		"GSUITE_MCP_HTTP_ADDR",
+		"GSUITE_MCP_AUDIT_LOG",
+		"GSUITE_MCP_MAX_RESULT_BYTES",
+		"GSUITE_MCP_INTERNAL_DOMAINS",
+	} {
+		t.Setenv(key, "")
+	}
+}
+
+func writeConfig(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	return path
+}
+
+const sampleTOML = `
+timezone = "America/New_York"
+scopes = ["https://www.googleapis.com/auth/gmail.readonly"]
+disabled_tools = ["gmail_send_message", "people_delete_contact"]
+log_level = "warn"
+
+[retry]
+max_retries = 5
+base_delay = "250ms"
+`
+
+func TestLoadFile_TOML(t *testing.T) {
+	clearConfigEnv(t)
+	path := writeConfig(t, "config.toml", sampleTOML)
+
+	cfg, err := LoadFile(path)
+	require.NoError(t, err)
+
+	assert.Equal(t, "America/New_York", cfg.Timezone)
+	assert.Equal(t, []string{"https://www.googleapis.com/auth/gmail.readonly"}, cfg.Scopes)
+	assert.Equal(t, []string{"gmail_send_message", "people_delete_contact"}, cfg.DisabledTools)
+	assert.Equal(t, "warn", cfg.LogLevel)
+
+	policy, err := cfg.RetryPolicy()
+	require.NoError(t, err)
+	assert.Equal(t, retry.Policy{MaxRetries: 5, BaseDelay: 250 * time.Millisecond}, policy)
+
+	loc, err := cfg.Location()
+	require.NoError(t, err)
+	assert.Equal(t, "America/New_York", loc.String())
+	assert.True(t, cfg.IsToolDisabled("gmail_send_message"))
+	assert.False(t, cfg.IsToolDisabled("gmail_list_messages"))
+}
+
+func TestLoadFile_JSON(t *testing.T) {
+	clearConfigEnv(t)
+	path := writeConfig(t, "config.json", `{"timezone": "Europe/London", "retry": {"max_retries": 1}}`)
+
+	cfg, err := LoadFile(path)
+	require.NoError(t, err)
+
+	assert.Equal(t, "Europe/London", cfg.Timezone)
+	assert.Equal(t, 1, cfg.Retry.MaxRetries)
+	assert.Equal(t, "info", cfg.LogLevel, "unset keys keep their defaults")
+}
+
+func TestLoadFile_MissingFileUsesDefaults(t *testing.T) {
+	clearConfigEnv(t)
+
+	cfg, err := LoadFile(filepath.Join(t.TempDir(), "missing.toml"))
+	require.NoError(t, err)
+
+	assert.Equal(t, Default(), cfg)
+}
+
+func TestLoadFile_EnvOverridesFile(t *testing.T) {
+	clearConfigEnv(t)
+	path := writeConfig(t, "config.toml", sampleTOML)
+
+	t.Setenv("GSUITE_MCP_TIMEZONE", "Asia/Tokyo")
+	t.Setenv("GSUITE_MCP_MAX_RETRIES", "0")
+	t.Setenv("GSUITE_MCP_DISABLED_TOOLS", "calendar_delete_event, ")
+	t.Setenv("GSUITE_MCP_DELEGATE", "boss@example.com")
+	t.Setenv("GSUITE_MCP_FROM_NAME", " José Müller ")
+	t.Setenv("GSUITE_MCP_WORK_START", "08:30")
+	t.Setenv("GSUITE_MCP_DRAFT_ONLY", "true")
+	t.Setenv("GSUITE_MCP_AUDIT_LOG", "/var/log/gsuite-mcp/audit.jsonl")
+	t.Setenv("GSUITE_MCP_MAX_RESULT_BYTES", "1048576")
+	t.Setenv("GSUITE_MCP_INTERNAL_DOMAINS", "example.com, corp.example.org")
+	t.Setenv("GSUITE_MCP_HTTP_ADDR", "127.0.0.1:8080")
+
+	cfg, err := LoadFile(path)
+	require.NoError(t, err)
+
+	assert.Equal(t, "Asia/Tokyo", cfg.Timezone)
+	assert.Equal(t, 0, cfg.Retry.MaxRetries)
+	assert.Equal(t, []string{"calendar_delete_event"}, cfg.DisabledTools)
+	assert.Equal(t, "boss@example.com", cfg.Delegate)
+	assert.Equal(t, "José Müller", cfg.FromName)
+	assert.True(t, cfg.DraftOnly)
+	assert.Equal(t, "/var/log/gsuite-mcp/audit.jsonl", cfg.AuditLog)
+	assert.Equal(t, 1048576, cfg.MaxResultBytes)
+	assert.Equal(t, []string{"example.com", "corp.example.org"}, cfg.InternalDomains)
+	assert.Equal(t, "127.0.0.1:8080", cfg.HTTPAddr)
+	start, end := cfg.WorkDay()
+	assert.Equal(t, "08:30", start)
+	assert.Equal(t, DefaultWorkEnd, end)
+	// Keys without an env override keep their file values
+	assert.Equal(t, "250ms", cfg.Retry.BaseDelay)
+	assert.Equal(t, "warn", cfg.LogLevel)
+}
+
+func TestLoadFile_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		env     map[string]string
+	}{
+		{name: "malformed toml", content: `timezone = `},
+		{name: "unknown timezone", content: `timezone = "Mars/Olympus"`},
+		{name: "bad log level", content: `log_level = "loud"`},
+		{name: "bad base delay", content: "[retry]\nbase_delay = \"soon\""},
+		{name: "negative retries", content: "[retry]\nmax_retries = -1"},
+		{name: "negative breaker threshold", content: "[breaker]\nthreshold = -1"},
+		{name: "zero breaker cooldown", content: "[breaker]\ncooldown = \"0s\""},
+		{name: "bad env breaker window", env: map[string]string{"GSUITE_MCP_BREAKER_WINDOW": "a while"}},
+		{name: "non-numeric env retries", env: map[string]string{"GSUITE_MCP_MAX_RETRIES": "many"}},
+		{name: "non-boolean env draft only", env: map[string]string{"GSUITE_MCP_DRAFT_ONLY": "sometimes"}},
+		{name: "delegate not an address", content: `delegate = "the boss"`},
+		{name: "http addr without port", content: `http_addr = "localhost"`},
+		{name: "from name with line break", content: `from_name = "Jane\nBcc: eve@example.com"`},
+		{name: "bad http timeout", content: `http_timeout = "forever"`},
+		{name: "negative recent thread contacts", content: `recent_thread_contacts = -5`},
+		{name: "negative max result bytes", env: map[string]string{"GSUITE_MCP_MAX_RESULT_BYTES": "-1"}},
+		{name: "non-numeric max result bytes", env: map[string]string{"GSUITE_MCP_MAX_RESULT_BYTES": "1MB"}},
+		{name: "negative http timeout", env: map[string]string{"GSUITE_MCP_HTTP_TIMEOUT": "-1s"}},
+		{name: "internal domain with @", content: `internal_domains = ["@example.com"]`},
+		{name: "bad cache ttl", content: `cache_ttl = "a while"`},
+		{name: "negative cache ttl", env: map[string]string{"GSUITE_MCP_CACHE_TTL": "-5m"}},
+		{name: "bad work start", content: `work_start = "9am"`},
+		{name: "work end before start", env: map[string]string{"GSUITE_MCP_WORK_START": "18:00"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clearConfigEnv(t)
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+			path := writeConfig(t, "config.toml", tt.content)
+
+			_, err := LoadFile(path)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestClientTimeout(t *testing.T) {
+	clearConfigEnv(t)
+
+	cfg, err := LoadFile(filepath.Join(t.TempDir(), "missing.toml"))
+	require.NoError(t, err)
+	timeout, err := cfg.ClientTimeout()
+	require.NoError(t, err)
+	assert.Equal(t, DefaultHTTPTimeout, timeout)
+
+	t.Setenv("GSUITE_MCP_HTTP_TIMEOUT", "90s")
+	cfg, err = LoadFile(writeConfig(t, "config.toml", `http_timeout = "10s"`))
+	require.NoError(t, err)
+	timeout, err = cfg.ClientTimeout()
+	require.NoError(t, err)
+	assert.Equal(t, 90*time.Second, timeout, "env overrides the file")
+}
+
+func TestLookupCacheTTL(t *testing.T) {
+	clearConfigEnv(t)
+
+	cfg, err := LoadFile(filepath.Join(t.TempDir(), "missing.toml"))
+	require.NoError(t, err)
+	ttl, err := cfg.LookupCacheTTL()
+	require.NoError(t, err)
+	assert.Equal(t, 10*time.Minute, ttl)
+
+	cfg, err = LoadFile(writeConfig(t, "config.toml", `cache_ttl = "0"`))
+	require.NoError(t, err)
+	ttl, err = cfg.LookupCacheTTL()
+	require.NoError(t, err)
+	assert.Zero(t, ttl)
+
+	t.Setenv("GSUITE_MCP_CACHE_TTL", "1h")
+	cfg, err = LoadFile(writeConfig(t, "config.toml", `cache_ttl = "30s"`))
+	require.NoError(t, err)
+	ttl, err = cfg.LookupCacheTTL()
+	require.NoError(t, err)
+	assert.Equal(t, time.Hour, ttl, "env overrides the file")
+}
+
+func TestBreakerPolicy(t *testing.T) {
+	clearConfigEnv(t)
+
+	cfg, err := LoadFile(filepath.Join(t.TempDir(), "missing.toml"))
+	require.NoError(t, err)
+	policy, err := cfg.BreakerPolicy()
+	require.NoError(t, err)
+	assert.Equal(t, retry.DefaultBreakerPolicy(), policy)
+
+	t.Setenv("GSUITE_MCP_BREAKER_COOLDOWN", "2m")
+	cfg, err = LoadFile(writeConfig(t, "config.toml", "[breaker]\nthreshold = 3\nwindow = \"10s\"\ncooldown = \"5s\""))
+	require.NoError(t, err)
+	policy, err = cfg.BreakerPolicy()
+	require.NoError(t, err)
+	assert.Equal(t, retry.BreakerPolicy{Threshold: 3, Window: 10 * time.Second, Cooldown: 2 * time.Minute}, policy, "env overrides the file")
+}
+
+func TestRecentThreadLimit(t *testing.T) {
+	clearConfigEnv(t)
+
+	assert.Equal(t, DefaultRecentThreadContacts, (&Config{}).RecentThreadLimit(), "zero means default")
+
+	t.Setenv("GSUITE_MCP_RECENT_THREAD_CONTACTS", "25")
+	cfg, err := LoadFile(filepath.Join(t.TempDir(), "missing.toml"))
+	require.NoError(t, err)
+	assert.Equal(t, 25, cfg.RecentThreadLimit())
+}
+
+func TestTemplateDir(t *testing.T) {
+	clearConfigEnv(t)
+	t.Setenv("GSUITE_MCP_CONFIG_PATH", "/etc/gsuite-mcp/config.toml")
+
+	assert.Equal(t, "/etc/gsuite-mcp/templates", Default().TemplateDir())
+
+	t.Setenv("GSUITE_MCP_TEMPLATES_DIR", "/srv/templates")
+	cfg, err := LoadFile(filepath.Join(t.TempDir(), "missing.toml"))
+	require.NoError(t, err)
+	assert.Equal(t, "/srv/templates", cfg.TemplateDir())
+}
+
+func TestPath(t *testing.T) {
+	t.Run("explicit override", func(t *testing.T) {
+		t.Setenv("GSUITE_MCP_CONFIG_PATH", "/tmp/custom/config.json")
+		assert.Equal(t, "/tmp/custom/config.json", Path())
+	})
+
+	t.Run("xdg config home", func(t *testing.T) {
+		t.Setenv("GSUITE_MCP_CONFIG_PATH", "")
+		t.Setenv("XDG_CONFIG_HOME", "/xdg/config")
+		assert.Equal(t, "/xdg/config/gsuite-mcp/config.toml", Path())
+	})
+}
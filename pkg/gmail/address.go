@@ -0,0 +1,116 @@
+// ABOUTME: Lenient parsing of address-list headers such as To and Cc
+// ABOUTME: Falls back to splitting on commas and semicolons when net/mail rejects a list
+
+package gmail
+
+import (
+	"net/mail"
+	"strings"
+)
+
+// parseAddressList parses an address-list header. Folded lines are unfolded
+// first, and net/mail handles display names, group syntax, and comments.
+// When it rejects the list (semicolon separators, an unclosed bracket),
+// entries are split on commas and semicolons outside quotes, angle brackets,
+// and comments and parsed one by one. Entries without an address are
+// dropped, as are repeats of an address compared case-insensitively.
+func parseAddressList(value string) []*mail.Address {
+	value = unfoldHeader(value)
+	if strings.TrimSpace(value) == "" {
+		return nil
+	}
+
+	list, err := mail.ParseAddressList(value)
+	if err != nil {
+		list = nil
+		for _, entry := range splitAddressEntries(value) {
+			if addr := parseAddressEntry(entry); addr != nil {
+				list = append(list, addr)
+			}
+		}
+	}
+
+	seen := make(map[string]bool, len(list))
+	result := make([]*mail.Address, 0, len(list))
+	for _, addr := range list {
+		key := strings.ToLower(addr.Address)
+		if !seen[key] {
+			seen[key] = true
+			result = append(result, addr)
+		}
+	}
+	return result
+}
+
+// unfoldHeader joins a folded header value back onto one line
+func unfoldHeader(value string) string {
+	value = strings.NewReplacer("\r\n", "", "\n", "", "\r", "").Replace(value)
+	return strings.TrimSpace(value)
+}
+
+// splitAddressEntries splits value on commas and semicolons that are not
+// inside a quoted string, angle brackets, or a parenthesized comment. An
+// unclosed < does not hide the separators after it.
+func splitAddressEntries(value string) []string {
+	var entries []string
+	var quoted, escaped bool
+	angle, comment := 0, 0
+	start := 0
+	for i, r := range value {
+		switch {
+		case escaped:
+			escaped = false
+		case r == '\\' && (quoted || comment > 0):
+			escaped = true
+		case r == '"' && comment == 0:
+			quoted = !quoted
+		case quoted:
+		case r == '(':
+			comment++
+		case r == ')' && comment > 0:
+			comment--
+		case comment > 0:
+		case r == '<' && strings.Contains(value[i:], ">"):
+			angle++
+		case r == '>' && angle > 0:
+			angle--
+		case (r == ',' || r == ';') && angle == 0:
+			entries = append(entries, value[start:i])
+			start = i + 1
+		}
+	}
+	return append(entries, value[start:])
+}
+
+// parseAddressEntry parses one address, dropping a leading "group:" label and
+// falling back to the text inside <...> or the first word with an @
+func parseAddressEntry(entry string) *mail.Address {
+	entry = strings.TrimSpace(entry)
+	if entry == "" {
+		return nil
+	}
+	if addr, err := mail.ParseAddress(entry); err == nil {
+		return addr
+	}
+	if i := strings.Index(entry, ":"); i >= 0 && !strings.ContainsAny(entry[:i], "<@\"") {
+		return parseAddressEntry(entry[i+1:])
+	}
+
+	if open := strings.LastIndex(entry, "<"); open >= 0 {
+		address := strings.TrimSpace(strings.TrimSuffix(entry[open+1:], ">"))
+		if i := strings.Index(address, ">"); i >= 0 {
+			address = address[:i]
+		}
+		if strings.Contains(address, "@") {
+			name := strings.Trim(strings.TrimSpace(entry[:open]), "\"' ")
+			return &mail.Address{Name: name, Address: address}
+		}
+	}
+	for _, word := range strings.Fields(entry) {
+		word = strings.Trim(word, "<>\"'()")
+		if strings.Contains(word, "@") {
+			return &mail.Address{Address: word}
+		}
+	}
+	return nil
+}
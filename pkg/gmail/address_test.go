@@ -0,0 +1,109 @@
+// ABOUTME: Tests for lenient address-list parsing
+// ABOUTME: Covers mixed separators, display names, folding, groups, comments, and reply header capture
+
+package gmail
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/mail"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// addressStrings flattens parsed addresses to "name|address" for comparison
+func addressStrings(list []*mail.Address) []string {
+	result := make([]string, 0, len(list))
+	for _, addr := range list {
+		result = append(result, addr.Name+"|"+addr.Address)
+	}
+	return result
+}
+
+func TestParseAddressList(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  []string
+	}{
+		{
+			name:  "mixed separators with display names",
+			value: `"Doe, Jane" <jane@example.com>; Bob Smith <bob@example.com>, carol@example.com;dan@example.com`,
+			want:  []string{"Doe, Jane|jane@example.com", "Bob Smith|bob@example.com", "|carol@example.com", "|dan@example.com"},
+		},
+		{
+			name:  "folded header",
+			value: "Alice <alice@example.com>,\r\n\tBob <bob@example.com>",
+			want:  []string{"Alice|alice@example.com", "Bob|bob@example.com"},
+		},
+		{
+			name:  "group syntax",
+			value: `Team: Alice <alice@example.com>, bob@example.com;, carol@example.com`,
+			want:  []string{"Alice|alice@example.com", "|bob@example.com", "|carol@example.com"},
+		},
+		{
+			name:  "empty group",
+			value: `undisclosed-recipients:;`,
+			want:  []string{},
+		},
+		{
+			name:  "group with semicolon separators",
+			value: `Team: alice@example.com; bob@example.com`,
+			want:  []string{"|alice@example.com", "|bob@example.com"},
+		},
+		{
+			name:  "obsolete comment form",
+			value: `bob@example.com (Bob Smith), carol@example.com (Carol; Ops)`,
+			want:  []string{"Bob Smith|bob@example.com", "Carol; Ops|carol@example.com"},
+		},
+		{
+			name:  "duplicates differing in case",
+			value: `Alice <alice@example.com>; ALICE@EXAMPLE.COM, alice@example.com`,
+			want:  []string{"Alice|alice@example.com"},
+		},
+		{
+			name:  "unclosed bracket and junk",
+			value: `Jane <jane@example.com>; Bob <bob@example.com, ???, carol@example.com`,
+			want:  []string{"Jane|jane@example.com", "Bob|bob@example.com", "|carol@example.com"},
+		},
+		{
+			name:  "blank",
+			value: " \r\n ",
+			want:  []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, addressStrings(parseAddressList(tt.value)))
+		})
+	}
+}
+
+func TestGetMessageHeaders_Recipients(t *testing.T) {
+	var query string
+	svc := newTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.RawQuery
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"id":       "orig-1",
+			"threadId": "thread-9",
+			"payload": map[string]interface{}{
+				"headers": []map[string]string{
+					{"name": "From", "value": "Alice <alice@example.com>"},
+					{"name": "To", "value": "me@example.com; \"Lee, Sam\" <sam@example.com>"},
+					{"name": "Cc", "value": "Ops: ops@example.com, Dana <dana@example.com>;"},
+				},
+			},
+		})
+	})
+
+	headers, err := svc.GetMessageHeaders(context.Background(), "orig-1")
+	require.NoError(t, err)
+	assert.Contains(t, query, "metadataHeaders=To")
+	assert.Contains(t, query, "metadataHeaders=Cc")
+	assert.Equal(t, []string{"|me@example.com", "Lee, Sam|sam@example.com"}, addressStrings(headers.To))
+	assert.Equal(t, []string{"|ops@example.com", "Dana|dana@example.com"}, addressStrings(headers.Cc))
+}
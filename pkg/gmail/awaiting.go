@@ -0,0 +1,150 @@
+// ABOUTME: Follow-up tracking for sent mail that hasn't been answered
+// ABOUTME: Finds threads whose latest message is still one the user sent, with days waiting
+
+package gmail
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/harper/gsuite-mcp/pkg/apierr"
+	"google.golang.org/api/gmail/v1"
+)
+
+// awaitingQuery selects the sent messages whose threads are checked
+const awaitingQuery = "in:sent"
+
+// AwaitingReplyReport lists sent threads that haven't had a reply since Since
+type AwaitingReplyReport struct {
+	Since     time.Time       `json:"since"`
+	Checked   int             `json:"threads_checked"`
+	Truncated bool            `json:"truncated"` // More sent messages matched than were listed
+	Failed    int             `json:"threads_failed,omitempty"`
+	Threads   []AwaitingReply `json:"threads"` // Longest waiting first
+}
+
+// AwaitingReply is a thread whose latest message is one the user sent
+type AwaitingReply struct {
+	ThreadID      string    `json:"thread_id"`
+	MessageID     string    `json:"message_id"` // The unanswered sent message
+	Subject       string    `json:"subject"`
+	To            string    `json:"to"`
+	SentAt        time.Time `json:"sent_at"`
+	DaysSinceSent int       `json:"days_since_sent"`
+}
+
+// AwaitingReply lists up to maxMessages messages sent since after, fetches
+// each distinct thread, and reports the threads nobody has replied to
+func (s *Service) AwaitingReply(ctx context.Context, after, now time.Time, maxMessages int64) (*AwaitingReplyReport, error) {
+	listed, err := s.ListMessages(ctx, WithDateRange(awaitingQuery, after, time.Time{}), maxMessages)
+	if err != nil {
+		return nil, err
+	}
+
+	var threadIDs []string
+	seen := make(map[string]bool)
+	for _, msg := range listed {
+		if !seen[msg.ThreadId] {
+			seen[msg.ThreadId] = true
+			threadIDs = append(threadIDs, msg.ThreadId)
+		}
+	}
+
+	threads := make([]*gmail.Thread, len(threadIDs))
+	errs := make([]error, len(threadIDs))
+	sem := make(chan struct{}, hydrationConcurrency)
+	var wg sync.WaitGroup
+	for i, id := range threadIDs {
+		wg.Add(1)
+		go func(i int, id string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			threads[i], errs[i] = s.getThreadMetadata(ctx, id)
+		}(i, id)
+	}
+	wg.Wait()
+
+	report := &AwaitingReplyReport{
+		Since:     after,
+		Truncated: int64(len(listed)) >= maxMessages,
+	}
+	fetched := make([]*gmail.Thread, 0, len(threads))
+	for i, thread := range threads {
+		if errs[i] != nil || thread == nil {
+			report.Failed++
+			continue
+		}
+		fetched = append(fetched, thread)
+	}
+	report.Checked = len(fetched)
+	report.Threads = FindAwaitingReply(fetched, now)
+	return report, nil
+}
+
+// getThreadMetadata fetches a thread with the headers FindAwaitingReply reads
+func (s *Service) getThreadMetadata(ctx context.Context, threadID string) (*gmail.Thread, error) {
+	var thread *gmail.Thread
+
+	err := s.breaker.Do(func() error {
+		var err error
+		thread, err = s.svc.Users.Threads.Get(s.userID, threadID).
+			Context(ctx).
+			Format("metadata").
+			MetadataHeaders("To", "Subject").
+			Do()
+		return err
+	})
+
+	if err != nil {
+		return nil, apierr.Wrap(err, "unable to get thread", "thread", threadID)
+	}
+	return thread, nil
+}
+
+// FindAwaitingReply returns the threads whose latest message carries the
+// SENT label, longest waiting first. Drafts don't count as the latest message,
+// so a half-written reply doesn't hide that the other side answered.
+func FindAwaitingReply(threads []*gmail.Thread, now time.Time) []AwaitingReply {
+	result := []AwaitingReply{}
+	for _, thread := range threads {
+		var last *gmail.Message
+		for _, msg := range thread.Messages {
+			if hasLabel(msg, "DRAFT") {
+				continue
+			}
+			if last == nil || msg.InternalDate >= last.InternalDate {
+				last = msg
+			}
+		}
+		if last == nil || !hasLabel(last, "SENT") {
+			continue
+		}
+
+		sentAt := time.UnixMilli(last.InternalDate)
+		headers := parseHeaders(last)
+		result = append(result, AwaitingReply{
+			ThreadID:      thread.Id,
+			MessageID:     last.Id,
+			Subject:       headers.Header("Subject"),
+			To:            headers.Header("To"),
+			SentAt:        sentAt,
+			DaysSinceSent: int(now.Sub(sentAt).Hours() / 24),
+		})
+	}
+
+	sort.SliceStable(result, func(i, j int) bool { return result[i].SentAt.Before(result[j].SentAt) })
+	return result
+}
+
+// hasLabel reports whether msg carries the label ID
+func hasLabel(msg *gmail.Message, labelID string) bool {
+	for _, id := range msg.LabelIds {
+		if id == labelID {
+			return true
+		}
+	}
+	return false
+}
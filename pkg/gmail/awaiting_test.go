@@ -0,0 +1,77 @@
+// ABOUTME: Tests for finding sent threads awaiting a reply
+// ABOUTME: Verifies threads ending in a sent message are flagged and answered threads are not
+
+package gmail
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/gmail/v1"
+)
+
+func TestFindAwaitingReply(t *testing.T) {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	at := func(days int) int64 { return now.AddDate(0, 0, -days).UnixMilli() }
+	msg := func(id string, date int64, labels ...string) *gmail.Message {
+		return &gmail.Message{Id: id, InternalDate: date, LabelIds: labels, Payload: &gmail.MessagePart{Headers: []*gmail.MessagePartHeader{
+			{Name: "Subject", Value: "Re: " + id},
+			{Name: "To", Value: "Jane <jane@acme.com>"},
+		}}}
+	}
+
+	threads := []*gmail.Thread{
+		{Id: "unanswered", Messages: []*gmail.Message{msg("in1", at(5), "INBOX"), msg("out1", at(3), "SENT")}},
+		{Id: "answered", Messages: []*gmail.Message{msg("out2", at(4), "SENT"), msg("in2", at(2), "INBOX")}},
+		{Id: "older", Messages: []*gmail.Message{msg("out3", at(8), "SENT")}},
+		// A draft reply doesn't make the thread look answered or unanswered
+		{Id: "drafting", Messages: []*gmail.Message{msg("out4", at(6), "SENT"), msg("in4", at(2), "INBOX"), msg("d4", at(1), "DRAFT")}},
+	}
+
+	awaiting := FindAwaitingReply(threads, now)
+	require.Len(t, awaiting, 2)
+	assert.Equal(t, "older", awaiting[0].ThreadID, "longest waiting first")
+	assert.Equal(t, 8, awaiting[0].DaysSinceSent)
+	assert.Equal(t, AwaitingReply{
+		ThreadID:      "unanswered",
+		MessageID:     "out1",
+		Subject:       "Re: out1",
+		To:            "Jane <jane@acme.com>",
+		SentAt:        time.UnixMilli(at(3)),
+		DaysSinceSent: 3,
+	}, awaiting[1])
+
+	assert.Empty(t, FindAwaitingReply(nil, now))
+}
+
+func TestAwaitingReply(t *testing.T) {
+	now := time.Now()
+	var query string
+	svc := newTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/messages"):
+			query = r.URL.Query().Get("q")
+			_, _ = w.Write([]byte(`{"messages": [{"id": "m1", "threadId": "t1"}, {"id": "m2", "threadId": "t1"}, {"id": "m3", "threadId": "t2"}]}`))
+		case strings.HasSuffix(r.URL.Path, "/threads/t1"):
+			_, _ = fmt.Fprintf(w, `{"id": "t1", "messages": [{"id": "m1", "internalDate": "%d", "labelIds": ["SENT"]}]}`, now.Add(-48*time.Hour).UnixMilli())
+		default:
+			http.NotFound(w, r)
+		}
+	})
+
+	report, err := svc.AwaitingReply(context.Background(), now.AddDate(0, 0, -7), now, 10)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(query, "in:sent after:"), query)
+	assert.Equal(t, 1, report.Checked, "each thread is fetched once")
+	assert.Equal(t, 1, report.Failed)
+	assert.False(t, report.Truncated)
+	require.Len(t, report.Threads, 1)
+	assert.Equal(t, "t1", report.Threads[0].ThreadID)
+	assert.Equal(t, 2, report.Threads[0].DaysSinceSent)
+}
@@ -0,0 +1,204 @@
+// ABOUTME: Contact detail extraction from Gmail message bodies
+// ABOUTME: Pulls emails, phones, URLs, and signature fields from a parsed message body
+
+package gmail
+
+import (
+	"context"
+	"html"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// ContactInfo holds contact candidates found in a message, for the caller to confirm
+type ContactInfo struct {
+	MessageID string   `json:"message_id"`
+	From      string   `json:"from,omitempty"`
+	Emails    []string `json:"emails"`
+	Phones    []string `json:"phones"`
+	URLs      []string `json:"urls"`
+	Name      string   `json:"name,omitempty"`    // Best effort, from the signature block
+	Title     string   `json:"title,omitempty"`   // Best effort, from the signature block
+	Company   string   `json:"company,omitempty"` // Best effort, from the signature block
+	Phone     string   `json:"phone,omitempty"`   // Best effort, the first phone in the signature block
+}
+
+var (
+	emailPattern = regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`)
+	phonePattern = regexp.MustCompile(`\+?\(?\d[\d\s().-]{6,}\d`)
+	urlPattern   = regexp.MustCompile(`(?i)\b(?:https?://|www\.)[^\s<>"']+`)
+
+	htmlDropPattern  = regexp.MustCompile(`(?is)<(script|style)[^>]*>.*?</(script|style)>`)
+	htmlBreakPattern = regexp.MustCompile(`(?i)<br\s*/?>|</(p|div|tr|li|h[1-6])>`)
+	htmlTagPattern   = regexp.MustCompile(`<[^>]*>`)
+
+	// signOffPattern matches the closing line that usually precedes a signature
+	signOffPattern = regexp.MustCompile(`(?i)^(best|best regards|regards|kind regards|warm regards|thanks|thank you|many thanks|cheers|sincerely|all the best)[,!.]?$`)
+)
+
+// ExtractContactInfo fetches a message and extracts contact candidates from its body
+func (s *Service) ExtractContactInfo(ctx context.Context, messageID string) (*ContactInfo, error) {
+	msg, err := s.GetMessage(ctx, messageID)
+	if err != nil {
+		return nil, err
+	}
+
+	parsed, err := ParseMessage(msg)
+	if err != nil {
+		return nil, err
+	}
+
+	info := ParseContactInfo(parsed.Text())
+	info.MessageID = messageID
+	info.From = parsed.Header("From")
+	return info, nil
+}
+
+// StripHTML converts an HTML body to plain text, keeping line breaks between blocks
+func StripHTML(body string) string {
+	body = htmlDropPattern.ReplaceAllString(body, "")
+	body = htmlBreakPattern.ReplaceAllString(body, "\n")
+	body = htmlTagPattern.ReplaceAllString(body, "")
+	return html.UnescapeString(body)
+}
+
+// ParseContactInfo extracts emails, phone numbers, URLs, and signature fields from text
+func ParseContactInfo(text string) *ContactInfo {
+	info := &ContactInfo{
+		Emails: uniqueMatches(emailPattern.FindAllString(text, -1), strings.ToLower),
+		Phones: uniqueMatches(phonePattern.FindAllString(text, -1), normalizePhone),
+		URLs:   uniqueMatches(urlPattern.FindAllString(text, -1), trimURL),
+	}
+	info.Name, info.Title, info.Company = parseSignature(text)
+	if info.Name != "" {
+		info.Phone = signaturePhone(text)
+	}
+	return info
+}
+
+// uniqueMatches applies normalize to each match and drops empty results and duplicates
+func uniqueMatches(matches []string, normalize func(string) string) []string {
+	seen := make(map[string]bool)
+	result := []string{}
+	for _, m := range matches {
+		m = normalize(m)
+		if m == "" || seen[m] {
+			continue
+		}
+		seen[m] = true
+		result = append(result, m)
+	}
+	return result
+}
+
+// normalizePhone reduces a phone match to +digits or digits, rejecting strings
+// that are too short or long to be a phone number (dates, order numbers)
+func normalizePhone(match string) string {
+	var digits strings.Builder
+	for _, r := range match {
+		if unicode.IsDigit(r) {
+			digits.WriteRune(r)
+		}
+	}
+	n := digits.Len()
+	if strings.HasPrefix(strings.TrimSpace(match), "+") {
+		if n < 8 || n > 15 {
+			return ""
+		}
+		return "+" + digits.String()
+	}
+	if n < 10 || n > 15 {
+		return ""
+	}
+	return digits.String()
+}
+
+// trimURL removes punctuation that ends a sentence rather than the URL
+func trimURL(match string) string {
+	return strings.TrimRight(match, ".,;:!?)]")
+}
+
+// parseSignature guesses name, title, and company from the block after a "--"
+// delimiter or a sign-off line. Lines holding emails, phones, or URLs are skipped.
+func parseSignature(text string) (name, title, company string) {
+	lines := signatureLines(text)
+	if lines == nil {
+		return "", "", ""
+	}
+
+	var fields []string
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if line == "" || emailPattern.MatchString(line) || urlPattern.MatchString(line) || phonePattern.MatchString(line) {
+			continue
+		}
+		fields = append(fields, line)
+		if len(fields) == 3 {
+			break
+		}
+	}
+
+	if len(fields) == 0 || !looksLikeName(fields[0]) {
+		return "", "", ""
+	}
+	name = fields[0]
+	if len(fields) > 1 {
+		title = fields[1]
+		// "CTO at Acme" or "CTO | Acme" carry both title and company
+		for _, sep := range []string{" at ", " | ", ", "} {
+			if before, after, ok := strings.Cut(title, sep); ok {
+				return name, strings.TrimSpace(before), strings.TrimSpace(after)
+			}
+		}
+	}
+	if len(fields) > 2 {
+		company = fields[2]
+	}
+	return name, title, company
+}
+
+// signatureLines returns the lines after the last "--" delimiter or sign-off
+// line, or nil if the text has no recognizable signature
+func signatureLines(text string) []string {
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+
+	start := -1
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "--" || signOffPattern.MatchString(trimmed) {
+			start = i + 1
+		}
+	}
+	if start < 0 {
+		return nil
+	}
+	return lines[start:]
+}
+
+// signaturePhone returns the first valid phone number in the signature block
+func signaturePhone(text string) string {
+	for _, line := range signatureLines(text) {
+		for _, match := range phonePattern.FindAllString(line, -1) {
+			if phone := normalizePhone(match); phone != "" {
+				return phone
+			}
+		}
+	}
+	return ""
+}
+
+// looksLikeName accepts two to four capitalized words without digits
+func looksLikeName(line string) bool {
+	words := strings.Fields(line)
+	if len(words) < 2 || len(words) > 4 {
+		return false
+	}
+	for _, w := range words {
+		r := []rune(w)
+		if !unicode.IsUpper(r[0]) || strings.ContainsAny(w, "0123456789") {
+			return false
+		}
+	}
+	return true
+}
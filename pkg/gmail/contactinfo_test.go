@@ -0,0 +1,84 @@
+// ABOUTME: Tests for contact detail extraction from message bodies
+// ABOUTME: Validates regex extraction, signature parsing, and HTML body handling
+
+package gmail
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const sampleSignature = `Hi there,
+
+Great meeting you at the conference. Let's talk next week.
+
+Best regards,
+Jane Smith
+Head of Partnerships at Acme Corp
+jane.smith@acme.com | +1 (415) 555-0123
+https://acme.com/team.
+`
+
+func TestParseContactInfo_Signature(t *testing.T) {
+	info := ParseContactInfo(sampleSignature)
+
+	assert.Equal(t, []string{"jane.smith@acme.com"}, info.Emails)
+	assert.Equal(t, []string{"+14155550123"}, info.Phones)
+	assert.Equal(t, []string{"https://acme.com/team"}, info.URLs)
+	assert.Equal(t, "Jane Smith", info.Name)
+	assert.Equal(t, "Head of Partnerships", info.Title)
+	assert.Equal(t, "Acme Corp", info.Company)
+	assert.Equal(t, "+14155550123", info.Phone)
+}
+
+func TestParseContactInfo_PhoneOnlyFromSignature(t *testing.T) {
+	info := ParseContactInfo("Call the front desk at +1 (212) 555-0199.\n\nThanks,\nJane Smith\nEngineer\n")
+
+	assert.Equal(t, []string{"+12125550199"}, info.Phones)
+	assert.Equal(t, "Jane Smith", info.Name)
+	assert.Empty(t, info.Phone, "phones in the body aren't attributed to the sender")
+}
+
+func TestParseContactInfo_IgnoresDatesAndShortNumbers(t *testing.T) {
+	info := ParseContactInfo("Order 12345 shipped on 2024-01-05. Call 555-0100 x12.")
+
+	assert.Empty(t, info.Phones)
+	assert.Empty(t, info.Name, "no signature block means no name guess")
+}
+
+func TestExtractContactInfo_HTMLBody(t *testing.T) {
+	htmlBody := `<div>Thanks,</div><div>Bob Jones</div><div>CTO</div><div>Widget Co</div>` +
+		`<p>Mobile: <a href="tel:+442071234567">+44 20 7123 4567</a><br>bob@widget.co.uk</p>` +
+		`<style>.sig{color:red}</style>`
+
+	svc := newTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"id": "msg-1",
+			"payload": map[string]interface{}{
+				"mimeType": "multipart/alternative",
+				"headers":  []map[string]string{{"name": "From", "value": "Bob Jones <bob@widget.co.uk>"}},
+				"parts": []map[string]interface{}{
+					{"mimeType": "text/html", "body": map[string]string{"data": base64.URLEncoding.EncodeToString([]byte(htmlBody))}},
+				},
+			},
+		})
+	})
+
+	info, err := svc.ExtractContactInfo(context.Background(), "msg-1")
+	require.NoError(t, err)
+
+	assert.Equal(t, "msg-1", info.MessageID)
+	assert.Equal(t, "Bob Jones <bob@widget.co.uk>", info.From)
+	assert.Equal(t, []string{"bob@widget.co.uk"}, info.Emails)
+	assert.Equal(t, []string{"+442071234567"}, info.Phones)
+	assert.Equal(t, "Bob Jones", info.Name)
+	assert.Equal(t, "CTO", info.Title)
+	assert.Equal(t, "Widget Co", info.Company)
+	assert.NotContains(t, info.Company, "color")
+}
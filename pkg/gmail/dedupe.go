@@ -0,0 +1,101 @@
+// ABOUTME: Idempotent draft creation keyed by a hash of the compose fields
+// ABOUTME: Recent drafts are scanned so a retried create returns the draft it already made
+
+package gmail
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"mime"
+	"sort"
+	"strings"
+
+	"google.golang.org/api/gmail/v1"
+)
+
+// dedupeScan bounds how many of the most recent drafts CreateDraftOnce compares against
+const dedupeScan = 25
+
+// ComposeHash returns a stable hash of a message's recipients, subject, and
+// body. Recipients are compared as a lowercased set of addresses, the subject
+// with surrounding whitespace trimmed, and the body with line endings
+// normalized and trailing whitespace removed from each line.
+func ComposeHash(to, subject, body string) string {
+	recipients := addresses(to)
+	for i, addr := range recipients {
+		recipients[i] = strings.ToLower(addr)
+	}
+	sort.Strings(recipients)
+
+	lines := strings.Split(strings.ReplaceAll(body, "\r\n", "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t\r")
+	}
+
+	h := sha256.New()
+	h.Write([]byte(strings.Join(recipients, ",")))
+	h.Write([]byte{0})
+	h.Write([]byte(strings.TrimSpace(sanitizeHeader(subject))))
+	h.Write([]byte{0})
+	h.Write([]byte(strings.TrimSpace(strings.Join(lines, "\n"))))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// CreateDraftOnce creates a draft like CreateDraft unless one of the most
+// recent drafts already has the same recipients, subject, and body, in which
+// case that draft is returned and existing is true
+func (s *Service) CreateDraftOnce(ctx context.Context, to, subject, body, inReplyTo string, opts *MessageOptions) (draft *gmail.Draft, existing bool, err error) {
+	composed, err := s.composeMessage(ctx, "draft", to, subject, body, inReplyTo, opts)
+	if err != nil {
+		return nil, false, err
+	}
+
+	match, err := s.findDraftByHash(ctx, ComposeHash(to, composed.subject, composed.body))
+	if err != nil {
+		return nil, false, err
+	}
+	if match != nil {
+		return match, true, nil
+	}
+
+	draft, err = s.createDraft(ctx, composed)
+	return draft, false, err
+}
+
+// findDraftByHash returns the first recent draft whose ComposeHash is hash, or nil.
+// Drafts that can no longer be fetched are skipped.
+func (s *Service) findDraftByHash(ctx context.Context, hash string) (*gmail.Draft, error) {
+	drafts, err := s.ListDrafts(ctx, dedupeScan)
+	if err != nil {
+		return nil, err
+	}
+
+	full, _ := s.getDrafts(ctx, drafts)
+	for _, draft := range full {
+		if draft == nil {
+			continue
+		}
+		parsed, err := ParseMessage(draft.Message)
+		if err != nil {
+			continue
+		}
+		body := parsed.HTMLBody
+		if body == "" {
+			body = parsed.PlainBody
+		}
+		if ComposeHash(parsed.Header("To"), decodeHeaderWords(parsed.Header("Subject")), body) == hash {
+			return draft, nil
+		}
+	}
+	return nil, nil
+}
+
+// decodeHeaderWords decodes RFC 2047 encoded-words, returning value unchanged if it can't
+func decodeHeaderWords(value string) string {
+	decoded, err := new(mime.WordDecoder).DecodeHeader(value)
+	if err != nil {
+		return value
+	}
+	return decoded
+}
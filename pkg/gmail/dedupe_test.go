@@ -0,0 +1,106 @@
+// ABOUTME: Tests for idempotent draft creation
+// ABOUTME: Verifies compose hashing and that a repeated create returns the existing draft
+
+package gmail
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/mail"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComposeHash(t *testing.T) {
+	base := ComposeHash("Bob <bob@example.com>, carol@example.com", "Budget review", "Numbers attached.\nThanks")
+
+	assert.Equal(t, base, ComposeHash("carol@example.com, BOB@example.com", "  Budget review ", "Numbers attached.  \r\nThanks\n"),
+		"recipient order, case, and whitespace don't matter")
+	assert.NotEqual(t, base, ComposeHash("bob@example.com", "Budget review", "Numbers attached.\nThanks"))
+	assert.NotEqual(t, base, ComposeHash("bob@example.com, carol@example.com", "Budget review v2", "Numbers attached.\nThanks"))
+	assert.NotEqual(t, base, ComposeHash("bob@example.com, carol@example.com", "Budget review", "Numbers attached. Thanks"))
+}
+
+// draftStoreHandler keeps created drafts in memory and serves them back from
+// drafts.list and drafts.get, counting creates
+func draftStoreHandler(t *testing.T, creates *int) http.HandlerFunc {
+	var stored []map[string]interface{}
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost:
+			*creates++
+			var req draftRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			raw, err := base64.URLEncoding.DecodeString(req.Message.Raw)
+			require.NoError(t, err)
+			msg, err := mail.ReadMessage(strings.NewReader(string(raw)))
+			require.NoError(t, err)
+			body, err := io.ReadAll(msg.Body)
+			require.NoError(t, err)
+
+			id := fmt.Sprintf("draft-%d", len(stored)+1)
+			draft := map[string]interface{}{
+				"id": id,
+				"message": map[string]interface{}{
+					"id": "msg-" + id,
+					"payload": map[string]interface{}{
+						"mimeType": "text/plain",
+						"headers": []map[string]string{
+							{"name": "To", "value": msg.Header.Get("To")},
+							{"name": "Subject", "value": msg.Header.Get("Subject")},
+						},
+						"body": map[string]string{"data": base64.URLEncoding.EncodeToString(body)},
+					},
+				},
+			}
+			// The newest draft lists first, as in Gmail
+			stored = append([]map[string]interface{}{draft}, stored...)
+			_ = json.NewEncoder(w).Encode(map[string]string{"id": id})
+		case strings.HasSuffix(r.URL.Path, "/drafts"):
+			var list []map[string]string
+			for _, d := range stored {
+				list = append(list, map[string]string{"id": d["id"].(string)})
+			}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"drafts": list})
+		default:
+			for _, d := range stored {
+				if d["id"] == path.Base(r.URL.Path) {
+					_ = json.NewEncoder(w).Encode(d)
+					return
+				}
+			}
+			http.NotFound(w, r)
+		}
+	}
+}
+
+func TestCreateDraftOnce(t *testing.T) {
+	var creates int
+	svc := newTestService(t, draftStoreHandler(t, &creates))
+	ctx := context.Background()
+
+	first, existing, err := svc.CreateDraftOnce(ctx, "bob@example.com", "Café plans", "See you at noon.\n", "", nil)
+	require.NoError(t, err)
+	assert.False(t, existing)
+	assert.Equal(t, "draft-1", first.Id)
+
+	second, existing, err := svc.CreateDraftOnce(ctx, "Bob@example.com", "Café plans", "See you at noon.", "", nil)
+	require.NoError(t, err)
+	assert.True(t, existing, "an identical create returns the existing draft")
+	assert.Equal(t, "draft-1", second.Id)
+	assert.Equal(t, 1, creates)
+
+	third, existing, err := svc.CreateDraftOnce(ctx, "bob@example.com", "Café plans", "See you at one.", "", nil)
+	require.NoError(t, err)
+	assert.False(t, existing)
+	assert.Equal(t, "draft-2", third.Id)
+	assert.Equal(t, 2, creates)
+}
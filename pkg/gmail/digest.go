@@ -0,0 +1,242 @@
+// ABOUTME: Mail digest: received messages over a window grouped by sender domain and label
+// ABOUTME: Counts volume and surfaces busy and important threads for a "catch me up" summary
+
+package gmail
+
+import (
+	"context"
+	"net/mail"
+	"sort"
+	"strings"
+	"time"
+
+	"google.golang.org/api/gmail/v1"
+)
+
+// digestQuery selects received mail; sent messages and drafts are left out
+const digestQuery = "-in:sent -in:drafts"
+
+// busyThreadMinMessages is how many messages a thread needs within the window to be highlighted
+const busyThreadMinMessages = 3
+
+// digestTopThreads caps the busy and important thread lists
+const digestTopThreads = 10
+
+// Digest summarizes the mail received between PeriodStart and PeriodEnd
+type Digest struct {
+	PeriodStart  time.Time      `json:"period_start"`
+	PeriodEnd    time.Time      `json:"period_end"`
+	MessageCount int            `json:"message_count"`
+	Truncated    bool           `json:"truncated"` // The window held more messages than were fetched
+	Domains      []DomainGroup  `json:"domains"`   // Ordered by volume
+	Labels       []LabelCount   `json:"labels"`    // Ordered by volume
+	BusyThreads  []DigestThread `json:"busy_threads"`
+	Important    []DigestThread `json:"important_threads"`
+}
+
+// DomainGroup is the mail from one sender domain
+type DomainGroup struct {
+	Domain  string        `json:"domain"`
+	Count   int           `json:"count"`
+	Senders []SenderCount `json:"senders"`
+}
+
+// SenderCount is the mail from one sender address
+type SenderCount struct {
+	Email string `json:"email"`
+	Name  string `json:"name,omitempty"`
+	Count int    `json:"count"`
+}
+
+// LabelCount is how many messages in the window carry a label
+type LabelCount struct {
+	Label string `json:"label"`
+	Count int    `json:"count"`
+}
+
+// DigestThread is a thread worth calling out in the digest
+type DigestThread struct {
+	ThreadID     string `json:"thread_id"`
+	Subject      string `json:"subject"`
+	MessageCount int    `json:"message_count"` // Messages in the window, not the whole thread
+	LatestFrom   string `json:"latest_from"`
+	Snippet      string `json:"snippet,omitempty"`
+}
+
+// Digest lists up to maxMessages received in [after, before), hydrates their
+// headers concurrently, and summarizes them with BuildDigest
+func (s *Service) Digest(ctx context.Context, after, before time.Time, maxMessages int64) (*Digest, error) {
+	listed, err := s.ListMessages(ctx, WithDateRange(digestQuery, after, before), maxMessages)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, len(listed))
+	for i, msg := range listed {
+		ids[i] = msg.Id
+	}
+	fetched, errs := s.GetMessagesMetadata(ctx, ids, "From", "Subject")
+	messages := make([]*gmail.Message, 0, len(fetched))
+	for i, msg := range fetched {
+		if errs[i] == nil && msg != nil {
+			messages = append(messages, msg)
+		}
+	}
+
+	labelNames, err := s.labelNameMap(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	digest := BuildDigest(messages, labelNames, after, before)
+	digest.Truncated = int64(len(listed)) >= maxMessages
+	return digest, nil
+}
+
+// BuildDigest groups metadata-format messages by sender domain and label.
+// Messages whose internal date falls outside [after, before) are skipped.
+// labelNames maps label IDs to display names; unknown IDs are shown as-is.
+func BuildDigest(messages []*gmail.Message, labelNames map[string]string, after, before time.Time) *Digest {
+	digest := &Digest{
+		PeriodStart: after,
+		PeriodEnd:   before,
+		Domains:     []DomainGroup{},
+		Labels:      []LabelCount{},
+		BusyThreads: []DigestThread{},
+		Important:   []DigestThread{},
+	}
+
+	domains := make(map[string]map[string]*SenderCount) // Domain -> email -> sender
+	labels := make(map[string]int)
+	threads := make(map[string]*DigestThread)
+	latest := make(map[string]int64) // Thread ID -> internal date of LatestFrom's message
+	important := make(map[string]bool)
+
+	for _, msg := range messages {
+		if msg == nil || !inWindow(msg.InternalDate, after, before) {
+			continue
+		}
+		digest.MessageCount++
+
+		headers := parseHeaders(msg)
+		name, email := parseSender(headers.Header("From"))
+		domain := senderDomain(email)
+		if domains[domain] == nil {
+			domains[domain] = make(map[string]*SenderCount)
+		}
+		sender := domains[domain][email]
+		if sender == nil {
+			sender = &SenderCount{Email: email, Name: name}
+			domains[domain][email] = sender
+		}
+		sender.Count++
+
+		for _, id := range msg.LabelIds {
+			if id == "IMPORTANT" {
+				important[msg.ThreadId] = true
+			}
+			if label, ok := labelNames[id]; ok {
+				id = label
+			}
+			labels[id]++
+		}
+
+		thread := threads[msg.ThreadId]
+		if thread == nil {
+			thread = &DigestThread{ThreadID: msg.ThreadId}
+			threads[msg.ThreadId] = thread
+		}
+		thread.MessageCount++
+		if thread.Subject == "" || msg.InternalDate >= latest[msg.ThreadId] {
+			thread.Subject = headers.Header("Subject")
+			thread.LatestFrom = headers.Header("From")
+			thread.Snippet = msg.Snippet
+			latest[msg.ThreadId] = msg.InternalDate
+		}
+	}
+
+	for domain, senders := range domains {
+		group := DomainGroup{Domain: domain}
+		for _, sender := range senders {
+			group.Count += sender.Count
+			group.Senders = append(group.Senders, *sender)
+		}
+		sort.Slice(group.Senders, func(i, j int) bool {
+			if group.Senders[i].Count != group.Senders[j].Count {
+				return group.Senders[i].Count > group.Senders[j].Count
+			}
+			return group.Senders[i].Email < group.Senders[j].Email
+		})
+		digest.Domains = append(digest.Domains, group)
+	}
+	sort.Slice(digest.Domains, func(i, j int) bool {
+		if digest.Domains[i].Count != digest.Domains[j].Count {
+			return digest.Domains[i].Count > digest.Domains[j].Count
+		}
+		return digest.Domains[i].Domain < digest.Domains[j].Domain
+	})
+
+	for label, count := range labels {
+		digest.Labels = append(digest.Labels, LabelCount{Label: label, Count: count})
+	}
+	sort.Slice(digest.Labels, func(i, j int) bool {
+		if digest.Labels[i].Count != digest.Labels[j].Count {
+			return digest.Labels[i].Count > digest.Labels[j].Count
+		}
+		return digest.Labels[i].Label < digest.Labels[j].Label
+	})
+
+	for id, thread := range threads {
+		if thread.MessageCount >= busyThreadMinMessages {
+			digest.BusyThreads = append(digest.BusyThreads, *thread)
+		}
+		if important[id] {
+			digest.Important = append(digest.Important, *thread)
+		}
+	}
+	digest.BusyThreads = topThreads(digest.BusyThreads, latest)
+	digest.Important = topThreads(digest.Important, latest)
+	return digest
+}
+
+// topThreads orders threads by message count, then most recent activity, and caps the list
+func topThreads(threads []DigestThread, latest map[string]int64) []DigestThread {
+	sort.Slice(threads, func(i, j int) bool {
+		if threads[i].MessageCount != threads[j].MessageCount {
+			return threads[i].MessageCount > threads[j].MessageCount
+		}
+		return latest[threads[i].ThreadID] > latest[threads[j].ThreadID]
+	})
+	if len(threads) > digestTopThreads {
+		threads = threads[:digestTopThreads]
+	}
+	return threads
+}
+
+// inWindow reports whether an internal date in epoch milliseconds is within [after, before).
+// Zero bounds are open.
+func inWindow(internalDate int64, after, before time.Time) bool {
+	t := time.UnixMilli(internalDate)
+	if !after.IsZero() && t.Before(after) {
+		return false
+	}
+	return before.IsZero() || t.Before(before)
+}
+
+// parseSender splits a From header into display name and lowercased address.
+// Unparseable headers are returned whole as the address.
+func parseSender(from string) (name, email string) {
+	addr, err := mail.ParseAddress(from)
+	if err != nil {
+		return "", strings.ToLower(strings.TrimSpace(from))
+	}
+	return addr.Name, strings.ToLower(addr.Address)
+}
+
+// senderDomain returns the part of email after the @, or "unknown"
+func senderDomain(email string) string {
+	if i := strings.LastIndex(email, "@"); i >= 0 && i < len(email)-1 {
+		return email[i+1:]
+	}
+	return "unknown"
+}
@@ -0,0 +1,106 @@
+// ABOUTME: Tests for the mail digest
+// ABOUTME: Checks sender/label grouping, thread highlights, and the day window
+
+package gmail
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/gmail/v1"
+)
+
+// digestMessage builds a metadata-format message received at t
+func digestMessage(id, threadID, from, subject string, t time.Time, labels ...string) *gmail.Message {
+	return &gmail.Message{
+		Id:           id,
+		ThreadId:     threadID,
+		InternalDate: t.UnixMilli(),
+		LabelIds:     labels,
+		Payload: &gmail.MessagePart{Headers: []*gmail.MessagePartHeader{
+			{Name: "From", Value: from},
+			{Name: "Subject", Value: subject},
+		}},
+	}
+}
+
+func TestBuildDigest(t *testing.T) {
+	before := time.Date(2025, 1, 13, 0, 0, 0, 0, time.UTC)
+	after := before.AddDate(0, 0, -7)
+	day := func(n int) time.Time { return after.Add(time.Duration(n)*24*time.Hour + time.Hour) }
+
+	messages := []*gmail.Message{
+		digestMessage("m1", "t1", "Jane Smith <Jane@acme.com>", "Budget", day(0), "INBOX", "Label_1"),
+		digestMessage("m2", "t1", "Bob <bob@acme.com>", "Re: Budget", day(1), "INBOX"),
+		digestMessage("m3", "t1", "jane@acme.com", "Re: Budget", day(2), "INBOX", "IMPORTANT"),
+		digestMessage("m4", "t2", "News <news@letters.io>", "Weekly", day(3), "CATEGORY_PROMOTIONS"),
+		digestMessage("m5", "t3", "Jane Smith <jane@acme.com>", "Old thread", after.Add(-time.Hour), "INBOX"),
+	}
+
+	digest := BuildDigest(messages, map[string]string{"Label_1": "Finance"}, after, before)
+
+	assert.Equal(t, 4, digest.MessageCount, "messages before the window are skipped")
+
+	require.Len(t, digest.Domains, 2)
+	assert.Equal(t, "acme.com", digest.Domains[0].Domain)
+	assert.Equal(t, 3, digest.Domains[0].Count)
+	assert.Equal(t, []SenderCount{
+		{Email: "jane@acme.com", Name: "Jane Smith", Count: 2},
+		{Email: "bob@acme.com", Name: "Bob", Count: 1},
+	}, digest.Domains[0].Senders)
+	assert.Equal(t, "letters.io", digest.Domains[1].Domain)
+
+	assert.Equal(t, LabelCount{Label: "INBOX", Count: 3}, digest.Labels[0])
+	assert.Contains(t, digest.Labels, LabelCount{Label: "Finance", Count: 1}, "label IDs are resolved to names")
+
+	require.Len(t, digest.BusyThreads, 1)
+	assert.Equal(t, "t1", digest.BusyThreads[0].ThreadID)
+	assert.Equal(t, 3, digest.BusyThreads[0].MessageCount)
+	assert.Equal(t, "Re: Budget", digest.BusyThreads[0].Subject)
+	assert.Equal(t, "jane@acme.com", digest.BusyThreads[0].LatestFrom)
+
+	require.Len(t, digest.Important, 1)
+	assert.Equal(t, "t1", digest.Important[0].ThreadID)
+}
+
+func TestService_Digest(t *testing.T) {
+	before := time.Date(2025, 1, 13, 0, 0, 0, 0, time.UTC)
+	after := before.AddDate(0, 0, -7)
+
+	var query string
+	svc := newTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		var resp interface{}
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/messages"):
+			query = r.URL.Query().Get("q")
+			resp = map[string]interface{}{"messages": []map[string]string{{"id": "m1"}, {"id": "m2"}}}
+		case strings.HasSuffix(r.URL.Path, "/messages/m1"):
+			resp = digestMessage("m1", "t1", "Jane <jane@acme.com>", "Hello", after.Add(time.Hour), "INBOX")
+		case strings.HasSuffix(r.URL.Path, "/messages/m2"):
+			resp = digestMessage("m2", "t2", "Bob <bob@other.org>", "Hi", after.Add(2*time.Hour), "Label_9")
+		case strings.HasSuffix(r.URL.Path, "/labels"):
+			resp = map[string]interface{}{"labels": []map[string]string{{"id": "Label_9", "name": "Clients"}}}
+		default:
+			http.NotFound(w, r)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	})
+
+	digest, err := svc.Digest(context.Background(), after, before, 2)
+	require.NoError(t, err)
+
+	assert.Contains(t, query, "-in:sent")
+	assert.Contains(t, query, "after:1736121600")
+	assert.Contains(t, query, "before:1736726400")
+	assert.Equal(t, 2, digest.MessageCount)
+	assert.True(t, digest.Truncated, "a full page may have left messages behind")
+	assert.Len(t, digest.Domains, 2)
+	assert.Contains(t, digest.Labels, LabelCount{Label: "Clients", Count: 1})
+}
@@ -0,0 +1,110 @@
+// ABOUTME: Draft search by subject, recipient, and body content
+// ABOUTME: Gmail search covers drafts poorly, so drafts are fetched and matched locally
+
+package gmail
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/harper/gsuite-mcp/pkg/apierr"
+	"google.golang.org/api/gmail/v1"
+)
+
+// DraftMatch is a draft whose subject, recipients, or body contained the search text
+type DraftMatch struct {
+	DraftID   string `json:"draft_id"`
+	MessageID string `json:"message_id"`
+	ThreadID  string `json:"thread_id,omitempty"`
+	To        string `json:"to,omitempty"`
+	Cc        string `json:"cc,omitempty"`
+	Bcc       string `json:"bcc,omitempty"`
+	Subject   string `json:"subject,omitempty"`
+	Snippet   string `json:"snippet,omitempty"`
+}
+
+// GetDraft retrieves a draft with its full message
+func (s *Service) GetDraft(ctx context.Context, draftID string) (*gmail.Draft, error) {
+	var draft *gmail.Draft
+
+	err := s.breaker.Do(func() error {
+		var err error
+		draft, err = s.svc.Users.Drafts.Get(s.userID, draftID).Context(ctx).Format("full").Do()
+		return err
+	})
+
+	if err != nil {
+		return nil, apierr.Wrap(err, "unable to get draft", "draft", draftID)
+	}
+	return draft, nil
+}
+
+// SearchDrafts fetches up to maxScan drafts and returns those whose subject,
+// To/Cc/Bcc, or body contains query, ignoring case. scanned is how many
+// drafts were listed; when it equals maxScan there may be more.
+// Drafts deleted while the search runs are skipped.
+func (s *Service) SearchDrafts(ctx context.Context, query string, maxScan int64) (matches []DraftMatch, scanned int, err error) {
+	drafts, err := s.ListDrafts(ctx, maxScan)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	full, errs := s.getDrafts(ctx, drafts)
+
+	needle := strings.ToLower(query)
+	matches = []DraftMatch{}
+	for i, draft := range full {
+		if errs[i] != nil {
+			if apierr.IsNotFound(errs[i]) {
+				continue
+			}
+			return nil, len(drafts), errs[i]
+		}
+		if draft.Message == nil || !draftContains(draft.Message, needle) {
+			continue
+		}
+		headers := parseHeaders(draft.Message)
+		matches = append(matches, DraftMatch{
+			DraftID:   draft.Id,
+			MessageID: draft.Message.Id,
+			ThreadID:  draft.Message.ThreadId,
+			To:        headers.Header("To"),
+			Cc:        headers.Header("Cc"),
+			Bcc:       headers.Header("Bcc"),
+			Subject:   headers.Header("Subject"),
+			Snippet:   draft.Message.Snippet,
+		})
+	}
+	return matches, len(drafts), nil
+}
+
+// getDrafts fetches the full form of each listed draft in parallel
+func (s *Service) getDrafts(ctx context.Context, drafts []*gmail.Draft) ([]*gmail.Draft, []error) {
+	full := make([]*gmail.Draft, len(drafts))
+	errs := make([]error, len(drafts))
+	sem := make(chan struct{}, hydrationConcurrency)
+	var wg sync.WaitGroup
+	for i, draft := range drafts {
+		wg.Add(1)
+		go func(i int, id string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			full[i], errs[i] = s.GetDraft(ctx, id)
+		}(i, draft.Id)
+	}
+	wg.Wait()
+	return full, errs
+}
+
+// draftContains reports whether the lowercased needle appears in msg's subject, recipients, or body
+func draftContains(msg *gmail.Message, needle string) bool {
+	headers := parseHeaders(msg)
+	for _, name := range []string{"Subject", "To", "Cc", "Bcc"} {
+		if strings.Contains(strings.ToLower(headers.Header(name)), needle) {
+			return true
+		}
+	}
+	return strings.Contains(strings.ToLower(MessageBody(msg)), needle)
+}
@@ -0,0 +1,92 @@
+// ABOUTME: Tests for draft search
+// ABOUTME: Verifies subject, recipient, and body matching and that deleted drafts are skipped
+
+package gmail
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// draftsHandler serves three drafts; d4 is listed but was deleted before it could be fetched
+func draftsHandler(listMax *string) http.HandlerFunc {
+	draft := func(id, to, subject, body string) map[string]interface{} {
+		return map[string]interface{}{
+			"id": id,
+			"message": map[string]interface{}{
+				"id":       "msg-" + id,
+				"threadId": "thread-" + id,
+				"payload": map[string]interface{}{
+					"mimeType": "text/plain",
+					"headers": []map[string]string{
+						{"name": "To", "value": to},
+						{"name": "Subject", "value": subject},
+					},
+					"body": map[string]string{"data": base64.URLEncoding.EncodeToString([]byte(body))},
+				},
+			},
+		}
+	}
+	drafts := map[string]interface{}{
+		"d1": draft("d1", "legal@example.com", "Contract redlines", "Please see the attached."),
+		"d2": draft("d2", "bob@example.com", "Lunch?", "Are you free Thursday?"),
+		"d3": draft("d3", "carol@example.com", "Follow-up", "The LEGAL review is done."),
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/drafts") {
+			*listMax = r.URL.Query().Get("maxResults")
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"drafts": []map[string]string{
+				{"id": "d1"}, {"id": "d2"}, {"id": "d3"}, {"id": "d4"},
+			}})
+			return
+		}
+		d, ok := drafts[path.Base(r.URL.Path)]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error": {"code": 404, "message": "Requested entity was not found."}}`))
+			return
+		}
+		if r.URL.Query().Get("format") != "full" {
+			http.Error(w, "expected format=full", http.StatusBadRequest)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(d)
+	}
+}
+
+func TestSearchDrafts(t *testing.T) {
+	var listMax string
+	svc := newTestService(t, draftsHandler(&listMax))
+	ctx := context.Background()
+
+	matches, scanned, err := svc.SearchDrafts(ctx, "redlines", 50)
+	require.NoError(t, err)
+	assert.Equal(t, "50", listMax)
+	assert.Equal(t, 4, scanned)
+	require.Len(t, matches, 1)
+	assert.Equal(t, "d1", matches[0].DraftID)
+	assert.Equal(t, "msg-d1", matches[0].MessageID)
+	assert.Equal(t, "Contract redlines", matches[0].Subject)
+	assert.Equal(t, "legal@example.com", matches[0].To)
+
+	// Recipient and body matches, case-insensitive, in list order
+	matches, _, err = svc.SearchDrafts(ctx, "Legal", 50)
+	require.NoError(t, err)
+	require.Len(t, matches, 2)
+	assert.Equal(t, "d1", matches[0].DraftID)
+	assert.Equal(t, "d3", matches[1].DraftID)
+
+	matches, _, err = svc.SearchDrafts(ctx, "quarterly report", 50)
+	require.NoError(t, err)
+	assert.NotNil(t, matches)
+	assert.Empty(t, matches)
+}
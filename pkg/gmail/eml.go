@@ -0,0 +1,111 @@
+// ABOUTME: Export of Gmail messages as RFC822 .eml files
+// ABOUTME: Fetches raw message bytes and derives a safe filename from the subject
+
+package gmail
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"mime"
+	"net/mail"
+	"strings"
+	"unicode"
+
+	"github.com/harper/gsuite-mcp/pkg/apierr"
+)
+
+const (
+	// LargeEMLBytes is the size above which callers should warn that the export is large
+	LargeEMLBytes = 10 * 1024 * 1024
+	// maxFilenameLength bounds the subject-derived part of an .eml filename
+	maxFilenameLength = 100
+)
+
+// EML is a message exported in RFC822 form
+type EML struct {
+	MessageID string // Gmail message ID
+	Filename  string // Suggested filename, e.g. "Quarterly report.eml"
+	Content   []byte // Exact RFC822 bytes
+}
+
+// GetRawMessage fetches a message with format=raw and returns its decoded RFC822 bytes
+// along with a filename derived from the Subject header.
+func (s *Service) GetRawMessage(ctx context.Context, messageID string) (*EML, error) {
+	var raw string
+
+	err := s.breaker.Do(func() error {
+		msg, err := s.svc.Users.Messages.Get(s.userID, messageID).Format("raw").Context(ctx).Do()
+		if err != nil {
+			return err
+		}
+		raw = msg.Raw
+		return nil
+	})
+
+	if err != nil {
+		return nil, apierr.Wrap(err, "unable to get raw message", "message", messageID)
+	}
+
+	// Gmail returns URL-safe base64, with or without padding
+	content, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(raw, "="))
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode raw message: %w", err)
+	}
+
+	return &EML{
+		MessageID: messageID,
+		Filename:  EMLFilename(rawSubject(content), messageID),
+		Content:   content,
+	}, nil
+}
+
+// rawSubject extracts and decodes the Subject header from RFC822 bytes
+func rawSubject(content []byte) string {
+	msg, err := mail.ReadMessage(bytes.NewReader(content))
+	if err != nil {
+		return ""
+	}
+	subject := msg.Header.Get("Subject")
+	if decoded, err := new(mime.WordDecoder).DecodeHeader(subject); err == nil {
+		subject = decoded
+	}
+	return subject
+}
+
+// EMLFilename builds a filesystem-safe .eml filename from a subject, falling back
+// to the message ID. Path separators, control characters, and characters
+// reserved on common filesystems are dropped.
+func EMLFilename(subject, messageID string) string {
+	var b strings.Builder
+	for _, r := range subject {
+		switch {
+		case unicode.IsControl(r):
+			continue
+		case strings.ContainsRune(`/\:*?"<>|`, r):
+			b.WriteRune('_')
+		default:
+			b.WriteRune(r)
+		}
+	}
+
+	name := strings.Trim(strings.Join(strings.Fields(b.String()), " "), ". ")
+	if runes := []rune(name); len(runes) > maxFilenameLength {
+		name = strings.TrimSpace(string(runes[:maxFilenameLength]))
+	}
+	if name == "" {
+		name = "message-" + safeID(messageID)
+	}
+	return name + ".eml"
+}
+
+// safeID strips anything but ASCII letters and digits from a message ID
+func safeID(messageID string) string {
+	return strings.Map(func(r rune) rune {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			return r
+		}
+		return -1
+	}, messageID)
+}
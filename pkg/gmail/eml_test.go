@@ -0,0 +1,75 @@
+// ABOUTME: Tests for .eml export
+// ABOUTME: Validates raw message decoding and filename sanitization
+
+package gmail
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/mail"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const sampleRFC822 = "From: Alice <alice@example.com>\r\n" +
+	"To: bob@example.com\r\n" +
+	"Subject: =?UTF-8?Q?Q3_report_/_draft=3F?=\r\n" +
+	"Date: Mon, 6 Jan 2025 09:00:00 +0000\r\n" +
+	"\r\n" +
+	"Numbers attached.\r\n"
+
+func TestGetRawMessage_DecodesRFC822(t *testing.T) {
+	var format string
+	svc := newTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		format = r.URL.Query().Get("format")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"id":  "msg-1",
+			"raw": base64.URLEncoding.EncodeToString([]byte(sampleRFC822)),
+		})
+	})
+
+	eml, err := svc.GetRawMessage(context.Background(), "msg-1")
+	require.NoError(t, err)
+
+	assert.Equal(t, "raw", format)
+	assert.Equal(t, []byte(sampleRFC822), eml.Content)
+	assert.True(t, strings.HasPrefix(string(eml.Content), "From: Alice <alice@example.com>\r\n"))
+
+	msg, err := mail.ReadMessage(bytes.NewReader(eml.Content))
+	require.NoError(t, err, "content should parse as RFC822")
+	assert.Equal(t, "bob@example.com", msg.Header.Get("To"))
+
+	assert.Equal(t, "Q3 report _ draft_.eml", eml.Filename)
+}
+
+func TestEMLFilename(t *testing.T) {
+	tests := []struct {
+		name      string
+		subject   string
+		messageID string
+		expected  string
+	}{
+		{name: "plain subject", subject: "Weekly sync notes", messageID: "abc", expected: "Weekly sync notes.eml"},
+		{name: "path separators", subject: "../../etc/passwd", messageID: "abc", expected: "_.._etc_passwd.eml"},
+		{name: "control characters", subject: "Hello\x00\r\nWorld\t!", messageID: "abc", expected: "HelloWorld!.eml"},
+		{name: "reserved characters", subject: `a:b*c?"d"<e>|f\g`, messageID: "abc", expected: "a_b_c__d__e__f_g.eml"},
+		{name: "empty subject uses id", subject: "", messageID: "18c/4f..", expected: "message-18c4f.eml"},
+		{name: "only dots uses id", subject: "...", messageID: "x1", expected: "message-x1.eml"},
+		{name: "long subject truncated", subject: strings.Repeat("a", 300), messageID: "abc", expected: strings.Repeat("a", maxFilenameLength) + ".eml"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			name := EMLFilename(tt.subject, tt.messageID)
+			assert.Equal(t, tt.expected, name)
+			assert.NotContains(t, name, "/")
+			assert.NotContains(t, name, `\`)
+		})
+	}
+}
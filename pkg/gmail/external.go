@@ -0,0 +1,46 @@
+// ABOUTME: Internal-domain checks that keep outgoing mail inside the organization
+// ABOUTME: Parses the configured domain list and finds recipients outside it
+
+package gmail
+
+import (
+	"fmt"
+	"strings"
+)
+
+// InternalDomains parses a list of domains such as example.com into a set
+// keyed by lowercase domain. Entries must be bare domains: no @, spaces, or
+// empty labels.
+func InternalDomains(domains []string) (map[string]bool, error) {
+	set := make(map[string]bool, len(domains))
+	for _, domain := range domains {
+		d := strings.ToLower(strings.TrimSpace(domain))
+		if d == "" || strings.ContainsAny(d, "@ \t<>,;") || strings.HasPrefix(d, ".") || strings.HasSuffix(d, ".") || strings.Contains(d, "..") {
+			return nil, fmt.Errorf("internal domain must be a bare domain like example.com (got %q)", domain)
+		}
+		set[d] = true
+	}
+	return set, nil
+}
+
+// ExternalRecipients returns the addresses in the address-list values (To,
+// Cc, Bcc) whose domain is not in internal, in order with repeats removed.
+// Subdomains of an internal domain count as external unless listed.
+func ExternalRecipients(internal map[string]bool, values ...string) []string {
+	var external []string
+	seen := make(map[string]bool)
+	for _, value := range values {
+		for _, addr := range parseAddressList(value) {
+			key := strings.ToLower(addr.Address)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			at := strings.LastIndex(key, "@")
+			if at < 0 || !internal[key[at+1:]] {
+				external = append(external, addr.Address)
+			}
+		}
+	}
+	return external
+}
@@ -0,0 +1,35 @@
+// ABOUTME: Tests for internal-domain parsing and external recipient detection
+// ABOUTME: Covers case folding, display names, repeats across headers, and malformed domains
+
+package gmail
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInternalDomains(t *testing.T) {
+	set, err := InternalDomains([]string{"Example.com", " corp.example.org "})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{"example.com": true, "corp.example.org": true}, set)
+
+	for _, bad := range []string{"", "@example.com", "example .com", ".example.com", "example..com", "example.com,other.com"} {
+		_, err := InternalDomains([]string{bad})
+		assert.Error(t, err, bad)
+	}
+}
+
+func TestExternalRecipients(t *testing.T) {
+	internal := map[string]bool{"example.com": true}
+
+	external := ExternalRecipients(internal,
+		`Alice <alice@Example.com>, "Partner, Bob" <bob@partner.io>`,
+		"eve@mail.example.com; BOB@partner.io",
+		"",
+	)
+	assert.Equal(t, []string{"bob@partner.io", "eve@mail.example.com"}, external)
+
+	assert.Empty(t, ExternalRecipients(internal, "alice@example.com, carol@EXAMPLE.COM"))
+}
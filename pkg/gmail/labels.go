@@ -0,0 +1,187 @@
+// ABOUTME: Gmail label listing and lookup, ID-to-name resolution, and creation by name
+// ABOUTME: The label list is cached per mailbox for a TTL and dropped when a label is created
+
+package gmail
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/harper/gsuite-mcp/pkg/apierr"
+	"google.golang.org/api/gmail/v1"
+)
+
+// ListLabels returns every system and user label in the mailbox
+func (s *Service) ListLabels(ctx context.Context) ([]*gmail.Label, error) {
+	var result *gmail.ListLabelsResponse
+	err := s.breaker.Do(func() error {
+		var err error
+		result, err = s.svc.Users.Labels.List(s.userID).Context(ctx).Do()
+		return err
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to list labels: %w", err)
+	}
+	return result.Labels, nil
+}
+
+// GetLabel fetches a single label, including its message and thread counts.
+// System labels are addressed by ID, e.g. "UNREAD" or "DRAFT".
+func (s *Service) GetLabel(ctx context.Context, labelID string) (*gmail.Label, error) {
+	var label *gmail.Label
+	err := s.breaker.Do(func() error {
+		var err error
+		label, err = s.svc.Users.Labels.Get(s.userID, labelID).Context(ctx).Do()
+		return err
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to get label %s: %w", labelID, err)
+	}
+	return label, nil
+}
+
+// ResolveLabels maps label IDs (e.g. "Label_42") to display names. System
+// labels such as INBOX are named after their IDs, so they map to themselves.
+// The label list is cached for the service's TTL; IDs missing from it (labels
+// created elsewhere since it was fetched) are returned unchanged.
+func (s *Service) ResolveLabels(ctx context.Context, labelIDs []string) ([]string, error) {
+	if len(labelIDs) == 0 {
+		return nil, nil
+	}
+
+	names, err := s.labelNameMap(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resolved := make([]string, len(labelIDs))
+	for i, id := range labelIDs {
+		if name, ok := names[id]; ok {
+			resolved[i] = name
+		} else {
+			resolved[i] = id
+		}
+	}
+	return resolved, nil
+}
+
+// labelNameMap returns the cached ID-to-name map, fetching it if it is
+// missing or older than the cache TTL. The returned map is never modified.
+func (s *Service) labelNameMap(ctx context.Context) (map[string]string, error) {
+	s.labelMu.Lock()
+	defer s.labelMu.Unlock()
+
+	if s.labelNames != nil && s.now().Sub(s.labelFetched) < s.cacheTTL {
+		return s.labelNames, nil
+	}
+
+	labels, err := s.ListLabels(ctx)
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[string]string, len(labels))
+	for _, label := range labels {
+		names[label.Id] = label.Name
+	}
+	s.labelNames = names
+	s.labelFetched = s.now()
+	return names, nil
+}
+
+// EnsureLabel returns the ID of the label named name, creating it if needed.
+// Names match case-insensitively, as in Gmail. Nested names such as
+// "Projects/Acme" create any missing parents first so the hierarchy shows in
+// Gmail; created lists the full names of labels that were made.
+func (s *Service) EnsureLabel(ctx context.Context, name string) (labelID string, created []string, err error) {
+	segments, err := splitLabelName(name)
+	if err != nil {
+		return "", nil, err
+	}
+	ids, err := s.labelIDsByName(ctx)
+	if err != nil {
+		return "", nil, err
+	}
+
+	for i := range segments {
+		path := strings.Join(segments[:i+1], "/")
+		if id, ok := ids[strings.ToLower(path)]; ok {
+			labelID = id
+			continue
+		}
+		label, err := s.CreateLabel(ctx, path)
+		if err != nil {
+			return "", created, err
+		}
+		labelID = label.Id
+		ids[strings.ToLower(path)] = label.Id
+		created = append(created, label.Name)
+	}
+	return labelID, created, nil
+}
+
+// LabelID returns the ID of the label named name (case-insensitive, with
+// whitespace around "/" ignored), or "" if there is no such label
+func (s *Service) LabelID(ctx context.Context, name string) (string, error) {
+	segments, err := splitLabelName(name)
+	if err != nil {
+		return "", err
+	}
+	ids, err := s.labelIDsByName(ctx)
+	if err != nil {
+		return "", err
+	}
+	return ids[strings.ToLower(strings.Join(segments, "/"))], nil
+}
+
+// splitLabelName splits a nested label name into trimmed, non-empty segments
+func splitLabelName(name string) ([]string, error) {
+	segments := strings.Split(name, "/")
+	for i, segment := range segments {
+		segments[i] = strings.TrimSpace(segment)
+		if segments[i] == "" {
+			return nil, fmt.Errorf("invalid label name %q: empty path segment", name)
+		}
+	}
+	return segments, nil
+}
+
+// labelIDsByName returns a fresh map from lowercased label name to ID
+func (s *Service) labelIDsByName(ctx context.Context) (map[string]string, error) {
+	names, err := s.labelNameMap(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ids := make(map[string]string, len(names))
+	for id, name := range names {
+		ids[strings.ToLower(name)] = id
+	}
+	return ids, nil
+}
+
+// CreateLabel creates a user label shown in the label list and on messages,
+// and drops the cached label list so the next lookup sees it
+func (s *Service) CreateLabel(ctx context.Context, name string) (*gmail.Label, error) {
+	var label *gmail.Label
+	err := s.breaker.Do(func() error {
+		var err error
+		label, err = s.svc.Users.Labels.Create(s.userID, &gmail.Label{
+			Name:                  name,
+			LabelListVisibility:   "labelShow",
+			MessageListVisibility: "show",
+		}).Context(ctx).Do()
+		return err
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to create label %q: %w", name, apierr.ClassifyScopeError(err, gmail.GmailLabelsScope))
+	}
+
+	s.labelMu.Lock()
+	s.labelNames = nil
+	s.labelMu.Unlock()
+
+	return label, nil
+}
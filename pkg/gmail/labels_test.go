@@ -0,0 +1,172 @@
+// ABOUTME: Tests for Gmail label resolution and creation
+// ABOUTME: Verifies IDs map to display names, the list is fetched once, and missing labels are created
+
+package gmail
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// labelsHandler serves labels.list and counts how often it is called
+func labelsHandler(calls *int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/labels") {
+			http.NotFound(w, r)
+			return
+		}
+		*calls++
+		_, _ = w.Write([]byte(`{"labels": [
+			{"id": "INBOX", "name": "INBOX", "type": "system"},
+			{"id": "UNREAD", "name": "UNREAD", "type": "system"},
+			{"id": "Label_42", "name": "Receipts", "type": "user"}
+		]}`))
+	}
+}
+
+func TestResolveLabels(t *testing.T) {
+	calls := 0
+	svc := newTestService(t, labelsHandler(&calls))
+
+	names, err := svc.ResolveLabels(context.Background(), []string{"INBOX", "Label_42", "UNREAD", "Label_99"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"INBOX", "Receipts", "UNREAD", "Label_99"}, names)
+
+	names, err = svc.ResolveLabels(context.Background(), []string{"Label_42"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Receipts"}, names)
+	assert.Equal(t, 1, calls, "label list is fetched once")
+
+	// Switching mailboxes invalidates the cache
+	require.NoError(t, svc.SetDelegate("boss@example.com"))
+	_, err = svc.ResolveLabels(context.Background(), []string{"Label_42"})
+	require.NoError(t, err)
+	assert.Equal(t, 2, calls)
+}
+
+func TestResolveLabels_NoLabelsSkipsFetch(t *testing.T) {
+	calls := 0
+	svc := newTestService(t, labelsHandler(&calls))
+
+	names, err := svc.ResolveLabels(context.Background(), nil)
+	require.NoError(t, err)
+	assert.Empty(t, names)
+	assert.Equal(t, 0, calls)
+}
+
+// creatingLabelsHandler serves labels.list from INBOX, Receipts, Projects,
+// and any labels created so far, recording the names passed to labels.create
+func creatingLabelsHandler(created *[]string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/labels") {
+			http.NotFound(w, r)
+			return
+		}
+		if r.Method == http.MethodPost {
+			var label struct {
+				Name string `json:"name"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&label)
+			*created = append(*created, label.Name)
+			_, _ = fmt.Fprintf(w, `{"id": "Label_new%d", "name": %q, "type": "user"}`, len(*created), label.Name)
+			return
+		}
+		labels := `{"id": "INBOX", "name": "INBOX", "type": "system"},
+			{"id": "Label_42", "name": "Receipts", "type": "user"},
+			{"id": "Label_7", "name": "Projects", "type": "user"}`
+		for i, name := range *created {
+			labels += fmt.Sprintf(`, {"id": "Label_new%d", "name": %q, "type": "user"}`, i+1, name)
+		}
+		_, _ = fmt.Fprintf(w, `{"labels": [%s]}`, labels)
+	}
+}
+
+func TestEnsureLabel_Existing(t *testing.T) {
+	var created []string
+	svc := newTestService(t, creatingLabelsHandler(&created))
+
+	id, made, err := svc.EnsureLabel(context.Background(), "receipts")
+	require.NoError(t, err)
+	assert.Equal(t, "Label_42", id, "names match case-insensitively")
+	assert.Empty(t, made)
+	assert.Empty(t, created)
+}
+
+func TestEnsureLabel_CreatesNestedPath(t *testing.T) {
+	var created []string
+	svc := newTestService(t, creatingLabelsHandler(&created))
+
+	id, made, err := svc.EnsureLabel(context.Background(), " Projects / Acme/Q3 ")
+	require.NoError(t, err)
+	assert.Equal(t, "Label_new2", id)
+	assert.Equal(t, []string{"Projects/Acme", "Projects/Acme/Q3"}, made, "the existing parent is reused")
+	assert.Equal(t, made, created)
+
+	// Creating drops the cached list, so the new labels resolve
+	names, err := svc.ResolveLabels(context.Background(), []string{"Label_new2"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Projects/Acme/Q3"}, names)
+
+	id, made, err = svc.EnsureLabel(context.Background(), "Projects/Acme")
+	require.NoError(t, err)
+	assert.Equal(t, "Label_new1", id)
+	assert.Empty(t, made)
+}
+
+func TestEnsureLabel_RejectsEmptySegments(t *testing.T) {
+	var created []string
+	svc := newTestService(t, creatingLabelsHandler(&created))
+
+	for _, name := range []string{"", "Projects//Acme", "Projects/"} {
+		_, _, err := svc.EnsureLabel(context.Background(), name)
+		assert.Error(t, err, name)
+	}
+	assert.Empty(t, created)
+}
+
+func TestLabelCache_TTLAndInvalidation(t *testing.T) {
+	var created []string
+	lists := 0
+	handler := creatingLabelsHandler(&created)
+	svc := newTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			lists++
+		}
+		handler(w, r)
+	})
+	now := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	svc.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	_, err := svc.ResolveLabels(ctx, []string{"Label_42"})
+	require.NoError(t, err)
+	now = now.Add(DefaultCacheTTL - time.Second)
+	_, err = svc.ResolveLabels(ctx, []string{"Label_42"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, lists, "a lookup within the TTL reuses the list")
+
+	now = now.Add(2 * time.Second)
+	_, err = svc.ResolveLabels(ctx, []string{"Label_42"})
+	require.NoError(t, err)
+	assert.Equal(t, 2, lists, "an expired list is fetched again")
+
+	_, err = svc.CreateLabel(ctx, "Travel")
+	require.NoError(t, err)
+	names, err := svc.ResolveLabels(ctx, []string{"Label_new1"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Travel"}, names)
+	assert.Equal(t, 3, lists, "creating a label invalidates the list")
+
+	svc.SetCacheTTL(0)
+	_, err = svc.ResolveLabels(ctx, []string{"Label_42"})
+	require.NoError(t, err)
+	assert.Equal(t, 4, lists, "a zero TTL disables caching")
+}
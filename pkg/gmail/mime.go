@@ -0,0 +1,185 @@
+// ABOUTME: Parsing of Gmail API message payloads into headers, bodies, and attachments
+// ABOUTME: Shared by message retrieval, list hydration, contact extraction, and reply quoting
+
+package gmail
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"mime/quotedprintable"
+	"net/textproto"
+	"strings"
+
+	"google.golang.org/api/gmail/v1"
+)
+
+// ParsedMessage is a message payload flattened into the parts callers use
+type ParsedMessage struct {
+	ID          string            `json:"id"`
+	ThreadID    string            `json:"thread_id"`
+	Headers     map[string]string `json:"headers"`    // Canonical header names; the first value wins for repeated headers
+	Recipients  []string          `json:"recipients"` // To, Cc, and Bcc addresses in header order
+	PlainBody   string            `json:"plain_body,omitempty"`
+	HTMLBody    string            `json:"html_body,omitempty"`
+	Attachments []AttachmentInfo  `json:"attachments"`
+}
+
+// AttachmentInfo describes an attachment without its content
+type AttachmentInfo struct {
+	PartID       string `json:"part_id"`
+	Filename     string `json:"filename"`
+	MimeType     string `json:"mime_type"`
+	SizeBytes    int64  `json:"size_bytes"`
+	AttachmentID string `json:"attachment_id,omitempty"` // Fetch the content with users.messages.attachments.get
+	Inline       bool   `json:"inline"`                  // Content-Disposition is inline, e.g. an embedded image
+}
+
+// ParseMessage walks msg's MIME tree. The first non-empty text/plain and
+// text/html parts become the bodies; parts with a filename or attachment ID
+// are listed as attachments. A message fetched without a payload (format
+// minimal) parses to empty headers and bodies.
+func ParseMessage(msg *gmail.Message) (*ParsedMessage, error) {
+	if msg == nil {
+		return nil, errors.New("no message to parse")
+	}
+
+	parsed := parseHeaders(msg)
+	if msg.Payload == nil {
+		return parsed, nil
+	}
+	if err := parsed.walk(msg.Payload); err != nil {
+		return nil, err
+	}
+	return parsed, nil
+}
+
+// parseHeaders returns msg's headers and recipients without decoding any
+// parts, so it can't fail. Bodies and attachments are left empty.
+func parseHeaders(msg *gmail.Message) *ParsedMessage {
+	parsed := &ParsedMessage{
+		ID:          msg.Id,
+		ThreadID:    msg.ThreadId,
+		Headers:     map[string]string{},
+		Recipients:  []string{},
+		Attachments: []AttachmentInfo{},
+	}
+	if msg.Payload == nil {
+		return parsed
+	}
+
+	for _, h := range msg.Payload.Headers {
+		key := textproto.CanonicalMIMEHeaderKey(h.Name)
+		if _, seen := parsed.Headers[key]; !seen {
+			parsed.Headers[key] = h.Value
+		}
+	}
+	for _, name := range []string{"To", "Cc", "Bcc"} {
+		parsed.Recipients = append(parsed.Recipients, addresses(parsed.Headers[name])...)
+	}
+	return parsed
+}
+
+// Header returns the named header, matched case-insensitively, or ""
+func (p *ParsedMessage) Header(name string) string {
+	return p.Headers[textproto.CanonicalMIMEHeaderKey(name)]
+}
+
+// Text returns the plain body, falling back to the HTML body with the markup stripped
+func (p *ParsedMessage) Text() string {
+	if p.PlainBody != "" {
+		return p.PlainBody
+	}
+	return StripHTML(p.HTMLBody)
+}
+
+func (p *ParsedMessage) walk(part *gmail.MessagePart) error {
+	if part.Filename != "" || (part.Body != nil && part.Body.AttachmentId != "") {
+		info := AttachmentInfo{
+			PartID:   part.PartId,
+			Filename: part.Filename,
+			MimeType: part.MimeType,
+			Inline:   strings.HasPrefix(strings.ToLower(partHeader(part, "Content-Disposition")), "inline"),
+		}
+		if part.Body != nil {
+			info.SizeBytes = part.Body.Size
+			info.AttachmentID = part.Body.AttachmentId
+		}
+		p.Attachments = append(p.Attachments, info)
+		return nil
+	}
+
+	for _, child := range part.Parts {
+		if err := p.walk(child); err != nil {
+			return err
+		}
+	}
+
+	var body *string
+	switch mimeType := strings.ToLower(part.MimeType); {
+	case strings.HasPrefix(mimeType, "text/plain"):
+		body = &p.PlainBody
+	case strings.HasPrefix(mimeType, "text/html"):
+		body = &p.HTMLBody
+	default:
+		return nil
+	}
+	if *body != "" || part.Body == nil || part.Body.Data == "" {
+		return nil
+	}
+	data, err := decodePartData(part)
+	if err != nil {
+		return fmt.Errorf("unable to decode part %s: %w", part.PartId, err)
+	}
+	*body = string(data)
+	return nil
+}
+
+// decodePartData decodes a part's base64url body. Gmail normally undoes the
+// transfer encoding itself, so quoted-printable is only decoded when the part
+// declares it and the data still has soft line breaks.
+func decodePartData(part *gmail.MessagePart) ([]byte, error) {
+	// Gmail returns URL-safe base64, with or without padding
+	data, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(part.Body.Data, "="))
+	if err != nil {
+		return nil, err
+	}
+	if strings.EqualFold(partHeader(part, "Content-Transfer-Encoding"), "quoted-printable") &&
+		(bytes.Contains(data, []byte("=\r\n")) || bytes.Contains(data, []byte("=\n"))) {
+		if decoded, err := io.ReadAll(quotedprintable.NewReader(bytes.NewReader(data))); err == nil {
+			return decoded, nil
+		}
+	}
+	return data, nil
+}
+
+// partHeader returns the value of the named header on one MIME part
+func partHeader(part *gmail.MessagePart, name string) string {
+	for _, h := range part.Headers {
+		if strings.EqualFold(h.Name, name) {
+			return strings.TrimSpace(h.Value)
+		}
+	}
+	return ""
+}
+
+// addresses extracts the email addresses from an address-list header
+func addresses(value string) []string {
+	var result []string
+	for _, addr := range parseAddressList(value) {
+		result = append(result, addr.Address)
+	}
+	return result
+}
+
+// MessageBody returns a message's text, preferring text/plain and falling back
+// to text/html with the markup stripped
+func MessageBody(msg *gmail.Message) string {
+	parsed, err := ParseMessage(msg)
+	if err != nil {
+		return ""
+	}
+	return parsed.Text()
+}
@@ -0,0 +1,164 @@
+// ABOUTME: Tests for parsing Gmail message payloads
+// ABOUTME: Covers multipart layouts, nested parts, attachments, and body decoding
+
+package gmail
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/gmail/v1"
+)
+
+func TestParseMessage_MixedWithAttachments(t *testing.T) {
+	msg := &gmail.Message{
+		Id:       "m1",
+		ThreadId: "t1",
+		Payload: &gmail.MessagePart{
+			MimeType: "multipart/mixed",
+			Headers: []*gmail.MessagePartHeader{
+				{Name: "From", Value: "Alice <alice@example.com>"},
+				{Name: "To", Value: "Bob <bob@example.com>, carol@example.com"},
+				{Name: "cc", Value: "\"Ops, Team\" <ops@example.com>"},
+				{Name: "Subject", Value: "Q3 numbers"},
+				{Name: "Received", Value: "by first"},
+				{Name: "Received", Value: "by second"},
+			},
+			Parts: []*gmail.MessagePart{
+				{
+					PartId:   "0",
+					MimeType: "multipart/alternative",
+					Parts: []*gmail.MessagePart{
+						textPart("text/plain", "Numbers attached."),
+						textPart("text/html", "<p>Numbers attached.</p>"),
+					},
+				},
+				{
+					PartId:   "1",
+					MimeType: "application/pdf",
+					Filename: "q3.pdf",
+					Headers:  []*gmail.MessagePartHeader{{Name: "Content-Disposition", Value: "attachment; filename=\"q3.pdf\""}},
+					Body:     &gmail.MessagePartBody{AttachmentId: "att-1", Size: 48213},
+				},
+				{
+					PartId:   "2",
+					MimeType: "text/plain",
+					Filename: "notes.txt",
+					Headers:  []*gmail.MessagePartHeader{{Name: "Content-Disposition", Value: "inline; filename=\"notes.txt\""}},
+					Body:     &gmail.MessagePartBody{Data: base64.URLEncoding.EncodeToString([]byte("not the body")), Size: 12},
+				},
+			},
+		},
+	}
+
+	parsed, err := ParseMessage(msg)
+	require.NoError(t, err)
+
+	assert.Equal(t, "m1", parsed.ID)
+	assert.Equal(t, "t1", parsed.ThreadID)
+	assert.Equal(t, "Q3 numbers", parsed.Header("subject"))
+	assert.Equal(t, "\"Ops, Team\" <ops@example.com>", parsed.Header("CC"))
+	assert.Equal(t, "by first", parsed.Header("Received"), "the first of repeated headers wins")
+	assert.Equal(t, []string{"bob@example.com", "carol@example.com", "ops@example.com"}, parsed.Recipients)
+
+	assert.Equal(t, "Numbers attached.", parsed.PlainBody)
+	assert.Equal(t, "<p>Numbers attached.</p>", parsed.HTMLBody)
+
+	assert.Equal(t, []AttachmentInfo{
+		{PartID: "1", Filename: "q3.pdf", MimeType: "application/pdf", SizeBytes: 48213, AttachmentID: "att-1"},
+		{PartID: "2", Filename: "notes.txt", MimeType: "text/plain", SizeBytes: 12, Inline: true},
+	}, parsed.Attachments)
+}
+
+func TestParseMessage_NestedParts(t *testing.T) {
+	// multipart/mixed > multipart/related > multipart/alternative, with an embedded image
+	msg := quotedMessage(&gmail.MessagePart{
+		MimeType: "multipart/related",
+		Parts: []*gmail.MessagePart{
+			{
+				MimeType: "multipart/alternative",
+				Parts: []*gmail.MessagePart{
+					{MimeType: "text/plain", Body: &gmail.MessagePartBody{}},
+					textPart("text/html", "<p>See the <b>chart</b></p><img src=\"cid:chart\">"),
+				},
+			},
+			{
+				PartId:   "0.1",
+				MimeType: "image/png",
+				Filename: "chart.png",
+				Headers:  []*gmail.MessagePartHeader{{Name: "Content-Disposition", Value: "INLINE"}},
+				Body:     &gmail.MessagePartBody{AttachmentId: "img-1", Size: 2048},
+			},
+		},
+	})
+	msg.Payload.MimeType = "multipart/mixed"
+
+	parsed, err := ParseMessage(msg)
+	require.NoError(t, err)
+
+	assert.Empty(t, parsed.PlainBody, "an empty text/plain part is skipped")
+	assert.Equal(t, "<p>See the <b>chart</b></p><img src=\"cid:chart\">", parsed.HTMLBody)
+	assert.Equal(t, "See the chart\n", parsed.Text(), "text falls back to the stripped HTML")
+	require.Len(t, parsed.Attachments, 1)
+	assert.True(t, parsed.Attachments[0].Inline)
+	assert.Equal(t, "img-1", parsed.Attachments[0].AttachmentID)
+}
+
+func TestParseMessage_Decoding(t *testing.T) {
+	single := func(data string, headers ...*gmail.MessagePartHeader) *gmail.Message {
+		return &gmail.Message{Payload: &gmail.MessagePart{
+			MimeType: "text/plain; charset=UTF-8",
+			Headers:  headers,
+			Body:     &gmail.MessagePartBody{Data: data},
+		}}
+	}
+	qp := &gmail.MessagePartHeader{Name: "Content-Transfer-Encoding", Value: "Quoted-Printable"}
+
+	tests := []struct {
+		name string
+		msg  *gmail.Message
+		want string
+	}{
+		{
+			name: "base64url with padding",
+			msg:  single(base64.URLEncoding.EncodeToString([]byte("Café? ok>>"))),
+			want: "Café? ok>>",
+		},
+		{
+			name: "base64url without padding",
+			msg:  single(base64.RawURLEncoding.EncodeToString([]byte("Café? ok>>"))),
+			want: "Café? ok>>",
+		},
+		{
+			name: "quoted-printable still encoded",
+			msg:  single(base64.URLEncoding.EncodeToString([]byte("Caf=C3=A9 and a very long line=\r\n that wraps")), qp),
+			want: "Café and a very long line that wraps",
+		},
+		{
+			name: "quoted-printable already decoded by Gmail",
+			msg:  single(base64.URLEncoding.EncodeToString([]byte("Totals: a=3D5\r\nDone")), qp),
+			want: "Totals: a=3D5\r\nDone",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parsed, err := ParseMessage(tt.msg)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, parsed.PlainBody)
+		})
+	}
+
+	_, err := ParseMessage(single("!!not base64!!"))
+	assert.ErrorContains(t, err, "unable to decode part")
+
+	_, err = ParseMessage(nil)
+	assert.Error(t, err)
+
+	parsed, err := ParseMessage(&gmail.Message{Id: "m2"})
+	require.NoError(t, err)
+	assert.Empty(t, parsed.Headers)
+	assert.Empty(t, parsed.Text())
+}
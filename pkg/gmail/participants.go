@@ -0,0 +1,108 @@
+// ABOUTME: Participant summary for a Gmail thread
+// ABOUTME: Collects unique From/To/Cc addresses across messages with per-person message counts
+
+package gmail
+
+import (
+	"context"
+	"net/mail"
+	"sort"
+	"strings"
+
+	"github.com/harper/gsuite-mcp/pkg/apierr"
+	"google.golang.org/api/gmail/v1"
+)
+
+// ThreadParticipants lists everyone who appears in a thread
+type ThreadParticipants struct {
+	ThreadID     string        `json:"thread_id"`
+	MessageCount int           `json:"message_count"`
+	Participants []Participant `json:"participants"` // Most messages sent first
+}
+
+// Participant is one address seen in a thread's headers
+type Participant struct {
+	Name             string `json:"name,omitempty"` // First display name seen for the address
+	Email            string `json:"email"`          // Lowercased address
+	MessagesSent     int    `json:"messages_sent"`
+	MessagesReceived int    `json:"messages_received"` // Messages with the address in To or Cc
+}
+
+// GetThreadParticipants fetches a thread's headers and summarizes its participants
+func (s *Service) GetThreadParticipants(ctx context.Context, threadID string) (*ThreadParticipants, error) {
+	var thread *gmail.Thread
+
+	err := s.breaker.Do(func() error {
+		var err error
+		thread, err = s.svc.Users.Threads.Get(s.userID, threadID).
+			Context(ctx).
+			Format("metadata").
+			MetadataHeaders("From", "To", "Cc").
+			Do()
+		return err
+	})
+
+	if err != nil {
+		return nil, apierr.Wrap(err, "unable to get thread", "thread", threadID)
+	}
+
+	return &ThreadParticipants{
+		ThreadID:     threadID,
+		MessageCount: len(thread.Messages),
+		Participants: CountParticipants(thread.Messages),
+	}, nil
+}
+
+// CountParticipants de-duplicates the From, To, and Cc addresses of messages
+// case-insensitively, counting the messages each address sent and received.
+// An address listed twice on one message is counted once for it.
+func CountParticipants(messages []*gmail.Message) []Participant {
+	byEmail := map[string]*Participant{}
+	var order []string
+
+	see := func(addr *mail.Address) *Participant {
+		email := strings.ToLower(strings.TrimSpace(addr.Address))
+		p, ok := byEmail[email]
+		if !ok {
+			p = &Participant{Email: email}
+			byEmail[email] = p
+			order = append(order, email)
+		}
+		if p.Name == "" {
+			p.Name = strings.TrimSpace(addr.Name)
+		}
+		return p
+	}
+
+	for _, msg := range messages {
+		parsed, err := ParseMessage(msg)
+		if err != nil {
+			continue
+		}
+
+		var sender string
+		for _, addr := range parseAddressList(parsed.Header("From")) {
+			p := see(addr)
+			p.MessagesSent++
+			sender = p.Email
+		}
+
+		received := map[string]bool{}
+		for _, value := range []string{parsed.Header("To"), parsed.Header("Cc")} {
+			for _, addr := range parseAddressList(value) {
+				p := see(addr)
+				if !received[p.Email] && p.Email != sender {
+					received[p.Email] = true
+					p.MessagesReceived++
+				}
+			}
+		}
+	}
+
+	result := make([]Participant, 0, len(order))
+	for _, email := range order {
+		result = append(result, *byEmail[email])
+	}
+	sort.SliceStable(result, func(i, j int) bool { return result[i].MessagesSent > result[j].MessagesSent })
+	return result
+}
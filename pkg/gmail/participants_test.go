@@ -0,0 +1,64 @@
+// ABOUTME: Tests for thread participant summaries
+// ABOUTME: Verifies case-insensitive de-duplication and per-participant message counts
+
+package gmail
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/gmail/v1"
+)
+
+// headerMessage builds a metadata-format message with the given From, To, and Cc
+func headerMessage(id, from, to, cc string) *gmail.Message {
+	msg := &gmail.Message{Id: id, ThreadId: "thread-9", Payload: &gmail.MessagePart{}}
+	for _, h := range [][2]string{{"From", from}, {"To", to}, {"Cc", cc}} {
+		if h[1] != "" {
+			msg.Payload.Headers = append(msg.Payload.Headers, &gmail.MessagePartHeader{Name: h[0], Value: h[1]})
+		}
+	}
+	return msg
+}
+
+func TestGetThreadParticipants(t *testing.T) {
+	var query string
+	svc := newTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.RawQuery
+		_ = json.NewEncoder(w).Encode(&gmail.Thread{Id: "thread-9", Messages: []*gmail.Message{
+			headerMessage("m1", "Alice Smith <alice@example.com>", "bob@example.com, Carol <carol@example.com>", ""),
+			headerMessage("m2", "Bob Jones <Bob@Example.com>", "ALICE@example.com", "carol@example.com, Carol <CAROL@example.com>"),
+			headerMessage("m3", "alice@example.com", "Bob <bob@example.com>", "Dan <dan@example.com>"),
+			headerMessage("m4", "\"Carol, Ops\" <carol@example.com>", "alice@example.com, carol@example.com", ""),
+		}})
+	})
+
+	got, err := svc.GetThreadParticipants(context.Background(), "thread-9")
+	require.NoError(t, err)
+	assert.Contains(t, query, "format=metadata")
+
+	assert.Equal(t, "thread-9", got.ThreadID)
+	assert.Equal(t, 4, got.MessageCount)
+	assert.Equal(t, []Participant{
+		{Name: "Alice Smith", Email: "alice@example.com", MessagesSent: 2, MessagesReceived: 2},
+		{Name: "Bob Jones", Email: "bob@example.com", MessagesSent: 1, MessagesReceived: 2},
+		{Name: "Carol", Email: "carol@example.com", MessagesSent: 1, MessagesReceived: 2},
+		{Name: "Dan", Email: "dan@example.com", MessagesSent: 0, MessagesReceived: 1},
+	}, got.Participants)
+}
+
+func TestCountParticipants_MalformedHeaders(t *testing.T) {
+	got := CountParticipants([]*gmail.Message{
+		headerMessage("m1", "Eve <eve@example.com>", "frank@example.com, <<broken, ", ""),
+		{Id: "no-payload"},
+	})
+
+	require.Len(t, got, 2, "fragments without an address are dropped")
+	assert.Equal(t, "eve@example.com", got[0].Email)
+	assert.Equal(t, "frank@example.com", got[1].Email)
+	assert.Empty(t, CountParticipants(nil))
+}
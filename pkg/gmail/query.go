@@ -0,0 +1,46 @@
+// ABOUTME: Gmail search query construction helpers
+// ABOUTME: Translates time bounds into after:/before: tokens and senders into from: terms
+
+package gmail
+
+import (
+	"fmt"
+	"net/mail"
+	"strings"
+	"time"
+)
+
+// WithDateRange appends after:/before: tokens for the non-zero bounds to query.
+// Bounds are written as epoch seconds, which Gmail applies exactly; the
+// YYYY/MM/DD form is interpreted in Pacific time regardless of the mailbox.
+func WithDateRange(query string, after, before time.Time) string {
+	tokens := []string{}
+	if q := strings.TrimSpace(query); q != "" {
+		tokens = append(tokens, q)
+	}
+	if !after.IsZero() {
+		tokens = append(tokens, fmt.Sprintf("after:%d", after.Unix()))
+	}
+	if !before.IsZero() {
+		tokens = append(tokens, fmt.Sprintf("before:%d", before.Unix()))
+	}
+	return strings.Join(tokens, " ")
+}
+
+// FromSender returns a from: query term matching mail sent by sender, which
+// may be a bare address or "Name <address>". Only the address is used, so the
+// term can't carry other search operators.
+func FromSender(sender string) (string, error) {
+	sender = strings.TrimSpace(sender)
+	if sender == "" {
+		return "", fmt.Errorf("sender must not be empty")
+	}
+	addr, err := mail.ParseAddress(sender)
+	if err != nil {
+		return "", fmt.Errorf("invalid sender %q: %w", sender, err)
+	}
+	if strings.ContainsAny(addr.Address, " \t\"") {
+		return "", fmt.Errorf("invalid sender %q: quoted addresses are not supported", sender)
+	}
+	return "from:" + addr.Address, nil
+}
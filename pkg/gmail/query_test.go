@@ -0,0 +1,53 @@
+// ABOUTME: Tests for Gmail search query construction
+// ABOUTME: Validates date range tokens, merging with an existing query, and sender terms
+
+package gmail
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithDateRange(t *testing.T) {
+	jan1 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	feb1 := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		query    string
+		after    time.Time
+		before   time.Time
+		expected string
+	}{
+		{name: "both bounds", after: jan1, before: feb1, expected: "after:1704067200 before:1706745600"},
+		{name: "merges with query", query: " from:boss@example.com is:unread ", after: jan1, expected: "from:boss@example.com is:unread after:1704067200"},
+		{name: "before only", before: feb1, expected: "before:1706745600"},
+		{name: "no bounds leaves query unchanged", query: "label:work", expected: "label:work"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, WithDateRange(tt.query, tt.after, tt.before))
+		})
+	}
+}
+
+func TestFromSender(t *testing.T) {
+	for sender, want := range map[string]string{
+		"boss@example.com":               "from:boss@example.com",
+		"  boss@example.com ":            "from:boss@example.com",
+		"The Boss <boss@example.com>":    "from:boss@example.com",
+		`"Boss, The" <boss@example.com>`: "from:boss@example.com",
+	} {
+		got, err := FromSender(sender)
+		assert.NoError(t, err, sender)
+		assert.Equal(t, want, got)
+	}
+
+	for _, sender := range []string{"", "   ", "boss", "boss@example.com OR label:x", `"a b"@example.com`} {
+		_, err := FromSender(sender)
+		assert.Error(t, err, sender)
+	}
+}
@@ -0,0 +1,79 @@
+// ABOUTME: Quoting the original message below a reply body
+// ABOUTME: Uses "> " prefixed lines for plain text and a blockquote for HTML
+
+package gmail
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"net/mail"
+	"strings"
+
+	"google.golang.org/api/gmail/v1"
+)
+
+// quoteDateLayout matches the attribution date style Gmail uses
+const quoteDateLayout = "Mon, Jan 2, 2006 at 3:04 PM"
+
+// quoteReply fetches the message being replied to and appends it to body as a quote
+func (s *Service) quoteReply(ctx context.Context, body, messageID string) (string, error) {
+	original, err := s.GetMessage(ctx, messageID)
+	if err != nil {
+		return "", err
+	}
+	return QuoteOriginal(body, original), nil
+}
+
+// QuoteOriginal appends original below body under an "On <date>, <sender> wrote:"
+// line. An HTML body gets the original's HTML (or escaped text) in a
+// blockquote, inserted before </body> when present; a plain body gets the
+// original's text with each line prefixed by "> ".
+func QuoteOriginal(body string, original *gmail.Message) string {
+	parsed, err := ParseMessage(original)
+	if err != nil {
+		parsed = &ParsedMessage{Headers: map[string]string{}}
+	}
+	attribution := quoteAttribution(parsed)
+
+	if isHTML(body) {
+		quoted := parsed.HTMLBody
+		if quoted == "" {
+			quoted = strings.ReplaceAll(html.EscapeString(parsed.Text()), "\n", "<br>\n")
+		}
+		block := fmt.Sprintf("<div class=\"gmail_quote\">\n<div>%s</div>\n<blockquote style=\"margin:0 0 0 .8ex;border-left:1px solid #ccc;padding-left:1ex\">\n%s\n</blockquote>\n</div>",
+			html.EscapeString(attribution), quoted)
+		if i := strings.LastIndex(strings.ToLower(body), "</body>"); i >= 0 {
+			return body[:i] + "<br>\n" + block + "\n" + body[i:]
+		}
+		return body + "\n<br>\n" + block
+	}
+
+	text := strings.ReplaceAll(strings.TrimRight(parsed.Text(), "\r\n"), "\r\n", "\n")
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		if line == "" || strings.HasPrefix(line, ">") {
+			lines[i] = ">" + line
+		} else {
+			lines[i] = "> " + line
+		}
+	}
+	return strings.TrimRight(body, "\n") + "\n\n" + attribution + "\n" + strings.Join(lines, "\n") + "\n"
+}
+
+// quoteAttribution builds the "On <date>, <sender> wrote:" line, leaving out
+// the date when the header is missing and showing it verbatim if unparseable
+func quoteAttribution(original *ParsedMessage) string {
+	sender := original.Header("From")
+	if sender == "" {
+		sender = "the sender"
+	}
+	date := original.Header("Date")
+	if date == "" {
+		return sender + " wrote:"
+	}
+	if t, err := mail.ParseDate(date); err == nil {
+		date = t.Format(quoteDateLayout)
+	}
+	return fmt.Sprintf("On %s, %s wrote:", date, sender)
+}
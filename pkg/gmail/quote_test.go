@@ -0,0 +1,97 @@
+// ABOUTME: Tests for quoting the original message in replies
+// ABOUTME: Covers plain-text prefixing, HTML blockquotes, and include_quote on drafts
+
+package gmail
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/gmail/v1"
+)
+
+// quotedMessage builds an original message with the given MIME parts
+func quotedMessage(parts ...*gmail.MessagePart) *gmail.Message {
+	return &gmail.Message{
+		Id:       "orig-1",
+		ThreadId: "thread-9",
+		Payload: &gmail.MessagePart{
+			MimeType: "multipart/alternative",
+			Headers: []*gmail.MessagePartHeader{
+				{Name: "From", Value: "Alice <alice@example.com>"},
+				{Name: "Date", Value: "Mon, 6 Jan 2025 09:30:00 -0600"},
+				{Name: "Subject", Value: "Budget review"},
+				{Name: "Message-ID", Value: "<orig@mail.example.com>"},
+			},
+			Parts: parts,
+		},
+	}
+}
+
+func textPart(mimeType, data string) *gmail.MessagePart {
+	return &gmail.MessagePart{
+		MimeType: mimeType,
+		Body:     &gmail.MessagePartBody{Data: base64.URLEncoding.EncodeToString([]byte(data))},
+	}
+}
+
+func TestQuoteOriginal_PlainText(t *testing.T) {
+	original := quotedMessage(textPart("text/plain", "Numbers attached.\r\n\r\n> earlier note\r\nThanks\r\n"))
+
+	got := QuoteOriginal("Looks good to me.\n", original)
+
+	assert.Equal(t, "Looks good to me.\n\n"+
+		"On Mon, Jan 6, 2025 at 9:30 AM, Alice <alice@example.com> wrote:\n"+
+		"> Numbers attached.\n"+
+		">\n"+
+		">> earlier note\n"+
+		"> Thanks\n", got)
+}
+
+func TestQuoteOriginal_HTML(t *testing.T) {
+	original := quotedMessage(
+		textPart("text/plain", "Numbers attached."),
+		textPart("text/html", "<p>Numbers <b>attached</b>.</p>"),
+	)
+
+	got := QuoteOriginal("<html><body><p>Looks good.</p></body></html>", original)
+
+	assert.Contains(t, got, "<p>Looks good.</p><br>\n<div class=\"gmail_quote\">")
+	assert.Contains(t, got, "<div>On Mon, Jan 6, 2025 at 9:30 AM, Alice &lt;alice@example.com&gt; wrote:</div>")
+	assert.Contains(t, got, "<blockquote style=\"margin:0 0 0 .8ex;border-left:1px solid #ccc;padding-left:1ex\">\n<p>Numbers <b>attached</b>.</p>\n</blockquote>")
+	assert.Regexp(t, `</blockquote>\n</div>\n</body></html>$`, got, "the quote goes inside the body")
+
+	// A plain-text original is escaped into the blockquote
+	plainOnly := quotedMessage(textPart("text/plain", "a < b\nsecond line"))
+	got = QuoteOriginal("<div>Agreed.</div>", plainOnly)
+	assert.Contains(t, got, "<blockquote style=\"margin:0 0 0 .8ex;border-left:1px solid #ccc;padding-left:1ex\">\na &lt; b<br>\nsecond line\n</blockquote>")
+}
+
+func TestCreateDraft_IncludeQuote(t *testing.T) {
+	var draft draftRequest
+	svc := newTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&draft))
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"id": "draft-1"})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(quotedMessage(textPart("text/plain", "Numbers attached.")))
+	})
+	ctx := context.Background()
+
+	_, err := svc.CreateDraft(ctx, "alice@example.com", "Budget review", "Looks good.", "orig-1", &MessageOptions{IncludeQuote: true})
+	require.NoError(t, err)
+
+	raw, err := base64.URLEncoding.DecodeString(draft.Message.Raw)
+	require.NoError(t, err)
+	assert.Contains(t, string(raw), "In-Reply-To: <orig@mail.example.com>")
+	assert.Contains(t, string(raw), "\r\n\r\nLooks good.\n\nOn Mon, Jan 6, 2025 at 9:30 AM, Alice <alice@example.com> wrote:\n> Numbers attached.\n")
+
+	_, err = svc.CreateDraft(ctx, "alice@example.com", "Budget review", "Hi", "", &MessageOptions{IncludeQuote: true})
+	assert.ErrorContains(t, err, "include_quote requires in_reply_to")
+}
@@ -0,0 +1,140 @@
+// ABOUTME: Repairs the threading of reply drafts created without reply headers
+// ABOUTME: Rewrites In-Reply-To, References, and Subject in the raw MIME, leaving the body untouched
+
+package gmail
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/harper/gsuite-mcp/pkg/apierr"
+	"google.golang.org/api/gmail/v1"
+)
+
+// FixDraftThreading makes draftID a reply to originalMessageID: its
+// In-Reply-To and References headers are rebuilt from the original, the
+// subject gets a "Re: " prefix if missing, and the draft is moved into the
+// original's thread. Recipients, other headers, and the body are preserved.
+func (s *Service) FixDraftThreading(ctx context.Context, draftID, originalMessageID string) (*gmail.Draft, error) {
+	original, err := s.GetMessageHeaders(ctx, originalMessageID)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch original message: %w", err)
+	}
+	if original.MessageID == "" {
+		return nil, fmt.Errorf("message %s has no Message-ID header to reply to", originalMessageID)
+	}
+
+	raw, err := s.getDraftRaw(ctx, draftID)
+	if err != nil {
+		return nil, err
+	}
+
+	fixed, err := rethreadMessage(raw, original)
+	if err != nil {
+		return nil, fmt.Errorf("unable to rewrite draft %s: %w", draftID, err)
+	}
+
+	draft := &gmail.Draft{
+		Id: draftID,
+		Message: &gmail.Message{
+			Raw:      base64.URLEncoding.EncodeToString(fixed),
+			ThreadId: original.ThreadId,
+		},
+	}
+
+	var updated *gmail.Draft
+	err = s.breaker.Do(func() error {
+		var err error
+		updated, err = s.svc.Users.Drafts.Update(s.userID, draftID, draft).Context(ctx).Do()
+		return err
+	})
+
+	if err != nil {
+		return nil, apierr.Wrap(apierr.ClassifyScopeError(err, gmail.GmailModifyScope), "unable to update draft", "draft", draftID)
+	}
+	return updated, nil
+}
+
+// getDraftRaw fetches a draft's message as decoded RFC822 bytes
+func (s *Service) getDraftRaw(ctx context.Context, draftID string) ([]byte, error) {
+	var draft *gmail.Draft
+
+	err := s.breaker.Do(func() error {
+		var err error
+		draft, err = s.svc.Users.Drafts.Get(s.userID, draftID).Context(ctx).Format("raw").Do()
+		return err
+	})
+
+	if err != nil {
+		return nil, apierr.Wrap(err, "unable to get draft", "draft", draftID)
+	}
+	if draft.Message == nil {
+		return nil, fmt.Errorf("draft %s has no message", draftID)
+	}
+
+	// Gmail returns URL-safe base64, with or without padding
+	content, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(draft.Message.Raw, "="))
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode draft %s: %w", draftID, err)
+	}
+	return content, nil
+}
+
+// rethreadMessage replaces raw's In-Reply-To and References headers with ones
+// pointing at original and prefixes the subject with "Re: ". Header lines are
+// otherwise kept verbatim, and the body bytes are not touched.
+func rethreadMessage(raw []byte, original *ThreadingHeaders) ([]byte, error) {
+	newline := []byte("\r\n")
+	end := bytes.Index(raw, []byte("\r\n\r\n"))
+	if end < 0 {
+		newline = []byte("\n")
+		end = bytes.Index(raw, []byte("\n\n"))
+	}
+	if end < 0 {
+		return nil, fmt.Errorf("message has no header/body separator")
+	}
+	body := raw[end+2*len(newline):]
+
+	var headers [][]byte
+	skipping := false
+	hasSubject := false
+	for _, line := range bytes.Split(raw[:end], newline) {
+		// Folded continuation lines belong to the previous header
+		if len(line) > 0 && (line[0] == ' ' || line[0] == '\t') {
+			if !skipping {
+				headers = append(headers, line)
+			}
+			continue
+		}
+
+		name, value, _ := strings.Cut(string(line), ":")
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case "in-reply-to", "references":
+			skipping = true
+			continue
+		case "subject":
+			hasSubject = true
+			line = []byte(name + ": " + ensureReplySubject(strings.TrimSpace(value)))
+		}
+		skipping = false
+		headers = append(headers, line)
+	}
+
+	if !hasSubject {
+		headers = append(headers, []byte("Subject: "+encodeHeaderWord(sanitizeHeader(ensureReplySubject(original.Subject)))))
+	}
+	headers = append(headers,
+		[]byte("In-Reply-To: "+sanitizeHeader(original.MessageID)),
+		[]byte("References: "+sanitizeHeader(buildReferences(original.MessageID, original.References))),
+	)
+
+	var out bytes.Buffer
+	out.Write(bytes.Join(headers, newline))
+	out.Write(newline)
+	out.Write(newline)
+	out.Write(body)
+	return out.Bytes(), nil
+}
@@ -0,0 +1,97 @@
+// ABOUTME: Tests for repairing reply-draft threading
+// ABOUTME: Checks rebuilt In-Reply-To/References, the thread ID, and that recipients and body are unchanged
+
+package gmail
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// brokenDraft is a reply draft saved without threading headers
+const brokenDraft = "To: alice@example.com\r\n" +
+	"Cc: Bob <bob@example.com>\r\n" +
+	"Subject: Budget review\r\n" +
+	"In-Reply-To: <wrong@mail.example.com>\r\n" +
+	"References: <wrong@mail.example.com>\r\n" +
+	" <older@mail.example.com>\r\n" +
+	"Content-Type: text/plain; charset=\"UTF-8\"\r\n" +
+	"Content-Transfer-Encoding: 7bit\r\n" +
+	"MIME-Version: 1.0\r\n" +
+	"\r\n" +
+	"Numbers look right.\r\n\r\nThanks\r\n"
+
+func TestRethreadMessage(t *testing.T) {
+	original := &ThreadingHeaders{
+		ThreadId:   "thread-9",
+		MessageID:  "<orig@mail.example.com>",
+		References: "<root@mail.example.com>",
+		Subject:    "Budget review",
+	}
+
+	fixed, err := rethreadMessage([]byte(brokenDraft), original)
+	require.NoError(t, err)
+
+	assert.Equal(t, "To: alice@example.com\r\n"+
+		"Cc: Bob <bob@example.com>\r\n"+
+		"Subject: Re: Budget review\r\n"+
+		"Content-Type: text/plain; charset=\"UTF-8\"\r\n"+
+		"Content-Transfer-Encoding: 7bit\r\n"+
+		"MIME-Version: 1.0\r\n"+
+		"In-Reply-To: <orig@mail.example.com>\r\n"+
+		"References: <root@mail.example.com> <orig@mail.example.com>\r\n"+
+		"\r\n"+
+		"Numbers look right.\r\n\r\nThanks\r\n", string(fixed))
+
+	// LF-only messages keep their line endings; a missing subject is added
+	fixed, err = rethreadMessage([]byte("To: alice@example.com\n\nHi\n"), original)
+	require.NoError(t, err)
+	assert.Equal(t, "To: alice@example.com\nSubject: Re: Budget review\nIn-Reply-To: <orig@mail.example.com>\n"+
+		"References: <root@mail.example.com> <orig@mail.example.com>\n\nHi\n", string(fixed))
+
+	_, err = rethreadMessage([]byte("To: alice@example.com"), original)
+	assert.Error(t, err)
+}
+
+func TestFixDraftThreading(t *testing.T) {
+	var update draftRequest
+	var updatePath, draftFormat string
+	svc := newTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPut:
+			updatePath = r.URL.Path
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&update))
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"id": "draft-1", "message": map[string]string{"id": "msg-2", "threadId": update.Message.ThreadId}})
+		case strings.HasSuffix(r.URL.Path, "/drafts/draft-1"):
+			draftFormat = r.URL.Query().Get("format")
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"id":      "draft-1",
+				"message": map[string]string{"id": "msg-1", "raw": base64.RawURLEncoding.EncodeToString([]byte(brokenDraft))},
+			})
+		default:
+			replyTestHandler(t, nil)(w, r)
+		}
+	})
+
+	draft, err := svc.FixDraftThreading(context.Background(), "draft-1", "orig-1")
+	require.NoError(t, err)
+	assert.Equal(t, "thread-9", draft.Message.ThreadId)
+	assert.Equal(t, "raw", draftFormat)
+	assert.True(t, strings.HasSuffix(updatePath, "/drafts/draft-1"), updatePath)
+	assert.Equal(t, "thread-9", update.Message.ThreadId)
+
+	raw, err := base64.URLEncoding.DecodeString(update.Message.Raw)
+	require.NoError(t, err)
+	assert.Contains(t, string(raw), "In-Reply-To: <orig@mail.example.com>\r\n")
+	assert.Contains(t, string(raw), "References: <root@mail.example.com> <orig@mail.example.com>\r\n")
+	assert.NotContains(t, string(raw), "wrong@mail.example.com")
+	assert.Equal(t, "Cc: Bob <bob@example.com>", strings.Split(string(raw), "\r\n")[1])
+	assert.True(t, strings.HasSuffix(string(raw), "\r\n\r\nNumbers look right.\r\n\r\nThanks\r\n"), "body is unchanged")
+}
@@ -0,0 +1,182 @@
+// ABOUTME: Scheduled send: a file-backed queue of drafts to send at a later time
+// ABOUTME: Gmail's native schedule-send isn't in the API, so a running process sends due drafts
+
+package gmail
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/harper/gsuite-mcp/pkg/apierr"
+)
+
+// ScheduledSend is a draft waiting to be sent
+type ScheduledSend struct {
+	DraftID   string    `json:"draft_id"`
+	SendAt    time.Time `json:"send_at"`
+	To        string    `json:"to,omitempty"`
+	Subject   string    `json:"subject,omitempty"`
+	LastError string    `json:"last_error,omitempty"` // Most recent failed attempt; the item stays queued
+}
+
+// SendResult reports what happened to one due item
+type SendResult struct {
+	DraftID   string `json:"draft_id"`
+	MessageID string `json:"message_id,omitempty"`
+	Error     string `json:"error,omitempty"`
+	Dropped   bool   `json:"dropped,omitempty"` // The draft no longer exists, so it was removed from the queue
+}
+
+// ScheduleQueue persists scheduled sends as a JSON file
+type ScheduleQueue struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewScheduleQueue returns a queue stored at path; the file is created on first Add
+func NewScheduleQueue(path string) *ScheduleQueue {
+	return &ScheduleQueue{path: path}
+}
+
+// Path returns the queue file location
+func (q *ScheduleQueue) Path() string {
+	return q.path
+}
+
+// List returns the queued items ordered by send time
+func (q *ScheduleQueue) List() ([]ScheduledSend, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.load()
+}
+
+// Add queues a draft to be sent at item.SendAt
+func (q *ScheduleQueue) Add(item ScheduledSend) error {
+	if item.DraftID == "" {
+		return fmt.Errorf("draft ID is required")
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	items, err := q.load()
+	if err != nil {
+		return err
+	}
+	return q.save(append(items, item))
+}
+
+// SendDue sends every queued draft whose send time is at or before now.
+// Sent drafts leave the queue; failures stay queued with LastError set so
+// the next run retries them, except drafts that no longer exist.
+func (q *ScheduleQueue) SendDue(ctx context.Context, svc *Service, now time.Time) ([]SendResult, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	items, err := q.load()
+	if err != nil {
+		return nil, err
+	}
+
+	var results []SendResult
+	done := make(map[string]bool)     // Sent or dropped draft IDs
+	failed := make(map[string]string) // Draft ID -> error to record
+	for _, item := range items {
+		if item.SendAt.After(now) {
+			continue
+		}
+
+		result := SendResult{DraftID: item.DraftID}
+		sent, err := svc.SendDraft(ctx, item.DraftID)
+		switch {
+		case err == nil:
+			result.MessageID = sent.Id
+			done[item.DraftID] = true
+		case apierr.IsNotFound(err):
+			result.Error = err.Error()
+			result.Dropped = true
+			done[item.DraftID] = true
+		default:
+			result.Error = err.Error()
+			failed[item.DraftID] = err.Error()
+		}
+		results = append(results, result)
+	}
+	if len(results) == 0 {
+		return nil, nil
+	}
+
+	// Re-read so items another process queued while we were sending survive
+	current, err := q.load()
+	if err != nil {
+		return results, err
+	}
+	remaining := make([]ScheduledSend, 0, len(current))
+	for _, item := range current {
+		if done[item.DraftID] {
+			continue
+		}
+		if msg, ok := failed[item.DraftID]; ok {
+			item.LastError = msg
+		}
+		remaining = append(remaining, item)
+	}
+	return results, q.save(remaining)
+}
+
+// load reads the queue file; a missing file is an empty queue
+func (q *ScheduleQueue) load() ([]ScheduledSend, error) {
+	data, err := os.ReadFile(q.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to read scheduled sends: %w", err)
+	}
+
+	var items []ScheduledSend
+	if err := json.Unmarshal(data, &items); err != nil {
+		return nil, fmt.Errorf("unable to parse scheduled sends %s: %w", q.path, err)
+	}
+	sort.SliceStable(items, func(i, j int) bool { return items[i].SendAt.Before(items[j].SendAt) })
+	return items, nil
+}
+
+// save writes the queue atomically so a concurrent reader never sees a partial file
+func (q *ScheduleQueue) save(items []ScheduledSend) error {
+	if items == nil {
+		items = []ScheduledSend{}
+	}
+	data, err := json.MarshalIndent(items, "", "  ")
+	if err != nil {
+		return fmt.Errorf("unable to encode scheduled sends: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(q.path), 0700); err != nil {
+		return fmt.Errorf("unable to create scheduled sends directory: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(q.path), ".scheduled-*.json")
+	if err != nil {
+		return fmt.Errorf("unable to write scheduled sends: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("unable to write scheduled sends: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("unable to write scheduled sends: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), q.path); err != nil {
+		return fmt.Errorf("unable to write scheduled sends: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+// ABOUTME: Tests for the scheduled-send queue
+// ABOUTME: Verifies due drafts are sent, future ones stay queued, and failures are retained or dropped
+
+package gmail
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// draftSendHandler answers drafts.send, failing drafts listed in statuses with that HTTP status
+func draftSendHandler(sent *[]string, statuses map[string]int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || !strings.HasSuffix(r.URL.Path, "/drafts/send") {
+			http.NotFound(w, r)
+			return
+		}
+		var draft struct {
+			ID string `json:"id"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&draft)
+		if status, ok := statuses[draft.ID]; ok {
+			w.WriteHeader(status)
+			_, _ = fmt.Fprintf(w, `{"error": {"code": %d, "message": "draft unavailable"}}`, status)
+			return
+		}
+		*sent = append(*sent, draft.ID)
+		_ = json.NewEncoder(w).Encode(map[string]string{"id": "msg-" + draft.ID})
+	}
+}
+
+func TestScheduleQueue_SendDue(t *testing.T) {
+	var sent []string
+	svc := newTestService(t, draftSendHandler(&sent, nil))
+
+	now := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+	queue := NewScheduleQueue(filepath.Join(t.TempDir(), "nested", "scheduled.json"))
+	require.NoError(t, queue.Add(ScheduledSend{DraftID: "future", SendAt: now.Add(time.Hour)}))
+	require.NoError(t, queue.Add(ScheduledSend{DraftID: "due", SendAt: now.Add(-time.Minute), Subject: "Hello"}))
+
+	results, err := queue.SendDue(context.Background(), svc, now)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"due"}, sent)
+	assert.Equal(t, []SendResult{{DraftID: "due", MessageID: "msg-due"}}, results)
+
+	remaining, err := queue.List()
+	require.NoError(t, err)
+	require.Len(t, remaining, 1)
+	assert.Equal(t, "future", remaining[0].DraftID)
+
+	// Nothing else is due, so a second run sends nothing
+	results, err = queue.SendDue(context.Background(), svc, now)
+	require.NoError(t, err)
+	assert.Empty(t, results)
+	assert.Equal(t, []string{"due"}, sent)
+}
+
+func TestScheduleQueue_SendDueFailures(t *testing.T) {
+	var sent []string
+	svc := newTestService(t, draftSendHandler(&sent, map[string]int{
+		"deleted":   http.StatusNotFound,
+		"forbidden": http.StatusForbidden,
+	}))
+
+	now := time.Now()
+	queue := NewScheduleQueue(filepath.Join(t.TempDir(), "scheduled.json"))
+	require.NoError(t, queue.Add(ScheduledSend{DraftID: "deleted", SendAt: now.Add(-time.Hour)}))
+	require.NoError(t, queue.Add(ScheduledSend{DraftID: "forbidden", SendAt: now.Add(-time.Hour)}))
+
+	results, err := queue.SendDue(context.Background(), svc, now)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Empty(t, sent)
+
+	byID := map[string]SendResult{}
+	for _, r := range results {
+		byID[r.DraftID] = r
+	}
+	assert.True(t, byID["deleted"].Dropped, "a draft that no longer exists is dropped")
+	assert.False(t, byID["forbidden"].Dropped)
+	assert.NotEmpty(t, byID["forbidden"].Error)
+
+	remaining, err := queue.List()
+	require.NoError(t, err)
+	require.Len(t, remaining, 1)
+	assert.Equal(t, "forbidden", remaining[0].DraftID)
+	assert.NotEmpty(t, remaining[0].LastError, "failures stay queued for retry")
+}
+
+func TestScheduleQueue_EmptyAndInvalid(t *testing.T) {
+	queue := NewScheduleQueue(filepath.Join(t.TempDir(), "missing.json"))
+
+	items, err := queue.List()
+	require.NoError(t, err)
+	assert.Empty(t, items)
+
+	assert.Error(t, queue.Add(ScheduledSend{SendAt: time.Now()}))
+}
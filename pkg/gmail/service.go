@@ -5,21 +5,44 @@ package gmail
 
 import (
 	"context"
+	"crypto/rand"
 	"encoding/base64"
+	"encoding/hex"
 	"fmt"
+	"mime"
+	"mime/quotedprintable"
 	"net/http"
+	"net/mail"
 	"os"
+	"regexp"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/harper/gsuite-mcp/pkg/apierr"
 	"github.com/harper/gsuite-mcp/pkg/retry"
 	"google.golang.org/api/gmail/v1"
 	"google.golang.org/api/option"
 )
 
+// defaultUserID addresses the authenticated user's own mailbox
+const defaultUserID = "me"
+
 // Service wraps Gmail API operations
 type Service struct {
-	svc *gmail.Service
+	svc     *gmail.Service
+	userID  string         // Mailbox every call acts on: "me" or a delegated address
+	breaker *retry.Breaker // Fails calls fast while the API is down
+
+	labelMu      sync.Mutex
+	labelNames   map[string]string // Label ID -> display name, refetched after cacheTTL
+	labelFetched time.Time         // When labelNames was fetched
+	cacheTTL     time.Duration     // How long the label list is reused; 0 fetches it every time
+	now          func() time.Time
+
+	fromName  string     // Display name for the From header; empty leaves From to Gmail
+	fromMu    sync.Mutex // Guards fromEmail
+	fromEmail string     // Primary sending address, looked up once per mailbox
 }
 
 // NewService creates a new Gmail service
@@ -45,15 +68,121 @@ func NewService(ctx context.Context, client *http.Client) (*Service, error) {
 		return nil, fmt.Errorf("unable to create Gmail service: %w", err)
 	}
 
-	return &Service{svc: svc}, nil
+	return &Service{
+		svc:      svc,
+		userID:   defaultUserID,
+		breaker:  retry.NewBreaker(breakerName, retry.DefaultBreakerPolicy()),
+		cacheTTL: DefaultCacheTTL,
+		now:      time.Now,
+	}, nil
+}
+
+// DefaultCacheTTL is how long the label list is reused before it is fetched again
+const DefaultCacheTTL = 10 * time.Minute
+
+// SetCacheTTL sets how long the label list is reused. Zero disables caching.
+func (s *Service) SetCacheTTL(ttl time.Duration) {
+	s.labelMu.Lock()
+	s.cacheTTL = ttl
+	s.labelMu.Unlock()
+}
+
+// breakerName prefixes the error returned while the circuit is open
+const breakerName = "Gmail API"
+
+// SetBreakerPolicy replaces the circuit breaker shared by this service's calls
+func (s *Service) SetBreakerPolicy(policy retry.BreakerPolicy) {
+	s.breaker = retry.NewBreaker(breakerName, policy)
+}
+
+// SetDelegate makes subsequent calls act on the mailbox of email instead of the
+// authenticated user. This needs domain-wide delegation (or equivalent mailbox
+// access) to be configured for the credentials. An empty email restores "me".
+func (s *Service) SetDelegate(email string) error {
+	if email == "" {
+		email = defaultUserID
+	} else if err := ValidateDelegate(email); err != nil {
+		return err
+	}
+	s.userID = email
+
+	// Label IDs and the sending address are per mailbox
+	s.labelMu.Lock()
+	s.labelNames = nil
+	s.labelMu.Unlock()
+	s.fromMu.Lock()
+	s.fromEmail = ""
+	s.fromMu.Unlock()
+	return nil
+}
+
+// SetFromName makes outgoing messages carry a From header with this display
+// name and the mailbox's address. An empty name leaves From to Gmail, which
+// uses the account's default.
+func (s *Service) SetFromName(name string) error {
+	if err := ValidateFromName(name); err != nil {
+		return err
+	}
+	s.fromName = strings.TrimSpace(name)
+	return nil
+}
+
+// ValidateFromName checks that name can be used as a From display name
+func ValidateFromName(name string) error {
+	if strings.ContainsAny(name, "\r\n") {
+		return fmt.Errorf("from name cannot contain line breaks")
+	}
+	return nil
+}
+
+// fromHeader returns the From header value for outgoing mail, or "" when no
+// display name is configured
+func (s *Service) fromHeader(ctx context.Context) (string, error) {
+	if s.fromName == "" {
+		return "", nil
+	}
+	email, err := s.SendingAddress(ctx)
+	if err != nil {
+		return "", err
+	}
+	return formatAddress(s.fromName, email), nil
+}
+
+// SendingAddress returns the mailbox's primary address: the delegated address,
+// or the authenticated account's, looked up once
+func (s *Service) SendingAddress(ctx context.Context) (string, error) {
+	s.fromMu.Lock()
+	defer s.fromMu.Unlock()
+	if s.fromEmail != "" {
+		return s.fromEmail, nil
+	}
+	if s.userID != defaultUserID {
+		s.fromEmail = s.userID
+		return s.fromEmail, nil
+	}
+	profile, err := s.GetProfile(ctx)
+	if err != nil {
+		return "", fmt.Errorf("unable to look up sending address: %w", err)
+	}
+	s.fromEmail = profile.EmailAddress
+	return s.fromEmail, nil
+}
+
+// ValidateDelegate checks that email is a bare address usable as a Gmail user ID
+func ValidateDelegate(email string) error {
+	addr, err := mail.ParseAddress(email)
+	if err != nil || addr.Address != email {
+		return fmt.Errorf("delegate must be a plain email address (got %q)", email)
+	}
+	return nil
 }
 
 // ListMessages lists messages matching query
 func (s *Service) ListMessages(ctx context.Context, query string, maxResults int64) ([]*gmail.Message, error) {
 	var result *gmail.ListMessagesResponse
 
-	err := retry.WithRetry(func() error {
-		call := s.svc.Users.Messages.List("me").Context(ctx).MaxResults(maxResults)
+	err := s.breaker.Do(func() error {
+		call := s.svc.Users.Messages.List(s.userID).Context(ctx).MaxResults(maxResults)
 
 		if query != "" {
 			call = call.Q(query)
@@ -62,7 +191,7 @@ func (s *Service) ListMessages(ctx context.Context, query string, maxResults int
 		var err error
 		result, err = call.Do()
 		return err
-	}, 3, time.Second)
+	})
 
 	if err != nil {
 		return nil, fmt.Errorf("unable to list messages: %w", err)
@@ -75,119 +204,398 @@ func (s *Service) ListMessages(ctx context.Context, query string, maxResults int
 func (s *Service) GetMessage(ctx context.Context, messageID string) (*gmail.Message, error) {
 	var msg *gmail.Message
 
-	err := retry.WithRetry(func() error {
+	err := s.breaker.Do(func() error {
+		var err error
+		msg, err = s.svc.Users.Messages.Get(s.userID, messageID).Context(ctx).Do()
+		return err
+	})
+
+	if err != nil {
+		return nil, apierr.Wrap(err, "unable to get message", "message", messageID)
+	}
+	return msg, nil
+}
+
+// hydrationConcurrency bounds parallel metadata fetches in GetMessagesMetadata
+const hydrationConcurrency = 8
+
+// GetMessageMetadata retrieves a message's labels, snippet, and the named headers only
+func (s *Service) GetMessageMetadata(ctx context.Context, messageID string, headers ...string) (*gmail.Message, error) {
+	var msg *gmail.Message
+
+	err := s.breaker.Do(func() error {
 		var err error
-		msg, err = s.svc.Users.Messages.Get("me", messageID).Context(ctx).Do()
+		msg, err = s.svc.Users.Messages.Get(s.userID, messageID).
+			Context(ctx).
+			Format("metadata").
+			MetadataHeaders(headers...).
+			Do()
 		return err
-	}, 3, time.Second)
+	})
 
 	if err != nil {
-		return nil, fmt.Errorf("unable to get message: %w", err)
+		return nil, apierr.Wrap(err, "unable to get message metadata", "message", messageID)
 	}
 	return msg, nil
 }
 
+// GetMessagesMetadata fetches metadata for several messages concurrently.
+// Results and errors are index-aligned with messageIDs; a failed fetch leaves
+// a nil message and sets the corresponding error without affecting the others.
+func (s *Service) GetMessagesMetadata(ctx context.Context, messageIDs []string, headers ...string) ([]*gmail.Message, []error) {
+	messages := make([]*gmail.Message, len(messageIDs))
+	errs := make([]error, len(messageIDs))
+
+	sem := make(chan struct{}, hydrationConcurrency)
+	var wg sync.WaitGroup
+	for i, id := range messageIDs {
+		wg.Add(1)
+		go func(i int, id string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			messages[i], errs[i] = s.GetMessageMetadata(ctx, id, headers...)
+		}(i, id)
+	}
+	wg.Wait()
+
+	return messages, errs
+}
+
 // ThreadingHeaders contains headers needed for proper email threading
 type ThreadingHeaders struct {
-	ThreadId   string // Original message's thread ID (required for Gmail API)
-	MessageID  string // Original message's Message-ID header
-	References string // References header (chain of message IDs)
-	Subject    string // Original subject
-	From       string // Original sender (for reply-to)
+	ThreadId   string          // Original message's thread ID (required for Gmail API)
+	MessageID  string          // Original message's Message-ID header
+	References string          // References header (chain of message IDs)
+	Subject    string          // Original subject
+	From       string          // Original sender (for reply-to)
+	ReplyTo    string          // Reply-To header, which takes precedence over From when replying
+	To         []*mail.Address // Original To recipients, de-duplicated
+	Cc         []*mail.Address // Original Cc recipients, de-duplicated
 }
 
 // GetMessageHeaders fetches a message and extracts threading headers
 func (s *Service) GetMessageHeaders(ctx context.Context, messageID string) (*ThreadingHeaders, error) {
 	var msg *gmail.Message
 
-	err := retry.WithRetry(func() error {
+	err := s.breaker.Do(func() error {
 		var err error
 		// Fetch with metadata format to get headers efficiently
-		msg, err = s.svc.Users.Messages.Get("me", messageID).
+		msg, err = s.svc.Users.Messages.Get(s.userID, messageID).
 			Context(ctx).
 			Format("metadata").
-			MetadataHeaders("Message-ID", "References", "Subject", "From").
+			MetadataHeaders("Message-ID", "References", "Subject", "From", "Reply-To", "To", "Cc").
 			Do()
 		return err
-	}, 3, time.Second)
+	})
+
+	if err != nil {
+		return nil, apierr.Wrap(err, "unable to get message headers", "message", messageID)
+	}
 
+	parsed, err := ParseMessage(msg)
 	if err != nil {
-		return nil, fmt.Errorf("unable to get message headers: %w", err)
-	}
-
-	headers := &ThreadingHeaders{
-		ThreadId: msg.ThreadId, // Capture thread ID from message object
-	}
-	if msg.Payload != nil {
-		for _, h := range msg.Payload.Headers {
-			switch strings.ToLower(h.Name) {
-			case "message-id":
-				headers.MessageID = h.Value
-			case "references":
-				headers.References = h.Value
-			case "subject":
-				headers.Subject = h.Value
-			case "from":
-				headers.From = h.Value
-			}
+		return nil, fmt.Errorf("unable to parse message headers: %w", err)
+	}
+
+	return &ThreadingHeaders{
+		ThreadId:   msg.ThreadId, // Capture thread ID from message object
+		MessageID:  parsed.Header("Message-ID"),
+		References: parsed.Header("References"),
+		Subject:    parsed.Header("Subject"),
+		From:       parsed.Header("From"),
+		ReplyTo:    parsed.Header("Reply-To"),
+		To:         parseAddressList(parsed.Header("To")),
+		Cc:         parseAddressList(parsed.Header("Cc")),
+	}, nil
+}
+
+// InlineImage is an image embedded in an HTML body and referenced as cid:<ContentID>
+type InlineImage struct {
+	ContentID     string `json:"content_id"`
+	ContentBase64 string `json:"content_base64"`
+	MIMEType      string `json:"mime_type"`
+}
+
+// MessageOptions holds optional settings for composing an outgoing message.
+// A nil *MessageOptions is equivalent to the zero value.
+type MessageOptions struct {
+	// InlineImages are attached as multipart/related parts for cid: references in an HTML body
+	InlineImages []InlineImage
+	// ThreadID files the message in an existing thread without replying to a
+	// specific message. Gmail only keeps it threaded if the subject matches.
+	ThreadID string
+	// IncludeQuote appends the message being replied to below the body as a
+	// quote. Only valid for replies.
+	IncludeQuote bool
+	// Priority is PriorityHigh, PriorityLow, or empty/PriorityNormal to omit
+	// the Importance and X-Priority headers
+	Priority string
+	// ReadReceipt asks recipients to confirm they opened the message with a
+	// Disposition-Notification-To header naming the sending address. Mail
+	// clients may ignore it or let the reader decline.
+	ReadReceipt bool
+}
+
+// Values accepted for MessageOptions.Priority
+const (
+	PriorityHigh   = "high"
+	PriorityNormal = "normal"
+	PriorityLow    = "low"
+)
+
+// ValidatePriority checks that value is a message priority, allowing empty for normal
+func ValidatePriority(value string) error {
+	switch value {
+	case "", PriorityHigh, PriorityNormal, PriorityLow:
+		return nil
+	}
+	return fmt.Errorf("priority must be high, normal, or low (got %q)", value)
+}
+
+// priorityHeaders returns the Importance and X-Priority header lines for
+// priority; normal priority has none, since that is what recipients assume
+func priorityHeaders(priority string) string {
+	var importance, xPriority string
+	switch priority {
+	case PriorityHigh:
+		importance, xPriority = "High", "1"
+	case PriorityLow:
+		importance, xPriority = "Low", "5"
+	default:
+		return ""
+	}
+	return fmt.Sprintf("Importance: %s\r\nX-Priority: %s\r\n", sanitizeHeader(importance), sanitizeHeader(xPriority))
+}
+
+// receiptAddress returns the address read receipts go to when opts requests
+// one, or "" when it doesn't
+func (s *Service) receiptAddress(ctx context.Context, opts *MessageOptions) (string, error) {
+	if opts == nil || !opts.ReadReceipt {
+		return "", nil
+	}
+	return s.SendingAddress(ctx)
+}
+
+// composedMessage is an RFC 2822 message ready to hand to the Gmail API
+type composedMessage struct {
+	raw        string
+	threadId   string
+	message    string // Unencoded MIME
+	subject    string // Final subject, including any "Re: " prefix
+	body       string // Final body, including any quote
+	inReplyTo  string // In-Reply-To header value
+	references string // References header value
+}
+
+// composeMessage validates inputs, resolves threading for replies, and builds the raw message.
+// action names the calling operation for error messages (e.g. "send", "draft").
+func (s *Service) composeMessage(ctx context.Context, action, to, subject, body, inReplyTo string, opts *MessageOptions) (*composedMessage, error) {
+	var original *ThreadingHeaders
+
+	// If replying, fetch original message headers for threading
+	if inReplyTo != "" {
+		var err error
+		original, err = s.GetMessageHeaders(ctx, inReplyTo)
+		if err != nil {
+			return nil, fmt.Errorf("unable to fetch original message for %s reply: %w", action, err)
+		}
+	}
+
+	if opts != nil && opts.IncludeQuote {
+		if inReplyTo == "" {
+			return nil, fmt.Errorf("include_quote requires in_reply_to")
+		}
+		var err error
+		body, err = s.quoteReply(ctx, body, inReplyTo)
+		if err != nil {
+			return nil, fmt.Errorf("unable to quote original message for %s reply: %w", action, err)
 		}
 	}
 
-	return headers, nil
+	from, err := s.fromHeader(ctx)
+	if err != nil {
+		return nil, err
+	}
+	receiptTo, err := s.receiptAddress(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	composed, err := buildComposedMessage(from, receiptTo, to, subject, body, original, opts)
+	if err != nil || opts == nil || opts.ThreadID == "" {
+		return composed, err
+	}
+
+	if original != nil {
+		if original.ThreadId != opts.ThreadID {
+			return nil, fmt.Errorf("in_reply_to message %s is in thread %s, not %s", inReplyTo, original.ThreadId, opts.ThreadID)
+		}
+		return composed, nil
+	}
+	if err := s.checkThread(ctx, opts.ThreadID); err != nil {
+		return nil, fmt.Errorf("unable to use thread for %s: %w", action, err)
+	}
+	composed.threadId = opts.ThreadID
+	return composed, nil
 }
 
-// SendMessage sends an email with automatic HTML detection
-// If inReplyTo is provided (a message ID), threading headers are auto-fetched
-func (s *Service) SendMessage(ctx context.Context, to, subject, body, inReplyTo string) (*gmail.Message, error) {
+// checkThread confirms a thread exists, returning a not-found error if it doesn't
+func (s *Service) checkThread(ctx context.Context, threadID string) error {
+	err := s.breaker.Do(func() error {
+		_, err := s.svc.Users.Threads.Get(s.userID, threadID).Format("minimal").Fields("id").Context(ctx).Do()
+		return err
+	})
+	if err != nil {
+		return apierr.Wrap(err, "unable to fetch thread", "thread", threadID)
+	}
+	return nil
+}
+
+// buildComposedMessage builds a message, threading it under original when non-nil.
+// A non-empty from is added as the From header, and a non-empty receiptTo as
+// the Disposition-Notification-To header.
+func buildComposedMessage(from, receiptTo, to, subject, body string, original *ThreadingHeaders, opts *MessageOptions) (*composedMessage, error) {
 	if to == "" {
 		return nil, fmt.Errorf("recipient address (to) cannot be empty")
 	}
 	if subject == "" {
 		return nil, fmt.Errorf("subject cannot be empty")
 	}
+	if opts == nil {
+		opts = &MessageOptions{}
+	}
+	if err := ValidatePriority(opts.Priority); err != nil {
+		return nil, err
+	}
+	if len(opts.InlineImages) > 0 {
+		if !isHTML(body) {
+			return nil, fmt.Errorf("inline images require an HTML body")
+		}
+		if err := validateInlineImages(opts.InlineImages); err != nil {
+			return nil, err
+		}
+	}
 
 	var inReplyToHeader, referencesHeader, threadId string
 
-	// If replying, fetch original message headers for threading
-	if inReplyTo != "" {
-		headers, err := s.GetMessageHeaders(ctx, inReplyTo)
-		if err != nil {
-			return nil, fmt.Errorf("unable to fetch original message for send reply: %w", err)
-		}
+	if original != nil {
 		// Capture thread ID for Gmail API
-		threadId = headers.ThreadId
+		threadId = original.ThreadId
 		// Only set threading headers if the original message has a Message-ID
-		if headers.MessageID != "" {
-			inReplyToHeader = headers.MessageID
-			referencesHeader = buildReferences(headers.MessageID, headers.References)
+		if original.MessageID != "" {
+			inReplyToHeader = original.MessageID
+			referencesHeader = buildReferences(original.MessageID, original.References)
 		}
 		// Auto-prefix "Re: " if not already present
 		subject = ensureReplySubject(subject)
 	}
 
 	var message string
-	if isHTML(body) {
+	switch {
+	case len(opts.InlineImages) > 0:
+		message = buildRelatedMessage(to, subject, body, inReplyToHeader, referencesHeader, opts.InlineImages)
+	case isHTML(body):
 		message = buildHTMLMessage(to, subject, body, inReplyToHeader, referencesHeader)
-	} else {
+	default:
 		message = buildPlainTextMessage(to, subject, body, inReplyToHeader, referencesHeader)
 	}
+	message = priorityHeaders(opts.Priority) + message
+	if receiptTo != "" {
+		message = "Disposition-Notification-To: " + sanitizeHeader(receiptTo) + "\r\n" + message
+	}
+	if from != "" {
+		message = "From: " + from + "\r\n" + message
+	}
+
+	return &composedMessage{
+		raw:        base64.URLEncoding.EncodeToString([]byte(message)),
+		threadId:   threadId,
+		message:    message,
+		subject:    subject,
+		body:       body,
+		inReplyTo:  inReplyToHeader,
+		references: referencesHeader,
+	}, nil
+}
+
+// ReplyPreview describes the draft CreateDraft would build for a reply, without creating it
+type ReplyPreview struct {
+	To         string   `json:"to"`
+	Cc         []string `json:"cc"` // Always empty: replies address only the reply target
+	Subject    string   `json:"subject"`
+	InReplyTo  string   `json:"in_reply_to,omitempty"`
+	References string   `json:"references,omitempty"`
+	ThreadID   string   `json:"thread_id"`
+	MIME       string   `json:"mime"`
+}
+
+// PreviewReply computes the recipients, threading headers, and MIME of a reply to
+// messageID. Empty to and subject default to the original's Reply-To (or From) and
+// subject, mirroring what an agent would pass to CreateDraft.
+func (s *Service) PreviewReply(ctx context.Context, messageID, to, subject, body string, opts *MessageOptions) (*ReplyPreview, error) {
+	original, err := s.GetMessageHeaders(ctx, messageID)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch original message for reply preview: %w", err)
+	}
+
+	if to == "" {
+		to = original.ReplyTo
+		if to == "" {
+			to = original.From
+		}
+	}
+	if subject == "" {
+		subject = original.Subject
+	}
+
+	from, err := s.fromHeader(ctx)
+	if err != nil {
+		return nil, err
+	}
+	receiptTo, err := s.receiptAddress(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	composed, err := buildComposedMessage(from, receiptTo, to, subject, body, original, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ReplyPreview{
+		To:         to,
+		Cc:         []string{},
+		Subject:    composed.subject,
+		InReplyTo:  composed.inReplyTo,
+		References: composed.references,
+		ThreadID:   composed.threadId,
+		MIME:       composed.message,
+	}, nil
+}
 
-	encoded := base64.URLEncoding.EncodeToString([]byte(message))
+// SendMessage sends an email with automatic HTML detection
+// If inReplyTo is provided (a message ID), threading headers are auto-fetched
+func (s *Service) SendMessage(ctx context.Context, to, subject, body, inReplyTo string, opts *MessageOptions) (*gmail.Message, error) {
+	composed, err := s.composeMessage(ctx, "send", to, subject, body, inReplyTo, opts)
+	if err != nil {
+		return nil, err
+	}
 
 	msg := &gmail.Message{
-		Raw:      encoded,
-		ThreadId: threadId, // Set thread ID for proper threading
+		Raw:      composed.raw,
+		ThreadId: composed.threadId, // Set thread ID for proper threading
 	}
 
 	var sent *gmail.Message
-	err := retry.WithRetry(func() error {
+	err = s.breaker.Do(func() error {
 		var err error
-		sent, err = s.svc.Users.Messages.Send("me", msg).Context(ctx).Do()
+		sent, err = s.svc.Users.Messages.Send(s.userID, msg).Context(ctx).Do()
 		return err
-	}, 3, time.Second)
+	})
 
 	if err != nil {
-		return nil, fmt.Errorf("unable to send message: %w", err)
+		return nil, fmt.Errorf("unable to send message: %w", apierr.ClassifyScopeError(err, gmail.GmailModifyScope))
 	}
 
 	return sent, nil
@@ -214,40 +622,220 @@ func sanitizeHeader(value string) string {
 	return value
 }
 
+// encodeHeaderWord RFC 2047 encodes a header value containing non-ASCII
+// characters as UTF-8 base64 encoded-words; ASCII values pass through unchanged
+func encodeHeaderWord(value string) string {
+	return mime.BEncoding.Encode("UTF-8", value)
+}
+
+// formatAddress renders a mailbox as "Name" <address>, encoding a non-ASCII name
+func formatAddress(name, address string) string {
+	if name != "" && encodeHeaderWord(name) != name {
+		return encodeHeaderWord(name) + " <" + address + ">"
+	}
+	return (&mail.Address{Name: name, Address: address}).String()
+}
+
+// encodeAddressList encodes non-ASCII display names in an address header.
+// Lists without such names, or that don't parse, are returned unchanged.
+func encodeAddressList(value string) string {
+	addrs, err := mail.ParseAddressList(value)
+	if err != nil {
+		return value
+	}
+	changed := false
+	formatted := make([]string, len(addrs))
+	for i, addr := range addrs {
+		if addr.Name != "" && encodeHeaderWord(addr.Name) != addr.Name {
+			changed = true
+		}
+		formatted[i] = formatAddress(addr.Name, addr.Address)
+	}
+	if !changed {
+		return value
+	}
+	return strings.Join(formatted, ", ")
+}
+
+// maxBodyLineLength is RFC 5322's limit on line length, excluding CRLF
+const maxBodyLineLength = 998
+
+// encodeBody picks a Content-Transfer-Encoding for body: 7bit for ASCII with
+// short lines, otherwise quoted-printable. Returns the encoding and encoded body.
+func encodeBody(body string) (string, string) {
+	needsEncoding := false
+	for _, line := range strings.Split(body, "\n") {
+		if len(strings.TrimSuffix(line, "\r")) > maxBodyLineLength {
+			needsEncoding = true
+			break
+		}
+	}
+	for i := 0; i < len(body) && !needsEncoding; i++ {
+		needsEncoding = body[i] >= 0x80
+	}
+	if !needsEncoding {
+		return "7bit", body
+	}
+
+	var encoded strings.Builder
+	w := quotedprintable.NewWriter(&encoded)
+	_, _ = w.Write([]byte(body))
+	_ = w.Close()
+	return "quoted-printable", encoded.String()
+}
+
 func buildPlainTextMessage(to, subject, body, inReplyTo, references string) string {
 	var headers strings.Builder
-	headers.WriteString(fmt.Sprintf("To: %s\r\n", sanitizeHeader(to)))
-	headers.WriteString(fmt.Sprintf("Subject: %s\r\n", sanitizeHeader(subject)))
+	headers.WriteString(fmt.Sprintf("To: %s\r\n", encodeAddressList(sanitizeHeader(to))))
+	headers.WriteString(fmt.Sprintf("Subject: %s\r\n", encodeHeaderWord(sanitizeHeader(subject))))
 	if inReplyTo != "" {
 		headers.WriteString(fmt.Sprintf("In-Reply-To: %s\r\n", sanitizeHeader(inReplyTo)))
 	}
 	if references != "" {
 		headers.WriteString(fmt.Sprintf("References: %s\r\n", sanitizeHeader(references)))
 	}
+	encoding, encoded := encodeBody(body)
 	headers.WriteString("Content-Type: text/plain; charset=\"UTF-8\"\r\n")
+	headers.WriteString(fmt.Sprintf("Content-Transfer-Encoding: %s\r\n", encoding))
 	headers.WriteString("MIME-Version: 1.0\r\n")
 	headers.WriteString("\r\n")
-	headers.WriteString(body)
+	headers.WriteString(encoded)
 	return headers.String()
 }
 
 func buildHTMLMessage(to, subject, body, inReplyTo, references string) string {
 	var headers strings.Builder
-	headers.WriteString(fmt.Sprintf("To: %s\r\n", sanitizeHeader(to)))
-	headers.WriteString(fmt.Sprintf("Subject: %s\r\n", sanitizeHeader(subject)))
+	headers.WriteString(fmt.Sprintf("To: %s\r\n", encodeAddressList(sanitizeHeader(to))))
+	headers.WriteString(fmt.Sprintf("Subject: %s\r\n", encodeHeaderWord(sanitizeHeader(subject))))
 	if inReplyTo != "" {
 		headers.WriteString(fmt.Sprintf("In-Reply-To: %s\r\n", sanitizeHeader(inReplyTo)))
 	}
 	if references != "" {
 		headers.WriteString(fmt.Sprintf("References: %s\r\n", sanitizeHeader(references)))
 	}
+	encoding, encoded := encodeBody(body)
 	headers.WriteString("Content-Type: text/html; charset=\"UTF-8\"\r\n")
+	headers.WriteString(fmt.Sprintf("Content-Transfer-Encoding: %s\r\n", encoding))
 	headers.WriteString("MIME-Version: 1.0\r\n")
 	headers.WriteString("\r\n")
-	headers.WriteString(body)
+	headers.WriteString(encoded)
 	return headers.String()
 }
 
+// buildRelatedMessage builds a multipart/related message with an HTML part
+// followed by inline image parts addressable from the HTML via cid: URLs
+func buildRelatedMessage(to, subject, htmlBody, inReplyTo, references string, images []InlineImage) string {
+	boundary := newBoundary()
+
+	var msg strings.Builder
+	msg.WriteString(fmt.Sprintf("To: %s\r\n", encodeAddressList(sanitizeHeader(to))))
+	msg.WriteString(fmt.Sprintf("Subject: %s\r\n", encodeHeaderWord(sanitizeHeader(subject))))
+	if inReplyTo != "" {
+		msg.WriteString(fmt.Sprintf("In-Reply-To: %s\r\n", sanitizeHeader(inReplyTo)))
+	}
+	if references != "" {
+		msg.WriteString(fmt.Sprintf("References: %s\r\n", sanitizeHeader(references)))
+	}
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString(fmt.Sprintf("Content-Type: multipart/related; boundary=\"%s\"; type=\"text/html\"\r\n", boundary))
+	msg.WriteString("\r\n")
+
+	msg.WriteString(fmt.Sprintf("--%s\r\n", boundary))
+	encoding, encoded := encodeBody(htmlBody)
+	msg.WriteString("Content-Type: text/html; charset=\"UTF-8\"\r\n")
+	msg.WriteString(fmt.Sprintf("Content-Transfer-Encoding: %s\r\n", encoding))
+	msg.WriteString("\r\n")
+	msg.WriteString(encoded)
+	msg.WriteString("\r\n")
+
+	for _, img := range images {
+		msg.WriteString(fmt.Sprintf("--%s\r\n", boundary))
+		msg.WriteString(fmt.Sprintf("Content-Type: %s\r\n", sanitizeHeader(img.MIMEType)))
+		msg.WriteString("Content-Transfer-Encoding: base64\r\n")
+		msg.WriteString(fmt.Sprintf("Content-ID: <%s>\r\n", sanitizeHeader(img.ContentID)))
+		msg.WriteString(fmt.Sprintf("Content-Disposition: inline; filename=\"%s\"\r\n", sanitizeHeader(img.ContentID)))
+		msg.WriteString("\r\n")
+		msg.WriteString(wrapBase64(strings.TrimSpace(img.ContentBase64)))
+	}
+
+	msg.WriteString(fmt.Sprintf("--%s--\r\n", boundary))
+	return msg.String()
+}
+
+// validateInlineImages rejects images that cannot be embedded
+func validateInlineImages(images []InlineImage) error {
+	seen := make(map[string]bool)
+	for i, img := range images {
+		if img.ContentID == "" {
+			return fmt.Errorf("inline image %d: content_id cannot be empty", i)
+		}
+		if seen[img.ContentID] {
+			return fmt.Errorf("inline image %d: duplicate content_id %q", i, img.ContentID)
+		}
+		seen[img.ContentID] = true
+		if !strings.HasPrefix(strings.ToLower(img.MIMEType), "image/") {
+			return fmt.Errorf("inline image %q: mime_type must be an image type, got %q", img.ContentID, img.MIMEType)
+		}
+		if _, err := base64.StdEncoding.DecodeString(strings.TrimSpace(img.ContentBase64)); err != nil {
+			return fmt.Errorf("inline image %q: content_base64 is not valid base64: %w", img.ContentID, err)
+		}
+	}
+	return nil
+}
+
+// cidPattern matches cid: references in HTML attributes, e.g. src="cid:logo"
+var cidPattern = regexp.MustCompile(`(?i)cid:([^"'\s>)]+)`)
+
+// CheckInlineImages compares cid: references in an HTML body against the supplied
+// inline images and returns a warning for each reference or image without a match
+func CheckInlineImages(htmlBody string, images []InlineImage) []string {
+	provided := make(map[string]bool)
+	for _, img := range images {
+		provided[img.ContentID] = true
+	}
+
+	var warnings []string
+	referenced := make(map[string]bool)
+	for _, match := range cidPattern.FindAllStringSubmatch(htmlBody, -1) {
+		cid := match[1]
+		if referenced[cid] {
+			continue
+		}
+		referenced[cid] = true
+		if !provided[cid] {
+			warnings = append(warnings, fmt.Sprintf("HTML references cid:%s but no inline image has that content_id", cid))
+		}
+	}
+
+	for _, img := range images {
+		if !referenced[img.ContentID] {
+			warnings = append(warnings, fmt.Sprintf("inline image %q is not referenced by any cid: in the HTML", img.ContentID))
+		}
+	}
+
+	return warnings
+}
+
+// wrapBase64 splits base64 content into 76-character lines per RFC 2045
+func wrapBase64(data string) string {
+	var b strings.Builder
+	for len(data) > 76 {
+		b.WriteString(data[:76])
+		b.WriteString("\r\n")
+		data = data[76:]
+	}
+	b.WriteString(data)
+	b.WriteString("\r\n")
+	return b.String()
+}
+
+// newBoundary returns a random MIME multipart boundary
+func newBoundary() string {
+	buf := make([]byte, 16)
+	_, _ = rand.Read(buf)
+	return "gsuite-mcp-" + hex.EncodeToString(buf)
+}
+
 // buildReferences constructs the References header for a reply
 func buildReferences(originalMessageID, originalReferences string) string {
 	if originalMessageID == "" {
@@ -269,58 +857,32 @@ func ensureReplySubject(subject string) string {
 
 // CreateDraft creates a new draft email with automatic HTML detection
 // If inReplyTo is provided (a message ID), threading headers are auto-fetched
-func (s *Service) CreateDraft(ctx context.Context, to, subject, body, inReplyTo string) (*gmail.Draft, error) {
-	if to == "" {
-		return nil, fmt.Errorf("recipient address (to) cannot be empty")
-	}
-	if subject == "" {
-		return nil, fmt.Errorf("subject cannot be empty")
-	}
-
-	var inReplyToHeader, referencesHeader, threadId string
-
-	// If replying, fetch original message headers for threading
-	if inReplyTo != "" {
-		headers, err := s.GetMessageHeaders(ctx, inReplyTo)
-		if err != nil {
-			return nil, fmt.Errorf("unable to fetch original message for draft reply: %w", err)
-		}
-		// Capture thread ID for Gmail API
-		threadId = headers.ThreadId
-		// Only set threading headers if the original message has a Message-ID
-		if headers.MessageID != "" {
-			inReplyToHeader = headers.MessageID
-			referencesHeader = buildReferences(headers.MessageID, headers.References)
-		}
-		// Auto-prefix "Re: " if not already present
-		subject = ensureReplySubject(subject)
-	}
-
-	var message string
-	if isHTML(body) {
-		message = buildHTMLMessage(to, subject, body, inReplyToHeader, referencesHeader)
-	} else {
-		message = buildPlainTextMessage(to, subject, body, inReplyToHeader, referencesHeader)
+func (s *Service) CreateDraft(ctx context.Context, to, subject, body, inReplyTo string, opts *MessageOptions) (*gmail.Draft, error) {
+	composed, err := s.composeMessage(ctx, "draft", to, subject, body, inReplyTo, opts)
+	if err != nil {
+		return nil, err
 	}
+	return s.createDraft(ctx, composed)
+}
 
-	encoded := base64.URLEncoding.EncodeToString([]byte(message))
-
+// createDraft saves a composed message as a new draft
+func (s *Service) createDraft(ctx context.Context, composed *composedMessage) (*gmail.Draft, error) {
 	draft := &gmail.Draft{
 		Message: &gmail.Message{
-			Raw:      encoded,
-			ThreadId: threadId, // Set thread ID for proper threading
+			Raw:      composed.raw,
+			ThreadId: composed.threadId, // Set thread ID for proper threading
 		},
 	}
 
 	var created *gmail.Draft
-	err := retry.WithRetry(func() error {
+	err := s.breaker.Do(func() error {
 		var err error
-		created, err = s.svc.Users.Drafts.Create("me", draft).Context(ctx).Do()
+		created, err = s.svc.Users.Drafts.Create(s.userID, draft).Context(ctx).Do()
 		return err
-	}, 3, time.Second)
+	})
 
 	if err != nil {
-		return nil, fmt.Errorf("unable to create draft: %w", err)
+		return nil, fmt.Errorf("unable to create draft: %w", apierr.ClassifyScopeError(err, gmail.GmailModifyScope))
 	}
 
 	return created, nil
@@ -330,13 +892,13 @@ func (s *Service) CreateDraft(ctx context.Context, to, subject, body, inReplyTo
 func (s *Service) ListDrafts(ctx context.Context, maxResults int64) ([]*gmail.Draft, error) {
 	var result *gmail.ListDraftsResponse
 
-	err := retry.WithRetry(func() error {
-		call := s.svc.Users.Drafts.List("me").Context(ctx).MaxResults(maxResults)
+	err := s.breaker.Do(func() error {
+		call := s.svc.Users.Drafts.List(s.userID).Context(ctx).MaxResults(maxResults)
 
 		var err error
 		result, err = call.Do()
 		return err
-	}, 3, time.Second)
+	})
 
 	if err != nil {
 		return nil, fmt.Errorf("unable to list drafts: %w", err)
@@ -352,14 +914,14 @@ func (s *Service) SendDraft(ctx context.Context, draftID string) (*gmail.Message
 	}
 
 	var sent *gmail.Message
-	err := retry.WithRetry(func() error {
+	err := s.breaker.Do(func() error {
 		var err error
-		sent, err = s.svc.Users.Drafts.Send("me", draft).Context(ctx).Do()
+		sent, err = s.svc.Users.Drafts.Send(s.userID, draft).Context(ctx).Do()
 		return err
-	}, 3, time.Second)
+	})
 
 	if err != nil {
-		return nil, fmt.Errorf("unable to send draft: %w", err)
+		return nil, apierr.Wrap(apierr.ClassifyScopeError(err, gmail.GmailModifyScope), "unable to send draft", "draft", draftID)
 	}
 
 	return sent, nil
@@ -373,27 +935,49 @@ func (s *Service) ModifyLabels(ctx context.Context, messageID string, addLabels,
 	}
 
 	var modified *gmail.Message
-	err := retry.WithRetry(func() error {
+	err := s.breaker.Do(func() error {
 		var err error
-		modified, err = s.svc.Users.Messages.Modify("me", messageID, req).Context(ctx).Do()
+		modified, err = s.svc.Users.Messages.Modify(s.userID, messageID, req).Context(ctx).Do()
 		return err
-	}, 3, time.Second)
+	})
 
 	if err != nil {
-		return nil, fmt.Errorf("unable to modify labels: %w", err)
+		return nil, apierr.Wrap(apierr.ClassifyScopeError(err, gmail.GmailModifyScope), "unable to modify labels", "message", messageID)
 	}
 
 	return modified, nil
 }
 
+// batchModifyLimit is the most message IDs Gmail accepts in one batchModify request
+const batchModifyLimit = 1000
+
+// BatchModifyLabels adds or removes labels on many messages, batchModifyLimit IDs per request
+func (s *Service) BatchModifyLabels(ctx context.Context, messageIDs []string, addLabels, removeLabels []string) error {
+	for start := 0; start < len(messageIDs); start += batchModifyLimit {
+		req := &gmail.BatchModifyMessagesRequest{
+			Ids:            messageIDs[start:min(start+batchModifyLimit, len(messageIDs))],
+			AddLabelIds:    addLabels,
+			RemoveLabelIds: removeLabels,
+		}
+
+		err := s.breaker.Do(func() error {
+			return s.svc.Users.Messages.BatchModify(s.userID, req).Context(ctx).Do()
+		})
+		if err != nil {
+			return fmt.Errorf("unable to batch modify labels: %w", apierr.ClassifyScopeError(err, gmail.GmailModifyScope))
+		}
+	}
+	return nil
+}
+
 // DeleteMessage permanently deletes a message
 func (s *Service) DeleteMessage(ctx context.Context, messageID string) error {
-	err := retry.WithRetry(func() error {
-		return s.svc.Users.Messages.Delete("me", messageID).Context(ctx).Do()
-	}, 3, time.Second)
+	err := s.breaker.Do(func() error {
+		return s.svc.Users.Messages.Delete(s.userID, messageID).Context(ctx).Do()
+	})
 
 	if err != nil {
-		return fmt.Errorf("unable to delete message: %w", err)
+		return apierr.Wrap(apierr.ClassifyScopeError(err, gmail.MailGoogleComScope), "unable to delete message", "message", messageID)
 	}
 
 	return nil
@@ -402,14 +986,14 @@ func (s *Service) DeleteMessage(ctx context.Context, messageID string) error {
 // TrashMessage moves a message to trash
 func (s *Service) TrashMessage(ctx context.Context, messageID string) (*gmail.Message, error) {
 	var trashed *gmail.Message
-	err := retry.WithRetry(func() error {
+	err := s.breaker.Do(func() error {
 		var err error
-		trashed, err = s.svc.Users.Messages.Trash("me", messageID).Context(ctx).Do()
+		trashed, err = s.svc.Users.Messages.Trash(s.userID, messageID).Context(ctx).Do()
 		return err
-	}, 3, time.Second)
+	})
 
 	if err != nil {
-		return nil, fmt.Errorf("unable to trash message: %w", err)
+		return nil, apierr.Wrap(apierr.ClassifyScopeError(err, gmail.GmailModifyScope), "unable to trash message", "message", messageID)
 	}
 
 	return trashed, nil
@@ -418,11 +1002,11 @@ func (s *Service) TrashMessage(ctx context.Context, messageID string) (*gmail.Me
 // GetProfile returns the authenticated user's email profile
 func (s *Service) GetProfile(ctx context.Context) (*gmail.Profile, error) {
 	var profile *gmail.Profile
-	err := retry.WithRetry(func() error {
+	err := s.breaker.Do(func() error {
 		var err error
-		profile, err = s.svc.Users.GetProfile("me").Context(ctx).Do()
+		profile, err = s.svc.Users.GetProfile(s.userID).Context(ctx).Do()
 		return err
-	}, 3, time.Second)
+	})
 
 	if err != nil {
 		return nil, fmt.Errorf("unable to get profile: %w", err)
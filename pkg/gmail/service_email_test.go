@@ -1,13 +1,24 @@
 // ABOUTME: Edge case tests for email HTML/MIME detection and composition
-// ABOUTME: Tests encoding, header injection, malformed HTML, and special characters
+// ABOUTME: Tests encoding, header injection, malformed HTML, special characters, and inline images
 
 package gmail
 
 import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/http"
+	"net/mail"
 	"strings"
 	"testing"
 
+	"github.com/harper/gsuite-mcp/pkg/apierr"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestIsHTML_EdgeCases_HTMLComments(t *testing.T) {
@@ -279,12 +290,12 @@ func TestBuildPlainTextMessage_EdgeCases_SpecialCharacters(t *testing.T) {
 
 			assert.Contains(t, result, "Content-Type: text/plain; charset=\"UTF-8\"")
 			assert.Contains(t, result, "MIME-Version: 1.0")
-			assert.Contains(t, result, tt.body)
+			assert.Equal(t, tt.body, decodedBody(t, result))
 
 			sanitizedTo := sanitizeHeader(tt.to)
 			sanitizedSubject := sanitizeHeader(tt.subject)
 			assert.Contains(t, result, sanitizedTo)
-			assert.Contains(t, result, sanitizedSubject)
+			assert.Equal(t, sanitizedSubject, decodedSubject(t, result))
 
 			lines := strings.Split(result, "\r\n")
 			headerEnded := false
@@ -301,6 +312,28 @@ func TestBuildPlainTextMessage_EdgeCases_SpecialCharacters(t *testing.T) {
 	}
 }
 
+// decodedSubject returns a built message's Subject with encoded-words decoded
+func decodedSubject(t *testing.T, message string) string {
+	msg, err := mail.ReadMessage(strings.NewReader(message))
+	require.NoError(t, err)
+	subject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	require.NoError(t, err)
+	return subject
+}
+
+// decodedBody returns a built single-part message's body with its transfer encoding undone
+func decodedBody(t *testing.T, message string) string {
+	msg, err := mail.ReadMessage(strings.NewReader(message))
+	require.NoError(t, err)
+	var body io.Reader = msg.Body
+	if msg.Header.Get("Content-Transfer-Encoding") == "quoted-printable" {
+		body = quotedprintable.NewReader(body)
+	}
+	data, err := io.ReadAll(body)
+	require.NoError(t, err)
+	return string(data)
+}
+
 func TestBuildHTMLMessage_EdgeCases_SpecialCharacters(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -340,12 +373,12 @@ func TestBuildHTMLMessage_EdgeCases_SpecialCharacters(t *testing.T) {
 
 			assert.Contains(t, result, "Content-Type: text/html; charset=\"UTF-8\"")
 			assert.Contains(t, result, "MIME-Version: 1.0")
-			assert.Contains(t, result, tt.body)
+			assert.Equal(t, tt.body, decodedBody(t, result))
 
 			sanitizedTo := sanitizeHeader(tt.to)
 			sanitizedSubject := sanitizeHeader(tt.subject)
 			assert.Contains(t, result, sanitizedTo)
-			assert.Contains(t, result, sanitizedSubject)
+			assert.Equal(t, sanitizedSubject, decodedSubject(t, result))
 		})
 	}
 }
@@ -382,7 +415,8 @@ func TestBuildPlainTextMessage_EdgeCases_LargeBody(t *testing.T) {
 			result := buildPlainTextMessage(to, subject, body, "", "")
 
 			assert.Contains(t, result, "Content-Type: text/plain; charset=\"UTF-8\"")
-			assert.Contains(t, result, body)
+			assert.Contains(t, result, "Content-Transfer-Encoding: quoted-printable", "lines over 998 characters are wrapped")
+			assert.Equal(t, body, decodedBody(t, result))
 			assert.Greater(t, len(result), tt.bodySize)
 		})
 	}
@@ -452,7 +486,7 @@ func TestBuildHTMLMessage_EdgeCases_MalformedHTML(t *testing.T) {
 
 			assert.Contains(t, result, "Content-Type: text/html; charset=\"UTF-8\"")
 			assert.Contains(t, result, "MIME-Version: 1.0")
-			assert.Contains(t, result, tt.body)
+			assert.Equal(t, tt.body, decodedBody(t, result))
 		})
 	}
 }
@@ -624,3 +658,415 @@ func TestBuildMessages_EdgeCases_EmptyFields(t *testing.T) {
 		})
 	}
 }
+
+func TestBuildRelatedMessage_Structure(t *testing.T) {
+	logo := base64.StdEncoding.EncodeToString([]byte("fake-png-bytes"))
+	chart := base64.StdEncoding.EncodeToString([]byte(strings.Repeat("x", 200)))
+	images := []InlineImage{
+		{ContentID: "logo", ContentBase64: logo, MIMEType: "image/png"},
+		{ContentID: "chart", ContentBase64: chart, MIMEType: "image/jpeg"},
+	}
+	html := `<html><body><img src="cid:logo"><img src="cid:chart"></body></html>`
+
+	raw := buildRelatedMessage("test@example.com", "Report", html, "", "", images)
+
+	msg, err := mail.ReadMessage(strings.NewReader(raw))
+	require.NoError(t, err)
+
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	require.NoError(t, err)
+	assert.Equal(t, "multipart/related", mediaType)
+
+	reader := multipart.NewReader(msg.Body, params["boundary"])
+
+	htmlPart, err := reader.NextPart()
+	require.NoError(t, err)
+	assert.Contains(t, htmlPart.Header.Get("Content-Type"), "text/html")
+	htmlBody, err := io.ReadAll(htmlPart)
+	require.NoError(t, err)
+	assert.Contains(t, string(htmlBody), `src="cid:logo"`)
+
+	for _, want := range images {
+		part, err := reader.NextPart()
+		require.NoError(t, err)
+		assert.Equal(t, "<"+want.ContentID+">", part.Header.Get("Content-ID"))
+		assert.Equal(t, want.MIMEType, part.Header.Get("Content-Type"))
+		assert.Equal(t, "base64", part.Header.Get("Content-Transfer-Encoding"))
+
+		encoded, err := io.ReadAll(part)
+		require.NoError(t, err)
+		decoded, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(string(encoded), "\r\n", ""))
+		require.NoError(t, err)
+		expected, _ := base64.StdEncoding.DecodeString(want.ContentBase64)
+		assert.Equal(t, expected, decoded)
+	}
+
+	_, err = reader.NextPart()
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestCheckInlineImages(t *testing.T) {
+	images := []InlineImage{
+		{ContentID: "logo", MIMEType: "image/png"},
+		{ContentID: "unused", MIMEType: "image/png"},
+	}
+	html := `<img src="cid:logo"><img src='cid:missing'><img src="cid:logo">`
+
+	warnings := CheckInlineImages(html, images)
+
+	require.Len(t, warnings, 2)
+	assert.Contains(t, warnings[0], "cid:missing")
+	assert.Contains(t, warnings[1], `"unused"`)
+
+	assert.Empty(t, CheckInlineImages(`<img src="cid:logo">`, images[:1]))
+}
+
+func TestValidateInlineImages(t *testing.T) {
+	valid := base64.StdEncoding.EncodeToString([]byte("png"))
+
+	tests := []struct {
+		name    string
+		images  []InlineImage
+		wantErr string
+	}{
+		{"valid", []InlineImage{{ContentID: "a", ContentBase64: valid, MIMEType: "image/png"}}, ""},
+		{"empty content id", []InlineImage{{ContentBase64: valid, MIMEType: "image/png"}}, "content_id cannot be empty"},
+		{"duplicate content id", []InlineImage{
+			{ContentID: "a", ContentBase64: valid, MIMEType: "image/png"},
+			{ContentID: "a", ContentBase64: valid, MIMEType: "image/png"},
+		}, "duplicate content_id"},
+		{"non-image type", []InlineImage{{ContentID: "a", ContentBase64: valid, MIMEType: "text/plain"}}, "must be an image type"},
+		{"bad base64", []InlineImage{{ContentID: "a", ContentBase64: "not base64!", MIMEType: "image/png"}}, "not valid base64"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateInlineImages(tt.images)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSendMessage_InlineImagesRequireHTML(t *testing.T) {
+	t.Setenv("ISH_MODE", "true")
+	t.Setenv("ISH_BASE_URL", "http://localhost:9000")
+
+	svc, err := NewService(context.Background(), nil)
+	require.NoError(t, err)
+
+	_, err = svc.SendMessage(context.Background(), "test@example.com", "Subject", "plain body", "", &MessageOptions{
+		InlineImages: []InlineImage{{ContentID: "a", ContentBase64: "cG5n", MIMEType: "image/png"}},
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "inline images require an HTML body")
+}
+
+// draftRequest is the body of a drafts.create call
+type draftRequest struct {
+	Message struct {
+		Raw      string `json:"raw"`
+		ThreadId string `json:"threadId"`
+	} `json:"message"`
+}
+
+// replyTestHandler serves an original message's metadata and records drafts created
+func replyTestHandler(t *testing.T, draft *draftRequest) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			require.NoError(t, json.NewDecoder(r.Body).Decode(draft))
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"id": "draft-1"})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"id":       "orig-1",
+			"threadId": "thread-9",
+			"payload": map[string]interface{}{
+				"headers": []map[string]string{
+					{"name": "Message-ID", "value": "<orig@mail.example.com>"},
+					{"name": "References", "value": "<root@mail.example.com>"},
+					{"name": "Subject", "value": "Budget review"},
+					{"name": "From", "value": "Alice <alice@example.com>"},
+					{"name": "Reply-To", "value": "finance@example.com"},
+				},
+			},
+		})
+	}
+}
+
+func TestPreviewReply_MatchesCreateDraft(t *testing.T) {
+	var draft draftRequest
+	svc := newTestService(t, replyTestHandler(t, &draft))
+	ctx := context.Background()
+
+	preview, err := svc.PreviewReply(ctx, "orig-1", "", "", "Looks good to me.", nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, "finance@example.com", preview.To, "Reply-To wins over From")
+	assert.Empty(t, preview.Cc)
+	assert.Equal(t, "Re: Budget review", preview.Subject)
+	assert.Equal(t, "<orig@mail.example.com>", preview.InReplyTo)
+	assert.Equal(t, "<root@mail.example.com> <orig@mail.example.com>", preview.References)
+	assert.Equal(t, "thread-9", preview.ThreadID)
+
+	// Drafting with the previewed recipient and subject produces the same message
+	_, err = svc.CreateDraft(ctx, preview.To, "Budget review", "Looks good to me.", "orig-1", nil)
+	require.NoError(t, err)
+
+	raw, err := base64.URLEncoding.DecodeString(draft.Message.Raw)
+	require.NoError(t, err)
+	assert.Equal(t, preview.MIME, string(raw))
+	assert.Equal(t, preview.ThreadID, draft.Message.ThreadId)
+
+	msg, err := mail.ReadMessage(strings.NewReader(preview.MIME))
+	require.NoError(t, err)
+	assert.Equal(t, preview.InReplyTo, msg.Header.Get("In-Reply-To"))
+	assert.Equal(t, preview.References, msg.Header.Get("References"))
+	assert.Equal(t, preview.Subject, msg.Header.Get("Subject"))
+}
+
+func TestPreviewReply_Overrides(t *testing.T) {
+	var draft draftRequest
+	svc := newTestService(t, replyTestHandler(t, &draft))
+
+	preview, err := svc.PreviewReply(context.Background(), "orig-1", "bob@example.com", "Re: Budget (updated)", "Thanks", nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, "bob@example.com", preview.To)
+	assert.Equal(t, "Re: Budget (updated)", preview.Subject)
+	assert.Empty(t, draft.Message.Raw, "preview must not create a draft")
+}
+
+func TestCreateDraft_ThreadID(t *testing.T) {
+	var draft draftRequest
+	var threadFormat string
+	svc := newTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost:
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&draft))
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"id": "draft-1"})
+		case strings.HasSuffix(r.URL.Path, "/threads/thread-9"):
+			threadFormat = r.URL.Query().Get("format")
+			_ = json.NewEncoder(w).Encode(map[string]string{"id": "thread-9"})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error": {"code": 404, "message": "Requested entity was not found."}}`))
+		}
+	})
+	ctx := context.Background()
+
+	_, err := svc.CreateDraft(ctx, "bob@example.com", "Budget review", "One more thing", "", &MessageOptions{ThreadID: "thread-9"})
+	require.NoError(t, err)
+	assert.Equal(t, "thread-9", draft.Message.ThreadId)
+	assert.Equal(t, "minimal", threadFormat)
+
+	raw, err := base64.URLEncoding.DecodeString(draft.Message.Raw)
+	require.NoError(t, err)
+	msg, err := mail.ReadMessage(strings.NewReader(string(raw)))
+	require.NoError(t, err)
+	assert.Equal(t, "Budget review", msg.Header.Get("Subject"), "a standalone draft isn't turned into a reply")
+	assert.Empty(t, msg.Header.Get("In-Reply-To"))
+
+	_, err = svc.CreateDraft(ctx, "bob@example.com", "Budget review", "Hi", "", &MessageOptions{ThreadID: "gone"})
+	assert.ErrorIs(t, err, apierr.ErrNotFound)
+	assert.ErrorContains(t, err, "thread gone not found")
+}
+
+func TestCreateDraft_ThreadIDMustMatchReply(t *testing.T) {
+	var draft draftRequest
+	svc := newTestService(t, replyTestHandler(t, &draft))
+
+	_, err := svc.CreateDraft(context.Background(), "alice@example.com", "Budget review", "Hi", "orig-1", &MessageOptions{ThreadID: "thread-9"})
+	require.NoError(t, err)
+	assert.Equal(t, "thread-9", draft.Message.ThreadId)
+
+	_, err = svc.CreateDraft(context.Background(), "alice@example.com", "Budget review", "Hi", "orig-1", &MessageOptions{ThreadID: "thread-1"})
+	assert.ErrorContains(t, err, "is in thread thread-9, not thread-1")
+}
+
+// profileDraftHandler serves the mailbox profile and captures created drafts
+func profileDraftHandler(t *testing.T, draft *draftRequest, profileCalls *int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost:
+			require.NoError(t, json.NewDecoder(r.Body).Decode(draft))
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"id": "draft-1"})
+		case strings.HasSuffix(r.URL.Path, "/profile"):
+			*profileCalls++
+			_ = json.NewEncoder(w).Encode(map[string]string{"emailAddress": "me@example.com"})
+		default:
+			http.NotFound(w, r)
+		}
+	}
+}
+
+// draftHeader decodes the captured draft and returns one of its headers
+func draftHeader(t *testing.T, draft draftRequest, name string) string {
+	raw, err := base64.URLEncoding.DecodeString(draft.Message.Raw)
+	require.NoError(t, err)
+	msg, err := mail.ReadMessage(strings.NewReader(string(raw)))
+	require.NoError(t, err)
+	return msg.Header.Get(name)
+}
+
+func TestCreateDraft_FromName(t *testing.T) {
+	var draft draftRequest
+	var profileCalls int
+	svc := newTestService(t, profileDraftHandler(t, &draft, &profileCalls))
+	ctx := context.Background()
+
+	// Unset: Gmail fills in From from the account default
+	_, err := svc.CreateDraft(ctx, "bob@example.com", "Hi", "Hello", "", nil)
+	require.NoError(t, err)
+	assert.Empty(t, draftHeader(t, draft, "From"))
+	assert.Zero(t, profileCalls)
+
+	require.NoError(t, svc.SetFromName("José Müller"))
+	_, err = svc.CreateDraft(ctx, "bob@example.com", "Hi", "Hello", "", nil)
+	require.NoError(t, err)
+
+	from := draftHeader(t, draft, "From")
+	assert.Equal(t, "=?UTF-8?b?Sm9zw6kgTcO8bGxlcg==?= <me@example.com>", from)
+	addr, err := mail.ParseAddress(from)
+	require.NoError(t, err)
+	assert.Equal(t, "José Müller", addr.Name)
+	assert.Equal(t, "me@example.com", addr.Address)
+
+	// ASCII names with specials are quoted; the address is looked up once
+	require.NoError(t, svc.SetFromName("Smith, Jane"))
+	_, err = svc.CreateDraft(ctx, "bob@example.com", "Hi", "Hello", "", nil)
+	require.NoError(t, err)
+	assert.Equal(t, `"Smith, Jane" <me@example.com>`, draftHeader(t, draft, "From"))
+	assert.Equal(t, 1, profileCalls)
+
+	// A delegated mailbox sends as the delegated address
+	require.NoError(t, svc.SetDelegate("team@example.com"))
+	_, err = svc.CreateDraft(ctx, "bob@example.com", "Hi", "Hello", "", nil)
+	require.NoError(t, err)
+	assert.Equal(t, `"Smith, Jane" <team@example.com>`, draftHeader(t, draft, "From"))
+	assert.Equal(t, 1, profileCalls)
+
+	assert.Error(t, svc.SetFromName("Jane\r\nBcc: eve@example.com"))
+}
+
+func TestCreateDraft_Priority(t *testing.T) {
+	var draft draftRequest
+	var profileCalls int
+	svc := newTestService(t, profileDraftHandler(t, &draft, &profileCalls))
+	ctx := context.Background()
+
+	_, err := svc.CreateDraft(ctx, "oncall@example.com", "Database down", "Paging you.", "", &MessageOptions{Priority: PriorityHigh})
+	require.NoError(t, err)
+	assert.Equal(t, "High", draftHeader(t, draft, "Importance"))
+	assert.Equal(t, "1", draftHeader(t, draft, "X-Priority"))
+	assert.Equal(t, "Database down", draftHeader(t, draft, "Subject"), "the headers above don't disturb the rest")
+
+	_, err = svc.CreateDraft(ctx, "team@example.com", "FYI", "No rush.", "", &MessageOptions{Priority: PriorityLow})
+	require.NoError(t, err)
+	assert.Equal(t, "Low", draftHeader(t, draft, "Importance"))
+	assert.Equal(t, "5", draftHeader(t, draft, "X-Priority"))
+
+	for _, priority := range []string{PriorityNormal, ""} {
+		_, err = svc.CreateDraft(ctx, "team@example.com", "FYI", "No rush.", "", &MessageOptions{Priority: priority})
+		require.NoError(t, err)
+		raw, err := base64.URLEncoding.DecodeString(draft.Message.Raw)
+		require.NoError(t, err)
+		assert.NotContains(t, string(raw), "Importance:")
+		assert.NotContains(t, string(raw), "X-Priority:")
+	}
+
+	_, err = svc.CreateDraft(ctx, "team@example.com", "FYI", "No rush.", "", &MessageOptions{Priority: "urgent"})
+	assert.ErrorContains(t, err, "priority must be high, normal, or low")
+}
+
+func TestSendMessage_ReadReceipt(t *testing.T) {
+	var sent struct {
+		Raw string `json:"raw"`
+	}
+	svc := newTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/messages/send"):
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&sent))
+			_ = json.NewEncoder(w).Encode(map[string]string{"id": "sent-1"})
+		case strings.HasSuffix(r.URL.Path, "/profile"):
+			_ = json.NewEncoder(w).Encode(map[string]string{"emailAddress": "me@example.com"})
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	ctx := context.Background()
+	header := func() mail.Header {
+		raw, err := base64.URLEncoding.DecodeString(sent.Raw)
+		require.NoError(t, err)
+		msg, err := mail.ReadMessage(strings.NewReader(string(raw)))
+		require.NoError(t, err)
+		return msg.Header
+	}
+
+	_, err := svc.SendMessage(ctx, "bob@example.com", "Contract", "Please sign.", "", &MessageOptions{ReadReceipt: true})
+	require.NoError(t, err)
+	assert.Equal(t, "me@example.com", header().Get("Disposition-Notification-To"))
+	assert.Equal(t, "Contract", header().Get("Subject"))
+
+	for _, opts := range []*MessageOptions{nil, {}} {
+		_, err = svc.SendMessage(ctx, "bob@example.com", "Contract", "Please sign.", "", opts)
+		require.NoError(t, err)
+		_, present := header()["Disposition-Notification-To"]
+		assert.False(t, present, "read receipts are off by default")
+	}
+}
+
+func TestEncodeHeaderWord(t *testing.T) {
+	assert.Equal(t, "Weekly sync (rescheduled)", encodeHeaderWord("Weekly sync (rescheduled)"), "ASCII passes through")
+	assert.Equal(t, "=?UTF-8?b?5Lya6K2w?=", encodeHeaderWord("会議"))
+
+	msg := buildPlainTextMessage("test@example.com", "会議の議題", "Body", "", "")
+	assert.Contains(t, msg, "Subject: =?UTF-8?b?5Lya6K2w44Gu6K2w6aGM?=\r\n")
+	assert.Equal(t, "会議の議題", decodedSubject(t, msg))
+
+	msg = buildHTMLMessage("test@example.com", "Weekly sync", "<p>Body</p>", "", "")
+	assert.Contains(t, msg, "Subject: Weekly sync\r\n")
+}
+
+func TestEncodeAddressList(t *testing.T) {
+	assert.Equal(t, "Bob <bob@example.com>, carol@example.com", encodeAddressList("Bob <bob@example.com>, carol@example.com"), "ASCII names are untouched")
+	assert.Equal(t, "not an address", encodeAddressList("not an address"))
+
+	encoded := encodeAddressList("山田 太郎 <taro@example.jp>, \"Smith, Jane\" <jane@example.com>")
+	assert.Equal(t, `=?UTF-8?b?5bGx55SwIOWkqumDjg==?= <taro@example.jp>, "Smith, Jane" <jane@example.com>`, encoded)
+	addrs, err := mail.ParseAddressList(encoded)
+	require.NoError(t, err)
+	require.Len(t, addrs, 2)
+	assert.Equal(t, "山田 太郎", addrs[0].Name)
+	assert.Equal(t, "Smith, Jane", addrs[1].Name)
+
+	msg := buildPlainTextMessage("José <jose@example.com>", "Hola", "Body", "", "")
+	assert.Contains(t, msg, "To: =?UTF-8?b?Sm9zw6k=?= <jose@example.com>\r\n")
+}
+
+func TestEncodeBody(t *testing.T) {
+	encoding, encoded := encodeBody("Plain ASCII body\nsecond line")
+	assert.Equal(t, "7bit", encoding)
+	assert.Equal(t, "Plain ASCII body\nsecond line", encoded)
+
+	body := "Café au lait ☕ " + strings.Repeat("long line ", 120)
+	msg := buildPlainTextMessage("test@example.com", "Menu", body, "", "")
+	assert.Contains(t, msg, "Content-Transfer-Encoding: quoted-printable\r\n")
+	assert.Contains(t, msg, "\r\n\r\nCaf=C3=A9 au lait =E2=98=95 long line")
+	for _, line := range strings.Split(msg, "\r\n") {
+		assert.LessOrEqual(t, len(line), 76)
+	}
+	assert.Equal(t, body, decodedBody(t, msg))
+
+	msg = buildHTMLMessage("test@example.com", "Menu", "<p>Crème brûlée</p>", "", "")
+	assert.Contains(t, msg, "Content-Transfer-Encoding: quoted-printable\r\n")
+	assert.Equal(t, "<p>Crème brûlée</p>", decodedBody(t, msg))
+
+	msg = buildHTMLMessage("test@example.com", "Menu", "<p>Soup</p>", "", "")
+	assert.Contains(t, msg, "Content-Transfer-Encoding: 7bit\r\n")
+	assert.Contains(t, msg, "\r\n\r\n<p>Soup</p>")
+}
@@ -5,12 +5,32 @@ package gmail
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
+	"github.com/harper/gsuite-mcp/pkg/apierr"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
+// newTestService creates an ish-mode Service backed by handler
+func newTestService(t *testing.T, handler http.HandlerFunc) *Service {
+	t.Helper()
+
+	api := httptest.NewServer(handler)
+	t.Cleanup(api.Close)
+
+	t.Setenv("ISH_MODE", "true")
+	t.Setenv("ISH_BASE_URL", api.URL)
+
+	svc, err := NewService(context.Background(), nil)
+	require.NoError(t, err)
+	return svc
+}
+
 func TestNewService_WithIshMode(t *testing.T) {
 	t.Setenv("ISH_MODE", "true")
 	t.Setenv("ISH_BASE_URL", "http://localhost:9000")
@@ -67,13 +87,13 @@ func TestSendMessage_Validation(t *testing.T) {
 	ctx := context.Background()
 
 	t.Run("Empty recipient fails", func(t *testing.T) {
-		_, err := svc.SendMessage(ctx, "", "Subject", "Body", "")
+		_, err := svc.SendMessage(ctx, "", "Subject", "Body", "", nil)
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "recipient address (to) cannot be empty")
 	})
 
 	t.Run("Empty subject fails", func(t *testing.T) {
-		_, err := svc.SendMessage(ctx, "test@example.com", "", "Body", "")
+		_, err := svc.SendMessage(ctx, "test@example.com", "", "Body", "", nil)
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "subject cannot be empty")
 	})
@@ -90,13 +110,13 @@ func TestCreateDraft_Validation(t *testing.T) {
 	ctx := context.Background()
 
 	t.Run("Empty recipient fails", func(t *testing.T) {
-		_, err := svc.CreateDraft(ctx, "", "Subject", "Body", "")
+		_, err := svc.CreateDraft(ctx, "", "Subject", "Body", "", nil)
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "recipient address (to) cannot be empty")
 	})
 
 	t.Run("Empty subject fails", func(t *testing.T) {
-		_, err := svc.CreateDraft(ctx, "test@example.com", "", "Body", "")
+		_, err := svc.CreateDraft(ctx, "test@example.com", "", "Body", "", nil)
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "subject cannot be empty")
 	})
@@ -406,3 +426,97 @@ func TestBuildPlainTextMessage_EmptyThreadingHeaders(t *testing.T) {
 	// Should NOT contain "References:" when references is empty
 	assert.NotContains(t, result, "References:")
 }
+
+func TestSetDelegate_SubstitutesUserID(t *testing.T) {
+	var paths []string
+	svc := newTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		_, _ = w.Write([]byte(`{"messages": [], "emailAddress": "boss@example.com"}`))
+	})
+
+	require.NoError(t, svc.SetDelegate("boss@example.com"))
+
+	_, err := svc.ListMessages(context.Background(), "", 10)
+	require.NoError(t, err)
+	_, err = svc.GetProfile(context.Background())
+	require.NoError(t, err)
+
+	require.Len(t, paths, 2)
+	for _, path := range paths {
+		assert.Contains(t, path, "/users/boss@example.com/")
+		assert.NotContains(t, path, "/users/me/")
+	}
+
+	// Clearing the delegate returns to the authenticated user's mailbox
+	paths = nil
+	require.NoError(t, svc.SetDelegate(""))
+	_, err = svc.ListMessages(context.Background(), "", 10)
+	require.NoError(t, err)
+	require.Len(t, paths, 1)
+	assert.Contains(t, paths[0], "/users/me/")
+}
+
+func TestValidateDelegate(t *testing.T) {
+	assert.NoError(t, ValidateDelegate("boss@example.com"))
+
+	for _, invalid := range []string{"boss", "Boss <boss@example.com>", "boss@example.com, eve@example.com", " boss@example.com"} {
+		assert.Error(t, ValidateDelegate(invalid), "delegate %q", invalid)
+	}
+}
+
+func TestGetMessage_NotFound(t *testing.T) {
+	svc := newTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"error": {"code": 404, "message": "Requested entity was not found."}}`))
+	})
+
+	_, err := svc.GetMessage(context.Background(), "18c0ffee")
+	require.Error(t, err)
+	assert.ErrorIs(t, err, apierr.ErrNotFound)
+	assert.EqualError(t, err, "message 18c0ffee not found")
+
+	var nf *apierr.NotFoundError
+	require.ErrorAs(t, err, &nf)
+	assert.Equal(t, "18c0ffee", nf.ID)
+
+	_, err = svc.SendDraft(context.Background(), "r-42")
+	assert.EqualError(t, err, "draft r-42 not found")
+}
+
+func TestSendMessage_InsufficientScope(t *testing.T) {
+	svc := newTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"error": {"code": 403, "message": "Request had insufficient authentication scopes.", "errors": [{"reason": "insufficientPermissions", "message": "Insufficient Permission"}]}}`))
+	})
+	ctx := context.Background()
+
+	_, err := svc.SendMessage(ctx, "bob@example.com", "Hi", "Hello", "", nil)
+	assert.ErrorIs(t, err, apierr.ErrInsufficientScope)
+	assert.EqualError(t, err, "unable to send message: this operation requires the https://www.googleapis.com/auth/gmail.modify scope; re-run auth_init with expanded scopes")
+
+	err = svc.DeleteMessage(ctx, "18c0ffee")
+	assert.ErrorContains(t, err, "requires the https://mail.google.com/ scope")
+	assert.False(t, apierr.IsNotFound(err))
+}
+
+func TestBatchModifyLabels_Chunks(t *testing.T) {
+	var batches [][]string
+	svc := newTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			IDs []string `json:"ids"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		batches = append(batches, req.IDs)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	ids := make([]string, batchModifyLimit+5)
+	for i := range ids {
+		ids[i] = fmt.Sprintf("m%d", i)
+	}
+
+	require.NoError(t, svc.BatchModifyLabels(context.Background(), ids, []string{"TRASH"}, []string{"INBOX"}))
+	require.Len(t, batches, 2)
+	assert.Len(t, batches[0], batchModifyLimit)
+	assert.Equal(t, ids[batchModifyLimit:], batches[1])
+}
@@ -0,0 +1,102 @@
+// ABOUTME: Read-only access to Gmail account settings
+// ABOUTME: Wraps forwarding, IMAP, POP, and language settings and maps missing-scope errors
+
+package gmail
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harper/gsuite-mcp/pkg/apierr"
+	"google.golang.org/api/gmail/v1"
+)
+
+// Settings combines the read-only Gmail settings exposed by gmail_get_settings
+type Settings struct {
+	AutoForwarding *gmail.AutoForwarding   `json:"auto_forwarding"`
+	Imap           *gmail.ImapSettings     `json:"imap"`
+	Pop            *gmail.PopSettings      `json:"pop"`
+	Language       *gmail.LanguageSettings `json:"language"`
+}
+
+// GetAutoForwarding returns the account's auto-forwarding setting
+func (s *Service) GetAutoForwarding(ctx context.Context) (*gmail.AutoForwarding, error) {
+	var result *gmail.AutoForwarding
+	err := s.breaker.Do(func() error {
+		var err error
+		result, err = s.svc.Users.Settings.GetAutoForwarding(s.userID).Context(ctx).Do()
+		return err
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to get auto-forwarding settings: %w", apierr.ClassifyScopeError(err, gmail.GmailSettingsBasicScope))
+	}
+	return result, nil
+}
+
+// GetImapSettings returns the account's IMAP settings
+func (s *Service) GetImapSettings(ctx context.Context) (*gmail.ImapSettings, error) {
+	var result *gmail.ImapSettings
+	err := s.breaker.Do(func() error {
+		var err error
+		result, err = s.svc.Users.Settings.GetImap(s.userID).Context(ctx).Do()
+		return err
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to get IMAP settings: %w", apierr.ClassifyScopeError(err, gmail.GmailSettingsBasicScope))
+	}
+	return result, nil
+}
+
+// GetPopSettings returns the account's POP settings
+func (s *Service) GetPopSettings(ctx context.Context) (*gmail.PopSettings, error) {
+	var result *gmail.PopSettings
+	err := s.breaker.Do(func() error {
+		var err error
+		result, err = s.svc.Users.Settings.GetPop(s.userID).Context(ctx).Do()
+		return err
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to get POP settings: %w", apierr.ClassifyScopeError(err, gmail.GmailSettingsBasicScope))
+	}
+	return result, nil
+}
+
+// GetLanguageSettings returns the account's display language
+func (s *Service) GetLanguageSettings(ctx context.Context) (*gmail.LanguageSettings, error) {
+	var result *gmail.LanguageSettings
+	err := s.breaker.Do(func() error {
+		var err error
+		result, err = s.svc.Users.Settings.GetLanguage(s.userID).Context(ctx).Do()
+		return err
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to get language settings: %w", apierr.ClassifyScopeError(err, gmail.GmailSettingsBasicScope))
+	}
+	return result, nil
+}
+
+// GetSettings fetches all read-only settings. A missing scope fails fast with
+// an apierr.ScopeError rather than repeating the same 403 for each setting.
+func (s *Service) GetSettings(ctx context.Context) (*Settings, error) {
+	settings := &Settings{}
+	var err error
+
+	if settings.AutoForwarding, err = s.GetAutoForwarding(ctx); err != nil {
+		return nil, err
+	}
+	if settings.Imap, err = s.GetImapSettings(ctx); err != nil {
+		return nil, err
+	}
+	if settings.Pop, err = s.GetPopSettings(ctx); err != nil {
+		return nil, err
+	}
+	if settings.Language, err = s.GetLanguageSettings(ctx); err != nil {
+		return nil, err
+	}
+
+	return settings, nil
+}
@@ -0,0 +1,100 @@
+// ABOUTME: Tests for read-only Gmail settings
+// ABOUTME: Uses a local HTTP server in place of the Gmail API
+
+package gmail
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/harper/gsuite-mcp/pkg/apierr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/googleapi"
+)
+
+func TestGetSettings_CombinesAllSettings(t *testing.T) {
+	svc := newTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		var body interface{}
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/settings/autoForwarding"):
+			body = map[string]interface{}{"enabled": true, "emailAddress": "archive@example.com", "disposition": "leaveInInbox"}
+		case strings.HasSuffix(r.URL.Path, "/settings/imap"):
+			body = map[string]interface{}{"enabled": true, "autoExpunge": true, "maxFolderSize": 1000}
+		case strings.HasSuffix(r.URL.Path, "/settings/pop"):
+			body = map[string]interface{}{"accessWindow": "disabled"}
+		case strings.HasSuffix(r.URL.Path, "/settings/language"):
+			body = map[string]interface{}{"displayLanguage": "en-GB"}
+		default:
+			http.NotFound(w, r)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(body)
+	})
+
+	settings, err := svc.GetSettings(context.Background())
+	require.NoError(t, err)
+
+	require.NotNil(t, settings.AutoForwarding)
+	assert.True(t, settings.AutoForwarding.Enabled)
+	assert.Equal(t, "archive@example.com", settings.AutoForwarding.EmailAddress)
+	require.NotNil(t, settings.Imap)
+	assert.Equal(t, int64(1000), settings.Imap.MaxFolderSize)
+	require.NotNil(t, settings.Pop)
+	assert.Equal(t, "disabled", settings.Pop.AccessWindow)
+	require.NotNil(t, settings.Language)
+	assert.Equal(t, "en-GB", settings.Language.DisplayLanguage)
+}
+
+func TestGetSettings_MissingScope(t *testing.T) {
+	svc := newTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"error": {"code": 403, "message": "Request had insufficient authentication scopes.",` +
+			`"errors": [{"reason": "insufficientPermissions"}]}}`))
+	})
+
+	_, err := svc.GetSettings(context.Background())
+	require.Error(t, err)
+	assert.ErrorIs(t, err, apierr.ErrInsufficientScope)
+	assert.Contains(t, err.Error(), "gmail.settings.basic")
+
+	var apiErr *googleapi.Error
+	require.ErrorAs(t, err, &apiErr, "the API error is kept as the cause")
+	assert.Equal(t, http.StatusForbidden, apiErr.Code)
+}
+
+func TestGetSettings_OtherForbiddenErrorsPassThrough(t *testing.T) {
+	for _, body := range []string{
+		`{"error": {"code": 403, "message": "User Rate Limit Exceeded", "errors": [{"reason": "userRateLimitExceeded"}]}}`,
+		`{"error": {"code": 403, "message": "Rate Limit Exceeded", "errors": [{"reason": "rateLimitExceeded"}]}}`,
+		`{"error": {"code": 403, "message": "Access restricted by domain policy", "errors": [{"reason": "domainPolicy"}]}}`,
+	} {
+		svc := newTestService(t, func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(body))
+		})
+
+		_, err := svc.GetSettings(context.Background())
+		require.Error(t, err)
+		assert.NotErrorIs(t, err, apierr.ErrInsufficientScope, body)
+		assert.NotContains(t, err.Error(), "re-run auth_init", body)
+
+		var apiErr *googleapi.Error
+		assert.ErrorAs(t, err, &apiErr, body)
+	}
+}
+
+func TestGetSettings_OtherErrorsPassThrough(t *testing.T) {
+	svc := newTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		http.NotFound(w, r)
+	})
+
+	_, err := svc.GetSettings(context.Background())
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, apierr.ErrInsufficientScope)
+}
@@ -0,0 +1,81 @@
+// ABOUTME: Gmail signatures per send-as address
+// ABOUTME: Reads and updates the HTML signature on the primary address or an alias
+
+package gmail
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/harper/gsuite-mcp/pkg/apierr"
+	"google.golang.org/api/gmail/v1"
+)
+
+// Signature is the signature configured for one send-as address
+type Signature struct {
+	SendAsEmail string `json:"send_as_email"`
+	IsPrimary   bool   `json:"is_primary"`
+	Signature   string `json:"signature"` // HTML; empty when none is set
+}
+
+// GetSendAsSignature returns the signature for sendAsEmail, or for the
+// primary address when sendAsEmail is empty
+func (s *Service) GetSendAsSignature(ctx context.Context, sendAsEmail string) (*Signature, error) {
+	email, err := s.resolveSendAs(ctx, sendAsEmail)
+	if err != nil {
+		return nil, err
+	}
+
+	var sendAs *gmail.SendAs
+	err = s.breaker.Do(func() error {
+		var err error
+		sendAs, err = s.svc.Users.Settings.SendAs.Get(s.userID, email).Context(ctx).Do()
+		return err
+	})
+
+	if err != nil {
+		return nil, apierr.Wrap(apierr.ClassifyScopeError(err, gmail.GmailSettingsBasicScope), "unable to get signature", "send-as address", email)
+	}
+	return newSignature(sendAs), nil
+}
+
+// UpdateSendAsSignature replaces the signature for sendAsEmail, or for the
+// primary address when sendAsEmail is empty
+func (s *Service) UpdateSendAsSignature(ctx context.Context, sendAsEmail, html string) (*Signature, error) {
+	if strings.TrimSpace(html) == "" {
+		return nil, fmt.Errorf("signature cannot be empty")
+	}
+	email, err := s.resolveSendAs(ctx, sendAsEmail)
+	if err != nil {
+		return nil, err
+	}
+
+	var sendAs *gmail.SendAs
+	err = s.breaker.Do(func() error {
+		var err error
+		sendAs, err = s.svc.Users.Settings.SendAs.Patch(s.userID, email, &gmail.SendAs{Signature: html}).Context(ctx).Do()
+		return err
+	})
+
+	if err != nil {
+		return nil, apierr.Wrap(apierr.ClassifyScopeError(err, gmail.GmailSettingsBasicScope), "unable to update signature", "send-as address", email)
+	}
+	return newSignature(sendAs), nil
+}
+
+// resolveSendAs defaults an empty send-as address to the mailbox's primary address
+func (s *Service) resolveSendAs(ctx context.Context, sendAsEmail string) (string, error) {
+	if email := strings.TrimSpace(sendAsEmail); email != "" {
+		return email, nil
+	}
+	return s.SendingAddress(ctx)
+}
+
+func newSignature(sendAs *gmail.SendAs) *Signature {
+	return &Signature{
+		SendAsEmail: sendAs.SendAsEmail,
+		IsPrimary:   sendAs.IsPrimary,
+		Signature:   sendAs.Signature,
+	}
+}
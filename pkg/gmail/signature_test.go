@@ -0,0 +1,82 @@
+// ABOUTME: Tests for send-as signatures
+// ABOUTME: Verifies the targeted send-as address, primary defaulting, and empty-signature validation
+
+package gmail
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/harper/gsuite-mcp/pkg/apierr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/gmail/v1"
+)
+
+// sendAsHandler serves a primary address and one alias, recording patches by address
+func sendAsHandler(t *testing.T, patched map[string]string) http.HandlerFunc {
+	sendAs := map[string]*gmail.SendAs{
+		"me@example.com":      {SendAsEmail: "me@example.com", IsPrimary: true, Signature: "<b>Me</b>"},
+		"support@example.com": {SendAsEmail: "support@example.com", Signature: "<i>Support team</i>"},
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/profile") {
+			_ = json.NewEncoder(w).Encode(map[string]string{"emailAddress": "me@example.com"})
+			return
+		}
+		entry, ok := sendAs[path.Base(r.URL.Path)]
+		if !strings.Contains(r.URL.Path, "/settings/sendAs/") || !ok {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error": {"code": 404, "message": "Requested entity was not found."}}`))
+			return
+		}
+		if r.Method == http.MethodPatch {
+			var update gmail.SendAs
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&update))
+			patched[entry.SendAsEmail] = update.Signature
+			entry.Signature = update.Signature
+		}
+		_ = json.NewEncoder(w).Encode(entry)
+	}
+}
+
+func TestGetSendAsSignature(t *testing.T) {
+	svc := newTestService(t, sendAsHandler(t, map[string]string{}))
+	ctx := context.Background()
+
+	sig, err := svc.GetSendAsSignature(ctx, "")
+	require.NoError(t, err)
+	assert.Equal(t, &Signature{SendAsEmail: "me@example.com", IsPrimary: true, Signature: "<b>Me</b>"}, sig)
+
+	sig, err = svc.GetSendAsSignature(ctx, "support@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "<i>Support team</i>", sig.Signature)
+	assert.False(t, sig.IsPrimary)
+
+	_, err = svc.GetSendAsSignature(ctx, "nobody@example.com")
+	assert.ErrorIs(t, err, apierr.ErrNotFound)
+	assert.ErrorContains(t, err, "send-as address nobody@example.com not found")
+}
+
+func TestUpdateSendAsSignature(t *testing.T) {
+	patched := map[string]string{}
+	svc := newTestService(t, sendAsHandler(t, patched))
+	ctx := context.Background()
+
+	sig, err := svc.UpdateSendAsSignature(ctx, "support@example.com", "<p>Support | Example Inc.</p>")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"support@example.com": "<p>Support | Example Inc.</p>"}, patched)
+	assert.Equal(t, "<p>Support | Example Inc.</p>", sig.Signature)
+
+	_, err = svc.UpdateSendAsSignature(ctx, "", "<p>Me</p>")
+	require.NoError(t, err)
+	assert.Equal(t, "<p>Me</p>", patched["me@example.com"], "omitted send-as defaults to the primary address")
+
+	_, err = svc.UpdateSendAsSignature(ctx, "support@example.com", "  ")
+	assert.ErrorContains(t, err, "signature cannot be empty")
+}
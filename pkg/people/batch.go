@@ -0,0 +1,77 @@
+// ABOUTME: Bulk contact creation through people.batchCreateContacts
+// ABOUTME: Splits large inputs into API-sized chunks and reports a result per input contact
+
+package people
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harper/gsuite-mcp/pkg/apierr"
+	"google.golang.org/api/people/v1"
+)
+
+// maxBatchCreate is the most contacts batchCreateContacts accepts per request
+const maxBatchCreate = 200
+
+// BatchCreateResult is the outcome for one contact passed to BatchCreateContacts
+type BatchCreateResult struct {
+	Person *people.Person // The created contact; nil on failure
+	Err    error
+}
+
+// BatchCreateContacts creates persons in chunks of up to 200, the API's
+// per-request limit. Results are index-aligned with persons. A chunk that fails
+// as a whole marks each of its contacts failed; other chunks still run.
+func (s *Service) BatchCreateContacts(ctx context.Context, persons []*people.Person) []BatchCreateResult {
+	results := make([]BatchCreateResult, len(persons))
+	for start := 0; start < len(persons); start += maxBatchCreate {
+		end := start + maxBatchCreate
+		if end > len(persons) {
+			end = len(persons)
+		}
+		s.batchCreateChunk(ctx, persons[start:end], results[start:end])
+	}
+	return results
+}
+
+// batchCreateChunk creates one request's worth of contacts, filling results
+func (s *Service) batchCreateChunk(ctx context.Context, persons []*people.Person, results []BatchCreateResult) {
+	contacts := make([]*people.ContactToCreate, len(persons))
+	for i, person := range persons {
+		contacts[i] = &people.ContactToCreate{ContactPerson: person}
+	}
+
+	var resp *people.BatchCreateContactsResponse
+	err := s.breaker.Do(func() error {
+		var err error
+		resp, err = s.svc.People.BatchCreateContacts(&people.BatchCreateContactsRequest{
+			Contacts: contacts,
+			ReadMask: "names,emailAddresses",
+		}).Context(ctx).Do()
+		return err
+	})
+
+	if err == nil && len(resp.CreatedPeople) != len(persons) {
+		err = fmt.Errorf("API returned %d results for %d contacts", len(resp.CreatedPeople), len(persons))
+	}
+	if err != nil {
+		err = fmt.Errorf("unable to create contacts: %w", apierr.ClassifyScopeError(err, people.ContactsScope))
+		for i := range results {
+			results[i].Err = err
+		}
+		return
+	}
+
+	// Created people come back in request order
+	for i, created := range resp.CreatedPeople {
+		switch {
+		case created.Status != nil && created.Status.Code != 0:
+			results[i].Err = fmt.Errorf("unable to create contact: %s", created.Status.Message)
+		case created.Person == nil:
+			results[i].Err = fmt.Errorf("unable to create contact: no person returned")
+		default:
+			results[i].Person = created.Person
+		}
+	}
+}
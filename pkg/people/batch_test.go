@@ -0,0 +1,85 @@
+// ABOUTME: Tests for bulk contact creation
+// ABOUTME: Verifies chunking at the 200-contact limit and that results line up with inputs
+
+package people
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/people/v1"
+)
+
+func TestBatchCreateContacts(t *testing.T) {
+	var chunkSizes []int
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req people.BatchCreateContactsRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		chunkSizes = append(chunkSizes, len(req.Contacts))
+
+		resp := people.BatchCreateContactsResponse{}
+		for _, contact := range req.Contacts {
+			name := contact.ContactPerson.Names[0].GivenName
+			if name == "bad" {
+				resp.CreatedPeople = append(resp.CreatedPeople, &people.PersonResponse{Status: &people.Status{Code: 3, Message: "invalid phone"}})
+				continue
+			}
+			resp.CreatedPeople = append(resp.CreatedPeople, &people.PersonResponse{
+				Person: &people.Person{ResourceName: "people/" + name},
+			})
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer api.Close()
+
+	t.Setenv("ISH_MODE", "true")
+	t.Setenv("ISH_BASE_URL", api.URL)
+	svc, err := NewService(context.Background(), nil)
+	require.NoError(t, err)
+
+	persons := make([]*people.Person, maxBatchCreate+1)
+	for i := range persons {
+		name := fmt.Sprintf("c%d", i)
+		if i == 5 {
+			name = "bad"
+		}
+		persons[i] = &people.Person{Names: []*people.Name{{GivenName: name}}}
+	}
+
+	results := svc.BatchCreateContacts(context.Background(), persons)
+	assert.Equal(t, []int{maxBatchCreate, 1}, chunkSizes)
+	require.Len(t, results, len(persons))
+	assert.Equal(t, "people/c0", results[0].Person.ResourceName)
+	assert.ErrorContains(t, results[5].Err, "invalid phone")
+	assert.Nil(t, results[5].Person)
+	assert.Equal(t, "people/c199", results[199].Person.ResourceName)
+	assert.Equal(t, "people/c200", results[200].Person.ResourceName, "the second chunk maps back to its inputs")
+
+	chunkSizes = nil
+	assert.Empty(t, svc.BatchCreateContacts(context.Background(), nil))
+	assert.Empty(t, chunkSizes, "no request for no contacts")
+}
+
+func TestBatchCreateContacts_ChunkFailure(t *testing.T) {
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"error": {"code": 400, "message": "Bad Request"}}`, http.StatusBadRequest)
+	}))
+	defer api.Close()
+
+	t.Setenv("ISH_MODE", "true")
+	t.Setenv("ISH_BASE_URL", api.URL)
+	svc, err := NewService(context.Background(), nil)
+	require.NoError(t, err)
+
+	results := svc.BatchCreateContacts(context.Background(), []*people.Person{{}, {}})
+	require.Len(t, results, 2)
+	for _, result := range results {
+		assert.ErrorContains(t, result.Err, "unable to create contacts")
+	}
+}
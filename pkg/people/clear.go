@@ -0,0 +1,65 @@
+// ABOUTME: Explicit removal of contact fields on update
+// ABOUTME: Empties named person fields and forces them into the request so the API clears them
+
+package people
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"google.golang.org/api/people/v1"
+)
+
+// clearableFields maps each person field that can be cleared to the Go field
+// name the API client needs in ForceSendFields. Names are left out so a
+// contact can't be left without one.
+var clearableFields = map[string]string{
+	"emailAddresses": "EmailAddresses",
+	"phoneNumbers":   "PhoneNumbers",
+	"addresses":      "Addresses",
+	"organizations":  "Organizations",
+	"biographies":    "Biographies",
+	"relations":      "Relations",
+}
+
+// ClearableFields lists the field names accepted by ClearFields, sorted
+func ClearableFields() []string {
+	names := make([]string, 0, len(clearableFields))
+	for name := range clearableFields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ClearFields empties each named field of person so an update whose mask
+// includes them removes every value. Unknown field names are an error and
+// leave person untouched.
+func ClearFields(person *people.Person, fields []string) error {
+	for _, field := range fields {
+		if _, ok := clearableFields[field]; !ok {
+			return fmt.Errorf("cannot clear %q: clearable fields are %s", field, strings.Join(ClearableFields(), ", "))
+		}
+	}
+
+	for _, field := range fields {
+		switch field {
+		case "emailAddresses":
+			person.EmailAddresses = []*people.EmailAddress{}
+		case "phoneNumbers":
+			person.PhoneNumbers = []*people.PhoneNumber{}
+		case "addresses":
+			person.Addresses = []*people.Address{}
+		case "organizations":
+			person.Organizations = []*people.Organization{}
+		case "biographies":
+			person.Biographies = []*people.Biography{}
+		case "relations":
+			person.Relations = []*people.Relation{}
+		}
+		// Empty slices are omitted from JSON unless forced
+		person.ForceSendFields = append(person.ForceSendFields, clearableFields[field])
+	}
+	return nil
+}
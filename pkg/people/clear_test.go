@@ -0,0 +1,30 @@
+// ABOUTME: Tests for clearing contact fields
+// ABOUTME: Verifies cleared fields are sent as empty arrays and unknown names are rejected
+
+package people
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/people/v1"
+)
+
+func TestClearFields(t *testing.T) {
+	person := &people.Person{
+		PhoneNumbers:   []*people.PhoneNumber{{Value: "555-0100"}},
+		Organizations:  []*people.Organization{{Name: "Acme"}},
+		EmailAddresses: []*people.EmailAddress{{Value: "jane@acme.com"}},
+	}
+	require.NoError(t, ClearFields(person, []string{"phoneNumbers", "organizations"}))
+
+	body, err := json.Marshal(person)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"phoneNumbers": [], "organizations": [], "emailAddresses": [{"value": "jane@acme.com"}]}`, string(body))
+
+	err = ClearFields(person, []string{"biographies", "names"})
+	assert.ErrorContains(t, err, `cannot clear "names"`)
+	assert.Nil(t, person.Biographies, "nothing is cleared when a name is invalid")
+}
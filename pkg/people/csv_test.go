@@ -0,0 +1,83 @@
+// ABOUTME: Tests for CSV export
+// ABOUTME: Reads the encoded output back to verify flattening, escaping, and paging
+
+package people
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/people/v1"
+)
+
+func TestEncodeCSV(t *testing.T) {
+	persons := []*people.Person{
+		testPerson(),
+		{
+			Names:          []*people.Name{{GivenName: "Grace", FamilyName: "Hopper"}},
+			EmailAddresses: []*people.EmailAddress{{Value: "grace@example.com"}},
+			Organizations:  []*people.Organization{{Name: `The "Navy"`, Title: "Rear Admiral,\nRetired"}},
+		},
+		{EmailAddresses: []*people.EmailAddress{{Value: "noname@example.com"}}},
+	}
+
+	text, err := EncodeCSV(persons)
+	require.NoError(t, err)
+	assert.Contains(t, text, `"The ""Navy"""`, "quotes are doubled inside a quoted field")
+
+	records, err := csv.NewReader(strings.NewReader(text)).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 4)
+	assert.Equal(t, csvHeader, records[0])
+
+	ada := records[1]
+	assert.Equal(t, "Ada Lovelace, Countess", ada[0])
+	assert.Equal(t, "Ada", ada[1])
+	assert.Equal(t, "Lovelace", ada[3])
+	assert.Equal(t, "ada@work.example.com;ada@home.example.com;ada@other.example.com", ada[6])
+	assert.Equal(t, "+1 555 0100;+1 555 0101", ada[7])
+	assert.Equal(t, "Analytical Engines; Ltd", ada[8])
+	assert.Equal(t, "Mathematician", ada[9])
+
+	grace := records[2]
+	assert.Equal(t, "Grace Hopper", grace[0])
+	assert.Equal(t, `The "Navy"`, grace[8])
+	assert.Equal(t, "Rear Admiral,\nRetired", grace[9])
+
+	assert.Equal(t, []string{"", "", "", "", "", "", "noname@example.com", "", "", ""}, records[3])
+}
+
+func TestListAllContacts_FollowsPages(t *testing.T) {
+	var tokens []string
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := r.URL.Query().Get("pageToken")
+		tokens = append(tokens, token)
+		assert.Equal(t, csvPersonFields, r.URL.Query().Get("personFields"))
+
+		resp := map[string]interface{}{"connections": []map[string]string{{"resourceName": "people/c1"}}, "nextPageToken": "page2"}
+		if token == "page2" {
+			resp = map[string]interface{}{"connections": []map[string]string{{"resourceName": "people/c2"}}}
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer api.Close()
+
+	t.Setenv("ISH_MODE", "true")
+	t.Setenv("ISH_BASE_URL", api.URL)
+
+	svc, err := NewService(context.Background(), nil)
+	require.NoError(t, err)
+
+	persons, err := svc.ListAllContacts(context.Background())
+	require.NoError(t, err)
+	require.Len(t, persons, 2)
+	assert.Equal(t, "people/c2", persons[1].ResourceName)
+	assert.Equal(t, []string{"", "page2"}, tokens)
+}
@@ -0,0 +1,129 @@
+// ABOUTME: Google Workspace domain directory listing
+// ABOUTME: Pages through directory profiles with a read mask and sync tokens for incremental updates
+
+package people
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/harper/gsuite-mcp/pkg/apierr"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/people/v1"
+)
+
+// DirectoryReadMask is the set of person fields returned by ListDirectory by default
+const DirectoryReadMask = "names,emailAddresses,organizations,phoneNumbers"
+
+// directoryPageSize is the People API maximum page size for listDirectoryPeople
+const directoryPageSize = 1000
+
+// directoryScope grants read access to the Workspace directory
+const directoryScope = "https://www.googleapis.com/auth/directory.readonly"
+
+// ErrDirectoryUnavailable is returned when the account isn't part of a
+// Workspace domain, so there is no directory to read. A token without the
+// directory scope gets an apierr.ScopeError instead.
+var ErrDirectoryUnavailable = errors.New("domain directory unavailable: it requires a Google Workspace account")
+
+// DirectoryOptions controls a ListDirectory call
+type DirectoryOptions struct {
+	ReadMask   string // Person fields to return; empty uses DirectoryReadMask
+	MaxResults int    // Stop after this many people; the next page token resumes from there
+	PageToken  string // Continue a previous listing
+	SyncToken  string // Return only changes since the listing that issued this token
+}
+
+// DirectoryPage is one run of ListDirectory
+type DirectoryPage struct {
+	People        []*people.Person
+	NextPageToken string // Set when MaxResults stopped the listing before the end
+	NextSyncToken string // Set once the listing reaches the end; pass as SyncToken later
+}
+
+// ListDirectory pages through the domain directory's profiles until the end
+// or opts.MaxResults people. With a sync token, only people changed since that
+// token was issued are returned; deleted people carry metadata.deleted.
+func (s *Service) ListDirectory(ctx context.Context, opts DirectoryOptions) (*DirectoryPage, error) {
+	readMask := opts.ReadMask
+	if readMask == "" {
+		readMask = DirectoryReadMask
+	}
+
+	page := &DirectoryPage{People: []*people.Person{}}
+	pageToken := opts.PageToken
+	for {
+		pageSize := int64(directoryPageSize)
+		if remaining := opts.MaxResults - len(page.People); opts.MaxResults > 0 && remaining < directoryPageSize {
+			pageSize = int64(remaining)
+		}
+
+		var result *people.ListDirectoryPeopleResponse
+		err := s.breaker.Do(func() error {
+			call := s.svc.People.ListDirectoryPeople().
+				Context(ctx).
+				Sources("DIRECTORY_SOURCE_TYPE_DOMAIN_PROFILE").
+				ReadMask(readMask).
+				PageSize(pageSize).
+				RequestSyncToken(true)
+			if pageToken != "" {
+				call = call.PageToken(pageToken)
+			}
+			if opts.SyncToken != "" {
+				call = call.SyncToken(opts.SyncToken)
+			}
+
+			var err error
+			result, err = call.Do()
+			return err
+		})
+		if err != nil {
+			return nil, fmt.Errorf("unable to list directory: %w", directoryError(err))
+		}
+
+		page.People = append(page.People, result.People...)
+		if result.NextPageToken == "" {
+			page.NextSyncToken = result.NextSyncToken
+			return page, nil
+		}
+		pageToken = result.NextPageToken
+		if opts.MaxResults > 0 && len(page.People) >= opts.MaxResults {
+			page.NextPageToken = pageToken
+			return page, nil
+		}
+	}
+}
+
+// directoryError reports a missing directory scope as an apierr.ScopeError
+// and any other 403 except rate limiting as ErrDirectoryUnavailable, keeping
+// the API error as the cause
+func directoryError(err error) error {
+	if err = apierr.ClassifyScopeError(err, directoryScope); errors.Is(err, apierr.ErrInsufficientScope) {
+		return err
+	}
+	var apiErr *googleapi.Error
+	if errors.As(err, &apiErr) && apiErr.Code == http.StatusForbidden && !isRateLimited(apiErr) {
+		return fmt.Errorf("%w: %w", ErrDirectoryUnavailable, err)
+	}
+	return err
+}
+
+// rateLimitReasons are the 403 reasons Google uses for quota and rate limits
+var rateLimitReasons = map[string]bool{
+	"rateLimitExceeded":     true,
+	"userRateLimitExceeded": true,
+	"quotaExceeded":         true,
+}
+
+// isRateLimited reports whether a 403 is a rate or quota limit rather than a
+// refusal, so retrying later can succeed
+func isRateLimited(apiErr *googleapi.Error) bool {
+	for _, item := range apiErr.Errors {
+		if rateLimitReasons[item.Reason] {
+			return true
+		}
+	}
+	return false
+}
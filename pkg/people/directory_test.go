@@ -0,0 +1,150 @@
+// ABOUTME: Tests for domain directory listing
+// ABOUTME: Covers paging, read masks, sync tokens, the result cap, and personal-account errors
+
+package people
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/harper/gsuite-mcp/pkg/apierr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/googleapi"
+)
+
+// directoryService serves three directory pages of two people each, recording each request's query
+func directoryService(t *testing.T, queries *[]url.Values) *Service {
+	pages := map[string]map[string]interface{}{
+		"": {
+			"people":        []map[string]string{{"resourceName": "people/d1"}, {"resourceName": "people/d2"}},
+			"nextPageToken": "page2",
+		},
+		"page2": {
+			"people":        []map[string]string{{"resourceName": "people/d3"}, {"resourceName": "people/d4"}},
+			"nextPageToken": "page3",
+		},
+		"page3": {
+			"people":        []map[string]string{{"resourceName": "people/d5"}},
+			"nextSyncToken": "sync-1",
+		},
+	}
+
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*queries = append(*queries, r.URL.Query())
+		_ = json.NewEncoder(w).Encode(pages[r.URL.Query().Get("pageToken")])
+	}))
+	t.Cleanup(api.Close)
+
+	t.Setenv("ISH_MODE", "true")
+	t.Setenv("ISH_BASE_URL", api.URL)
+
+	svc, err := NewService(context.Background(), nil)
+	require.NoError(t, err)
+	return svc
+}
+
+func resourceNames(page *DirectoryPage) []string {
+	names := make([]string, len(page.People))
+	for i, person := range page.People {
+		names[i] = person.ResourceName
+	}
+	return names
+}
+
+func TestListDirectory_FollowsPages(t *testing.T) {
+	var queries []url.Values
+	svc := directoryService(t, &queries)
+
+	page, err := svc.ListDirectory(context.Background(), DirectoryOptions{ReadMask: "names,photos"})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"people/d1", "people/d2", "people/d3", "people/d4", "people/d5"}, resourceNames(page))
+	assert.Empty(t, page.NextPageToken)
+	assert.Equal(t, "sync-1", page.NextSyncToken)
+
+	require.Len(t, queries, 3)
+	assert.Equal(t, []string{"", "page2", "page3"}, []string{queries[0].Get("pageToken"), queries[1].Get("pageToken"), queries[2].Get("pageToken")})
+	for _, q := range queries {
+		assert.Equal(t, "names,photos", q.Get("readMask"))
+		assert.Equal(t, "DIRECTORY_SOURCE_TYPE_DOMAIN_PROFILE", q.Get("sources"))
+		assert.Equal(t, "true", q.Get("requestSyncToken"))
+	}
+}
+
+func TestListDirectory_MaxResultsAndSyncToken(t *testing.T) {
+	var queries []url.Values
+	svc := directoryService(t, &queries)
+	ctx := context.Background()
+
+	page, err := svc.ListDirectory(ctx, DirectoryOptions{MaxResults: 3})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"people/d1", "people/d2", "people/d3", "people/d4"}, resourceNames(page), "whole pages are kept")
+	assert.Equal(t, "page3", page.NextPageToken)
+	assert.Empty(t, page.NextSyncToken)
+	assert.Equal(t, DirectoryReadMask, queries[0].Get("readMask"))
+	assert.Equal(t, []string{"3", "1"}, []string{queries[0].Get("pageSize"), queries[1].Get("pageSize")})
+
+	queries = nil
+	page, err = svc.ListDirectory(ctx, DirectoryOptions{PageToken: "page3", SyncToken: "sync-0"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"people/d5"}, resourceNames(page))
+	require.Len(t, queries, 1)
+	assert.Equal(t, "sync-0", queries[0].Get("syncToken"))
+}
+
+func TestListDirectory_Forbidden(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want error
+	}{
+		{
+			name: "personal account",
+			body: `{"error": {"code": 403, "message": "Must be a G Suite domain user.", "status": "PERMISSION_DENIED"}}`,
+			want: ErrDirectoryUnavailable,
+		},
+		{
+			name: "missing directory scope",
+			body: `{"error": {"code": 403, "message": "Request had insufficient authentication scopes.", "errors": [{"reason": "insufficientPermissions"}]}}`,
+			want: apierr.ErrInsufficientScope,
+		},
+		{
+			name: "rate limited",
+			body: `{"error": {"code": 403, "message": "Rate Limit Exceeded", "errors": [{"reason": "rateLimitExceeded"}]}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusForbidden)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer api.Close()
+
+			t.Setenv("ISH_MODE", "true")
+			t.Setenv("ISH_BASE_URL", api.URL)
+
+			svc, err := NewService(context.Background(), nil)
+			require.NoError(t, err)
+
+			_, err = svc.ListDirectory(context.Background(), DirectoryOptions{})
+			require.Error(t, err)
+			if tt.want == nil {
+				assert.NotErrorIs(t, err, ErrDirectoryUnavailable)
+				assert.NotErrorIs(t, err, apierr.ErrInsufficientScope)
+			} else {
+				assert.ErrorIs(t, err, tt.want)
+			}
+
+			var apiErr *googleapi.Error
+			assert.ErrorAs(t, err, &apiErr, "the API error is kept as the cause")
+		})
+	}
+}
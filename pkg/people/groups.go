@@ -0,0 +1,151 @@
+// ABOUTME: Contact group membership listing, for viewing one segment of the contact list
+// ABOUTME: Resolves a group by resource name or display name, then batch-gets its members
+
+package people
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/harper/gsuite-mcp/pkg/apierr"
+	"google.golang.org/api/people/v1"
+)
+
+// contactGroupPrefix starts every contact group resource name
+const contactGroupPrefix = "contactGroups/"
+
+// maxGroupListPages bounds how many pages of groups are scanned for a name
+const maxGroupListPages = 10
+
+// GroupMembers is a contact group and the contacts in it
+type GroupMembers struct {
+	Group       string           `json:"group"` // Resource name, e.g. contactGroups/abc123
+	Name        string           `json:"name"`
+	MemberCount int64            `json:"member_count"` // Members in the group, which may exceed len(Contacts)
+	Contacts    []*people.Person `json:"contacts"`
+	Truncated   bool             `json:"truncated"` // The group has more members than were fetched
+}
+
+// ListContactsInGroup returns up to pageSize contacts in group, which is a
+// contactGroups/<id> resource name or a group's name (matched
+// case-insensitively). Members are fetched with ListReadMask. An empty group
+// returns no contacts rather than an error.
+func (s *Service) ListContactsInGroup(ctx context.Context, group string, pageSize int64) (*GroupMembers, error) {
+	group = strings.TrimSpace(group)
+	if group == "" {
+		return nil, fmt.Errorf("group must not be empty")
+	}
+	if pageSize <= 0 {
+		pageSize = 100
+	}
+
+	resourceName := group
+	if !strings.HasPrefix(group, contactGroupPrefix) {
+		var err error
+		if resourceName, err = s.findContactGroup(ctx, group); err != nil {
+			return nil, err
+		}
+	}
+
+	var found *people.ContactGroup
+	err := s.breaker.Do(func() error {
+		var err error
+		found, err = s.svc.ContactGroups.Get(resourceName).
+			Context(ctx).
+			MaxMembers(pageSize).
+			Do()
+		return err
+	})
+	if err != nil {
+		return nil, apierr.Wrap(err, "unable to get contact group", "contact group", resourceName)
+	}
+
+	result := &GroupMembers{
+		Group:       found.ResourceName,
+		Name:        groupName(found),
+		MemberCount: found.MemberCount,
+		Contacts:    []*people.Person{},
+	}
+	members := found.MemberResourceNames
+	if int64(len(members)) > pageSize {
+		members = members[:pageSize]
+	}
+	result.Truncated = found.MemberCount > int64(len(members))
+
+	for start := 0; start < len(members); start += maxBatchGet {
+		persons, err := s.getMembers(ctx, members[start:min(start+maxBatchGet, len(members))])
+		if err != nil {
+			return nil, err
+		}
+		result.Contacts = append(result.Contacts, persons...)
+	}
+	return result, nil
+}
+
+// findContactGroup returns the resource name of the group whose name or
+// formatted name equals name, ignoring case
+func (s *Service) findContactGroup(ctx context.Context, name string) (string, error) {
+	pageToken := ""
+	for page := 0; page < maxGroupListPages; page++ {
+		var result *people.ListContactGroupsResponse
+		err := s.breaker.Do(func() error {
+			call := s.svc.ContactGroups.List().Context(ctx).PageSize(1000)
+			if pageToken != "" {
+				call = call.PageToken(pageToken)
+			}
+			var err error
+			result, err = call.Do()
+			return err
+		})
+		if err != nil {
+			return "", fmt.Errorf("unable to list contact groups: %w", err)
+		}
+
+		for _, group := range result.ContactGroups {
+			if strings.EqualFold(group.Name, name) || strings.EqualFold(group.FormattedName, name) {
+				return group.ResourceName, nil
+			}
+		}
+		if result.NextPageToken == "" {
+			break
+		}
+		pageToken = result.NextPageToken
+	}
+	return "", fmt.Errorf("no contact group named %q", name)
+}
+
+// getMembers batch-gets one request's worth of group members. Members that
+// no longer resolve, such as contacts deleted since the group was read, are
+// skipped.
+func (s *Service) getMembers(ctx context.Context, resourceNames []string) ([]*people.Person, error) {
+	var result *people.GetPeopleResponse
+	err := s.breaker.Do(func() error {
+		var err error
+		result, err = s.svc.People.GetBatchGet().
+			Context(ctx).
+			ResourceNames(resourceNames...).
+			PersonFields(ListReadMask).
+			Do()
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to get group members: %w", err)
+	}
+
+	persons := make([]*people.Person, 0, len(result.Responses))
+	for _, resp := range result.Responses {
+		if resp.Person != nil {
+			persons = append(persons, resp.Person)
+		}
+	}
+	return persons, nil
+}
+
+// groupName prefers the localized name Google shows for system groups
+func groupName(group *people.ContactGroup) string {
+	if group.FormattedName != "" {
+		return group.FormattedName
+	}
+	return group.Name
+}
@@ -0,0 +1,78 @@
+// ABOUTME: Tests for listing the contacts in one contact group
+// ABOUTME: Verifies name lookup, that only members are fetched, empty groups, and missing groups
+
+package people
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// groupsAPI serves a Clients group with two members and an empty Vendors group
+func groupsAPI(t *testing.T, batchGets *[][]string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/v1/contactGroups":
+			_, _ = w.Write([]byte(`{"contactGroups": [
+				{"resourceName": "contactGroups/myContacts", "name": "myContacts", "formattedName": "My Contacts"},
+				{"resourceName": "contactGroups/c1", "name": "Clients", "formattedName": "Clients"},
+				{"resourceName": "contactGroups/v1", "name": "Vendors", "formattedName": "Vendors"}
+			]}`))
+		case r.URL.Path == "/v1/contactGroups/c1":
+			assert.Equal(t, "50", r.URL.Query().Get("maxMembers"))
+			_, _ = w.Write([]byte(`{"resourceName": "contactGroups/c1", "name": "Clients", "formattedName": "Clients",
+				"memberCount": 2, "memberResourceNames": ["people/p1", "people/p3"]}`))
+		case r.URL.Path == "/v1/contactGroups/v1":
+			_, _ = w.Write([]byte(`{"resourceName": "contactGroups/v1", "name": "Vendors", "formattedName": "Vendors", "memberCount": 0}`))
+		case r.URL.Path == "/v1/people:batchGet":
+			names := r.URL.Query()["resourceNames"]
+			*batchGets = append(*batchGets, names)
+			var responses []string
+			for _, name := range names {
+				responses = append(responses, `{"requestedResourceName": "`+name+`", "person": {"resourceName": "`+name+`"}}`)
+			}
+			_, _ = w.Write([]byte(`{"responses": [` + strings.Join(responses, ",") + `]}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}
+}
+
+func TestListContactsInGroup(t *testing.T) {
+	var batchGets [][]string
+	api := httptest.NewServer(groupsAPI(t, &batchGets))
+	defer api.Close()
+
+	t.Setenv("ISH_MODE", "true")
+	t.Setenv("ISH_BASE_URL", api.URL)
+
+	svc, err := NewService(context.Background(), nil)
+	require.NoError(t, err)
+
+	for _, group := range []string{"clients", "contactGroups/c1"} {
+		members, err := svc.ListContactsInGroup(context.Background(), group, 50)
+		require.NoError(t, err, group)
+		assert.Equal(t, "contactGroups/c1", members.Group)
+		assert.Equal(t, "Clients", members.Name)
+		require.Len(t, members.Contacts, 2)
+		assert.Equal(t, "people/p1", members.Contacts[0].ResourceName)
+		assert.Equal(t, "people/p3", members.Contacts[1].ResourceName)
+		assert.False(t, members.Truncated)
+	}
+	assert.Equal(t, [][]string{{"people/p1", "people/p3"}, {"people/p1", "people/p3"}}, batchGets, "only members are fetched")
+
+	members, err := svc.ListContactsInGroup(context.Background(), "Vendors", 100)
+	require.NoError(t, err)
+	assert.Empty(t, members.Contacts)
+	assert.NotNil(t, members.Contacts)
+	assert.Len(t, batchGets, 2, "empty groups need no batch get")
+
+	_, err = svc.ListContactsInGroup(context.Background(), "Prospects", 100)
+	assert.EqualError(t, err, `no contact group named "Prospects"`)
+}
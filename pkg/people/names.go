@@ -0,0 +1,90 @@
+// ABOUTME: Display name lookup for email addresses
+// ABOUTME: Checks contacts, then the Workspace directory, caching answers for the life of a resolver
+
+package people
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/harper/gsuite-mcp/pkg/apierr"
+	"google.golang.org/api/people/v1"
+)
+
+// NameResolver maps email addresses to display names from the user's
+// contacts and, on Workspace accounts, the domain directory. Answers,
+// including misses, are cached so each address is looked up once. It is not
+// safe for concurrent use.
+type NameResolver struct {
+	svc         *Service
+	names       map[string]string // Lowercased email -> name; "" records a miss
+	noDirectory bool              // The directory was unavailable, so stop asking it
+}
+
+// NewNameResolver returns a resolver with an empty cache
+func (s *Service) NewNameResolver() *NameResolver {
+	return &NameResolver{svc: s, names: make(map[string]string)}
+}
+
+// Resolve returns the display name for email, or "" if neither contacts nor
+// the directory know it. Lookup failures count as misses.
+func (r *NameResolver) Resolve(ctx context.Context, email string) string {
+	key := strings.ToLower(strings.TrimSpace(email))
+	if key == "" {
+		return ""
+	}
+	if name, ok := r.names[key]; ok {
+		return name
+	}
+
+	var name string
+	if contact, err := r.svc.FindContactByEmail(ctx, key); err == nil && contact != nil {
+		name = displayName(contact)
+	}
+	if name == "" && !r.noDirectory {
+		person, err := r.svc.FindDirectoryPersonByEmail(ctx, key)
+		if errors.Is(err, ErrDirectoryUnavailable) || errors.Is(err, apierr.ErrInsufficientScope) {
+			r.noDirectory = true
+		} else if err == nil && person != nil {
+			name = displayName(person)
+		}
+	}
+
+	r.names[key] = name
+	return name
+}
+
+// FindDirectoryPersonByEmail returns the domain directory profile with exactly
+// this email address (case-insensitive), or nil if there is none. It returns
+// ErrDirectoryUnavailable outside Workspace and an apierr.ScopeError without
+// the directory scope.
+func (s *Service) FindDirectoryPersonByEmail(ctx context.Context, email string) (*people.Person, error) {
+	var result *people.SearchDirectoryPeopleResponse
+
+	err := s.breaker.Do(func() error {
+		var err error
+		result, err = s.svc.People.SearchDirectoryPeople().
+			Context(ctx).
+			Query(email).
+			ReadMask("names,emailAddresses").
+			Sources("DIRECTORY_SOURCE_TYPE_DOMAIN_PROFILE").
+			PageSize(10).
+			Do()
+		return err
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to search directory: %w", directoryError(err))
+	}
+
+	for _, person := range result.People {
+		for _, address := range person.EmailAddresses {
+			if strings.EqualFold(address.Value, email) {
+				return person, nil
+			}
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,82 @@
+// ABOUTME: Tests for resolving email addresses to display names
+// ABOUTME: Verifies contact and directory lookups, per-resolver caching, and graceful misses
+
+package people
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNameResolver(t *testing.T) {
+	calls := map[string]int{}
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query().Get("query")
+		switch {
+		case strings.HasSuffix(r.URL.Path, ":searchContacts"):
+			calls["contacts"]++
+			if query == "jane@acme.com" {
+				_, _ = w.Write([]byte(`{"results": [{"person": {"names": [{"displayName": "Jane Smith"}], "emailAddresses": [{"value": "Jane@Acme.com"}]}}]}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{}`))
+		case strings.HasSuffix(r.URL.Path, ":searchDirectoryPeople"):
+			calls["directory"]++
+			if query == "cto@acme.com" {
+				_, _ = w.Write([]byte(`{"people": [{"names": [{"givenName": "Pat", "familyName": "Lee"}], "emailAddresses": [{"value": "cto@acme.com"}]}]}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer api.Close()
+
+	t.Setenv("ISH_MODE", "true")
+	t.Setenv("ISH_BASE_URL", api.URL)
+	svc, err := NewService(context.Background(), nil)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	resolver := svc.NewNameResolver()
+	assert.Equal(t, "Jane Smith", resolver.Resolve(ctx, "JANE@acme.com"))
+	assert.Equal(t, "Jane Smith", resolver.Resolve(ctx, "jane@acme.com"))
+	assert.Equal(t, 1, calls["contacts"], "repeat lookups hit the cache")
+	assert.Zero(t, calls["directory"], "contacts answered first")
+
+	assert.Equal(t, "Pat Lee", resolver.Resolve(ctx, "cto@acme.com"))
+	assert.Equal(t, "", resolver.Resolve(ctx, "stranger@example.com"))
+	assert.Equal(t, "", resolver.Resolve(ctx, "stranger@example.com"))
+	assert.Equal(t, 3, calls["contacts"])
+	assert.Equal(t, 2, calls["directory"], "misses are cached too")
+}
+
+func TestNameResolver_DirectoryUnavailable(t *testing.T) {
+	directoryCalls := 0
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, ":searchDirectoryPeople") {
+			directoryCalls++
+			http.Error(w, `{"error": {"code": 403, "message": "Forbidden"}}`, http.StatusForbidden)
+			return
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer api.Close()
+
+	t.Setenv("ISH_MODE", "true")
+	t.Setenv("ISH_BASE_URL", api.URL)
+	svc, err := NewService(context.Background(), nil)
+	require.NoError(t, err)
+
+	resolver := svc.NewNameResolver()
+	assert.Equal(t, "", resolver.Resolve(context.Background(), "a@example.com"))
+	assert.Equal(t, "", resolver.Resolve(context.Background(), "b@example.com"))
+	assert.Equal(t, 1, directoryCalls, "a consumer account's directory is only tried once")
+}
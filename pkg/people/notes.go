@@ -0,0 +1,67 @@
+// ABOUTME: Freeform notes and relationships on contacts
+// ABOUTME: Maps notes to the person's biography and named relations like "assistant" or "manager"
+
+package people
+
+import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/api/people/v1"
+)
+
+// Relation is a named person related to a contact, e.g. their assistant
+type Relation struct {
+	Person string `json:"person"`
+	Type   string `json:"type"` // e.g. assistant, manager, spouse, referredBy
+}
+
+// relationTypes maps the People API's predefined relation types, keyed
+// lowercase without underscores, to their canonical camelCase spelling
+var relationTypes = map[string]string{
+	"spouse":          "spouse",
+	"child":           "child",
+	"mother":          "mother",
+	"father":          "father",
+	"parent":          "parent",
+	"brother":         "brother",
+	"sister":          "sister",
+	"friend":          "friend",
+	"relative":        "relative",
+	"domesticpartner": "domesticPartner",
+	"manager":         "manager",
+	"assistant":       "assistant",
+	"referredby":      "referredBy",
+	"partner":         "partner",
+}
+
+// canonicalRelationType returns the API spelling of a predefined type, ignoring
+// case and underscores ("Referred_By" is referredBy). Custom types are only trimmed.
+func canonicalRelationType(relType string) string {
+	relType = strings.TrimSpace(relType)
+	if canonical, ok := relationTypes[strings.ToLower(strings.ReplaceAll(relType, "_", ""))]; ok {
+		return canonical
+	}
+	return relType
+}
+
+// SetNotes replaces a contact's notes. Contacts hold a single plain-text
+// biography, so any existing one is overwritten.
+func SetNotes(person *people.Person, notes string) {
+	person.Biographies = []*people.Biography{{Value: notes, ContentType: "TEXT_PLAIN"}}
+}
+
+// SetRelations replaces a contact's relations. Each needs a person; predefined
+// types are stored in the API's spelling so "Assistant" and "assistant" match.
+func SetRelations(person *people.Person, relations []Relation) error {
+	result := make([]*people.Relation, 0, len(relations))
+	for i, rel := range relations {
+		name := strings.TrimSpace(rel.Person)
+		if name == "" {
+			return fmt.Errorf("relations[%d] is missing a person", i)
+		}
+		result = append(result, &people.Relation{Person: name, Type: canonicalRelationType(rel.Type)})
+	}
+	person.Relations = result
+	return nil
+}
@@ -0,0 +1,41 @@
+// ABOUTME: Tests for setting contact notes and relations
+// ABOUTME: Verifies relation types keep the People API's spelling and custom labels pass through
+
+package people
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/people/v1"
+)
+
+func TestSetRelations(t *testing.T) {
+	tests := []struct {
+		relType  string
+		expected string
+	}{
+		{"assistant", "assistant"},
+		{" Manager ", "manager"},
+		{"domesticPartner", "domesticPartner"},
+		{"DOMESTICPARTNER", "domesticPartner"},
+		{"referredBy", "referredBy"},
+		{"referred_by", "referredBy"},
+		{"Godparent", "Godparent"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.relType, func(t *testing.T) {
+			person := &people.Person{}
+			require.NoError(t, SetRelations(person, []Relation{{Person: " Sam Lee ", Type: tt.relType}}))
+			require.Len(t, person.Relations, 1)
+			assert.Equal(t, "Sam Lee", person.Relations[0].Person)
+			assert.Equal(t, tt.expected, person.Relations[0].Type)
+		})
+	}
+
+	person := &people.Person{}
+	assert.Error(t, SetRelations(person, []Relation{{Type: "manager"}}), "a relation needs a person")
+}
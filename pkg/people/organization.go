@@ -0,0 +1,64 @@
+// ABOUTME: Organization inference from contact email addresses
+// ABOUTME: Derives a company name from a corporate email domain, skipping free-mail providers
+
+package people
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// freeMailProviders are second-level domain labels that say nothing about an employer
+var freeMailProviders = map[string]bool{
+	"gmail":      true,
+	"googlemail": true,
+	"yahoo":      true,
+	"ymail":      true,
+	"outlook":    true,
+	"hotmail":    true,
+	"live":       true,
+	"msn":        true,
+	"icloud":     true,
+	"me":         true,
+	"mac":        true,
+	"aol":        true,
+	"protonmail": true,
+	"proton":     true,
+	"gmx":        true,
+	"yandex":     true,
+	"zoho":       true,
+	"fastmail":   true,
+}
+
+// secondLevelSuffixes are labels used under country-code TLDs (acme.co.uk, acme.com.au)
+var secondLevelSuffixes = map[string]bool{
+	"co": true, "com": true, "org": true, "net": true, "ac": true, "gov": true, "edu": true,
+}
+
+// InferOrganization guesses an organization name from an email's domain, e.g.
+// jane@acme-corp.com -> "Acme-corp". It returns "" for free-mail providers and
+// addresses without a usable domain.
+func InferOrganization(email string) string {
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return ""
+	}
+
+	labels := strings.Split(strings.Trim(strings.ToLower(strings.TrimSpace(email[at+1:])), "."), ".")
+	if len(labels) < 2 {
+		return ""
+	}
+
+	label := labels[len(labels)-2]
+	if len(labels) >= 3 && len(labels[len(labels)-1]) == 2 && secondLevelSuffixes[label] {
+		label = labels[len(labels)-3]
+	}
+
+	if label == "" || freeMailProviders[label] {
+		return ""
+	}
+
+	first, size := utf8.DecodeRuneInString(label)
+	return string(unicode.ToUpper(first)) + label[size:]
+}
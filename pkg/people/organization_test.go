@@ -0,0 +1,35 @@
+// ABOUTME: Tests for organization inference from email domains
+// ABOUTME: Validates corporate domains, country-code suffixes, and free-mail skipping
+
+package people
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInferOrganization(t *testing.T) {
+	tests := []struct {
+		email    string
+		expected string
+	}{
+		{email: "jane@acme-corp.com", expected: "Acme-corp"},
+		{email: "bob@mail.initech.io", expected: "Initech"},
+		{email: "  Sam@Globex.COM ", expected: "Globex"},
+		{email: "ann@acme.co.uk", expected: "Acme"},
+		{email: "lee@widgets.com.au", expected: "Widgets"},
+		{email: "jane@gmail.com", expected: ""},
+		{email: "jane@yahoo.co.uk", expected: ""},
+		{email: "jane@outlook.com", expected: ""},
+		{email: "jane@localhost", expected: ""},
+		{email: "not-an-email", expected: ""},
+		{email: "", expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.email, func(t *testing.T) {
+			assert.Equal(t, tt.expected, InferOrganization(tt.email))
+		})
+	}
+}
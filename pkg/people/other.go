@@ -0,0 +1,112 @@
+// ABOUTME: Search of "Other contacts", the people Gmail saves automatically from mail
+// ABOUTME: Merges them with saved contact matches, de-duplicated by email and tagged by source
+
+package people
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/harper/gsuite-mcp/pkg/apierr"
+	"google.golang.org/api/people/v1"
+)
+
+// Values of SearchMatch.Source
+const (
+	SourceContact = "contact" // A saved contact
+	SourceOther   = "other"   // An "Other contacts" entry: someone the user has emailed
+)
+
+// otherContactFields are the only person fields otherContacts.search can return
+var otherContactFields = map[string]bool{
+	"emailAddresses": true,
+	"metadata":       true,
+	"names":          true,
+	"phoneNumbers":   true,
+	"photos":         true,
+}
+
+// SearchMatch is a search result and where it was found
+type SearchMatch struct {
+	Source string         `json:"source"` // SourceContact or SourceOther
+	Person *people.Person `json:"person"`
+}
+
+// SearchOtherContacts searches the user's "Other contacts". Fields in readMask
+// the endpoint can't return are dropped; if none are left, names and email
+// addresses are returned. It needs the contacts.other.readonly scope.
+func (s *Service) SearchOtherContacts(ctx context.Context, query string, pageSize int64, readMask string) ([]*people.Person, error) {
+	var fields []string
+	for _, field := range strings.Split(readMask, ",") {
+		if field = strings.TrimSpace(field); otherContactFields[field] {
+			fields = append(fields, field)
+		}
+	}
+	if len(fields) == 0 {
+		fields = []string{"names", "emailAddresses"}
+	}
+
+	var result *people.SearchResponse
+	err := s.breaker.Do(func() error {
+		var err error
+		result, err = s.svc.OtherContacts.Search().
+			Context(ctx).
+			Query(query).
+			ReadMask(strings.Join(fields, ",")).
+			PageSize(pageSize).
+			Do()
+		return err
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to search other contacts: %w", apierr.ClassifyScopeError(err, people.ContactsOtherReadonlyScope))
+	}
+
+	var others []*people.Person
+	for _, result := range result.Results {
+		if result.Person != nil {
+			others = append(others, result.Person)
+		}
+	}
+	return others, nil
+}
+
+// MergeSearchMatches tags contacts and others with their source, dropping any
+// other-contact that shares an email address (case-insensitively) with a
+// contact or an earlier other-contact. Contacts come first, in given order.
+func MergeSearchMatches(contacts, others []*people.Person) []SearchMatch {
+	matches := make([]SearchMatch, 0, len(contacts)+len(others))
+	seen := make(map[string]bool)
+	for _, person := range contacts {
+		matches = append(matches, SearchMatch{Source: SourceContact, Person: person})
+		markEmailsSeen(person, seen)
+	}
+
+others:
+	for _, person := range others {
+		for _, address := range person.EmailAddresses {
+			if seen[strings.ToLower(address.Value)] {
+				continue others
+			}
+		}
+		matches = append(matches, SearchMatch{Source: SourceOther, Person: person})
+		markEmailsSeen(person, seen)
+	}
+	return matches
+}
+
+// markEmailsSeen records person's lowercased email addresses in seen
+func markEmailsSeen(person *people.Person, seen map[string]bool) {
+	for _, address := range person.EmailAddresses {
+		seen[strings.ToLower(address.Value)] = true
+	}
+}
+
+// SortMatchesByName orders matches as SortByName orders contacts
+func SortMatchesByName(matches []SearchMatch) {
+	sort.SliceStable(matches, func(i, j int) bool {
+		return lessByName(matches[i].Person, matches[j].Person)
+	})
+}
@@ -0,0 +1,66 @@
+// ABOUTME: Tests for searching Other contacts and merging them with saved contacts
+// ABOUTME: Verifies read mask filtering, case-insensitive email de-duplication, and source tags
+
+package people
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/people/v1"
+)
+
+func TestSearchOtherContacts_FiltersReadMask(t *testing.T) {
+	var readMask string
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		readMask = r.URL.Query().Get("readMask")
+		_, _ = w.Write([]byte(`{"results": [{"person": {"resourceName": "otherContacts/o1"}}]}`))
+	}))
+	defer api.Close()
+
+	t.Setenv("ISH_MODE", "true")
+	t.Setenv("ISH_BASE_URL", api.URL)
+
+	svc, err := NewService(context.Background(), nil)
+	require.NoError(t, err)
+
+	others, err := svc.SearchOtherContacts(context.Background(), "jane", 10, "names,organizations,emailAddresses")
+	require.NoError(t, err)
+	require.Len(t, others, 1)
+	assert.Equal(t, "names,emailAddresses", readMask, "fields other contacts can't return are dropped")
+
+	_, err = svc.SearchOtherContacts(context.Background(), "jane", 10, "organizations")
+	require.NoError(t, err)
+	assert.Equal(t, "names,emailAddresses", readMask)
+}
+
+func TestMergeSearchMatches(t *testing.T) {
+	person := func(name string, emails ...string) *people.Person {
+		p := &people.Person{Names: []*people.Name{{DisplayName: name}}}
+		for _, email := range emails {
+			p.EmailAddresses = append(p.EmailAddresses, &people.EmailAddress{Value: email})
+		}
+		return p
+	}
+	contacts := []*people.Person{person("Jane Smith", "jane@acme.com")}
+	others := []*people.Person{
+		person("Jane", "JANE@acme.com"),
+		person("Jane Doe", "jdoe@example.com"),
+		person("J. Doe", "jdoe@example.com"),
+		person("No Email"),
+	}
+
+	matches := MergeSearchMatches(contacts, others)
+	require.Len(t, matches, 3)
+	assert.Equal(t, SearchMatch{Source: SourceContact, Person: contacts[0]}, matches[0])
+	assert.Equal(t, SearchMatch{Source: SourceOther, Person: others[1]}, matches[1])
+	assert.Equal(t, SearchMatch{Source: SourceOther, Person: others[3]}, matches[2])
+
+	SortMatchesByName(matches)
+	assert.Equal(t, "Jane Doe", displayName(matches[0].Person))
+	assert.Equal(t, "Jane Smith", displayName(matches[1].Person))
+}
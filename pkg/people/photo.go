@@ -0,0 +1,109 @@
+// ABOUTME: Contact photo lookup and download
+// ABOUTME: Picks a contact's primary photo, tells custom photos from default avatars, and fetches the image
+
+package people
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"google.golang.org/api/people/v1"
+)
+
+// maxPhotoBytes bounds a downloaded contact photo
+const maxPhotoBytes = 5 << 20
+
+// photoHostSuffix is the domain Google serves contact photos from
+const photoHostSuffix = ".googleusercontent.com"
+
+// photoClient fetches contact photos. It is deliberately not the OAuth client:
+// photo URLs are public, and the bearer token must never leave Google's APIs.
+var photoClient = &http.Client{Timeout: 30 * time.Second}
+
+// ContactPhoto is a contact's primary photo
+type ContactPhoto struct {
+	URL           string `json:"url"`
+	Default       bool   `json:"default"`                  // A generated letter avatar or silhouette, not a photo someone uploaded
+	MIMEType      string `json:"mime_type,omitempty"`      // Set once downloaded
+	ContentBase64 string `json:"content_base64,omitempty"` // Set once downloaded
+}
+
+// PrimaryPhoto returns the photo marked primary, falling back to the first, or nil if person has none
+func PrimaryPhoto(person *people.Person) *ContactPhoto {
+	var chosen *people.Photo
+	for _, photo := range person.Photos {
+		if photo.Url == "" {
+			continue
+		}
+		if chosen == nil {
+			chosen = photo
+		}
+		if photo.Metadata != nil && photo.Metadata.Primary {
+			chosen = photo
+			break
+		}
+	}
+	if chosen == nil {
+		return nil
+	}
+	return &ContactPhoto{URL: chosen.Url, Default: chosen.Default}
+}
+
+// DownloadPhoto fetches photo's image into its MIMEType and ContentBase64.
+// Default avatars carry no information about the contact and are refused, as
+// are URLs outside Google's photo CDN.
+func (s *Service) DownloadPhoto(ctx context.Context, photo *ContactPhoto) error {
+	if photo.Default {
+		return fmt.Errorf("contact has no custom photo")
+	}
+	if !s.photoURLAllowed(photo.URL) {
+		return fmt.Errorf("unable to download photo: %q is not a Google contact photo URL", photo.URL)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, photo.URL, nil)
+	if err != nil {
+		return fmt.Errorf("unable to download photo: %w", err)
+	}
+	resp, err := photoClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("unable to download photo: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unable to download photo: %s", resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxPhotoBytes+1))
+	if err != nil {
+		return fmt.Errorf("unable to download photo: %w", err)
+	}
+	if len(data) > maxPhotoBytes {
+		return fmt.Errorf("photo is larger than %d bytes", maxPhotoBytes)
+	}
+
+	photo.MIMEType = resp.Header.Get("Content-Type")
+	if photo.MIMEType == "" {
+		photo.MIMEType = http.DetectContentType(data)
+	}
+	photo.ContentBase64 = base64.StdEncoding.EncodeToString(data)
+	return nil
+}
+
+// photoURLAllowed reports whether rawURL is an https URL on Google's photo CDN,
+// or on the fake API's host in ish mode
+func (s *Service) photoURLAllowed(rawURL string) bool {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+	if s.ishHost != "" && u.Host == s.ishHost {
+		return true
+	}
+	return u.Scheme == "https" && strings.HasSuffix(u.Hostname(), photoHostSuffix)
+}
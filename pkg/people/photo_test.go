@@ -0,0 +1,82 @@
+// ABOUTME: Tests for contact photo lookup and download
+// ABOUTME: Verifies primary photo selection, the default-avatar flag, download limits, and the photo host allowlist
+
+package people
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/people/v1"
+)
+
+func TestPrimaryPhoto(t *testing.T) {
+	assert.Nil(t, PrimaryPhoto(&people.Person{}))
+
+	person := &people.Person{Photos: []*people.Photo{
+		{Url: "https://lh3.example.com/contact", Metadata: &people.FieldMetadata{Source: &people.Source{Type: "CONTACT"}}},
+		{Url: "https://lh3.example.com/profile", Metadata: &people.FieldMetadata{Primary: true}},
+	}}
+	assert.Equal(t, &ContactPhoto{URL: "https://lh3.example.com/profile"}, PrimaryPhoto(person))
+
+	avatar := &people.Person{Photos: []*people.Photo{{Url: "https://lh3.example.com/letter-j", Default: true}}}
+	assert.Equal(t, &ContactPhoto{URL: "https://lh3.example.com/letter-j", Default: true}, PrimaryPhoto(avatar))
+}
+
+func TestDownloadPhoto(t *testing.T) {
+	png := []byte("\x89PNG\r\n\x1a\nfake")
+	var authorization string
+	photos := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		if r.URL.Path == "/missing" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "image/png")
+		_, _ = w.Write(png)
+	}))
+	defer photos.Close()
+
+	t.Setenv("ISH_MODE", "true")
+	t.Setenv("ISH_BASE_URL", photos.URL)
+	svc, err := NewService(context.Background(), &http.Client{Transport: bearerTransport{}})
+	require.NoError(t, err)
+
+	photo := &ContactPhoto{URL: photos.URL + "/photo"}
+	require.NoError(t, svc.DownloadPhoto(context.Background(), photo))
+	assert.Empty(t, authorization, "the API client's token isn't sent to the photo host")
+	assert.Equal(t, "image/png", photo.MIMEType)
+	assert.Equal(t, base64.StdEncoding.EncodeToString(png), photo.ContentBase64)
+
+	err = svc.DownloadPhoto(context.Background(), &ContactPhoto{URL: photos.URL + "/missing"})
+	assert.ErrorContains(t, err, "404")
+
+	err = svc.DownloadPhoto(context.Background(), &ContactPhoto{URL: photos.URL + "/photo", Default: true})
+	assert.ErrorContains(t, err, "no custom photo")
+}
+
+// bearerTransport stands in for the OAuth client by adding a token to every request
+type bearerTransport struct{}
+
+func (bearerTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	r = r.Clone(r.Context())
+	r.Header.Set("Authorization", "Bearer secret")
+	return http.DefaultTransport.RoundTrip(r)
+}
+
+func TestPhotoURLAllowed(t *testing.T) {
+	svc := &Service{}
+	assert.True(t, svc.photoURLAllowed("https://lh3.googleusercontent.com/contacts/abc"))
+	assert.False(t, svc.photoURLAllowed("http://lh3.googleusercontent.com/contacts/abc"), "https only")
+	assert.False(t, svc.photoURLAllowed("https://evil.example.com/photo"))
+	assert.False(t, svc.photoURLAllowed("https://googleusercontent.com.evil.example.com/photo"))
+	assert.False(t, svc.photoURLAllowed("https://127.0.0.1:9000/photo"))
+
+	err := svc.DownloadPhoto(context.Background(), &ContactPhoto{URL: "https://evil.example.com/photo"})
+	assert.ErrorContains(t, err, "not a Google contact photo URL")
+}
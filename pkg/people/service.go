@@ -7,9 +7,13 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"net/url"
 	"os"
-	"time"
+	"regexp"
+	"sort"
+	"strings"
 
+	"github.com/harper/gsuite-mcp/pkg/apierr"
 	"github.com/harper/gsuite-mcp/pkg/retry"
 	"google.golang.org/api/option"
 	"google.golang.org/api/people/v1"
@@ -17,12 +21,15 @@ import (
 
 // Service wraps People API operations
 type Service struct {
-	svc *people.Service
+	svc     *people.Service
+	breaker *retry.Breaker // Fails calls fast while the API is down
+	ishHost string         // Photo host allowed in ish mode, where the fake API serves photos
 }
 
 // NewService creates a new People service
 func NewService(ctx context.Context, client *http.Client) (*Service, error) {
 	opts := []option.ClientOption{}
+	var ishHost string
 
 	// Check for ish mode
 	if os.Getenv("ISH_MODE") == "true" {
@@ -32,6 +39,9 @@ func NewService(ctx context.Context, client *http.Client) (*Service, error) {
 		}
 		opts = append(opts, option.WithEndpoint(baseURL))
 		opts = append(opts, option.WithoutAuthentication())
+		if u, err := url.Parse(baseURL); err == nil {
+			ishHost = u.Host
+		}
 	}
 
 	if client != nil {
@@ -43,23 +53,36 @@ func NewService(ctx context.Context, client *http.Client) (*Service, error) {
 		return nil, fmt.Errorf("unable to create People service: %w", err)
 	}
 
-	return &Service{svc: svc}, nil
+	return &Service{svc: svc, breaker: retry.NewBreaker(breakerName, retry.DefaultBreakerPolicy()), ishHost: ishHost}, nil
+}
+
+// breakerName prefixes the error returned while the circuit is open
+const breakerName = "People API"
+
+// SetBreakerPolicy replaces the circuit breaker shared by this service's calls
+func (s *Service) SetBreakerPolicy(policy retry.BreakerPolicy) {
+	s.breaker = retry.NewBreaker(breakerName, policy)
 }
 
-// ListContacts lists contacts from the user's contact list
-func (s *Service) ListContacts(ctx context.Context, pageSize int64) ([]*people.Person, error) {
+// ListContacts lists contacts from the user's contact list.
+// readMask selects the person fields to return; empty uses ListReadMask.
+func (s *Service) ListContacts(ctx context.Context, pageSize int64, readMask string) ([]*people.Person, error) {
 	var result *people.ListConnectionsResponse
 
-	err := retry.WithRetry(func() error {
+	if readMask == "" {
+		readMask = ListReadMask
+	}
+
+	err := s.breaker.Do(func() error {
 		call := s.svc.People.Connections.List("people/me").
 			Context(ctx).
-			PersonFields("names,emailAddresses,phoneNumbers").
+			PersonFields(readMask).
 			PageSize(pageSize)
 
 		var err error
 		result, err = call.Do()
 		return err
-	}, 3, time.Second)
+	})
 
 	if err != nil {
 		return nil, fmt.Errorf("unable to list contacts: %w", err)
@@ -68,21 +91,103 @@ func (s *Service) ListContacts(ctx context.Context, pageSize int64) ([]*people.P
 	return result.Connections, nil
 }
 
-// SearchContacts searches for contacts matching the query
-func (s *Service) SearchContacts(ctx context.Context, query string, pageSize int64) ([]*people.Person, error) {
+// Default read masks. Every extra person field adds payload for each contact
+// returned, so list and search fetch only what a picker needs; callers that
+// want a detail view pass a wider mask or use GetPerson.
+const (
+	// ListReadMask is the set of person fields returned by ListContacts
+	ListReadMask = "names,emailAddresses"
+
+	// SearchReadMask is the set of person fields returned by SearchContacts.
+	// Search matches phone numbers, so they're included to show why a contact matched.
+	SearchReadMask = "names,emailAddresses,phoneNumbers"
+
+	// DetailReadMask is the set of person fields returned by GetPerson
+	DetailReadMask = "names,emailAddresses,phoneNumbers,addresses,organizations,biographies,relations,photos"
+)
+
+// validPersonFields are the field names accepted in a People API read mask
+var validPersonFields = map[string]bool{
+	"addresses": true, "ageRanges": true, "biographies": true, "birthdays": true,
+	"calendarUrls": true, "clientData": true, "coverPhotos": true, "emailAddresses": true,
+	"events": true, "externalIds": true, "genders": true, "imClients": true,
+	"interests": true, "locales": true, "locations": true, "memberships": true,
+	"metadata": true, "miscKeywords": true, "names": true, "nicknames": true,
+	"occupations": true, "organizations": true, "phoneNumbers": true, "photos": true,
+	"relations": true, "sipAddresses": true, "skills": true, "urls": true,
+	"userDefined": true,
+}
+
+// NormalizeReadMask validates a comma-separated list of person fields and
+// returns it with whitespace and empty entries removed
+func NormalizeReadMask(fields string) (string, error) {
+	var normalized []string
+	for _, field := range strings.Split(fields, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		if !validPersonFields[field] {
+			return "", fmt.Errorf("unknown person field %q", field)
+		}
+		normalized = append(normalized, field)
+	}
+	if len(normalized) == 0 {
+		return "", fmt.Errorf("read mask must include at least one person field")
+	}
+	return strings.Join(normalized, ","), nil
+}
+
+// SortByName orders contacts alphabetically by display name (case-insensitive).
+// Contacts without a name sort last.
+func SortByName(contacts []*people.Person) {
+	sort.SliceStable(contacts, func(i, j int) bool {
+		return lessByName(contacts[i], contacts[j])
+	})
+}
+
+// lessByName compares display names case-insensitively, putting unnamed people last
+func lessByName(x, y *people.Person) bool {
+	a, b := strings.ToLower(displayName(x)), strings.ToLower(displayName(y))
+	if a == "" || b == "" {
+		return a != "" && b == ""
+	}
+	return a < b
+}
+
+// displayName returns the best available name for a person
+func displayName(person *people.Person) string {
+	if len(person.Names) == 0 {
+		return ""
+	}
+	name := person.Names[0]
+	if name.DisplayName != "" {
+		return name.DisplayName
+	}
+	return strings.TrimSpace(name.GivenName + " " + name.FamilyName)
+}
+
+// SearchContacts searches for contacts matching the query.
+// readMask selects the person fields to return; empty uses SearchReadMask.
+// Unlike listing, the search endpoint rejects requests without a read mask.
+func (s *Service) SearchContacts(ctx context.Context, query string, pageSize int64, readMask string) ([]*people.Person, error) {
 	var result *people.SearchResponse
 
-	err := retry.WithRetry(func() error {
+	if readMask == "" {
+		readMask = SearchReadMask
+	}
+
+	err := s.breaker.Do(func() error {
 		call := s.svc.People.SearchContacts().
 			Context(ctx).
 			Query(query).
-			ReadMask("names,emailAddresses,phoneNumbers").
+			ReadMask(readMask).
 			PageSize(pageSize)
 
 		var err error
 		result, err = call.Do()
 		return err
-	}, 3, time.Second)
+	})
 
 	if err != nil {
 		return nil, fmt.Errorf("unable to search contacts: %w", err)
@@ -99,21 +204,40 @@ func (s *Service) SearchContacts(ctx context.Context, query string, pageSize int
 	return contacts, nil
 }
 
+// FindContactByEmail returns the contact with exactly this email address
+// (case-insensitive), or nil if there is none
+func (s *Service) FindContactByEmail(ctx context.Context, email string) (*people.Person, error) {
+	contacts, err := s.SearchContacts(ctx, email, 10, "names,emailAddresses")
+	if err != nil {
+		return nil, err
+	}
+
+	// Search matches prefixes of names and emails, so confirm the exact address
+	for _, contact := range contacts {
+		for _, address := range contact.EmailAddresses {
+			if strings.EqualFold(address.Value, email) {
+				return contact, nil
+			}
+		}
+	}
+	return nil, nil
+}
+
 // GetPerson retrieves a specific person by resource name
 func (s *Service) GetPerson(ctx context.Context, resourceName string) (*people.Person, error) {
 	var person *people.Person
 
-	err := retry.WithRetry(func() error {
+	err := s.breaker.Do(func() error {
 		var err error
 		person, err = s.svc.People.Get(resourceName).
 			Context(ctx).
-			PersonFields("names,emailAddresses,phoneNumbers,addresses,organizations").
+			PersonFields(DetailReadMask).
 			Do()
 		return err
-	}, 3, time.Second)
+	})
 
 	if err != nil {
-		return nil, fmt.Errorf("unable to get person: %w", err)
+		return nil, apierr.Wrap(err, "unable to get person", "contact", resourceName)
 	}
 	return person, nil
 }
@@ -122,14 +246,14 @@ func (s *Service) GetPerson(ctx context.Context, resourceName string) (*people.P
 func (s *Service) CreateContact(ctx context.Context, person *people.Person) (*people.Person, error) {
 	var created *people.Person
 
-	err := retry.WithRetry(func() error {
+	err := s.breaker.Do(func() error {
 		var err error
 		created, err = s.svc.People.CreateContact(person).Context(ctx).Do()
 		return err
-	}, 3, time.Second)
+	})
 
 	if err != nil {
-		return nil, fmt.Errorf("unable to create contact: %w", err)
+		return nil, fmt.Errorf("unable to create contact: %w", apierr.ClassifyScopeError(err, people.ContactsScope))
 	}
 
 	return created, nil
@@ -139,31 +263,50 @@ func (s *Service) CreateContact(ctx context.Context, person *people.Person) (*pe
 func (s *Service) UpdateContact(ctx context.Context, resourceName string, person *people.Person, updateMask string) (*people.Person, error) {
 	var updated *people.Person
 
-	err := retry.WithRetry(func() error {
+	err := s.breaker.Do(func() error {
 		var err error
 		updated, err = s.svc.People.UpdateContact(resourceName, person).
 			Context(ctx).
 			UpdatePersonFields(updateMask).
 			Do()
 		return err
-	}, 3, time.Second)
+	})
 
 	if err != nil {
-		return nil, fmt.Errorf("unable to update contact: %w", err)
+		return nil, apierr.Wrap(apierr.ClassifyScopeError(err, people.ContactsScope), "unable to update contact", "contact", resourceName)
 	}
 
 	return updated, nil
 }
 
+// contactResourceNamePattern matches contact resource names like people/c12345
+var contactResourceNamePattern = regexp.MustCompile(`^people/[A-Za-z0-9]+$`)
+
+// ValidateContactResourceName checks that resourceName identifies a single contact.
+// It rejects people/me (the authenticated user) and anything not shaped like people/<id>.
+func ValidateContactResourceName(resourceName string) error {
+	if resourceName == "people/me" {
+		return fmt.Errorf("resource name people/me refers to the authenticated user, not a contact")
+	}
+	if !contactResourceNamePattern.MatchString(resourceName) {
+		return fmt.Errorf("invalid resource name %q: expected people/<id>", resourceName)
+	}
+	return nil
+}
+
 // DeleteContact deletes a contact
 func (s *Service) DeleteContact(ctx context.Context, resourceName string) error {
-	err := retry.WithRetry(func() error {
+	if err := ValidateContactResourceName(resourceName); err != nil {
+		return err
+	}
+
+	err := s.breaker.Do(func() error {
 		_, callErr := s.svc.People.DeleteContact(resourceName).Context(ctx).Do()
 		return callErr
-	}, 3, time.Second)
+	})
 
 	if err != nil {
-		return fmt.Errorf("unable to delete contact: %w", err)
+		return apierr.Wrap(apierr.ClassifyScopeError(err, people.ContactsScope), "unable to delete contact", "contact", resourceName)
 	}
 
 	return nil
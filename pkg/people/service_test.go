@@ -5,10 +5,14 @@ package people
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
+	"github.com/harper/gsuite-mcp/pkg/apierr"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/api/people/v1"
 )
 
 func TestNewService_WithIshMode(t *testing.T) {
@@ -33,12 +37,49 @@ func TestNewService_WithoutIshMode(t *testing.T) {
 	_ = err
 }
 
+// maskRecorder serves empty list and search responses, recording the read mask each request sent
+func maskRecorder(t *testing.T, masks *[]string) *Service {
+	t.Helper()
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mask := r.URL.Query().Get("personFields")
+		if mask == "" {
+			mask = r.URL.Query().Get("readMask")
+		}
+		*masks = append(*masks, mask)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(api.Close)
+
+	t.Setenv("ISH_MODE", "true")
+	t.Setenv("ISH_BASE_URL", api.URL)
+
+	svc, err := NewService(context.Background(), nil)
+	require.NoError(t, err)
+	return svc
+}
+
 func TestService_ListContacts(t *testing.T) {
-	t.Skip("TODO: Implement with ish server")
+	var masks []string
+	svc := maskRecorder(t, &masks)
+
+	_, err := svc.ListContacts(context.Background(), 10, "")
+	require.NoError(t, err)
+	_, err = svc.ListContacts(context.Background(), 10, "names,emailAddresses,organizations,photos")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{ListReadMask, "names,emailAddresses,organizations,photos"}, masks)
 }
 
 func TestService_SearchContacts(t *testing.T) {
-	t.Skip("TODO: Implement with ish server")
+	var masks []string
+	svc := maskRecorder(t, &masks)
+
+	_, err := svc.SearchContacts(context.Background(), "jane", 10, "")
+	require.NoError(t, err)
+	_, err = svc.SearchContacts(context.Background(), "jane", 10, "names,birthdays")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{SearchReadMask, "names,birthdays"}, masks, "search always sends a read mask")
 }
 
 // TestNewService_EnvironmentConfig tests various environment configurations
@@ -74,3 +115,58 @@ func TestNewService_EnvironmentConfig(t *testing.T) {
 		assert.NotNil(t, svc2)
 	})
 }
+
+func TestNormalizeReadMask(t *testing.T) {
+	mask, err := NormalizeReadMask(" names , emailAddresses,,organizations ")
+	require.NoError(t, err)
+	assert.Equal(t, "names,emailAddresses,organizations", mask)
+
+	_, err = NormalizeReadMask("names,favoriteColor")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "favoriteColor")
+
+	_, err = NormalizeReadMask(" , ")
+	assert.Error(t, err)
+}
+
+func TestSortByName(t *testing.T) {
+	named := func(display, given string) *people.Person {
+		return &people.Person{Names: []*people.Name{{DisplayName: display, GivenName: given}}}
+	}
+	contacts := []*people.Person{
+		named("zoe Adams", ""),
+		{ResourceName: "people/nameless"},
+		named("", "Bob"),
+		named("alice Zed", ""),
+	}
+
+	SortByName(contacts)
+
+	assert.Equal(t, "alice Zed", contacts[0].Names[0].DisplayName)
+	assert.Equal(t, "Bob", contacts[1].Names[0].GivenName)
+	assert.Equal(t, "zoe Adams", contacts[2].Names[0].DisplayName)
+	assert.Equal(t, "people/nameless", contacts[3].ResourceName)
+}
+
+func TestGetPerson_NotFound(t *testing.T) {
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"error": {"code": 404, "message": "Requested entity was not found."}}`))
+	}))
+	defer api.Close()
+
+	t.Setenv("ISH_MODE", "true")
+	t.Setenv("ISH_BASE_URL", api.URL)
+
+	svc, err := NewService(context.Background(), nil)
+	require.NoError(t, err)
+
+	_, err = svc.GetPerson(context.Background(), "people/c404")
+	assert.ErrorIs(t, err, apierr.ErrNotFound)
+	assert.EqualError(t, err, "contact people/c404 not found")
+
+	var nf *apierr.NotFoundError
+	require.ErrorAs(t, err, &nf)
+	assert.Equal(t, "contact", nf.Kind)
+	assert.Equal(t, "people/c404", nf.ID)
+}
@@ -363,7 +363,7 @@ func TestListContacts_PaginationEdgeCases(t *testing.T) {
 			svc, err := NewService(context.Background(), nil)
 			require.NoError(t, err)
 
-			contacts, err := svc.ListContacts(context.Background(), tc.pageSize)
+			contacts, err := svc.ListContacts(context.Background(), tc.pageSize, "")
 
 			t.Logf("%s: contacts=%v, error=%v", tc.description, len(contacts), err)
 		})
@@ -424,7 +424,7 @@ func TestSearchContacts_PaginationEdgeCases(t *testing.T) {
 			svc, err := NewService(context.Background(), nil)
 			require.NoError(t, err)
 
-			contacts, err := svc.SearchContacts(context.Background(), tc.query, tc.pageSize)
+			contacts, err := svc.SearchContacts(context.Background(), tc.query, tc.pageSize, "")
 
 			t.Logf("%s: contacts=%v, error=%v", tc.description, len(contacts), err)
 		})
@@ -556,6 +556,35 @@ func TestDeleteContact_InvalidResourceNames(t *testing.T) {
 	}
 }
 
+func TestValidateContactResourceName(t *testing.T) {
+	tests := []struct {
+		resourceName string
+		valid        bool
+	}{
+		{"people/c12345", true},
+		{"people/12345", true},
+		{"people/me", false},
+		{"people/", false},
+		{"c12345", false},
+		{"contacts/c12345", false},
+		{"people/c12345!@#", false},
+		{"people/../admin", false},
+		{"people/c12345/extra", false},
+		{"", false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.resourceName, func(t *testing.T) {
+			err := ValidateContactResourceName(tc.resourceName)
+			if tc.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
 // TestContactOperations_NilContext tests that operations properly handle nil contexts
 func TestContactOperations_NilContext(t *testing.T) {
 	t.Setenv("ISH_MODE", "true")
@@ -568,7 +597,7 @@ func TestContactOperations_NilContext(t *testing.T) {
 	require.NoError(t, err)
 
 	t.Run("list with background context", func(t *testing.T) {
-		_, err := svc.ListContacts(context.Background(), 10)
+		_, err := svc.ListContacts(context.Background(), 10, "")
 		t.Logf("ListContacts with background context: error=%v", err)
 	})
 
@@ -593,7 +622,7 @@ func TestContactOperations_CanceledContext(t *testing.T) {
 		ctx, cancel := context.WithCancel(context.Background())
 		cancel() // Cancel immediately
 
-		_, err := svc.ListContacts(ctx, 10)
+		_, err := svc.ListContacts(ctx, 10, "")
 
 		// Should get context canceled error
 		assert.Error(t, err)
@@ -689,7 +718,7 @@ func TestService_ErrorHandling(t *testing.T) {
 	require.NoError(t, err)
 
 	t.Run("list contacts with unreachable server", func(t *testing.T) {
-		_, err := svc.ListContacts(context.Background(), 10)
+		_, err := svc.ListContacts(context.Background(), 10, "")
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "unable to list contacts")
 		t.Logf("Expected error with unreachable server: %v", err)
@@ -0,0 +1,263 @@
+// ABOUTME: vCard 3.0/4.0 export for People API contacts
+// ABOUTME: Serializes names, typed emails and phones, organization, addresses, and birthday
+
+package people
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"google.golang.org/api/people/v1"
+)
+
+// Supported vCard versions
+const (
+	VCard3 = "3.0"
+	VCard4 = "4.0"
+)
+
+// vcardPersonFields are the person fields GetPeople requests for export
+const vcardPersonFields = "names,emailAddresses,phoneNumbers,addresses,organizations,birthdays"
+
+// maxBatchGet is the People API limit on resource names per getBatchGet call
+const maxBatchGet = 200
+
+// vcardLineLength is the folding limit in octets, excluding the CRLF
+const vcardLineLength = 75
+
+// ValidateVCardVersion checks that version is one EncodeVCards supports
+func ValidateVCardVersion(version string) error {
+	if version != VCard3 && version != VCard4 {
+		return fmt.Errorf("version must be %s or %s (got %q)", VCard3, VCard4, version)
+	}
+	return nil
+}
+
+// GetPeople fetches several contacts in one call with the fields vCard export uses
+func (s *Service) GetPeople(ctx context.Context, resourceNames []string) ([]*people.Person, error) {
+	if len(resourceNames) == 0 {
+		return nil, fmt.Errorf("at least one resource name is required")
+	}
+	if len(resourceNames) > maxBatchGet {
+		return nil, fmt.Errorf("at most %d contacts can be fetched at once (got %d)", maxBatchGet, len(resourceNames))
+	}
+	for _, name := range resourceNames {
+		if err := ValidateContactResourceName(name); err != nil {
+			return nil, err
+		}
+	}
+
+	var result *people.GetPeopleResponse
+	err := s.breaker.Do(func() error {
+		var err error
+		result, err = s.svc.People.GetBatchGet().
+			Context(ctx).
+			ResourceNames(resourceNames...).
+			PersonFields(vcardPersonFields).
+			Do()
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to get people: %w", err)
+	}
+
+	persons := make([]*people.Person, 0, len(result.Responses))
+	for _, resp := range result.Responses {
+		if resp.Person == nil {
+			return nil, fmt.Errorf("unable to get person %s: not found", resp.RequestedResourceName)
+		}
+		persons = append(persons, resp.Person)
+	}
+	return persons, nil
+}
+
+// EncodeVCards serializes contacts as consecutive vCards of the given version
+func EncodeVCards(persons []*people.Person, version string) (string, error) {
+	if err := ValidateVCardVersion(version); err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	for _, person := range persons {
+		writeVCard(&b, person, version)
+	}
+	return b.String(), nil
+}
+
+// writeVCard appends one vCard with CRLF line endings
+func writeVCard(b *strings.Builder, person *people.Person, version string) {
+	line := func(name string, params []string, value string) {
+		prop := name
+		for _, p := range params {
+			prop += ";" + p
+		}
+		b.WriteString(foldLine(prop + ":" + value))
+		b.WriteString("\r\n")
+	}
+
+	line("BEGIN", nil, "VCARD")
+	line("VERSION", nil, version)
+
+	// FN is required in both versions; N is required in 3.0
+	fn := displayName(person)
+	var name *people.Name
+	if len(person.Names) > 0 {
+		name = person.Names[0]
+	}
+	if fn == "" && len(person.EmailAddresses) > 0 {
+		fn = person.EmailAddresses[0].Value
+	}
+	line("FN", nil, escapeValue(fn))
+	if name != nil {
+		line("N", nil, structured(name.FamilyName, name.GivenName, name.MiddleName, name.HonorificPrefix, name.HonorificSuffix))
+	} else if version == VCard3 {
+		line("N", nil, structured("", "", "", "", ""))
+	}
+
+	for _, email := range person.EmailAddresses {
+		line("EMAIL", typeParams(version, emailTypes(email.Type), version == VCard3), escapeValue(email.Value))
+	}
+	for _, phone := range person.PhoneNumbers {
+		line("TEL", typeParams(version, phoneTypes(phone.Type), false), escapeValue(phone.Value))
+	}
+	for _, org := range person.Organizations {
+		if org.Name != "" || org.Department != "" {
+			line("ORG", nil, structured(org.Name, org.Department))
+		}
+		if org.Title != "" {
+			line("TITLE", nil, escapeValue(org.Title))
+		}
+	}
+	for _, addr := range person.Addresses {
+		line("ADR", typeParams(version, addressTypes(addr.Type), false),
+			structured(addr.PoBox, addr.ExtendedAddress, addr.StreetAddress, addr.City, addr.Region, addr.PostalCode, addr.Country))
+	}
+	for _, birthday := range person.Birthdays {
+		if bday := formatBirthday(birthday.Date, version); bday != "" {
+			line("BDAY", nil, bday)
+			break
+		}
+	}
+	if person.ResourceName != "" {
+		line("UID", nil, escapeValue(person.ResourceName))
+	}
+
+	line("END", nil, "VCARD")
+}
+
+// escapeValue escapes backslashes, commas, semicolons, and newlines per RFC 6350 section 3.4
+func escapeValue(value string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		",", `\,`,
+		";", `\;`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	).Replace(value)
+}
+
+// structured joins escaped components with semicolons, as N, ORG, and ADR require
+func structured(components ...string) string {
+	escaped := make([]string, len(components))
+	for i, c := range components {
+		escaped[i] = escapeValue(c)
+	}
+	return strings.Join(escaped, ";")
+}
+
+// typeParams renders TYPE parameters: upper-case and comma-separated for 3.0
+// (with INTERNET on emails), lower-case for 4.0
+func typeParams(version string, types []string, internet bool) []string {
+	if internet {
+		types = append([]string{"internet"}, types...)
+	}
+	if len(types) == 0 {
+		return nil
+	}
+	if version == VCard3 {
+		return []string{"TYPE=" + strings.ToUpper(strings.Join(types, ","))}
+	}
+	return []string{"TYPE=" + strings.Join(types, ",")}
+}
+
+// emailTypes maps People API email types to vCard TYPE values
+func emailTypes(t string) []string {
+	switch strings.ToLower(t) {
+	case "home", "work":
+		return []string{strings.ToLower(t)}
+	}
+	return nil
+}
+
+// phoneTypes maps People API phone types to vCard TYPE values
+func phoneTypes(t string) []string {
+	switch strings.ToLower(t) {
+	case "home", "work":
+		return []string{strings.ToLower(t)}
+	case "mobile", "workmobile":
+		return []string{"cell"}
+	case "homefax":
+		return []string{"home", "fax"}
+	case "workfax", "otherfax":
+		return []string{"work", "fax"}
+	case "pager", "workpager":
+		return []string{"pager"}
+	}
+	return nil
+}
+
+// addressTypes maps People API address types to vCard TYPE values
+func addressTypes(t string) []string {
+	switch strings.ToLower(t) {
+	case "home", "work":
+		return []string{strings.ToLower(t)}
+	}
+	return nil
+}
+
+// formatBirthday renders a date as YYYY-MM-DD (3.0) or YYYYMMDD (4.0). A 4.0
+// birthday without a year uses the --MMDD form; 3.0 has no yearless form.
+func formatBirthday(d *people.Date, version string) string {
+	if d == nil || d.Month == 0 || d.Day == 0 {
+		return ""
+	}
+	if d.Year == 0 {
+		if version == VCard4 {
+			return fmt.Sprintf("--%02d%02d", d.Month, d.Day)
+		}
+		return ""
+	}
+	if version == VCard4 {
+		return fmt.Sprintf("%04d%02d%02d", d.Year, d.Month, d.Day)
+	}
+	return fmt.Sprintf("%04d-%02d-%02d", d.Year, d.Month, d.Day)
+}
+
+// foldLine splits lines longer than 75 octets, continuing with a leading space.
+// Splits never fall inside a multi-byte UTF-8 sequence.
+func foldLine(line string) string {
+	if len(line) <= vcardLineLength {
+		return line
+	}
+
+	var b strings.Builder
+	limit := vcardLineLength
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !isRuneStart(line[cut]) {
+			cut--
+		}
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n ")
+		line = line[cut:]
+		limit = vcardLineLength - 1 // the leading space counts toward the limit
+	}
+	b.WriteString(line)
+	return b.String()
+}
+
+// isRuneStart reports whether b begins a UTF-8 sequence
+func isRuneStart(b byte) bool {
+	return b&0xC0 != 0x80
+}
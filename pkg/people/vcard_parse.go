@@ -0,0 +1,429 @@
+// ABOUTME: Tolerant vCard 2.1/3.0/4.0 parser producing People API contacts
+// ABOUTME: Handles folded lines, quoted-printable values, and grouped or bare-type properties
+
+package people
+
+import (
+	"fmt"
+	"io"
+	"mime/quotedprintable"
+	"strconv"
+	"strings"
+
+	"google.golang.org/api/people/v1"
+)
+
+// ParsedVCard is one card from ParseVCards: the contact, or why it was skipped
+type ParsedVCard struct {
+	Index  int            // 1-based position of the card in the input
+	Person *people.Person // nil when Err is set
+	Err    error
+}
+
+// contentLine is one unfolded and decoded vCard property
+type contentLine struct {
+	Name      string   // Upper-case property name without its group prefix
+	Types     []string // Lower-case TYPE values, including v2.1 bare parameters
+	ValueType string   // Lower-case VALUE parameter, e.g. "text" or "uri"
+	Value     string   // Raw value, still escaped
+}
+
+// ParseVCards splits text into cards and converts each to a Person. A
+// malformed card is reported in its entry and parsing continues with the
+// next BEGIN:VCARD; text outside cards is ignored.
+func ParseVCards(text string) []ParsedVCard {
+	var cards []ParsedVCard
+	var current *cardBuilder
+
+	for _, line := range unfoldLines(text) {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		prop, err := parseContentLine(line)
+		if err != nil {
+			if current != nil && current.err == nil {
+				current.err = err
+			}
+			continue
+		}
+
+		switch {
+		case prop.Name == "BEGIN" && strings.EqualFold(prop.Value, "VCARD"):
+			if current != nil {
+				cards = append(cards, current.finish(fmt.Errorf("missing END:VCARD")))
+			}
+			current = &cardBuilder{index: len(cards) + 1, person: &people.Person{}}
+		case prop.Name == "END" && strings.EqualFold(prop.Value, "VCARD"):
+			if current != nil {
+				cards = append(cards, current.finish(nil))
+				current = nil
+			}
+		case current != nil:
+			current.add(prop)
+		}
+	}
+	if current != nil {
+		cards = append(cards, current.finish(fmt.Errorf("missing END:VCARD")))
+	}
+	return cards
+}
+
+// unfoldLines joins folded continuation lines (leading space or tab) and
+// quoted-printable soft line breaks (trailing "=")
+func unfoldLines(text string) []string {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	text = strings.ReplaceAll(text, "\r", "\n")
+
+	var lines []string
+	for _, line := range strings.Split(text, "\n") {
+		if n := len(lines); n > 0 {
+			last := lines[n-1]
+			if isQuotedPrintable(last) && strings.HasSuffix(last, "=") {
+				lines[n-1] = strings.TrimSuffix(last, "=") + line
+				continue
+			}
+			if strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t") {
+				lines[n-1] = last + line[1:]
+				continue
+			}
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// isQuotedPrintable reports whether a content line declares quoted-printable encoding
+func isQuotedPrintable(line string) bool {
+	head, _, ok := strings.Cut(line, ":")
+	return ok && strings.Contains(strings.ToUpper(head), "QUOTED-PRINTABLE")
+}
+
+// parseContentLine splits "group.NAME;PARAM=x:value" and decodes quoted-printable values
+func parseContentLine(line string) (contentLine, error) {
+	colon := indexUnquoted(line, ':')
+	if colon < 0 {
+		return contentLine{}, fmt.Errorf("malformed line %q: missing ':'", truncate(line, 40))
+	}
+
+	params := splitUnquoted(line[:colon], ';')
+	name := strings.ToUpper(strings.TrimSpace(params[0]))
+	if dot := strings.LastIndex(name, "."); dot >= 0 {
+		name = name[dot+1:]
+	}
+	if name == "" {
+		return contentLine{}, fmt.Errorf("malformed line %q: missing property name", truncate(line, 40))
+	}
+
+	prop := contentLine{Name: name, Value: line[colon+1:]}
+	var encoding string
+	for _, param := range params[1:] {
+		key, value, ok := strings.Cut(param, "=")
+		if !ok {
+			// vCard 2.1 allows bare types such as EMAIL;WORK;INTERNET
+			switch upper := strings.ToUpper(param); upper {
+			case "QUOTED-PRINTABLE", "BASE64":
+				encoding = upper
+			default:
+				prop.Types = append(prop.Types, strings.ToLower(param))
+			}
+			continue
+		}
+		value = strings.Trim(value, `"`)
+		switch strings.ToUpper(key) {
+		case "TYPE":
+			for _, t := range strings.Split(value, ",") {
+				if t = strings.TrimSpace(t); t != "" {
+					prop.Types = append(prop.Types, strings.ToLower(t))
+				}
+			}
+		case "VALUE":
+			prop.ValueType = strings.ToLower(value)
+		case "ENCODING":
+			encoding = strings.ToUpper(value)
+		}
+	}
+
+	if encoding == "QUOTED-PRINTABLE" {
+		decoded, err := io.ReadAll(quotedprintable.NewReader(strings.NewReader(prop.Value)))
+		if err != nil {
+			return contentLine{}, fmt.Errorf("invalid quoted-printable value for %s: %w", name, err)
+		}
+		prop.Value = string(decoded)
+	}
+	return prop, nil
+}
+
+// indexUnquoted returns the index of the first sep outside double quotes, or -1
+func indexUnquoted(s string, sep byte) int {
+	quoted := false
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '"':
+			quoted = !quoted
+		case sep:
+			if !quoted {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// splitUnquoted splits s on sep outside double quotes
+func splitUnquoted(s string, sep byte) []string {
+	var parts []string
+	for {
+		i := indexUnquoted(s, sep)
+		if i < 0 {
+			return append(parts, s)
+		}
+		parts = append(parts, s[:i])
+		s = s[i+1:]
+	}
+}
+
+// unescapeComponents splits a value on unescaped semicolons and reverses
+// backslash escaping in each component
+func unescapeComponents(value string) []string {
+	var components []string
+	var current strings.Builder
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		switch {
+		case c == '\\' && i+1 < len(value):
+			i++
+			if value[i] == 'n' || value[i] == 'N' {
+				current.WriteByte('\n')
+			} else {
+				current.WriteByte(value[i])
+			}
+		case c == ';':
+			components = append(components, strings.TrimSpace(current.String()))
+			current.Reset()
+		default:
+			current.WriteByte(c)
+		}
+	}
+	return append(components, strings.TrimSpace(current.String()))
+}
+
+// unescapeText reverses escaping in a single-valued property, keeping literal semicolons
+func unescapeText(value string) string {
+	return strings.Join(unescapeComponents(value), ";")
+}
+
+// component returns the i-th structured component, or "" if absent
+func component(components []string, i int) string {
+	if i < len(components) {
+		return components[i]
+	}
+	return ""
+}
+
+// hasType reports whether types contains t
+func hasType(types []string, t string) bool {
+	for _, v := range types {
+		if v == t {
+			return true
+		}
+	}
+	return false
+}
+
+// cardBuilder accumulates properties for one card
+type cardBuilder struct {
+	index  int
+	person *people.Person
+	fn     string
+	err    error
+}
+
+// add applies one property; unknown properties are ignored
+func (b *cardBuilder) add(prop contentLine) {
+	p := b.person
+	switch prop.Name {
+	case "FN":
+		b.fn = unescapeText(prop.Value)
+	case "N":
+		c := unescapeComponents(prop.Value)
+		name := &people.Name{
+			FamilyName:      component(c, 0),
+			GivenName:       component(c, 1),
+			MiddleName:      component(c, 2),
+			HonorificPrefix: component(c, 3),
+			HonorificSuffix: component(c, 4),
+		}
+		if strings.Join(c, "") != "" {
+			p.Names = []*people.Name{name}
+		}
+	case "EMAIL":
+		value := strings.TrimPrefix(unescapeText(prop.Value), "mailto:")
+		if value != "" {
+			p.EmailAddresses = append(p.EmailAddresses, &people.EmailAddress{Value: value, Type: emailTypeFromVCard(prop.Types)})
+		}
+	case "TEL":
+		value := strings.TrimPrefix(unescapeText(prop.Value), "tel:")
+		if value != "" {
+			p.PhoneNumbers = append(p.PhoneNumbers, &people.PhoneNumber{Value: value, Type: phoneTypeFromVCard(prop.Types)})
+		}
+	case "ORG":
+		c := unescapeComponents(prop.Value)
+		org := b.organization(func(o *people.Organization) bool { return o.Name == "" && o.Department == "" })
+		org.Name, org.Department = component(c, 0), component(c, 1)
+	case "TITLE":
+		org := b.organization(func(o *people.Organization) bool { return o.Title == "" })
+		org.Title = unescapeText(prop.Value)
+	case "ADR":
+		c := unescapeComponents(prop.Value)
+		p.Addresses = append(p.Addresses, &people.Address{
+			Type:            addressTypeFromVCard(prop.Types),
+			PoBox:           component(c, 0),
+			ExtendedAddress: component(c, 1),
+			StreetAddress:   component(c, 2),
+			City:            component(c, 3),
+			Region:          component(c, 4),
+			PostalCode:      component(c, 5),
+			Country:         component(c, 6),
+		})
+	case "BDAY":
+		// vCard 4.0 allows free-text birthdays ("circa 1800"), which have no Date form
+		if prop.ValueType == "text" {
+			return
+		}
+		date, err := parseBirthday(prop.Value)
+		if err != nil {
+			if b.err == nil {
+				b.err = err
+			}
+			return
+		}
+		p.Birthdays = []*people.Birthday{{Date: date}}
+	}
+}
+
+// organization returns the last organization if it still has room for the
+// field being set, otherwise appends a new one, so ORG and TITLE pair up in either order
+func (b *cardBuilder) organization(hasRoom func(*people.Organization) bool) *people.Organization {
+	orgs := b.person.Organizations
+	if n := len(orgs); n > 0 && hasRoom(orgs[n-1]) {
+		return orgs[n-1]
+	}
+	org := &people.Organization{}
+	b.person.Organizations = append(orgs, org)
+	return org
+}
+
+// finish returns the card's entry, preferring err over any error recorded while parsing
+func (b *cardBuilder) finish(err error) ParsedVCard {
+	if err == nil {
+		err = b.err
+	}
+	if err == nil && len(b.person.Names) == 0 && b.fn != "" {
+		b.person.Names = []*people.Name{{UnstructuredName: b.fn}}
+	}
+	if err == nil && len(b.person.Names) == 0 && len(b.person.EmailAddresses) == 0 && len(b.person.PhoneNumbers) == 0 {
+		err = fmt.Errorf("card has no name, email, or phone")
+	}
+	if err != nil {
+		return ParsedVCard{Index: b.index, Err: err}
+	}
+	return ParsedVCard{Index: b.index, Person: b.person}
+}
+
+// emailTypeFromVCard maps vCard TYPE values to a People API email type
+func emailTypeFromVCard(types []string) string {
+	switch {
+	case hasType(types, "work"):
+		return "work"
+	case hasType(types, "home"):
+		return "home"
+	}
+	return "other"
+}
+
+// phoneTypeFromVCard maps vCard TYPE values to a People API phone type
+func phoneTypeFromVCard(types []string) string {
+	switch {
+	case hasType(types, "fax") && hasType(types, "home"):
+		return "homeFax"
+	case hasType(types, "fax") && hasType(types, "work"):
+		return "workFax"
+	case hasType(types, "fax"):
+		return "otherFax"
+	case hasType(types, "cell"):
+		return "mobile"
+	case hasType(types, "pager"):
+		return "pager"
+	case hasType(types, "work"):
+		return "work"
+	case hasType(types, "home"):
+		return "home"
+	}
+	return "other"
+}
+
+// addressTypeFromVCard maps vCard TYPE values to a People API address type
+func addressTypeFromVCard(types []string) string {
+	switch {
+	case hasType(types, "work"):
+		return "work"
+	case hasType(types, "home"):
+		return "home"
+	}
+	return "other"
+}
+
+// parseBirthday accepts YYYY-MM-DD, YYYYMMDD, --MMDD, and --MM-DD, ignoring any time part
+func parseBirthday(value string) (*people.Date, error) {
+	value = strings.TrimSpace(value)
+	if t := strings.IndexByte(value, 'T'); t >= 0 {
+		value = value[:t]
+	}
+
+	var year, rest string
+	if strings.HasPrefix(value, "--") {
+		rest = value[2:]
+	} else {
+		digits := strings.ReplaceAll(value, "-", "")
+		if len(digits) != 8 {
+			return nil, fmt.Errorf("invalid BDAY %q", value)
+		}
+		year, rest = digits[:4], digits[4:]
+	}
+	rest = strings.ReplaceAll(rest, "-", "")
+	if len(rest) != 4 {
+		return nil, fmt.Errorf("invalid BDAY %q", value)
+	}
+
+	date := &people.Date{}
+	var err error
+	if year != "" {
+		if date.Year, err = parseDatePart(year, 1, 9999); err != nil {
+			return nil, fmt.Errorf("invalid BDAY %q", value)
+		}
+	}
+	if date.Month, err = parseDatePart(rest[:2], 1, 12); err != nil {
+		return nil, fmt.Errorf("invalid BDAY %q", value)
+	}
+	if date.Day, err = parseDatePart(rest[2:], 1, 31); err != nil {
+		return nil, fmt.Errorf("invalid BDAY %q", value)
+	}
+	return date, nil
+}
+
+// parseDatePart parses a numeric date field within [min, max]
+func parseDatePart(s string, min, max int64) (int64, error) {
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || n < min || n > max {
+		return 0, fmt.Errorf("out of range")
+	}
+	return n, nil
+}
+
+// truncate shortens s for error messages
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n] + "..."
+}
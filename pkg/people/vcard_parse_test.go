@@ -0,0 +1,161 @@
+// ABOUTME: Tests for the vCard parser
+// ABOUTME: Covers multi-card input, folding, quoted-printable, v2.1/v4 forms, and malformed cards
+
+package people
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/people/v1"
+)
+
+func TestParseVCards_MultipleContacts(t *testing.T) {
+	input := strings.Join([]string{
+		"BEGIN:VCARD",
+		"VERSION:3.0",
+		"FN:Jane Doe",
+		"N:Doe;Jane;;;",
+		"EMAIL;TYPE=INTERNET,WORK:jane@work.example.com",
+		"item1.EMAIL;TYPE=INTERNET;TYPE=HOME:jane@home.example.com",
+		"TEL;TYPE=CELL:+1 555 0100",
+		"ORG:Acme\\, Inc.;Research",
+		"TITLE:Chief Scientist",
+		"ADR;TYPE=WORK:;;1 Main St;Springfield;IL;62701;USA",
+		"NOTE:this is a long note that was folded",
+		"  across two lines",
+		"BDAY:1980-04-02",
+		"END:VCARD",
+		"BEGIN:VCARD",
+		"VERSION:4.0",
+		"FN:John Smith",
+		"EMAIL;TYPE=home:john@example.com",
+		"TEL;VALUE=uri;TYPE=\"voice,work\":tel:+1-555-0199",
+		"BDAY:--0714",
+		"END:VCARD",
+	}, "\r\n")
+
+	cards := ParseVCards(input)
+	require.Len(t, cards, 2)
+
+	jane := cards[0]
+	require.NoError(t, jane.Err)
+	assert.Equal(t, 1, jane.Index)
+	require.Len(t, jane.Person.Names, 1)
+	assert.Equal(t, "Jane", jane.Person.Names[0].GivenName)
+	assert.Equal(t, "Doe", jane.Person.Names[0].FamilyName)
+	require.Len(t, jane.Person.EmailAddresses, 2)
+	assert.Equal(t, &people.EmailAddress{Value: "jane@work.example.com", Type: "work"}, jane.Person.EmailAddresses[0])
+	assert.Equal(t, &people.EmailAddress{Value: "jane@home.example.com", Type: "home"}, jane.Person.EmailAddresses[1])
+	assert.Equal(t, "mobile", jane.Person.PhoneNumbers[0].Type)
+	assert.Equal(t, &people.Organization{Name: "Acme, Inc.", Department: "Research", Title: "Chief Scientist"}, jane.Person.Organizations[0])
+	assert.Equal(t, "1 Main St", jane.Person.Addresses[0].StreetAddress)
+	assert.Equal(t, "work", jane.Person.Addresses[0].Type)
+	assert.Equal(t, &people.Date{Year: 1980, Month: 4, Day: 2}, jane.Person.Birthdays[0].Date)
+
+	john := cards[1]
+	require.NoError(t, john.Err)
+	assert.Equal(t, "John Smith", john.Person.Names[0].UnstructuredName)
+	assert.Equal(t, "home", john.Person.EmailAddresses[0].Type)
+	assert.Equal(t, &people.PhoneNumber{Value: "+1-555-0199", Type: "work"}, john.Person.PhoneNumbers[0])
+	assert.Equal(t, &people.Date{Month: 7, Day: 14}, john.Person.Birthdays[0].Date)
+}
+
+func TestParseVCards_Version21QuotedPrintable(t *testing.T) {
+	input := "BEGIN:VCARD\n" +
+		"VERSION:2.1\n" +
+		"N;CHARSET=UTF-8;ENCODING=QUOTED-PRINTABLE:M=C3=BCller;J=C3=\n" +
+		"=B6rg\n" +
+		"EMAIL;WORK;INTERNET:jorg@example.de\n" +
+		"END:VCARD\n"
+
+	cards := ParseVCards(input)
+	require.Len(t, cards, 1)
+	require.NoError(t, cards[0].Err)
+	assert.Equal(t, "Müller", cards[0].Person.Names[0].FamilyName)
+	assert.Equal(t, "Jörg", cards[0].Person.Names[0].GivenName)
+	assert.Equal(t, "work", cards[0].Person.EmailAddresses[0].Type)
+}
+
+func TestParseVCards_MalformedCardsSkipped(t *testing.T) {
+	input := strings.Join([]string{
+		"BEGIN:VCARD",
+		"FN:No Colon Problem",
+		"this line has no colon",
+		"END:VCARD",
+		"BEGIN:VCARD",
+		"FN:Bad Birthday",
+		"BDAY:sometime",
+		"END:VCARD",
+		"BEGIN:VCARD",
+		"NOTE:nothing identifying",
+		"END:VCARD",
+		"BEGIN:VCARD",
+		"FN:Good",
+		"EMAIL:good@example.com",
+		"END:VCARD",
+		"BEGIN:VCARD",
+		"FN:Truncated",
+	}, "\n")
+
+	cards := ParseVCards(input)
+	require.Len(t, cards, 5)
+
+	assert.ErrorContains(t, cards[0].Err, "missing ':'")
+	assert.ErrorContains(t, cards[1].Err, "invalid BDAY")
+	assert.ErrorContains(t, cards[2].Err, "no name, email, or phone")
+	require.NoError(t, cards[3].Err)
+	assert.Equal(t, "good@example.com", cards[3].Person.EmailAddresses[0].Value)
+	assert.ErrorContains(t, cards[4].Err, "missing END:VCARD")
+	assert.Nil(t, cards[4].Person)
+}
+
+func TestParseVCards_RoundTripsExport(t *testing.T) {
+	original := testPerson()
+	for _, version := range []string{VCard3, VCard4} {
+		t.Run(version, func(t *testing.T) {
+			text, err := EncodeVCards([]*people.Person{original}, version)
+			require.NoError(t, err)
+
+			cards := ParseVCards(text)
+			require.Len(t, cards, 1)
+			require.NoError(t, cards[0].Err)
+
+			parsed := cards[0].Person
+			assert.Equal(t, original.EmailAddresses, parsed.EmailAddresses)
+			assert.Equal(t, original.PhoneNumbers, parsed.PhoneNumbers)
+			assert.Equal(t, original.Organizations, parsed.Organizations)
+			assert.Equal(t, original.Addresses[0].StreetAddress, parsed.Addresses[0].StreetAddress)
+			assert.Equal(t, original.Birthdays[0].Date, parsed.Birthdays[0].Date)
+		})
+	}
+}
+
+func TestParseBirthday(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected *people.Date
+	}{
+		{"1980-04-02", &people.Date{Year: 1980, Month: 4, Day: 2}},
+		{"19800402", &people.Date{Year: 1980, Month: 4, Day: 2}},
+		{"19800402T120000Z", &people.Date{Year: 1980, Month: 4, Day: 2}},
+		{"--0402", &people.Date{Month: 4, Day: 2}},
+		{"--04-02", &people.Date{Month: 4, Day: 2}},
+		{"1980-13-02", nil},
+		{"April 2", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			date, err := parseBirthday(tt.input)
+			if tt.expected == nil {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, date)
+		})
+	}
+}
@@ -0,0 +1,147 @@
+// ABOUTME: Tests for vCard export
+// ABOUTME: Parses the encoded output back to verify escaping, folding, and typed properties round-trip
+
+package people
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/people/v1"
+)
+
+// vcardProperty is one parsed content line
+type vcardProperty struct {
+	Name   string
+	Params string
+	Value  string
+}
+
+// parseVCard unfolds lines and splits each into name, params, and value
+func parseVCard(t *testing.T, text string) []vcardProperty {
+	t.Helper()
+
+	require.True(t, strings.HasSuffix(text, "\r\n"), "vCard must end with CRLF")
+	unfolded := strings.ReplaceAll(text, "\r\n ", "")
+
+	var props []vcardProperty
+	for _, line := range strings.Split(strings.TrimSuffix(unfolded, "\r\n"), "\r\n") {
+		head, value, ok := strings.Cut(line, ":")
+		require.True(t, ok, "line %q has no value", line)
+		name, params, _ := strings.Cut(head, ";")
+		props = append(props, vcardProperty{Name: name, Params: params, Value: value})
+	}
+	return props
+}
+
+func testPerson() *people.Person {
+	return &people.Person{
+		ResourceName: "people/c123",
+		Names: []*people.Name{{
+			DisplayName: "Ada Lovelace, Countess",
+			GivenName:   "Ada",
+			FamilyName:  "Lovelace",
+		}},
+		EmailAddresses: []*people.EmailAddress{
+			{Value: "ada@work.example.com", Type: "work"},
+			{Value: "ada@home.example.com", Type: "home"},
+			{Value: "ada@other.example.com", Type: "other"},
+		},
+		PhoneNumbers: []*people.PhoneNumber{
+			{Value: "+1 555 0100", Type: "mobile"},
+			{Value: "+1 555 0101", Type: "workFax"},
+		},
+		Organizations: []*people.Organization{{Name: "Analytical Engines; Ltd", Title: "Mathematician"}},
+		Addresses: []*people.Address{{
+			Type:          "home",
+			StreetAddress: "12 St. James's Square\nFlat 2",
+			City:          "London",
+			PostalCode:    "SW1Y 4JH",
+			Country:       "UK",
+		}},
+		Birthdays: []*people.Birthday{{Date: &people.Date{Year: 1815, Month: 12, Day: 10}}},
+	}
+}
+
+func TestEncodeVCards_RoundTrip(t *testing.T) {
+	text, err := EncodeVCards([]*people.Person{testPerson()}, VCard3)
+	require.NoError(t, err)
+
+	props := parseVCard(t, text)
+	byName := make(map[string][]vcardProperty)
+	for _, p := range props {
+		byName[p.Name] = append(byName[p.Name], p)
+	}
+
+	assert.Equal(t, "BEGIN", props[0].Name)
+	assert.Equal(t, "END", props[len(props)-1].Name)
+	assert.Equal(t, "3.0", byName["VERSION"][0].Value)
+
+	assert.Equal(t, []string{"Ada Lovelace, Countess"}, unescapeComponents(byName["FN"][0].Value))
+	assert.Equal(t, []string{"Lovelace", "Ada", "", "", ""}, unescapeComponents(byName["N"][0].Value))
+
+	emails := byName["EMAIL"]
+	require.Len(t, emails, 3)
+	assert.Equal(t, "TYPE=INTERNET,WORK", emails[0].Params)
+	assert.Equal(t, "ada@work.example.com", emails[0].Value)
+	assert.Equal(t, "TYPE=INTERNET,HOME", emails[1].Params)
+	assert.Equal(t, "ada@home.example.com", emails[1].Value)
+	assert.Equal(t, "TYPE=INTERNET", emails[2].Params)
+
+	tels := byName["TEL"]
+	require.Len(t, tels, 2)
+	assert.Equal(t, "TYPE=CELL", tels[0].Params)
+	assert.Equal(t, "TYPE=WORK,FAX", tels[1].Params)
+
+	assert.Equal(t, []string{"Analytical Engines; Ltd", ""}, unescapeComponents(byName["ORG"][0].Value))
+	assert.Equal(t, "Mathematician", byName["TITLE"][0].Value)
+
+	adr := byName["ADR"][0]
+	assert.Equal(t, "TYPE=HOME", adr.Params)
+	assert.Equal(t, []string{"", "", "12 St. James's Square\nFlat 2", "London", "", "SW1Y 4JH", "UK"}, unescapeComponents(adr.Value))
+
+	assert.Equal(t, "1815-12-10", byName["BDAY"][0].Value)
+}
+
+func TestEncodeVCards_Version4(t *testing.T) {
+	person := testPerson()
+	person.Birthdays = []*people.Birthday{{Date: &people.Date{Month: 12, Day: 10}}}
+
+	text, err := EncodeVCards([]*people.Person{person}, VCard4)
+	require.NoError(t, err)
+
+	assert.Contains(t, text, "VERSION:4.0\r\n")
+	assert.Contains(t, text, "EMAIL;TYPE=work:ada@work.example.com\r\n")
+	assert.Contains(t, text, "TEL;TYPE=cell:+1 555 0100\r\n")
+	assert.Contains(t, text, "BDAY:--1210\r\n")
+}
+
+func TestEncodeVCards_Multiple(t *testing.T) {
+	other := &people.Person{EmailAddresses: []*people.EmailAddress{{Value: "grace@example.com"}}}
+
+	text, err := EncodeVCards([]*people.Person{testPerson(), other}, VCard3)
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, strings.Count(text, "BEGIN:VCARD\r\n"))
+	assert.Equal(t, 2, strings.Count(text, "END:VCARD\r\n"))
+	assert.Contains(t, text, "FN:grace@example.com\r\n")
+	assert.Contains(t, text, "N:;;;;\r\n")
+}
+
+func TestEncodeVCards_InvalidVersion(t *testing.T) {
+	_, err := EncodeVCards([]*people.Person{testPerson()}, "2.1")
+	assert.Error(t, err)
+}
+
+func TestFoldLine(t *testing.T) {
+	long := "NOTE:" + strings.Repeat("é", 60)
+	folded := foldLine(long)
+
+	for _, line := range strings.Split(folded, "\r\n") {
+		assert.LessOrEqual(t, len(line), vcardLineLength)
+	}
+	assert.Equal(t, long, strings.ReplaceAll(folded, "\r\n ", ""))
+	assert.Equal(t, "FN:short", foldLine("FN:short"))
+}
@@ -0,0 +1,155 @@
+// ABOUTME: Circuit breaker that stops retrying a service during a sustained outage
+// ABOUTME: Opens after consecutive rate-limit or server failures, fails fast for a cool-down, then probes
+
+package retry
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"google.golang.org/api/googleapi"
+)
+
+// ErrCircuitOpen is returned, wrapped, by calls rejected while a breaker is open
+var ErrCircuitOpen = errors.New("service temporarily unavailable, backing off")
+
+// BreakerPolicy controls when a Breaker opens and how long it stays open
+type BreakerPolicy struct {
+	Threshold int           // consecutive transient failures that open the circuit; 0 disables the breaker
+	Window    time.Duration // failures further apart than this don't count as consecutive
+	Cooldown  time.Duration // how long the circuit stays open before a probe call is let through
+}
+
+// DefaultBreakerThreshold, DefaultBreakerWindow, and DefaultBreakerCooldown are
+// the breaker policy services start with
+const (
+	DefaultBreakerThreshold = 5
+	DefaultBreakerWindow    = time.Minute
+	DefaultBreakerCooldown  = 30 * time.Second
+)
+
+// DefaultBreakerPolicy returns the policy built from the Default* constants
+func DefaultBreakerPolicy() BreakerPolicy {
+	return BreakerPolicy{
+		Threshold: DefaultBreakerThreshold,
+		Window:    DefaultBreakerWindow,
+		Cooldown:  DefaultBreakerCooldown,
+	}
+}
+
+// Breaker states
+const (
+	breakerClosed = iota
+	breakerOpen
+	breakerHalfOpen
+)
+
+// Breaker is a circuit breaker shared by every call to one service. Each
+// attempt made under Do is recorded, so a single call's retries count toward
+// the threshold too. A nil *Breaker is valid and behaves like the package Do.
+type Breaker struct {
+	name   string
+	policy BreakerPolicy
+	now    func() time.Time // Overridden by tests
+
+	mu           sync.Mutex
+	state        int
+	failures     int       // Consecutive transient failures while closed
+	lastFailure  time.Time // When the most recent of those failures happened
+	openedAt     time.Time
+	probeRunning bool // A half-open probe call is in flight
+}
+
+// NewBreaker creates a closed breaker; name prefixes its errors, e.g. "Gmail API"
+func NewBreaker(name string, policy BreakerPolicy) *Breaker {
+	return &Breaker{name: name, policy: policy, now: time.Now}
+}
+
+// Do runs operation with the package Do, rejecting attempts with
+// ErrCircuitOpen while the circuit is open. A rejected attempt isn't retried.
+func (b *Breaker) Do(operation func() error) error {
+	if b == nil || b.policy.Threshold <= 0 {
+		return Do(operation)
+	}
+	return Do(func() error {
+		if err := b.allow(); err != nil {
+			return err
+		}
+		err := operation()
+		b.record(err)
+		return err
+	})
+}
+
+// allow reports whether an attempt may run, moving an open circuit to
+// half-open once the cool-down has passed
+func (b *Breaker) allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case breakerOpen:
+		remaining := b.policy.Cooldown - b.now().Sub(b.openedAt)
+		if remaining > 0 {
+			return fmt.Errorf("%s: %w (retry in %s)", b.name, ErrCircuitOpen, remaining.Round(time.Second))
+		}
+		b.state = breakerHalfOpen
+		b.probeRunning = true
+		return nil
+	case breakerHalfOpen:
+		// Only one probe at a time; everyone else waits for its verdict
+		if b.probeRunning {
+			return fmt.Errorf("%s: %w (checking whether it has recovered)", b.name, ErrCircuitOpen)
+		}
+		b.probeRunning = true
+	}
+	return nil
+}
+
+// record updates the breaker with an attempt's outcome. Only rate limits and
+// server errors count as failures; any other result shows the service is up.
+func (b *Breaker) record(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.now()
+	if !isTransient(err) {
+		b.state = breakerClosed
+		b.failures = 0
+		b.probeRunning = false
+		return
+	}
+
+	if b.state == breakerHalfOpen {
+		b.state = breakerOpen
+		b.openedAt = now
+		b.probeRunning = false
+		return
+	}
+
+	if b.failures > 0 && now.Sub(b.lastFailure) > b.policy.Window {
+		b.failures = 0
+	}
+	b.failures++
+	b.lastFailure = now
+	if b.failures >= b.policy.Threshold {
+		b.state = breakerOpen
+		b.openedAt = now
+		b.failures = 0
+	}
+}
+
+// isTransient reports whether err is a rate limit or server error
+func isTransient(err error) bool {
+	code := StatusCode(err)
+	if code == 0 {
+		var apiErr *googleapi.Error
+		if errors.As(err, &apiErr) {
+			code = apiErr.Code
+		}
+	}
+	return code == http.StatusTooManyRequests || (code >= 500 && code < 600)
+}
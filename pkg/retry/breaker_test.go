@@ -0,0 +1,107 @@
+// ABOUTME: Tests for the circuit breaker
+// ABOUTME: Verifies it opens after the threshold, fails fast while open, and recovers after the cool-down
+
+package retry
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/api/googleapi"
+)
+
+// newTestBreaker returns a breaker driven by the returned clock, with retries disabled
+func newTestBreaker(t *testing.T, policy BreakerPolicy) (*Breaker, *time.Time) {
+	t.Helper()
+	previous := DefaultPolicy()
+	SetDefaultPolicy(Policy{MaxRetries: 0})
+	t.Cleanup(func() { SetDefaultPolicy(previous) })
+
+	now := time.Date(2025, 3, 3, 10, 0, 0, 0, time.UTC)
+	b := NewBreaker("Test API", policy)
+	b.now = func() time.Time { return now }
+	return b, &now
+}
+
+func TestBreaker_OpensAfterThresholdAndRecovers(t *testing.T) {
+	b, now := newTestBreaker(t, BreakerPolicy{Threshold: 3, Window: time.Minute, Cooldown: 30 * time.Second})
+
+	calls := 0
+	outage := func() error {
+		calls++
+		return &googleapi.Error{Code: http.StatusServiceUnavailable}
+	}
+	for i := 0; i < 3; i++ {
+		err := b.Do(outage)
+		assert.False(t, errors.Is(err, ErrCircuitOpen), "call %d reaches the API", i+1)
+	}
+	assert.Equal(t, 3, calls)
+
+	// Open: fail fast without calling the API
+	err := b.Do(outage)
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+	assert.ErrorContains(t, err, "Test API: service temporarily unavailable, backing off (retry in 30s)")
+	assert.Equal(t, 3, calls)
+
+	// Half-open after the cool-down; a failed probe reopens the circuit
+	*now = now.Add(31 * time.Second)
+	assert.NotErrorIs(t, b.Do(outage), ErrCircuitOpen)
+	assert.Equal(t, 4, calls)
+	assert.ErrorIs(t, b.Do(outage), ErrCircuitOpen)
+
+	// A successful probe closes it again
+	*now = now.Add(31 * time.Second)
+	assert.NoError(t, b.Do(func() error { return nil }))
+	assert.NoError(t, b.Do(func() error { return nil }))
+	assert.NotErrorIs(t, b.Do(outage), ErrCircuitOpen)
+}
+
+func TestBreaker_OnlyConsecutiveTransientFailuresCount(t *testing.T) {
+	b, now := newTestBreaker(t, BreakerPolicy{Threshold: 2, Window: time.Minute, Cooldown: time.Minute})
+	serverError := func() error { return &googleapi.Error{Code: http.StatusInternalServerError} }
+
+	// Client errors and successes reset the count
+	_ = b.Do(serverError)
+	_ = b.Do(func() error { return &googleapi.Error{Code: http.StatusNotFound} })
+	_ = b.Do(serverError)
+	_ = b.Do(func() error { return nil })
+	_ = b.Do(serverError)
+
+	// Failures further apart than the window aren't consecutive
+	*now = now.Add(2 * time.Minute)
+	assert.NotErrorIs(t, b.Do(serverError), ErrCircuitOpen)
+	assert.NotErrorIs(t, b.Do(serverError), ErrCircuitOpen)
+
+	// Rate limits count, and the HTTPError interface is recognized too
+	assert.ErrorIs(t, b.Do(serverError), ErrCircuitOpen)
+	assert.True(t, isTransient(NewRetryableError(http.StatusTooManyRequests, "")))
+	assert.False(t, isTransient(errors.New("connection reset")))
+}
+
+func TestBreaker_RetriesStopOnceOpen(t *testing.T) {
+	previous := DefaultPolicy()
+	SetDefaultPolicy(Policy{MaxRetries: 5, BaseDelay: time.Millisecond})
+	defer SetDefaultPolicy(previous)
+
+	b := NewBreaker("Test API", BreakerPolicy{Threshold: 2, Window: time.Minute, Cooldown: time.Minute})
+	calls := 0
+	err := b.Do(func() error {
+		calls++
+		return NewRetryableError(http.StatusServiceUnavailable, "down")
+	})
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+	assert.Equal(t, 2, calls, "each retry counts toward the threshold")
+}
+
+func TestBreaker_NilAndDisabled(t *testing.T) {
+	var nilBreaker *Breaker
+	assert.NoError(t, nilBreaker.Do(func() error { return nil }))
+
+	b, _ := newTestBreaker(t, BreakerPolicy{Threshold: 0})
+	for i := 0; i < 10; i++ {
+		assert.NotErrorIs(t, b.Do(func() error { return &googleapi.Error{Code: http.StatusBadGateway} }), ErrCircuitOpen)
+	}
+}
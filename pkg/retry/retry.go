@@ -4,7 +4,9 @@
 package retry
 
 import (
+	"errors"
 	"fmt"
+	"sync"
 	"time"
 )
 
@@ -14,6 +16,64 @@ type HTTPError interface {
 	HTTPStatusCode() int
 }
 
+// Policy controls how many times an operation is retried and how long to wait
+type Policy struct {
+	MaxRetries int           // retry attempts after the initial attempt
+	BaseDelay  time.Duration // delay before the first retry (doubles each attempt)
+}
+
+// DefaultMaxRetries and DefaultBaseDelay are the policy used until SetDefaultPolicy is called
+const (
+	DefaultMaxRetries = 3
+	DefaultBaseDelay  = time.Second
+)
+
+// RetryHook is called before each retry with the 1-based retry number, the
+// error that triggered it, and the backoff about to be slept
+type RetryHook func(attempt int, err error, delay time.Duration)
+
+// Options configures WithRetryOptions
+type Options struct {
+	MaxRetries int           // retry attempts after the initial attempt
+	BaseDelay  time.Duration // delay before the first retry (doubles each attempt)
+	OnRetry    RetryHook     // optional; nil disables the callback
+}
+
+var (
+	policyMu       sync.RWMutex
+	defaultPolicy  = Policy{MaxRetries: DefaultMaxRetries, BaseDelay: DefaultBaseDelay}
+	defaultOnRetry RetryHook
+)
+
+// SetDefaultPolicy changes the policy used by Do
+func SetDefaultPolicy(p Policy) {
+	policyMu.Lock()
+	defer policyMu.Unlock()
+	defaultPolicy = p
+}
+
+// DefaultPolicy returns the policy currently used by Do
+func DefaultPolicy() Policy {
+	policyMu.RLock()
+	defer policyMu.RUnlock()
+	return defaultPolicy
+}
+
+// SetOnRetry installs the hook Do calls before each retry; nil removes it
+func SetOnRetry(hook RetryHook) {
+	policyMu.Lock()
+	defer policyMu.Unlock()
+	defaultOnRetry = hook
+}
+
+// Do executes an operation with WithRetryOptions using the default policy and hook
+func Do(operation func() error) error {
+	policyMu.RLock()
+	p, hook := defaultPolicy, defaultOnRetry
+	policyMu.RUnlock()
+	return WithRetryOptions(operation, Options{MaxRetries: p.MaxRetries, BaseDelay: p.BaseDelay, OnRetry: hook})
+}
+
 // WithRetry executes an operation with exponential backoff retry logic
 // It retries on:
 // - 429 (Rate Limit)
@@ -30,6 +90,13 @@ type HTTPError interface {
 //
 // Returns the error from the last attempt if all retries are exhausted
 func WithRetry(operation func() error, maxRetries int, baseDelay time.Duration) error {
+	return WithRetryOptions(operation, Options{MaxRetries: maxRetries, BaseDelay: baseDelay})
+}
+
+// WithRetryOptions is WithRetry with an optional OnRetry callback, invoked
+// once per retry so callers can log or count retries
+func WithRetryOptions(operation func() error, opts Options) error {
+	maxRetries, baseDelay := opts.MaxRetries, opts.BaseDelay
 	var lastErr error
 
 	for attempt := 0; attempt <= maxRetries; attempt++ {
@@ -55,6 +122,9 @@ func WithRetry(operation func() error, maxRetries int, baseDelay time.Duration)
 
 		// Calculate delay with exponential backoff
 		delay := baseDelay * time.Duration(1<<uint(attempt))
+		if opts.OnRetry != nil {
+			opts.OnRetry(attempt+1, err, delay)
+		}
 		time.Sleep(delay)
 	}
 
@@ -91,6 +161,15 @@ func shouldRetry(err error) bool {
 	return false
 }
 
+// StatusCode returns err's HTTP status code, or 0 if it doesn't carry one
+func StatusCode(err error) int {
+	var httpErr HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.HTTPStatusCode()
+	}
+	return 0
+}
+
 // RetryableError wraps an HTTP status code as an error
 type RetryableError struct {
 	StatusCode int
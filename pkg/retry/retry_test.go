@@ -1,5 +1,5 @@
 // ABOUTME: This file contains tests for the retry logic with exponential backoff.
-// ABOUTME: It verifies retry behavior for rate limits, server errors, and non-retryable errors.
+// ABOUTME: It verifies retry behavior for rate limits, server errors, non-retryable errors, and the OnRetry hook.
 
 package retry
 
@@ -226,3 +226,83 @@ func TestExponentialBackoff(t *testing.T) {
 		t.Errorf("Third delay expected ~%v, got %v", expectedThird, delays[2])
 	}
 }
+
+// TestWithRetryOptionsCallsOnRetry tests that the hook runs once per retry with 1-based attempt numbers
+func TestWithRetryOptionsCallsOnRetry(t *testing.T) {
+	var attempts []int
+	var delays []time.Duration
+	var statuses []int
+
+	calls := 0
+	operation := func() error {
+		calls++
+		if calls < 3 {
+			return &mockHTTPError{StatusCode: http.StatusTooManyRequests}
+		}
+		return nil
+	}
+
+	err := WithRetryOptions(operation, Options{
+		MaxRetries: 5,
+		BaseDelay:  time.Millisecond,
+		OnRetry: func(attempt int, err error, delay time.Duration) {
+			attempts = append(attempts, attempt)
+			delays = append(delays, delay)
+			statuses = append(statuses, StatusCode(err))
+		},
+	})
+
+	if err != nil {
+		t.Fatalf("Expected success, got error: %v", err)
+	}
+	if fmt.Sprint(attempts) != "[1 2]" {
+		t.Errorf("Expected hook attempts [1 2], got %v", attempts)
+	}
+	if fmt.Sprint(delays) != "[1ms 2ms]" {
+		t.Errorf("Expected backoff delays [1ms 2ms], got %v", delays)
+	}
+	if fmt.Sprint(statuses) != "[429 429]" {
+		t.Errorf("Expected status codes [429 429], got %v", statuses)
+	}
+}
+
+// TestWithRetryOptionsHookSkippedWithoutRetry tests that the hook isn't called when nothing is retried
+func TestWithRetryOptionsHookSkippedWithoutRetry(t *testing.T) {
+	hookCalls := 0
+	hook := func(int, error, time.Duration) { hookCalls++ }
+
+	// Non-retryable error
+	_ = WithRetryOptions(func() error {
+		return &mockHTTPError{StatusCode: http.StatusBadRequest}
+	}, Options{MaxRetries: 3, BaseDelay: time.Millisecond, OnRetry: hook})
+
+	// Retries exhausted: the final failure isn't followed by a retry
+	_ = WithRetryOptions(func() error {
+		return &mockHTTPError{StatusCode: http.StatusServiceUnavailable}
+	}, Options{MaxRetries: 2, BaseDelay: time.Millisecond, OnRetry: hook})
+
+	if hookCalls != 2 {
+		t.Errorf("Expected 2 hook calls (one per retry), got %d", hookCalls)
+	}
+}
+
+// TestDoUsesDefaultOnRetry tests that Do invokes the hook installed with SetOnRetry
+func TestDoUsesDefaultOnRetry(t *testing.T) {
+	previous := DefaultPolicy()
+	SetDefaultPolicy(Policy{MaxRetries: 1, BaseDelay: time.Millisecond})
+	defer SetDefaultPolicy(previous)
+
+	var attempts []int
+	SetOnRetry(func(attempt int, err error, delay time.Duration) {
+		attempts = append(attempts, attempt)
+	})
+	defer SetOnRetry(nil)
+
+	_ = Do(func() error {
+		return &mockHTTPError{StatusCode: http.StatusInternalServerError}
+	})
+
+	if fmt.Sprint(attempts) != "[1]" {
+		t.Errorf("Expected hook attempts [1], got %v", attempts)
+	}
+}
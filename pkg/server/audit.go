@@ -0,0 +1,186 @@
+// ABOUTME: Append-only audit log of mutating tool calls
+// ABOUTME: Writes one JSON line per send, create, update, or delete with identifying parameters, never content
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// auditedTools are the tools that change the account: sending, drafting,
+// creating, updating, or deleting, plus changes to the stored credentials
+var auditedTools = map[string]bool{
+	"gmail_send_message":                  true,
+	"gmail_create_draft":                  true,
+	"gmail_schedule_send":                 true,
+	"gmail_send_draft":                    true,
+	"gmail_fix_draft_threading":           true,
+	"gmail_message_to_contact":            true,
+	"gmail_modify_labels":                 true,
+	"gmail_modify_labels_by_query":        true,
+	"gmail_file_message":                  true,
+	"gmail_trash_message":                 true,
+	"gmail_delete_message":                true,
+	"gmail_trash_by_query":                true,
+	"gmail_recover_from_trash":            true,
+	"gmail_label_by_sender":               true,
+	"gmail_set_signature":                 true,
+	"calendar_create_event":               true,
+	"calendar_create_event_from_template": true,
+	"calendar_block_time":                 true,
+	"calendar_update_event":               true,
+	"calendar_delete_event":               true,
+	"calendar_cancel_event":               true,
+	"calendar_reschedule_event":           true,
+	"calendar_delete_events_bulk":         true,
+	"calendar_email_agenda":               true,
+	"people_create_contact":               true,
+	"people_update_contact":               true,
+	"people_delete_contact":               true,
+	"people_import_vcard":                 true,
+	"people_batch_create":                 true,
+	"auth_complete":                       true,
+	"auth_revoke":                         true,
+}
+
+// auditedParams are the arguments copied into audit entries: who was
+// addressed and what was acted on. Subjects, bodies, descriptions, notes,
+// signatures, vCards, and authorization codes are deliberately absent.
+var auditedParams = map[string]bool{
+	"to":                     true,
+	"in_reply_to":            true,
+	"thread_id":              true,
+	"draft_id":               true,
+	"original_message_id":    true,
+	"message_id":             true,
+	"label":                  true,
+	"sender":                 true,
+	"remove":                 true,
+	"add_labels":             true,
+	"remove_labels":          true,
+	"query":                  true,
+	"send_at":                true,
+	"send_as_email":          true,
+	"event_id":               true,
+	"calendar_id":            true,
+	"template":               true,
+	"start_time":             true,
+	"end_time":               true,
+	"offset":                 true,
+	"start_date":             true,
+	"end_date":               true,
+	"time_min":               true,
+	"time_max":               true,
+	"attendees":              true,
+	"room_email":             true,
+	"optional_attendees":     true,
+	"add_attendees":          true,
+	"add_optional_attendees": true,
+	"remove_attendees":       true,
+	"send_updates":           true,
+	"date":                   true,
+	"send":                   true,
+	"confirm":                true,
+	"allow_external":         true,
+	"resource_name":          true,
+	"email":                  true,
+	"clear_fields":           true,
+}
+
+// AuditEntry is one line of the audit log
+type AuditEntry struct {
+	Time   time.Time              `json:"time"`
+	Tool   string                 `json:"tool"`
+	Params map[string]interface{} `json:"params,omitempty"`
+	OK     bool                   `json:"ok"`
+	Error  string                 `json:"error,omitempty"`
+}
+
+// auditLog appends entries to a JSON lines file
+type auditLog struct {
+	path string
+	mu   sync.Mutex
+}
+
+// newAuditLog returns a log writing to path, or nil when path is empty
+func newAuditLog(path string) *auditLog {
+	if path == "" {
+		return nil
+	}
+	return &auditLog{path: path}
+}
+
+// write appends entry as one line. The file is opened per write so it can be
+// rotated or removed while the server runs.
+func (a *auditLog) write(entry AuditEntry) error {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("unable to encode audit entry: %w", err)
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	f, err := os.OpenFile(a.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("unable to open audit log: %w", err)
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("unable to write audit log: %w", err)
+	}
+	return f.Close()
+}
+
+// auditTools wraps tool handlers so each mutating call is recorded in the
+// audit log after it runs. A failed write is logged but never fails the call.
+func (s *Server) auditTools(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		result, err := next(ctx, request)
+		if s.audit == nil || !auditedTools[request.Params.Name] {
+			return result, err
+		}
+
+		entry := AuditEntry{
+			Time: time.Now().UTC(),
+			Tool: request.Params.Name,
+			OK:   err == nil && result != nil && !result.IsError,
+		}
+		for name, value := range request.GetArguments() {
+			if auditedParams[name] && value != nil {
+				if entry.Params == nil {
+					entry.Params = make(map[string]interface{})
+				}
+				entry.Params[name] = value
+			}
+		}
+		switch {
+		case err != nil:
+			entry.Error = err.Error()
+		case result != nil && result.IsError:
+			entry.Error = resultText(result)
+		}
+
+		if werr := s.audit.write(entry); werr != nil {
+			s.logs.printf(levelError, "audit: %v", werr)
+		}
+		return result, err
+	}
+}
+
+// resultText returns the text of a result's first text content, or ""
+func resultText(result *mcp.CallToolResult) string {
+	for _, content := range result.Content {
+		if text, ok := content.(mcp.TextContent); ok {
+			return text.Text
+		}
+	}
+	return ""
+}
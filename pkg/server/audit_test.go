@@ -0,0 +1,101 @@
+// ABOUTME: Tests for the audit log of mutating tool calls
+// ABOUTME: Verifies sends and deletes each write one line with identifying fields and no message content
+
+package server
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// readAuditLog parses every line of the audit log at path
+func readAuditLog(t *testing.T, path string) []AuditEntry {
+	t.Helper()
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer func() { _ = f.Close() }()
+
+	var entries []AuditEntry
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var entry AuditEntry
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &entry), scanner.Text())
+		entries = append(entries, entry)
+	}
+	require.NoError(t, scanner.Err())
+	return entries
+}
+
+func TestAuditTools(t *testing.T) {
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			http.Error(w, `{"error": {"code": 404, "message": "Not Found"}}`, http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{"id": "m1", "threadId": "t1", "messages": []}`))
+	})
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	srv.audit = newAuditLog(path)
+	ctx := context.Background()
+
+	send := srv.auditTools(srv.handleGmailSendMessage)
+	result, err := send(ctx, createMockRequest("gmail_send_message", map[string]interface{}{
+		"to":      "jane@example.com",
+		"subject": "Confidential plans",
+		"body":    "The secret body text",
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError, result.Content)
+
+	del := srv.auditTools(srv.handleGmailDeleteMessage)
+	result, err = del(ctx, createMockRequest("gmail_delete_message", map[string]interface{}{"message_id": "m404"}))
+	require.NoError(t, err)
+	require.True(t, result.IsError)
+
+	// Read-only tools aren't audited
+	list := srv.auditTools(srv.handleGmailListMessages)
+	_, err = list(ctx, createMockRequest("gmail_list_messages", map[string]interface{}{"query": "is:unread"}))
+	require.NoError(t, err)
+
+	entries := readAuditLog(t, path)
+	require.Len(t, entries, 2)
+
+	assert.Equal(t, "gmail_send_message", entries[0].Tool)
+	assert.True(t, entries[0].OK)
+	assert.Empty(t, entries[0].Error)
+	assert.Equal(t, map[string]interface{}{"to": "jane@example.com"}, entries[0].Params, "subjects and bodies are never logged")
+	assert.False(t, entries[0].Time.IsZero())
+
+	assert.Equal(t, "gmail_delete_message", entries[1].Tool)
+	assert.False(t, entries[1].OK)
+	assert.NotEmpty(t, entries[1].Error)
+	assert.Equal(t, map[string]interface{}{"message_id": "m404"}, entries[1].Params)
+
+	raw, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(raw), "secret body")
+	assert.NotContains(t, string(raw), "Confidential")
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+}
+
+func TestAuditTools_Disabled(t *testing.T) {
+	srv := &Server{}
+	handler := srv.auditTools(func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultText("ok"), nil
+	})
+	result, err := handler(context.Background(), createMockRequest("gmail_send_message", nil))
+	require.NoError(t, err)
+	assert.False(t, result.IsError)
+}
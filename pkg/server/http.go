@@ -0,0 +1,75 @@
+// ABOUTME: Streamable HTTP transport with liveness and readiness probes
+// ABOUTME: /mcp serves the protocol; /healthz reports the process is up and /readyz whether any Google API accepts the credentials
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// readyTimeout bounds the API probes behind /readyz so a hung call can't
+// outlast the orchestrator's probe timeout
+const readyTimeout = 10 * time.Second
+
+// shutdownTimeout is how long in-flight HTTP requests get to finish on shutdown
+const shutdownTimeout = 10 * time.Second
+
+// httpHandler routes the MCP endpoint and the probes:
+//
+//	/mcp      the streamable HTTP transport
+//	/healthz  200 whenever the process can answer
+//	/readyz   200 when at least one API accepts the stored credentials, 503
+//	          otherwise, with the per-service auth_status detail as JSON
+func (s *Server) httpHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle("/mcp", server.NewStreamableHTTPServer(s.mcp))
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		_, _ = w.Write([]byte("ok\n"))
+	})
+	mux.HandleFunc("/readyz", func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), readyTimeout)
+		defer cancel()
+
+		status := s.detailedAuthStatus(ctx)
+		code := http.StatusServiceUnavailable
+		for _, service := range status.Services {
+			if service.Valid {
+				code = http.StatusOK
+				break
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(code)
+		_ = json.NewEncoder(w).Encode(status)
+	})
+	return mux
+}
+
+// serveHTTP listens on addr until ctx is done, then drains in-flight requests
+func (s *Server) serveHTTP(ctx context.Context, addr string) error {
+	httpServer := &http.Server{
+		Addr:              addr,
+		Handler:           s.httpHandler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		_ = httpServer.Shutdown(shutdownCtx)
+	}()
+
+	s.logs.printf(levelInfo, "serving MCP over HTTP at http://%s/mcp", addr)
+	if err := httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+// ABOUTME: Tests for the HTTP transport and its liveness and readiness probes
+// ABOUTME: Verifies /healthz always succeeds, /readyz fails without working credentials, and /mcp answers initialize
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func probe(t *testing.T, srv *Server, path string) *httptest.ResponseRecorder {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	srv.httpHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+	return rec
+}
+
+func TestHTTPHandler_Unauthenticated(t *testing.T) {
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"error": {"code": 401, "message": "Request had invalid authentication credentials."}}`))
+	})
+
+	assert.Equal(t, http.StatusOK, probe(t, srv, "/healthz").Code, "liveness doesn't depend on auth")
+
+	rec := probe(t, srv, "/readyz")
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	var status AuthStatusResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &status))
+	assert.False(t, status.Valid)
+	assert.Len(t, status.Services, 3)
+}
+
+func TestHTTPHandler_ReadyWhenAnyServiceWorks(t *testing.T) {
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		// Only Calendar is enabled for this project
+		if strings.Contains(r.URL.Path, "/calendars/") {
+			_, _ = w.Write([]byte(`{}`))
+			return
+		}
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"error": {"code": 403, "message": "API not enabled"}}`))
+	})
+
+	assert.Equal(t, http.StatusOK, probe(t, srv, "/healthz").Code)
+	assert.Equal(t, http.StatusOK, probe(t, srv, "/readyz").Code)
+	assert.Equal(t, http.StatusNotFound, probe(t, srv, "/other").Code)
+}
+
+func TestServeHTTP(t *testing.T) {
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		http.NotFound(w, r)
+	})
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := listener.Addr().String()
+	require.NoError(t, listener.Close())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- srv.serveHTTP(ctx, addr) }()
+
+	var resp *http.Response
+	require.Eventually(t, func() bool {
+		resp, err = http.Post("http://"+addr+"/mcp", "application/json", strings.NewReader(
+			`{"jsonrpc": "2.0", "id": 1, "method": "initialize", "params": {"protocolVersion": "2025-03-26", "capabilities": {}, "clientInfo": {"name": "test", "version": "1"}}}`))
+		return err == nil
+	}, 2*time.Second, 20*time.Millisecond)
+	defer func() { _ = resp.Body.Close() }()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	var body struct {
+		Result struct {
+			ServerInfo struct {
+				Name string `json:"name"`
+			} `json:"serverInfo"`
+		} `json:"result"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.Equal(t, "gsuite-mcp", body.Result.ServerInfo.Name)
+
+	cancel()
+	select {
+	case err := <-done:
+		assert.NoError(t, err, "shutdown isn't reported as an error")
+	case <-time.After(5 * time.Second):
+		t.Fatal("server did not stop")
+	}
+}
@@ -0,0 +1,83 @@
+// ABOUTME: Leveled logging to stderr filtered by the log_level setting
+// ABOUTME: debug also traces each tool call; warn and error progressively quiet the server
+
+package server
+
+import (
+	"context"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/harper/gsuite-mcp/pkg/retry"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// logLevel orders log messages by severity
+type logLevel int
+
+const (
+	levelDebug logLevel = iota // Per-call tracing
+	levelInfo                  // Startup and other routine events
+	levelWarn                  // Retries and skipped configuration
+	levelError                 // Failed background work
+)
+
+// logLevels maps log_level values to their logLevel
+var logLevels = map[string]logLevel{
+	"debug": levelDebug,
+	"info":  levelInfo,
+	"warn":  levelWarn,
+	"error": levelError,
+}
+
+// logger writes messages at or above its minimum level
+type logger struct {
+	min logLevel
+}
+
+// newLogger returns a logger for a log_level value; empty or unknown means info
+func newLogger(level string) logger {
+	if min, ok := logLevels[strings.ToLower(level)]; ok {
+		return logger{min: min}
+	}
+	return logger{min: levelInfo}
+}
+
+// enabled reports whether messages at level are written
+func (l logger) enabled(level logLevel) bool {
+	return level >= l.min
+}
+
+// printf logs the message if level is enabled
+func (l logger) printf(level logLevel, format string, args ...interface{}) {
+	if l.enabled(level) {
+		log.Printf(format, args...)
+	}
+}
+
+// logRetry reports each retried API call so rate limiting and transient failures show up in the logs
+func (l logger) logRetry(attempt int, err error, delay time.Duration) {
+	l.printf(levelWarn, "retry: attempt %d after HTTP %d, backing off %s: %v", attempt, retry.StatusCode(err), delay, err)
+}
+
+// logToolCalls wraps tool handlers to trace each call's outcome and duration at debug level
+func (s *Server) logToolCalls(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		if !s.logs.enabled(levelDebug) {
+			return next(ctx, request)
+		}
+		start := time.Now()
+		result, err := next(ctx, request)
+		outcome := "ok"
+		switch {
+		case err != nil:
+			outcome = "failed: " + err.Error()
+		case result != nil && result.IsError:
+			outcome = "error result"
+		}
+		s.logs.printf(levelDebug, "tool %s: %s in %s", request.Params.Name, outcome, time.Since(start).Round(time.Millisecond))
+		return result, err
+	}
+}
@@ -0,0 +1,71 @@
+// ABOUTME: Tests for leveled logging
+// ABOUTME: Verifies log_level filters messages and debug traces tool calls
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+)
+
+// captureLog redirects the standard logger for the rest of the test
+func captureLog(t *testing.T) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	t.Cleanup(func() { log.SetOutput(os.Stderr) })
+	return &buf
+}
+
+func TestLogger_FiltersByLevel(t *testing.T) {
+	tests := []struct {
+		level  string
+		logged []logLevel
+	}{
+		{"debug", []logLevel{levelDebug, levelInfo, levelWarn, levelError}},
+		{"", []logLevel{levelInfo, levelWarn, levelError}},
+		{"WARN", []logLevel{levelWarn, levelError}},
+		{"error", []logLevel{levelError}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.level, func(t *testing.T) {
+			buf := captureLog(t)
+			logs := newLogger(tt.level)
+			for _, level := range []logLevel{levelDebug, levelInfo, levelWarn, levelError} {
+				logs.printf(level, "level %d", level)
+			}
+			for _, level := range tt.logged {
+				assert.Contains(t, buf.String(), fmt.Sprintf("level %d\n", level))
+			}
+			assert.Equal(t, len(tt.logged), bytes.Count(buf.Bytes(), []byte("\n")))
+		})
+	}
+
+	buf := captureLog(t)
+	newLogger("error").logRetry(1, errors.New("unavailable"), time.Second)
+	assert.Empty(t, buf.String(), "retries are warnings")
+}
+
+func TestLogToolCalls_DebugOnly(t *testing.T) {
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultError("no such message"), nil
+	}
+
+	buf := captureLog(t)
+	s := &Server{logs: newLogger("info")}
+	_, _ = s.logToolCalls(handler)(context.Background(), createMockRequest("gmail_get_message", nil))
+	assert.Empty(t, buf.String())
+
+	s.logs = newLogger("debug")
+	_, _ = s.logToolCalls(handler)(context.Background(), createMockRequest("gmail_get_message", nil))
+	assert.Contains(t, buf.String(), "tool gmail_get_message: error result in")
+}
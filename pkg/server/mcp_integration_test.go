@@ -20,7 +20,7 @@ import (
 func TestFullMCPRequestResponseCycleWithMultipleTools(t *testing.T) {
 	t.Setenv("ISH_MODE", "true")
 
-	srv, err := NewServer(context.Background())
+	srv, err := NewServer(context.Background(), nil)
 	require.NoError(t, err)
 
 	ctx := context.Background()
@@ -86,7 +86,7 @@ func TestFullMCPRequestResponseCycleWithMultipleTools(t *testing.T) {
 func TestMCPPromptListingAndExecution(t *testing.T) {
 	t.Setenv("ISH_MODE", "true")
 
-	srv, err := NewServer(context.Background())
+	srv, err := NewServer(context.Background(), nil)
 	require.NoError(t, err)
 
 	ctx := context.Background()
@@ -171,6 +171,23 @@ func TestMCPPromptListingAndExecution(t *testing.T) {
 			args:       map[string]string{"tone": "casual"},
 			// context is optional, should use default
 		},
+		{
+			name:       "bulk_cleanup_prompt",
+			promptName: "bulk_cleanup",
+			args:       map[string]string{"categories": "newsletters,receipts"},
+		},
+		{
+			name:          "bulk_cleanup_missing_categories",
+			promptName:    "bulk_cleanup",
+			args:          map[string]string{},
+			expectError:   true,
+			errorContains: "required",
+		},
+		{
+			name:       "weekly_digest_prompt",
+			promptName: "weekly_digest",
+			args:       map[string]string{"days": "14"},
+		},
 		{
 			name:          "add_contact_missing_subject",
 			promptName:    "add_contact_from_email",
@@ -213,6 +230,10 @@ func TestMCPPromptListingAndExecution(t *testing.T) {
 				result, err = srv.handleEmailReplyPrompt(ctx, request)
 			case "add_contact_from_email":
 				result, err = srv.handleAddContactFromEmailPrompt(ctx, request)
+			case "bulk_cleanup":
+				result, err = srv.handleBulkCleanupPrompt(ctx, request)
+			case "weekly_digest":
+				result, err = srv.handleWeeklyDigestPrompt(ctx, request)
 			default:
 				t.Fatalf("Unknown prompt: %s", tt.promptName)
 			}
@@ -236,13 +257,13 @@ func TestMCPPromptListingAndExecution(t *testing.T) {
 	}
 }
 
-// TestMCPResourceEndpointsReturnValidJSON tests all 8 resource endpoints
+// TestMCPResourceEndpointsReturnValidJSON tests all 13 resource endpoints
 // Note: This test requires ISH_MODE to be set and an ish server running.
 // It skips if the server is not available to allow unit testing.
 func TestMCPResourceEndpointsReturnValidJSON(t *testing.T) {
 	t.Setenv("ISH_MODE", "true")
 
-	srv, err := NewServer(context.Background())
+	srv, err := NewServer(context.Background(), nil)
 	require.NoError(t, err)
 
 	ctx := context.Background()
@@ -340,6 +361,23 @@ func TestMCPResourceEndpointsReturnValidJSON(t *testing.T) {
 				assert.Contains(t, data, "timestamp")
 			},
 		},
+		{
+			name:    "recent_contact_threads",
+			uri:     "gsuite://contacts/recent-threads",
+			handler: srv.handleRecentContactThreadsResource,
+			validate: func(t *testing.T, contents []mcp.ResourceContents) {
+				require.Len(t, contents, 1)
+				textContent := contents[0].(mcp.TextResourceContents)
+				assert.Equal(t, "application/json", textContent.MIMEType)
+
+				var data map[string]interface{}
+				err := json.Unmarshal([]byte(textContent.Text), &data)
+				require.NoError(t, err, "Response should be valid JSON")
+				assert.Contains(t, data, "contact_count")
+				assert.Contains(t, data, "contacts")
+				assert.Contains(t, data, "timestamp")
+			},
+		},
 		{
 			name:    "upcoming_meetings",
 			uri:     "gsuite://calendar/upcoming",
@@ -389,6 +427,57 @@ func TestMCPResourceEndpointsReturnValidJSON(t *testing.T) {
 				}
 			},
 		},
+		{
+			name:    "calendar_load",
+			uri:     "gsuite://calendar/load",
+			handler: srv.handleCalendarLoadResource,
+			validate: func(t *testing.T, contents []mcp.ResourceContents) {
+				require.Len(t, contents, 1)
+				textContent := contents[0].(mcp.TextResourceContents)
+				assert.Equal(t, "application/json", textContent.MIMEType)
+
+				var data map[string]interface{}
+				err := json.Unmarshal([]byte(textContent.Text), &data)
+				require.NoError(t, err, "Response should be valid JSON")
+				assert.Contains(t, data, "total_meeting_hours")
+				assert.Contains(t, data, "meeting_percent_of_work_hours")
+				assert.Contains(t, data, "generated_at")
+			},
+		},
+		{
+			name:    "calendar_invites",
+			uri:     "gsuite://calendar/invites",
+			handler: srv.handleCalendarInvitesResource,
+			validate: func(t *testing.T, contents []mcp.ResourceContents) {
+				require.Len(t, contents, 1)
+				textContent := contents[0].(mcp.TextResourceContents)
+				assert.Equal(t, "application/json", textContent.MIMEType)
+
+				var data map[string]interface{}
+				err := json.Unmarshal([]byte(textContent.Text), &data)
+				require.NoError(t, err, "Response should be valid JSON")
+				assert.Contains(t, data, "invite_count")
+				assert.Contains(t, data, "invites")
+				assert.Contains(t, data, "timestamp")
+			},
+		},
+		{
+			name:    "calendar_reminders",
+			uri:     "gsuite://calendar/reminders",
+			handler: srv.handleCalendarRemindersResource,
+			validate: func(t *testing.T, contents []mcp.ResourceContents) {
+				require.Len(t, contents, 1)
+				textContent := contents[0].(mcp.TextResourceContents)
+				assert.Equal(t, "application/json", textContent.MIMEType)
+
+				var data map[string]interface{}
+				err := json.Unmarshal([]byte(textContent.Text), &data)
+				require.NoError(t, err, "Response should be valid JSON")
+				assert.Contains(t, data, "event_count")
+				assert.Contains(t, data, "events")
+				assert.Contains(t, data, "timezone")
+			},
+		},
 		{
 			name:    "draft_emails",
 			uri:     "gsuite://gmail/drafts",
@@ -406,6 +495,23 @@ func TestMCPResourceEndpointsReturnValidJSON(t *testing.T) {
 				assert.Contains(t, data, "timestamp")
 			},
 		},
+		{
+			name:    "overview",
+			uri:     "gsuite://overview",
+			handler: srv.handleOverviewResource,
+			validate: func(t *testing.T, contents []mcp.ResourceContents) {
+				require.Len(t, contents, 1)
+				textContent := contents[0].(mcp.TextResourceContents)
+				assert.Equal(t, "application/json", textContent.MIMEType)
+
+				var data map[string]interface{}
+				err := json.Unmarshal([]byte(textContent.Text), &data)
+				require.NoError(t, err, "Response should be valid JSON")
+				assert.Contains(t, data, "unread")
+				assert.Contains(t, data, "next_event")
+				assert.Contains(t, data, "complete")
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -441,7 +547,7 @@ func TestMCPResourceEndpointsReturnValidJSON(t *testing.T) {
 func TestServerHandlesUnknownToolGracefully(t *testing.T) {
 	t.Setenv("ISH_MODE", "true")
 
-	srv, err := NewServer(context.Background())
+	srv, err := NewServer(context.Background(), nil)
 	require.NoError(t, err)
 
 	ctx := context.Background()
@@ -505,7 +611,7 @@ func TestServerHandlesUnknownToolGracefully(t *testing.T) {
 func TestServerHandlesMalformedRequestsGracefully(t *testing.T) {
 	t.Setenv("ISH_MODE", "true")
 
-	srv, err := NewServer(context.Background())
+	srv, err := NewServer(context.Background(), nil)
 	require.NoError(t, err)
 
 	ctx := context.Background()
@@ -592,7 +698,7 @@ func TestServerHandlesMalformedRequestsGracefully(t *testing.T) {
 func TestGmailModifyLabelsWithArrays(t *testing.T) {
 	t.Setenv("ISH_MODE", "true")
 
-	srv, err := NewServer(context.Background())
+	srv, err := NewServer(context.Background(), nil)
 	require.NoError(t, err)
 
 	ctx := context.Background()
@@ -666,7 +772,7 @@ func TestGmailModifyLabelsWithArrays(t *testing.T) {
 func TestCalendarTimeRangeParsing(t *testing.T) {
 	t.Setenv("ISH_MODE", "true")
 
-	srv, err := NewServer(context.Background())
+	srv, err := NewServer(context.Background(), nil)
 	require.NoError(t, err)
 
 	ctx := context.Background()
@@ -750,7 +856,7 @@ func TestCalendarTimeRangeParsing(t *testing.T) {
 func TestPeopleUpdateContactFieldMask(t *testing.T) {
 	t.Setenv("ISH_MODE", "true")
 
-	srv, err := NewServer(context.Background())
+	srv, err := NewServer(context.Background(), nil)
 	require.NoError(t, err)
 
 	ctx := context.Background()
@@ -838,7 +944,7 @@ func TestPeopleUpdateContactFieldMask(t *testing.T) {
 func TestToolRegistrationCompleteness(t *testing.T) {
 	t.Setenv("ISH_MODE", "true")
 
-	srv, err := NewServer(context.Background())
+	srv, err := NewServer(context.Background(), nil)
 	require.NoError(t, err)
 
 	tools := srv.ListTools()
@@ -851,28 +957,71 @@ func TestToolRegistrationCompleteness(t *testing.T) {
 		// Gmail tools
 		"gmail_list_messages",
 		"gmail_get_message",
+		"gmail_download_eml",
+		"gmail_extract_contact_info",
+		"gmail_message_to_contact",
 		"gmail_send_message",
 		"gmail_create_draft",
+		"gmail_schedule_send",
+		"gmail_preview_reply",
 		"gmail_send_draft",
+		"gmail_fix_draft_threading",
+		"gmail_thread_participants",
+		"gmail_search_drafts",
 		"gmail_modify_labels",
+		"gmail_modify_labels_by_query",
+		"gmail_file_message",
 		"gmail_trash_message",
 		"gmail_delete_message",
+		"gmail_trash_by_query",
+		"gmail_recover_from_trash",
+		"gmail_label_by_sender",
+		"gmail_digest",
+		"gmail_awaiting_reply",
+		"gmail_get_profile",
+		"gmail_get_settings",
+		"gmail_get_signature",
+		"gmail_set_signature",
 		// Calendar tools
 		"calendar_list_events",
+		"calendar_sync_events",
+		"calendar_snapshot",
+		"calendar_diff",
+		"calendar_export_ics",
+		"calendar_get_timezone",
 		"calendar_get_event",
+		"calendar_list_event_attachments",
 		"calendar_create_event",
+		"calendar_create_event_from_template",
+		"calendar_block_time",
 		"calendar_update_event",
+		"calendar_reschedule_event",
+		"calendar_validate_schedule",
 		"calendar_delete_event",
+		"calendar_cancel_event",
+		"calendar_delete_events_bulk",
+		"calendar_find_by_property",
+		"calendar_suggest_slots",
+		"calendar_find_room",
+		"calendar_email_agenda",
 		// People tools
 		"people_list_contacts",
+		"people_list_group_members",
+		"people_interaction_history",
 		"people_search_contacts",
 		"people_get_contact",
 		"people_create_contact",
 		"people_update_contact",
 		"people_delete_contact",
+		"people_export_vcard",
+		"people_export_csv",
+		"people_list_directory",
+		"people_import_vcard",
+		"people_batch_create",
 		// Auth tools
 		"auth_status",
 		"auth_info",
+		"gsuite_selftest",
 		"auth_init",
 		"auth_complete",
 		"auth_revoke",
@@ -6,9 +6,11 @@ package server
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/harper/gsuite-mcp/pkg/timeutil"
 	"github.com/mark3labs/mcp-go/mcp"
 )
 
@@ -19,7 +21,7 @@ func (s *Server) registerPrompts() {
 		mcp.NewPrompt(
 			"email_triage",
 			mcp.WithPromptDescription("Help triage and organize unread emails efficiently"),
-			mcp.WithArgument("priority", mcp.ArgumentDescription("Priority level to focus on (urgent/normal/all)")),
+			mcp.WithArgument("priority", mcp.ArgumentDescription("Priority level to focus on (urgent/normal/all, default: all)")),
 		),
 		s.handleEmailTriagePrompt,
 	)
@@ -41,7 +43,7 @@ func (s *Server) registerPrompts() {
 			"compose_email",
 			mcp.WithPromptDescription("Help compose a professional email"),
 			mcp.WithArgument("context", mcp.ArgumentDescription("Context or purpose of the email"), mcp.RequiredArgument()),
-			mcp.WithArgument("tone", mcp.ArgumentDescription("Desired tone (formal/casual/friendly)")),
+			mcp.WithArgument("tone", mcp.ArgumentDescription("Desired tone (professional/formal/casual/friendly, default: professional)")),
 		),
 		s.handleComposeEmailPrompt,
 	)
@@ -61,7 +63,7 @@ func (s *Server) registerPrompts() {
 		mcp.NewPrompt(
 			"calendar_summary",
 			mcp.WithPromptDescription("Summarize calendar events for a time period"),
-			mcp.WithArgument("period", mcp.ArgumentDescription("Time period (today/tomorrow/this_week/next_week)")),
+			mcp.WithArgument("period", mcp.ArgumentDescription("Time period (today/tomorrow/this_week/next_week, default: today)")),
 		),
 		s.handleCalendarSummaryPrompt,
 	)
@@ -72,7 +74,7 @@ func (s *Server) registerPrompts() {
 			"follow_up_reminder",
 			mcp.WithPromptDescription("Set up follow-up reminders for important emails or meetings"),
 			mcp.WithArgument("context", mcp.ArgumentDescription("What needs follow-up"), mcp.RequiredArgument()),
-			mcp.WithArgument("when", mcp.ArgumentDescription("When to follow up (tomorrow/next_week/specific_date)")),
+			mcp.WithArgument("when", mcp.ArgumentDescription("When to follow up: "+timeutil.Expressions+" (default: tomorrow)")),
 		),
 		s.handleFollowUpReminderPrompt,
 	)
@@ -99,16 +101,71 @@ func (s *Server) registerPrompts() {
 		),
 		s.handleAddContactFromEmailPrompt,
 	)
+
+	// Bulk inbox cleanup prompt
+	s.mcp.AddPrompt(
+		mcp.NewPrompt(
+			"bulk_cleanup",
+			mcp.WithPromptDescription("Archive whole categories of low-value email in batches (never deletes)"),
+			mcp.WithArgument("categories", mcp.ArgumentDescription("Comma-separated buckets to clean: newsletters, notifications, receipts"), mcp.RequiredArgument()),
+		),
+		s.handleBulkCleanupPrompt,
+	)
+
+	// Weekly digest prompt
+	s.mcp.AddPrompt(
+		mcp.NewPrompt(
+			"weekly_digest",
+			mcp.WithPromptDescription("Catch up on recent email with a digest grouped by sender, label, and busy threads"),
+			mcp.WithArgument("days", mcp.ArgumentDescription(fmt.Sprintf("How many days back to cover (default: %d)", defaultDigestDays))),
+		),
+		s.handleWeeklyDigestPrompt,
+	)
+}
+
+// cleanupCategory is a bulk_cleanup bucket and the Gmail query that finds it
+type cleanupCategory struct {
+	name  string
+	label string
+	query string
+}
+
+// cleanupCategories are the buckets bulk_cleanup knows how to find, in prompt order
+var cleanupCategories = []cleanupCategory{
+	{name: "newsletters", label: "Newsletters", query: `in:inbox unsubscribe older_than:7d -is:starred -is:important`},
+	{name: "notifications", label: "Old notifications", query: `in:inbox category:updates older_than:30d -is:starred -is:important`},
+	{name: "receipts", label: "Automated receipts", query: `in:inbox (receipt OR invoice OR "order confirmation") older_than:30d -is:starred`},
+}
+
+// Accepted values for enumerated prompt arguments, default first
+var (
+	triagePriorities = []string{"all", "urgent", "normal"}
+	composeTones     = []string{"professional", "formal", "casual", "friendly"}
+	summaryPeriods   = []string{"today", "tomorrow", "this_week", "next_week"}
+)
+
+// enumArgument returns the named prompt argument trimmed and lowercased, or
+// allowed[0] when it is absent or empty. Any other value outside allowed is an
+// error listing the valid values, so a typo doesn't quietly fall back.
+func enumArgument(request mcp.GetPromptRequest, name string, allowed []string) (string, error) {
+	value := strings.ToLower(strings.TrimSpace(request.Params.Arguments[name]))
+	if value == "" {
+		return allowed[0], nil
+	}
+	for _, valid := range allowed {
+		if value == valid {
+			return value, nil
+		}
+	}
+	return "", fmt.Errorf("invalid %s %q (valid: %s)", name, request.Params.Arguments[name], strings.Join(allowed, ", "))
 }
 
 // Prompt handlers
 
 func (s *Server) handleEmailTriagePrompt(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
-	priority := "all"
-	if request.Params.Arguments != nil {
-		if p, ok := request.Params.Arguments["priority"]; ok {
-			priority = p
-		}
+	priority, err := enumArgument(request, "priority", triagePriorities)
+	if err != nil {
+		return nil, err
 	}
 
 	query := "is:unread"
@@ -160,8 +217,9 @@ func (s *Server) handleScheduleMeetingPrompt(ctx context.Context, request mcp.Ge
 
 	promptText := fmt.Sprintf(`I'll help you schedule a meeting. Here's my plan:
 
-1. **Check your calendar** for the next 7 days using calendar_list_events
-2. **Find available time slots** of %s minutes%s
+1. **Find available time slots** of %s minutes%s using calendar_suggest_slots
+   (checks free/busy for you and every attendee over the next 7 days)
+2. **Review context** with calendar_list_events if surrounding meetings matter
 3. **Suggest 3-5 best meeting times** considering:
    - No conflicts with existing events
    - Business hours (9 AM - 5 PM)
@@ -171,16 +229,13 @@ func (s *Server) handleScheduleMeetingPrompt(ctx context.Context, request mcp.Ge
 4. **Create the calendar event** once you choose a time
 
 **Timezone Handling:**
-- All calendar events use **America/Chicago** as primary timezone
-- If scheduling with international attendees, I'll provide times in BOTH timezones
-- Example: "9am Chicago time (4pm Zurich)" or "2pm Chicago time (7am Tokyo)"
-- Common timezone offsets from Chicago:
-  - Europe (CET/CEST): +7 hours
-  - UK (GMT/BST): +6 hours
-  - Tokyo (JST): +15 hours
-  - Sydney (AEDT): +17 hours
+- First call calendar_get_timezone to learn your calendar's timezone; don't assume one
+- Give times in that zone, with an explicit offset in RFC3339 when creating the event
+- If scheduling with attendees elsewhere, I'll provide times in BOTH timezones
+- Example: "9am New York time (3pm Zurich)", working out offsets for the meeting date
+  since daylight saving changes them
 
-Let me start by checking your calendar availability...`, duration, attendeeList)
+Let me start by checking your timezone and everyone's availability...`, duration, attendeeList)
 
 	messages := []mcp.PromptMessage{
 		mcp.NewPromptMessage(mcp.RoleUser, mcp.NewTextContent(promptText)),
@@ -191,15 +246,12 @@ Let me start by checking your calendar availability...`, duration, attendeeList)
 
 func (s *Server) handleComposeEmailPrompt(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
 	emailContext := "a professional email"
-	tone := "professional"
-
-	if request.Params.Arguments != nil {
-		if c, ok := request.Params.Arguments["context"]; ok && c != "" {
-			emailContext = c
-		}
-		if t, ok := request.Params.Arguments["tone"]; ok && t != "" {
-			tone = t
-		}
+	if c, ok := request.Params.Arguments["context"]; ok && c != "" {
+		emailContext = c
+	}
+	tone, err := enumArgument(request, "tone", composeTones)
+	if err != nil {
+		return nil, err
 	}
 
 	promptText := fmt.Sprintf(`I'll help you compose %s with a %s tone.
@@ -290,18 +342,15 @@ Let me search your contacts now...`, searchTerm, searchTerm)
 }
 
 func (s *Server) handleCalendarSummaryPrompt(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
-	period := "today"
-
-	if request.Params.Arguments != nil {
-		if p, ok := request.Params.Arguments["period"]; ok {
-			period = p
-		}
+	period, err := enumArgument(request, "period", summaryPeriods)
+	if err != nil {
+		return nil, err
 	}
 
 	var timeRange string
 	var description string
 
-	switch strings.ToLower(period) {
+	switch period {
 	case "today":
 		timeRange = "the next 24 hours"
 		description = "today's schedule"
@@ -314,9 +363,6 @@ func (s *Server) handleCalendarSummaryPrompt(ctx context.Context, request mcp.Ge
 	case "next_week":
 		timeRange = "next week (7-14 days from now)"
 		description = "next week's schedule"
-	default:
-		timeRange = "the specified period"
-		description = "schedule for the period"
 	}
 
 	promptText := fmt.Sprintf(`I'll provide a summary of %s.
@@ -358,7 +404,7 @@ func (s *Server) handleFollowUpReminderPrompt(ctx context.Context, request mcp.G
 		if c, ok := request.Params.Arguments["context"]; ok {
 			followUpContext = c
 		}
-		if w, ok := request.Params.Arguments["when"]; ok {
+		if w, ok := request.Params.Arguments["when"]; ok && strings.TrimSpace(w) != "" {
 			when = w
 		}
 	}
@@ -367,28 +413,17 @@ func (s *Server) handleFollowUpReminderPrompt(ctx context.Context, request mcp.G
 		return nil, fmt.Errorf("context argument is required")
 	}
 
-	var reminderTime time.Time
-	var timeDescription string
-
-	now := time.Now()
-	switch strings.ToLower(when) {
-	case "tomorrow":
-		reminderTime = now.Add(24 * time.Hour)
-		timeDescription = "tomorrow at 9 AM"
-	case "next_week":
-		reminderTime = now.Add(7 * 24 * time.Hour)
-		timeDescription = "next week at 9 AM"
-	default:
-		// Try to parse as date
-		parsed, err := time.Parse("2006-01-02", when)
-		if err != nil {
-			reminderTime = now.Add(24 * time.Hour)
-			timeDescription = "tomorrow at 9 AM"
-		} else {
-			reminderTime = parsed
-			timeDescription = parsed.Format("January 2 at 9 AM")
-		}
+	reminderTime, err := timeutil.ParseRelativeTime(when, time.Now(), s.loc)
+	if err != nil {
+		return nil, fmt.Errorf("invalid when: %w", err)
 	}
+	// Days without a time of their own mean the start of the work day. Set
+	// the wall clock rather than adding hours so DST changes don't shift it.
+	reminderTime = reminderTime.In(s.loc)
+	if y, m, d := reminderTime.Date(); reminderTime.Equal(time.Date(y, m, d, 0, 0, 0, 0, s.loc)) {
+		reminderTime = time.Date(y, m, d, 9, 0, 0, 0, s.loc)
+	}
+	timeDescription := reminderTime.Format("Monday, January 2 at 3:04 PM")
 
 	promptText := fmt.Sprintf(`I'll set up a follow-up reminder for: "%s"
 
@@ -472,20 +507,24 @@ Using gmail_get_message to retrieve:
 - Original subject line
 - Any attachments or references
 
-**Step 4: Draft the Reply**
-Create a draft using gmail_create_draft with:
+**Step 4: Preview, Then Draft the Reply**
+Call gmail_preview_reply with the message_id and body first - it returns the to, subject,
+in_reply_to, references, and thread_id the draft will get, without creating anything.
+Once they look right, create a draft using gmail_create_draft with:
 - **To**: Recipient email (CRITICAL - must explicitly provide, not auto-extracted!)
 - **Thread ID**: To keep it in conversation
 - **In-Reply-To**: Message ID of email we're replying to
 - **Subject**: Maintain thread subject (usually "Re: [original]")
 - **Body**: Your reply content
+- **Include Quote**: Set include_quote=true to quote the original below the reply
 - NO signature (unless explicitly requested)
 
 **Step 5: Verify Threading**
 After creating draft:
 - Confirm it appears in the correct conversation thread
 - Verify subject line maintains thread format
-- Ensure draft is saved (not sent)%s
+- Ensure draft is saved (not sent)
+- If it landed outside the thread, repair it with gmail_fix_draft_threading (draft_id and the original message_id)%s
 
 **Important Reminders:**
 - ALWAYS draft first, NEVER send directly
@@ -536,7 +575,8 @@ Using gmail_list_messages and gmail_get_message to retrieve:
 - Any contact details in the body
 
 **Step 2: Extract Contact Information**
-Look for:
+Run gmail_extract_contact_info on the message to get candidate emails, phones, URLs,
+and the signature's name/title/company, then confirm them against the email. Look for:
 - Full name
 - Email address(es)
 - Phone number(s)
@@ -561,12 +601,15 @@ If the person works for a company:
   - Context notes
 
 **Step 5: Add/Update Contact**
-Using people_create_contact:
+For the sender, gmail_message_to_contact does steps 2, 3, and 5 in one call: it builds
+the contact from the From header and signature, and returns the existing contact
+instead of creating a duplicate. Otherwise use people_create_contact:
 - Full name
 - Email address
-- Company association (ALWAYS link if known)
+- Company association (ALWAYS link if known): pass organization, or infer_org_from_email=true for a corporate address
 - Phone number
-- Notes: Include how you met, context from email, any relevant details
+- notes: how you met, context from the email, any relevant details
+- relations: people linked to the contact, e.g. an assistant who schedules for them
 
 **Step 6: Log the Interaction**
 If there's a meaningful exchange:
@@ -590,3 +633,105 @@ Let me start by finding and reading the email...`, searchQuery)
 
 	return mcp.NewGetPromptResult("Contact extraction and CRM workflow assistant", messages), nil
 }
+
+func (s *Server) handleBulkCleanupPrompt(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	requested := ""
+	if request.Params.Arguments != nil {
+		requested = request.Params.Arguments["categories"]
+	}
+
+	selected := make(map[string]bool)
+	for _, name := range strings.Split(requested, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		known := false
+		for _, category := range cleanupCategories {
+			if category.name == name {
+				known = true
+				break
+			}
+		}
+		if !known {
+			return nil, fmt.Errorf("unknown category %q (valid: newsletters, notifications, receipts)", name)
+		}
+		selected[name] = true
+	}
+
+	if len(selected) == 0 {
+		return nil, fmt.Errorf("categories argument is required (newsletters, notifications, receipts)")
+	}
+
+	var plan strings.Builder
+	step := 1
+	for _, category := range cleanupCategories {
+		if !selected[category.name] {
+			continue
+		}
+		fmt.Fprintf(&plan, "%d. **%s** - gmail_list_messages with query: `%s`\n", step, category.label, category.query)
+		step++
+	}
+
+	promptText := fmt.Sprintf(`I'll help you get to inbox zero by archiving whole categories of email in batches.
+
+**Categories to clean:**
+%s
+**Workflow for each category:**
+1. Run the query with gmail_list_messages (max_results 50) and show me a sample of senders and subjects
+2. Ask me to confirm before archiving; to keep a sender's mail, add -from:<sender> to the query
+3. Archive the batch in one call with gmail_modify_labels_by_query: the confirmed query, remove_labels ["INBOX"], and max_messages 50
+4. Repeat until cap_reached is false, then report how many messages were archived
+
+**Important:** NEVER delete or trash emails. Only archive them by removing the INBOX label - archived mail stays searchable in All Mail. Starred and important messages are excluded from every query.
+
+Let me start with the first category...`, plan.String())
+
+	messages := []mcp.PromptMessage{
+		mcp.NewPromptMessage(mcp.RoleUser, mcp.NewTextContent(promptText)),
+	}
+
+	return mcp.NewGetPromptResult("Bulk inbox cleanup that archives selected categories in batches", messages), nil
+}
+
+func (s *Server) handleWeeklyDigestPrompt(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	days := defaultDigestDays
+	if request.Params.Arguments != nil {
+		if d := strings.TrimSpace(request.Params.Arguments["days"]); d != "" {
+			parsed, err := strconv.Atoi(d)
+			if err != nil || parsed < 1 || parsed > maxDigestDays {
+				return nil, fmt.Errorf("days must be a whole number between 1 and %d", maxDigestDays)
+			}
+			days = parsed
+		}
+	}
+
+	promptText := fmt.Sprintf(`I'll catch you up on the last %d days of email.
+
+**Step 1: Build the digest**
+Call gmail_digest with days=%d. It returns:
+- Message volume by sender domain, with the top senders in each
+- Message counts per label
+- Busy threads (several messages in the window) and threads Gmail marked important
+- Whether it was truncated (more mail than it read)
+
+**Step 2: Summarize**
+- **Headline**: total volume and anything unusual about it
+- **Needs attention**: important threads and busy threads, with who wrote last and what it's about
+- **Who's writing**: the domains and people sending the most
+- **Noise**: promotions, updates, and newsletters worth archiving later
+If the digest was truncated, say so and offer to narrow the window.
+
+**Step 3: Offer next steps**
+- Open a thread with gmail_get_message for the full story
+- Draft replies with gmail_create_draft (never send directly)
+- Suggest bulk_cleanup for the noisy categories (archive only, never delete)
+
+Let me build your digest...`, days, days)
+
+	messages := []mcp.PromptMessage{
+		mcp.NewPromptMessage(mcp.RoleUser, mcp.NewTextContent(promptText)),
+	}
+
+	return mcp.NewGetPromptResult("Email digest to catch up on recent mail", messages), nil
+}
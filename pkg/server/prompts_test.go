@@ -0,0 +1,156 @@
+// ABOUTME: Tests for MCP prompt rendering
+// ABOUTME: Validates prompt text built from arguments and argument validation, including enum values
+
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func bulkCleanupRequest(categories string) mcp.GetPromptRequest {
+	return mcp.GetPromptRequest{
+		Params: mcp.GetPromptParams{
+			Name:      "bulk_cleanup",
+			Arguments: map[string]string{"categories": categories},
+		},
+	}
+}
+
+func TestHandleBulkCleanupPrompt_RendersRequestedQueries(t *testing.T) {
+	s := &Server{}
+
+	result, err := s.handleBulkCleanupPrompt(context.Background(), bulkCleanupRequest(" Newsletters , receipts"))
+	require.NoError(t, err)
+	require.Len(t, result.Messages, 1)
+
+	text := result.Messages[0].Content.(mcp.TextContent).Text
+	assert.Contains(t, text, cleanupCategories[0].query)
+	assert.Contains(t, text, cleanupCategories[2].query)
+	assert.NotContains(t, text, cleanupCategories[1].query, "unrequested categories are left out")
+	assert.Contains(t, text, "gmail_modify_labels_by_query")
+	assert.Contains(t, text, "NEVER delete")
+}
+
+func TestHandleBulkCleanupPrompt_InvalidCategories(t *testing.T) {
+	s := &Server{}
+
+	for _, categories := range []string{"", " , ", "newsletters,spam"} {
+		_, err := s.handleBulkCleanupPrompt(context.Background(), bulkCleanupRequest(categories))
+		assert.Error(t, err, "categories %q", categories)
+	}
+}
+
+func TestHandleWeeklyDigestPrompt(t *testing.T) {
+	s := &Server{}
+
+	result, err := s.handleWeeklyDigestPrompt(context.Background(), mcp.GetPromptRequest{})
+	require.NoError(t, err)
+	text := result.Messages[0].Content.(mcp.TextContent).Text
+	assert.Contains(t, text, "gmail_digest with days=7")
+
+	result, err = s.handleWeeklyDigestPrompt(context.Background(), mcp.GetPromptRequest{
+		Params: mcp.GetPromptParams{Name: "weekly_digest", Arguments: map[string]string{"days": "3"}},
+	})
+	require.NoError(t, err)
+	assert.Contains(t, result.Messages[0].Content.(mcp.TextContent).Text, "days=3")
+
+	for _, days := range []string{"0", "week", "90"} {
+		_, err := s.handleWeeklyDigestPrompt(context.Background(), mcp.GetPromptRequest{
+			Params: mcp.GetPromptParams{Name: "weekly_digest", Arguments: map[string]string{"days": days}},
+		})
+		assert.Error(t, err, "days %q", days)
+	}
+}
+
+func TestPromptEnumArguments(t *testing.T) {
+	s := &Server{}
+	request := func(name string, args map[string]string) mcp.GetPromptRequest {
+		return mcp.GetPromptRequest{Params: mcp.GetPromptParams{Name: name, Arguments: args}}
+	}
+
+	_, err := s.handleCalendarSummaryPrompt(context.Background(), request("calendar_summary", map[string]string{"period": "thsi_week"}))
+	assert.EqualError(t, err, `invalid period "thsi_week" (valid: today, tomorrow, this_week, next_week)`)
+	_, err = s.handleEmailTriagePrompt(context.Background(), request("email_triage", map[string]string{"priority": "high"}))
+	assert.EqualError(t, err, `invalid priority "high" (valid: all, urgent, normal)`)
+	_, err = s.handleComposeEmailPrompt(context.Background(), request("compose_email", map[string]string{"context": "a thank-you", "tone": "snarky"}))
+	assert.EqualError(t, err, `invalid tone "snarky" (valid: professional, formal, casual, friendly)`)
+
+	// Valid values are case-insensitive and missing ones use the default
+	result, err := s.handleCalendarSummaryPrompt(context.Background(), request("calendar_summary", map[string]string{"period": "Next_Week"}))
+	require.NoError(t, err)
+	assert.Contains(t, result.Messages[0].Content.(mcp.TextContent).Text, "next week's schedule")
+	result, err = s.handleCalendarSummaryPrompt(context.Background(), request("calendar_summary", nil))
+	require.NoError(t, err)
+	assert.Contains(t, result.Messages[0].Content.(mcp.TextContent).Text, "today's schedule")
+	result, err = s.handleEmailTriagePrompt(context.Background(), request("email_triage", map[string]string{"priority": "urgent"}))
+	require.NoError(t, err)
+	assert.Contains(t, result.Messages[0].Content.(mcp.TextContent).Text, "is:unread is:important")
+	result, err = s.handleComposeEmailPrompt(context.Background(), request("compose_email", map[string]string{"context": "a thank-you"}))
+	require.NoError(t, err)
+	assert.Contains(t, result.Messages[0].Content.(mcp.TextContent).Text, "with a professional tone")
+}
+
+func TestHandleScheduleMeetingPrompt_ChecksTimezone(t *testing.T) {
+	s := &Server{}
+
+	result, err := s.handleScheduleMeetingPrompt(context.Background(), mcp.GetPromptRequest{})
+	require.NoError(t, err)
+	text := result.Messages[0].Content.(mcp.TextContent).Text
+	assert.Contains(t, text, "calendar_get_timezone")
+	assert.NotContains(t, text, "Chicago", "the prompt must not assume a timezone")
+}
+
+func TestHandleFollowUpReminderPrompt_RelativeWhen(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	s := &Server{loc: loc}
+	request := func(when string) mcp.GetPromptRequest {
+		return mcp.GetPromptRequest{Params: mcp.GetPromptParams{Name: "follow_up_reminder", Arguments: map[string]string{"context": "the proposal", "when": when}}}
+	}
+
+	result, err := s.handleFollowUpReminderPrompt(context.Background(), request("2026-03-20"))
+	require.NoError(t, err)
+	text := result.Messages[0].Content.(mcp.TextContent).Text
+	assert.Contains(t, text, "Friday, March 20 at 9:00 AM")
+	assert.Contains(t, text, "2026-03-20T09:00:00-04:00")
+
+	result, err = s.handleFollowUpReminderPrompt(context.Background(), request("2026-03-20T15:30:00Z"))
+	require.NoError(t, err)
+	assert.Contains(t, result.Messages[0].Content.(mcp.TextContent).Text, "Friday, March 20 at 11:30 AM", "explicit times are kept")
+
+	_, err = s.handleFollowUpReminderPrompt(context.Background(), request("whenever"))
+	assert.ErrorContains(t, err, `unrecognized time "whenever"`)
+}
+
+func TestHandleFollowUpReminderPrompt_DefaultsToNineAM(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	require.NoError(t, err)
+	request := func(when string) mcp.GetPromptRequest {
+		return mcp.GetPromptRequest{Params: mcp.GetPromptParams{Name: "follow_up_reminder", Arguments: map[string]string{"context": "the proposal", "when": when}}}
+	}
+
+	s := &Server{loc: newYork}
+	result, err := s.handleFollowUpReminderPrompt(context.Background(), request("2026-03-08"))
+	require.NoError(t, err)
+	assert.Contains(t, result.Messages[0].Content.(mcp.TextContent).Text, "2026-03-08T09:00:00-04:00", "spring-forward days still start at 9")
+
+	result, err = s.handleFollowUpReminderPrompt(context.Background(), request(""))
+	require.NoError(t, err)
+	tomorrow := time.Now().In(newYork).AddDate(0, 0, 1)
+	expected := time.Date(tomorrow.Year(), tomorrow.Month(), tomorrow.Day(), 9, 0, 0, 0, newYork)
+	assert.Contains(t, result.Messages[0].Content.(mcp.TextContent).Text, expected.Format(time.RFC3339), "an empty when means tomorrow")
+
+	// Midnight in the server's timezone, written in UTC where it's still the previous day
+	s = &Server{loc: tokyo}
+	result, err = s.handleFollowUpReminderPrompt(context.Background(), request("2026-03-19T15:00:00Z"))
+	require.NoError(t, err)
+	assert.Contains(t, result.Messages[0].Content.(mcp.TextContent).Text, "2026-03-20T09:00:00+09:00")
+}
@@ -7,9 +7,15 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sync"
 	"time"
 
+	"github.com/harper/gsuite-mcp/pkg/calendar"
+	"github.com/harper/gsuite-mcp/pkg/config"
+	"github.com/harper/gsuite-mcp/pkg/gmail"
 	"github.com/mark3labs/mcp-go/mcp"
+	googlecalendar "google.golang.org/api/calendar/v3"
+	googlegmail "google.golang.org/api/gmail/v1"
 )
 
 // registerResources registers all MCP resources
@@ -69,6 +75,17 @@ func (s *Server) registerResources() {
 		s.handleRecentContactsResource,
 	)
 
+	// Latest thread with each recent contact
+	s.mcp.AddResource(
+		mcp.NewResource(
+			"gsuite://contacts/recent-threads",
+			"Recent Contact Threads",
+			mcp.WithResourceDescription("Last email interaction with each recent contact"),
+			mcp.WithMIMEType("application/json"),
+		),
+		s.handleRecentContactThreadsResource,
+	)
+
 	// Upcoming meetings
 	s.mcp.AddResource(
 		mcp.NewResource(
@@ -91,6 +108,39 @@ func (s *Server) registerResources() {
 		s.handleCalendarAvailabilityResource,
 	)
 
+	// Weekly meeting load
+	s.mcp.AddResource(
+		mcp.NewResource(
+			"gsuite://calendar/load",
+			"Meeting Load",
+			mcp.WithResourceDescription("This week's meeting hours, longest focus block, busiest day, and share of work hours in meetings"),
+			mcp.WithMIMEType("application/json"),
+		),
+		s.handleCalendarLoadResource,
+	)
+
+	// Unanswered invitations
+	s.mcp.AddResource(
+		mcp.NewResource(
+			"gsuite://calendar/invites",
+			"Pending Invitations",
+			mcp.WithResourceDescription(fmt.Sprintf("Invitations in the next %d days you haven't responded to, with other attendees' responses", inviteWindowDays)),
+			mcp.WithMIMEType("application/json"),
+		),
+		s.handleCalendarInvitesResource,
+	)
+
+	// Reminder schedule
+	s.mcp.AddResource(
+		mcp.NewResource(
+			"gsuite://calendar/reminders",
+			"Upcoming Reminders",
+			mcp.WithResourceDescription("Events in the next day with their reminder settings and when each reminder fires"),
+			mcp.WithMIMEType("application/json"),
+		),
+		s.handleCalendarRemindersResource,
+	)
+
 	// Draft emails
 	s.mcp.AddResource(
 		mcp.NewResource(
@@ -101,16 +151,34 @@ func (s *Server) registerResources() {
 		),
 		s.handleDraftsResource,
 	)
+
+	// One-fetch session overview
+	s.mcp.AddResource(
+		mcp.NewResource(
+			"gsuite://overview",
+			"Overview",
+			mcp.WithResourceDescription("Mailbox-wide unread, important-unread, and draft totals, today's event count, and the next upcoming event in one fetch"),
+			mcp.WithMIMEType("application/json"),
+		),
+		s.handleOverviewResource,
+	)
 }
 
 // Resource handlers
 
-func (s *Server) handleTodayCalendarResource(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
-	now := time.Now()
-	startOfDay := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+// dayEvents lists the events on day's date in the server's timezone, returning
+// the start of that day along with them
+func (s *Server) dayEvents(ctx context.Context, day time.Time) (time.Time, []*googlecalendar.Event, error) {
+	day = day.In(s.loc)
+	startOfDay := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, s.loc)
 	endOfDay := startOfDay.Add(24 * time.Hour)
 
-	events, err := s.calendar.ListEvents(ctx, 50, startOfDay, endOfDay)
+	events, err := s.calendar.ListEvents(ctx, 50, startOfDay, endOfDay, nil)
+	return startOfDay, events, err
+}
+
+func (s *Server) handleTodayCalendarResource(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	startOfDay, events, err := s.dayEvents(ctx, time.Now().In(s.loc))
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch today's events: %w", err)
 	}
@@ -135,15 +203,15 @@ func (s *Server) handleTodayCalendarResource(ctx context.Context, request mcp.Re
 }
 
 func (s *Server) handleThisWeekCalendarResource(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
-	now := time.Now()
-	startOfWeek := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	now := time.Now().In(s.loc)
+	startOfWeek := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, s.loc)
 	// Adjust to Monday if not already
 	for startOfWeek.Weekday() != time.Monday {
-		startOfWeek = startOfWeek.Add(-24 * time.Hour)
+		startOfWeek = startOfWeek.AddDate(0, 0, -1)
 	}
-	endOfWeek := startOfWeek.Add(7 * 24 * time.Hour)
+	endOfWeek := startOfWeek.AddDate(0, 0, 7)
 
-	events, err := s.calendar.ListEvents(ctx, 100, startOfWeek, endOfWeek)
+	events, err := s.calendar.ListEvents(ctx, 100, startOfWeek, endOfWeek, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch this week's events: %w", err)
 	}
@@ -157,7 +225,7 @@ func (s *Server) handleThisWeekCalendarResource(ctx context.Context, request mcp
 			if event.Start.DateTime != "" {
 				t, err := time.Parse(time.RFC3339, event.Start.DateTime)
 				if err == nil {
-					eventDate = t.Format("2006-01-02")
+					eventDate = t.In(s.loc).Format("2006-01-02")
 				}
 			} else if event.Start.Date != "" {
 				// All-day event
@@ -193,8 +261,23 @@ func (s *Server) handleThisWeekCalendarResource(ctx context.Context, request mcp
 	}, nil
 }
 
+// unreadMessages lists up to 20 unread messages
+func (s *Server) unreadMessages(ctx context.Context) ([]*googlegmail.Message, error) {
+	return s.gmail.ListMessages(ctx, "is:unread", 20)
+}
+
+// importantUnreadMessages lists up to 10 unread messages Gmail marked important
+func (s *Server) importantUnreadMessages(ctx context.Context) ([]*googlegmail.Message, error) {
+	return s.gmail.ListMessages(ctx, "is:unread is:important", 10)
+}
+
+// upcomingEvents lists up to max events in the 7 days after now
+func (s *Server) upcomingEvents(ctx context.Context, now time.Time, max int64) ([]*googlecalendar.Event, error) {
+	return s.calendar.ListEvents(ctx, max, now, now.Add(7*24*time.Hour), nil)
+}
+
 func (s *Server) handleUnreadEmailsResource(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
-	messages, err := s.gmail.ListMessages(ctx, "is:unread", 20)
+	messages, err := s.unreadMessages(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch unread emails: %w", err)
 	}
@@ -202,7 +285,7 @@ func (s *Server) handleUnreadEmailsResource(ctx context.Context, request mcp.Rea
 	data, err := json.MarshalIndent(map[string]interface{}{
 		"unread_count": len(messages),
 		"messages":     messages,
-		"timestamp":    time.Now().Format(time.RFC3339),
+		"timestamp":    time.Now().In(s.loc).Format(time.RFC3339),
 	}, "", "  ")
 	if err != nil {
 		return nil, err
@@ -218,7 +301,7 @@ func (s *Server) handleUnreadEmailsResource(ctx context.Context, request mcp.Rea
 }
 
 func (s *Server) handleImportantEmailsResource(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
-	messages, err := s.gmail.ListMessages(ctx, "is:unread is:important", 10)
+	messages, err := s.importantUnreadMessages(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch important emails: %w", err)
 	}
@@ -226,7 +309,7 @@ func (s *Server) handleImportantEmailsResource(ctx context.Context, request mcp.
 	data, err := json.MarshalIndent(map[string]interface{}{
 		"important_unread_count": len(messages),
 		"messages":               messages,
-		"timestamp":              time.Now().Format(time.RFC3339),
+		"timestamp":              time.Now().In(s.loc).Format(time.RFC3339),
 	}, "", "  ")
 	if err != nil {
 		return nil, err
@@ -242,7 +325,7 @@ func (s *Server) handleImportantEmailsResource(ctx context.Context, request mcp.
 }
 
 func (s *Server) handleRecentContactsResource(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
-	contacts, err := s.people.ListContacts(ctx, 20)
+	contacts, err := s.people.ListContacts(ctx, 20, "")
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch recent contacts: %w", err)
 	}
@@ -250,7 +333,76 @@ func (s *Server) handleRecentContactsResource(ctx context.Context, request mcp.R
 	data, err := json.MarshalIndent(map[string]interface{}{
 		"contact_count": len(contacts),
 		"contacts":      contacts,
-		"timestamp":     time.Now().Format(time.RFC3339),
+		"timestamp":     time.Now().In(s.loc).Format(time.RFC3339),
+	}, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return []mcp.ResourceContents{
+		mcp.TextResourceContents{
+			URI:      request.Params.URI,
+			MIMEType: "application/json",
+			Text:     string(data),
+		},
+	}, nil
+}
+
+// LastInteraction is the most recent message exchanged with a contact
+type LastInteraction struct {
+	ThreadID  string `json:"thread_id"`
+	MessageID string `json:"message_id"`
+	Subject   string `json:"subject"`
+	Date      string `json:"date"`
+}
+
+// ContactThread pairs a contact with their latest Gmail interaction
+type ContactThread struct {
+	ResourceName    string           `json:"resource_name"`
+	Name            string           `json:"name,omitempty"`
+	Email           string           `json:"email,omitempty"`
+	LastInteraction *LastInteraction `json:"last_interaction"` // null when no mail was found
+	Note            string           `json:"note,omitempty"`
+}
+
+func (s *Server) handleRecentContactThreadsResource(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	limit := s.recentThreads
+	if limit <= 0 {
+		limit = config.DefaultRecentThreadContacts
+	}
+
+	contacts, err := s.people.ListContacts(ctx, int64(limit), "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch recent contacts: %w", err)
+	}
+
+	threads := make([]ContactThread, 0, len(contacts))
+	for _, person := range contacts {
+		entry := ContactThread{ResourceName: person.ResourceName}
+		if len(person.Names) > 0 {
+			entry.Name = person.Names[0].DisplayName
+		}
+		if len(person.EmailAddresses) > 0 {
+			entry.Email = person.EmailAddresses[0].Value
+		}
+
+		switch last, err := s.lastInteraction(ctx, entry.Email); {
+		case entry.Email == "":
+			entry.Note = "no email address"
+		case err != nil:
+			entry.Note = fmt.Sprintf("lookup failed: %v", err)
+		case last == nil:
+			entry.Note = "no interactions"
+		default:
+			entry.LastInteraction = last
+		}
+		threads = append(threads, entry)
+	}
+
+	data, err := json.MarshalIndent(map[string]interface{}{
+		"contact_count": len(threads),
+		"contacts":      threads,
+		"timestamp":     time.Now().In(s.loc).Format(time.RFC3339),
 	}, "", "  ")
 	if err != nil {
 		return nil, err
@@ -265,12 +417,43 @@ func (s *Server) handleRecentContactsResource(ctx context.Context, request mcp.R
 	}, nil
 }
 
+// lastInteraction finds the newest message to or from email. It returns nil
+// without error when email is empty or no message matches.
+func (s *Server) lastInteraction(ctx context.Context, email string) (*LastInteraction, error) {
+	if email == "" {
+		return nil, nil
+	}
+
+	messages, err := s.gmail.ListMessages(ctx, fmt.Sprintf("from:%s OR to:%s", email, email), 1)
+	if err != nil {
+		return nil, err
+	}
+	if len(messages) == 0 {
+		return nil, nil
+	}
+
+	msg, err := s.gmail.GetMessageMetadata(ctx, messages[0].Id, "Subject")
+	if err != nil {
+		return nil, err
+	}
+
+	last := &LastInteraction{
+		ThreadID:  msg.ThreadId,
+		MessageID: msg.Id,
+		Date:      time.UnixMilli(msg.InternalDate).In(s.loc).Format(time.RFC3339),
+	}
+	if parsed, err := gmail.ParseMessage(msg); err == nil {
+		last.Subject = parsed.Header("Subject")
+	}
+	return last, nil
+}
+
 func (s *Server) handleUpcomingMeetingsResource(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
-	now := time.Now()
+	now := time.Now().In(s.loc)
 	// Get events for next 7 days
 	endTime := now.Add(7 * 24 * time.Hour)
 
-	events, err := s.calendar.ListEvents(ctx, 5, now, endTime)
+	events, err := s.upcomingEvents(ctx, now, 5)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch upcoming meetings: %w", err)
 	}
@@ -297,72 +480,37 @@ func (s *Server) handleUpcomingMeetingsResource(ctx context.Context, request mcp
 }
 
 func (s *Server) handleCalendarAvailabilityResource(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
-	now := time.Now()
-	endTime := now.Add(7 * 24 * time.Hour)
+	hours, err := calendar.ParseWorkHours(s.workStart, s.workEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().In(s.loc)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, s.loc)
+	endTime := today.AddDate(0, 0, 7)
 
-	events, err := s.calendar.ListEvents(ctx, 100, now, endTime)
+	events, err := s.calendar.ListEvents(ctx, 100, today, endTime, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch calendar for availability: %w", err)
 	}
 
-	// Calculate free/busy slots by day
+	// Calculate free/busy hours by day against the configured working day
+	load := calendar.ComputeMeetingLoad(events, today, endTime, hours)
+	workDayHours := (hours.End - hours.Start).Hours()
 	availability := make(map[string]interface{})
 
-	for day := 0; day < 7; day++ {
-		currentDay := now.Add(time.Duration(day) * 24 * time.Hour)
-		dayKey := currentDay.Format("2006-01-02")
-		dayName := currentDay.Format("Monday")
-
-		busyHours := 0.0
-		dayEvents := 0
-
-		// Count busy hours for this day
-		for _, event := range events {
-			if event.Start == nil || event.End == nil {
-				continue
-			}
-
-			// Parse start time
-			var startTime time.Time
-			var err error
-			if event.Start.DateTime != "" {
-				startTime, err = time.Parse(time.RFC3339, event.Start.DateTime)
-				if err != nil {
-					continue
-				}
-			} else {
-				// Skip all-day events for busy hours calculation
-				continue
-			}
-
-			// Check if event is on this day
-			if startTime.Format("2006-01-02") == dayKey {
-				// Parse end time
-				var endTime time.Time
-				if event.End.DateTime != "" {
-					endTime, err = time.Parse(time.RFC3339, event.End.DateTime)
-					if err != nil {
-						continue
-					}
-					duration := endTime.Sub(startTime).Hours()
-					busyHours += duration
-					dayEvents++
-				}
-			}
-		}
-
-		// Business hours: 8 AM - 6 PM = 10 hours
-		freeHours := 10.0 - busyHours
+	for _, day := range load.Days {
+		freeHours := workDayHours - day.MeetingHours
 		if freeHours < 0 {
 			freeHours = 0
 		}
 
-		availability[dayKey] = map[string]interface{}{
-			"day_name":    dayName,
-			"busy_hours":  busyHours,
+		availability[day.Date] = map[string]interface{}{
+			"day_name":    day.Weekday,
+			"busy_hours":  day.MeetingHours,
 			"free_hours":  freeHours,
-			"event_count": dayEvents,
-			"status":      getAvailabilityStatus(busyHours),
+			"event_count": day.MeetingCount,
+			"status":      getAvailabilityStatus(day.MeetingHours),
 		}
 	}
 
@@ -384,6 +532,114 @@ func (s *Server) handleCalendarAvailabilityResource(ctx context.Context, request
 	}, nil
 }
 
+func (s *Server) handleCalendarLoadResource(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	hours, err := calendar.ParseWorkHours(s.workStart, s.workEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().In(s.loc)
+	startOfWeek := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, s.loc)
+	for startOfWeek.Weekday() != time.Monday {
+		startOfWeek = startOfWeek.AddDate(0, 0, -1)
+	}
+	endOfWeek := startOfWeek.AddDate(0, 0, 7)
+
+	events, err := s.calendar.ListEvents(ctx, 250, startOfWeek, endOfWeek, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch calendar for meeting load: %w", err)
+	}
+
+	data, err := json.MarshalIndent(struct {
+		*calendar.MeetingLoad
+		WorkStart   string `json:"work_start"`
+		WorkEnd     string `json:"work_end"`
+		GeneratedAt string `json:"generated_at"`
+	}{
+		MeetingLoad: calendar.ComputeMeetingLoad(events, startOfWeek, endOfWeek, hours),
+		WorkStart:   s.workStart,
+		WorkEnd:     s.workEnd,
+		GeneratedAt: now.Format(time.RFC3339),
+	}, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return []mcp.ResourceContents{
+		mcp.TextResourceContents{
+			URI:      request.Params.URI,
+			MIMEType: "application/json",
+			Text:     string(data),
+		},
+	}, nil
+}
+
+// inviteWindowDays is how far ahead gsuite://calendar/invites looks for unanswered invitations
+const inviteWindowDays = 14
+
+func (s *Server) handleCalendarInvitesResource(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	now := time.Now().In(s.loc)
+	endTime := now.AddDate(0, 0, inviteWindowDays)
+
+	events, err := s.calendar.ListEvents(ctx, 250, now, endTime, calendar.DefaultListEventsOptions())
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch calendar for invitations: %w", err)
+	}
+
+	invites := calendar.PendingInvites(events)
+	data, err := json.MarshalIndent(map[string]interface{}{
+		"period":       fmt.Sprintf("next %d days", inviteWindowDays),
+		"invite_count": len(invites),
+		"invites":      invites,
+		"timestamp":    now.Format(time.RFC3339),
+	}, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return []mcp.ResourceContents{
+		mcp.TextResourceContents{
+			URI:      request.Params.URI,
+			MIMEType: "application/json",
+			Text:     string(data),
+		},
+	}, nil
+}
+
+func (s *Server) handleCalendarRemindersResource(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	now := time.Now().In(s.loc)
+	endTime := now.Add(24 * time.Hour)
+
+	events, err := s.calendar.ListEvents(ctx, 250, now, endTime, calendar.DefaultListEventsOptions())
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch upcoming events: %w", err)
+	}
+	defaults, err := s.calendar.DefaultReminders(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch default reminders: %w", err)
+	}
+
+	reminders := calendar.UpcomingReminders(events, defaults, s.loc, now)
+	data, err := json.MarshalIndent(map[string]interface{}{
+		"period":      "next 24 hours",
+		"timezone":    s.loc.String(),
+		"event_count": len(reminders),
+		"events":      reminders,
+		"timestamp":   now.Format(time.RFC3339),
+	}, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return []mcp.ResourceContents{
+		mcp.TextResourceContents{
+			URI:      request.Params.URI,
+			MIMEType: "application/json",
+			Text:     string(data),
+		},
+	}, nil
+}
+
 func (s *Server) handleDraftsResource(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
 	// List draft emails
 	drafts, err := s.gmail.ListDrafts(ctx, 10)
@@ -394,7 +650,7 @@ func (s *Server) handleDraftsResource(ctx context.Context, request mcp.ReadResou
 	data, err := json.MarshalIndent(map[string]interface{}{
 		"draft_count": len(drafts),
 		"drafts":      drafts,
-		"timestamp":   time.Now().Format(time.RFC3339),
+		"timestamp":   time.Now().In(s.loc).Format(time.RFC3339),
 	}, "", "  ")
 	if err != nil {
 		return nil, err
@@ -409,8 +665,138 @@ func (s *Server) handleDraftsResource(ctx context.Context, request mcp.ReadResou
 	}, nil
 }
 
+// Bounds on the gsuite://overview fan-out
+const (
+	overviewConcurrency    = 3               // Sections fetched at once
+	overviewSectionTimeout = 5 * time.Second // A slower section is reported as failed
+)
+
+// OverviewCount is one counted section of gsuite://overview. Mail counts are
+// mailbox totals from the system labels; today's events are capped at 50.
+type OverviewCount struct {
+	Count int    `json:"count"`
+	Error string `json:"error,omitempty"` // Set when the section couldn't be fetched; Count is then 0
+}
+
+// OverviewNextEvent is the next-event section of gsuite://overview
+type OverviewNextEvent struct {
+	Event *BulkEventSummary `json:"event"` // Nil when nothing is scheduled in the next 7 days
+	Error string            `json:"error,omitempty"`
+}
+
+// Overview is the gsuite://overview resource. Each section is fetched
+// independently, so one failing service leaves the others intact.
+type Overview struct {
+	Unread          OverviewCount     `json:"unread"`
+	ImportantUnread OverviewCount     `json:"important_unread"`
+	Drafts          OverviewCount     `json:"drafts"`
+	TodayEvents     OverviewCount     `json:"today_events"`
+	NextEvent       OverviewNextEvent `json:"next_event"`
+	Complete        bool              `json:"complete"` // False if any section has an error
+	Timestamp       string            `json:"timestamp"`
+}
+
+// overview fetches every section concurrently, each with its own timeout
+func (s *Server) overview(ctx context.Context) *Overview {
+	now := time.Now().In(s.loc)
+	result := &Overview{Timestamp: now.Format(time.RFC3339)}
+
+	count := func(section *OverviewCount, fetch func(context.Context) (int, error)) func(context.Context) {
+		return func(ctx context.Context) {
+			n, err := fetch(ctx)
+			if err != nil {
+				section.Error = err.Error()
+				return
+			}
+			section.Count = n
+		}
+	}
+	sections := []func(context.Context){
+		count(&result.Unread, func(ctx context.Context) (int, error) {
+			label, err := s.gmail.GetLabel(ctx, "UNREAD")
+			if err != nil {
+				return 0, err
+			}
+			return int(label.MessagesUnread), nil
+		}),
+		count(&result.ImportantUnread, func(ctx context.Context) (int, error) {
+			label, err := s.gmail.GetLabel(ctx, "IMPORTANT")
+			if err != nil {
+				return 0, err
+			}
+			return int(label.MessagesUnread), nil
+		}),
+		count(&result.Drafts, func(ctx context.Context) (int, error) {
+			label, err := s.gmail.GetLabel(ctx, "DRAFT")
+			if err != nil {
+				return 0, err
+			}
+			return int(label.MessagesTotal), nil
+		}),
+		count(&result.TodayEvents, func(ctx context.Context) (int, error) {
+			_, events, err := s.dayEvents(ctx, now)
+			return len(events), err
+		}),
+		func(ctx context.Context) {
+			events, err := s.upcomingEvents(ctx, now, 1)
+			if err != nil {
+				result.NextEvent.Error = err.Error()
+				return
+			}
+			if len(events) > 0 {
+				result.NextEvent.Event = &BulkEventSummary{ID: events[0].Id, Summary: events[0].Summary, Start: eventStart(events[0])}
+			}
+		},
+	}
+
+	sem := make(chan struct{}, overviewConcurrency)
+	var wg sync.WaitGroup
+	for _, section := range sections {
+		wg.Add(1)
+		go func(section func(context.Context)) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			sectionCtx, cancel := context.WithTimeout(ctx, overviewSectionTimeout)
+			defer cancel()
+			section(sectionCtx)
+		}(section)
+	}
+	wg.Wait()
+
+	result.Complete = result.Unread.Error == "" && result.ImportantUnread.Error == "" &&
+		result.Drafts.Error == "" && result.TodayEvents.Error == "" && result.NextEvent.Error == ""
+	return result
+}
+
+func (s *Server) handleOverviewResource(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	data, err := json.MarshalIndent(s.overview(ctx), "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return []mcp.ResourceContents{
+		mcp.TextResourceContents{
+			URI:      request.Params.URI,
+			MIMEType: "application/json",
+			Text:     string(data),
+		},
+	}, nil
+}
+
 // Helper functions
 
+// eventStart returns an event's start dateTime, or its date for all-day events
+func eventStart(event *googlecalendar.Event) string {
+	if event.Start == nil {
+		return ""
+	}
+	if event.Start.DateTime != "" {
+		return event.Start.DateTime
+	}
+	return event.Start.Date
+}
+
 func getAvailabilityStatus(busyHours float64) string {
 	if busyHours < 3 {
 		return "available"
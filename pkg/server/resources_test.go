@@ -0,0 +1,308 @@
+// ABOUTME: Tests for MCP resource handlers backed by a fake API server
+// ABOUTME: Validates the recent-threads lookup, meeting-load analytics, pending invitations, and the overview
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandleRecentContactThreadsResource(t *testing.T) {
+	var contactPageSize string
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		var body interface{}
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/connections"):
+			contactPageSize = r.URL.Query().Get("pageSize")
+			body = map[string]interface{}{"connections": []map[string]interface{}{
+				{
+					"resourceName":   "people/c1",
+					"names":          []map[string]string{{"displayName": "Alice Active"}},
+					"emailAddresses": []map[string]string{{"value": "alice@example.com"}},
+				},
+				{
+					"resourceName":   "people/c2",
+					"names":          []map[string]string{{"displayName": "Quiet Quinn"}},
+					"emailAddresses": []map[string]string{{"value": "quinn@example.com"}},
+				},
+			}}
+		case strings.HasSuffix(r.URL.Path, "/messages"):
+			if strings.Contains(r.URL.Query().Get("q"), "alice@example.com") {
+				body = map[string]interface{}{"messages": []map[string]string{{"id": "m1", "threadId": "t1"}}}
+			} else {
+				body = map[string]interface{}{}
+			}
+		case strings.HasSuffix(r.URL.Path, "/messages/m1"):
+			body = map[string]interface{}{
+				"id":           "m1",
+				"threadId":     "t1",
+				"internalDate": "1704110400000",
+				"payload":      map[string]interface{}{"headers": []map[string]string{{"name": "Subject", "value": "Q1 planning"}}},
+			}
+		default:
+			http.NotFound(w, r)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(body)
+	})
+	srv.loc = time.UTC
+	srv.recentThreads = 2
+
+	contents, err := srv.handleRecentContactThreadsResource(context.Background(), mcp.ReadResourceRequest{
+		Params: mcp.ReadResourceParams{URI: "gsuite://contacts/recent-threads"},
+	})
+	require.NoError(t, err)
+	require.Len(t, contents, 1)
+	assert.Equal(t, "2", contactPageSize, "contact count bounds the lookups")
+
+	var data struct {
+		Contacts []ContactThread `json:"contacts"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(contents[0].(mcp.TextResourceContents).Text), &data))
+	require.Len(t, data.Contacts, 2)
+
+	alice := data.Contacts[0]
+	require.NotNil(t, alice.LastInteraction)
+	assert.Equal(t, "t1", alice.LastInteraction.ThreadID)
+	assert.Equal(t, "Q1 planning", alice.LastInteraction.Subject)
+	assert.Equal(t, "2024-01-01T12:00:00Z", alice.LastInteraction.Date)
+
+	quinn := data.Contacts[1]
+	assert.Nil(t, quinn.LastInteraction)
+	assert.Equal(t, "no interactions", quinn.Note)
+	assert.Contains(t, contents[0].(mcp.TextResourceContents).Text, `"last_interaction": null`)
+}
+
+func TestHandleCalendarLoadResource(t *testing.T) {
+	now := time.Now().UTC()
+	monday := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	for monday.Weekday() != time.Monday {
+		monday = monday.AddDate(0, 0, -1)
+	}
+	at := func(day int, hour, minute int) string {
+		return monday.AddDate(0, 0, day).Add(time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute).Format(time.RFC3339)
+	}
+
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/events") {
+			http.NotFound(w, r)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"items": []map[string]interface{}{
+			{"id": "e1", "start": map[string]string{"dateTime": at(0, 10, 0)}, "end": map[string]string{"dateTime": at(0, 11, 0)}},
+			{"id": "e2", "start": map[string]string{"dateTime": at(1, 13, 0)}, "end": map[string]string{"dateTime": at(1, 14, 30)}},
+			{"id": "e3", "start": map[string]string{"dateTime": at(2, 9, 0)}, "end": map[string]string{"dateTime": at(2, 9, 45)}},
+			{
+				"id":           "free",
+				"transparency": "transparent",
+				"start":        map[string]string{"dateTime": at(3, 9, 0)},
+				"end":          map[string]string{"dateTime": at(3, 12, 0)},
+			},
+		}})
+	})
+	srv.loc = time.UTC
+
+	contents, err := srv.handleCalendarLoadResource(context.Background(), mcp.ReadResourceRequest{
+		Params: mcp.ReadResourceParams{URI: "gsuite://calendar/load"},
+	})
+	require.NoError(t, err)
+	require.Len(t, contents, 1)
+
+	var data map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(contents[0].(mcp.TextResourceContents).Text), &data))
+	for _, field := range []string{
+		"total_meeting_hours", "meeting_count", "longest_focus_block",
+		"busiest_day", "meeting_percent_of_work_hours", "work_start", "work_end",
+	} {
+		assert.Contains(t, data, field)
+	}
+
+	// Non-transparent durations: 1h + 1.5h + 0.75h
+	assert.Equal(t, 3.25, data["total_meeting_hours"])
+	assert.Equal(t, 3.0, data["meeting_count"])
+	assert.Equal(t, "09:00", data["work_start"])
+	busiest := data["busiest_day"].(map[string]interface{})
+	assert.Equal(t, "Tuesday", busiest["weekday"])
+}
+
+func TestHandleCalendarInvitesResource(t *testing.T) {
+	var singleEvents string
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/events") {
+			http.NotFound(w, r)
+			return
+		}
+		singleEvents = r.URL.Query().Get("singleEvents")
+		attendees := func(selfStatus string) []map[string]interface{} {
+			return []map[string]interface{}{
+				{"email": "me@example.com", "self": true, "responseStatus": selfStatus},
+				{"email": "peer@example.com", "responseStatus": "accepted"},
+			}
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"items": []map[string]interface{}{
+			{"id": "pending", "summary": "Roadmap review", "organizer": map[string]string{"email": "lead@example.com"}, "attendees": attendees("needsAction")},
+			{"id": "answered", "summary": "Standup", "organizer": map[string]string{"email": "lead@example.com"}, "attendees": attendees("accepted")},
+			{"id": "organized", "summary": "My meeting", "organizer": map[string]interface{}{"email": "me@example.com", "self": true}, "attendees": attendees("needsAction")},
+		}})
+	})
+
+	contents, err := srv.handleCalendarInvitesResource(context.Background(), mcp.ReadResourceRequest{
+		Params: mcp.ReadResourceParams{URI: "gsuite://calendar/invites"},
+	})
+	require.NoError(t, err)
+	require.Len(t, contents, 1)
+	assert.Equal(t, "true", singleEvents, "recurring invitations are expanded into instances")
+
+	var data struct {
+		InviteCount int `json:"invite_count"`
+		Invites     []struct {
+			EventID   string `json:"event_id"`
+			Organizer string `json:"organizer"`
+			Attendees []struct {
+				Email          string `json:"email"`
+				ResponseStatus string `json:"response_status"`
+			} `json:"attendees"`
+		} `json:"invites"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(contents[0].(mcp.TextResourceContents).Text), &data))
+	assert.Equal(t, 1, data.InviteCount)
+	require.Len(t, data.Invites, 1)
+	assert.Equal(t, "pending", data.Invites[0].EventID)
+	assert.Equal(t, "lead@example.com", data.Invites[0].Organizer)
+	require.Len(t, data.Invites[0].Attendees, 1)
+	assert.Equal(t, "accepted", data.Invites[0].Attendees[0].ResponseStatus)
+}
+
+func TestHandleThisWeekCalendarResource_UsesServerTimezone(t *testing.T) {
+	var timeMin string
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		timeMin = r.URL.Query().Get("timeMin")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"items": []map[string]interface{}{
+			{"id": "late", "summary": "Late call", "start": map[string]string{"dateTime": "2025-01-06T12:00:00Z"}},
+		}})
+	})
+	loc, err := time.LoadLocation("Pacific/Kiritimati")
+	require.NoError(t, err)
+	srv.loc = loc
+
+	contents, err := srv.handleThisWeekCalendarResource(context.Background(), mcp.ReadResourceRequest{
+		Params: mcp.ReadResourceParams{URI: "gsuite://calendar/this-week"},
+	})
+	require.NoError(t, err)
+
+	start, err := time.Parse(time.RFC3339, timeMin)
+	require.NoError(t, err)
+	_, offset := start.Zone()
+	assert.Equal(t, 14*60*60, offset, "the week is computed in the server's timezone")
+	assert.Equal(t, time.Monday, start.Weekday())
+	assert.Zero(t, start.Hour())
+
+	var data struct {
+		EventsByDay map[string]interface{} `json:"events_by_day"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(contents[0].(mcp.TextResourceContents).Text), &data))
+	assert.Contains(t, data.EventsByDay, "2025-01-07", "events are grouped by their local day")
+}
+
+func TestHandleCalendarRemindersResource(t *testing.T) {
+	start := time.Now().Add(2 * time.Hour).Truncate(time.Minute).UTC()
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/users/me/calendarList/primary"):
+			_, _ = w.Write([]byte(`{"defaultReminders": [{"method": "popup", "minutes": 30}]}`))
+		case strings.HasSuffix(r.URL.Path, "/events"):
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"items": []map[string]interface{}{{
+				"id":        "sync",
+				"start":     map[string]string{"dateTime": start.Format(time.RFC3339)},
+				"end":       map[string]string{"dateTime": start.Add(time.Hour).Format(time.RFC3339)},
+				"reminders": map[string]interface{}{"useDefault": true},
+			}}})
+		default:
+			http.NotFound(w, r)
+		}
+	})
+
+	contents, err := srv.handleCalendarRemindersResource(context.Background(), mcp.ReadResourceRequest{
+		Params: mcp.ReadResourceParams{URI: "gsuite://calendar/reminders"},
+	})
+	require.NoError(t, err)
+
+	var data struct {
+		EventCount int `json:"event_count"`
+		Events     []struct {
+			EventID   string `json:"event_id"`
+			Reminders []struct {
+				FireAt time.Time `json:"fire_at"`
+				Fired  bool      `json:"fired"`
+			} `json:"reminders"`
+		} `json:"events"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(contents[0].(mcp.TextResourceContents).Text), &data))
+	require.Equal(t, 1, data.EventCount)
+	require.Len(t, data.Events[0].Reminders, 1)
+	assert.True(t, start.Add(-30*time.Minute).Equal(data.Events[0].Reminders[0].FireAt), "default reminder fires 30 minutes before start")
+	assert.False(t, data.Events[0].Reminders[0].Fired)
+}
+
+func TestHandleOverviewResource(t *testing.T) {
+	start := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+	var draftsFail bool
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/labels/DRAFT"):
+			if draftsFail {
+				w.WriteHeader(http.StatusInternalServerError)
+				_, _ = w.Write([]byte(`{"error": {"code": 500, "message": "backend error"}}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"id": "DRAFT", "messagesTotal": 2}`))
+		case strings.HasSuffix(r.URL.Path, "/labels/UNREAD"):
+			// Totals beyond any list page size are reported as-is
+			_, _ = w.Write([]byte(`{"id": "UNREAD", "messagesTotal": 137, "messagesUnread": 137}`))
+		case strings.HasSuffix(r.URL.Path, "/labels/IMPORTANT"):
+			_, _ = w.Write([]byte(`{"id": "IMPORTANT", "messagesTotal": 900, "messagesUnread": 12}`))
+		case strings.HasSuffix(r.URL.Path, "/events"):
+			_, _ = w.Write([]byte(`{"items": [{"id": "evt-1", "summary": "Standup", "start": {"dateTime": "` + start + `"}}]}`))
+		default:
+			http.NotFound(w, r)
+		}
+	})
+
+	read := func() Overview {
+		contents, err := srv.handleOverviewResource(context.Background(), mcp.ReadResourceRequest{
+			Params: mcp.ReadResourceParams{URI: "gsuite://overview"},
+		})
+		require.NoError(t, err)
+		require.Len(t, contents, 1)
+		var overview Overview
+		require.NoError(t, json.Unmarshal([]byte(contents[0].(mcp.TextResourceContents).Text), &overview))
+		return overview
+	}
+
+	overview := read()
+	assert.True(t, overview.Complete)
+	assert.Equal(t, OverviewCount{Count: 137}, overview.Unread)
+	assert.Equal(t, OverviewCount{Count: 12}, overview.ImportantUnread)
+	assert.Equal(t, OverviewCount{Count: 2}, overview.Drafts)
+	assert.Equal(t, OverviewCount{Count: 1}, overview.TodayEvents)
+	require.NotNil(t, overview.NextEvent.Event)
+	assert.Equal(t, "Standup", overview.NextEvent.Event.Summary)
+	assert.Equal(t, start, overview.NextEvent.Event.Start)
+
+	draftsFail = true
+	overview = read()
+	assert.False(t, overview.Complete)
+	assert.Zero(t, overview.Drafts.Count)
+	assert.Contains(t, overview.Drafts.Error, "backend error")
+	assert.Equal(t, OverviewCount{Count: 137}, overview.Unread, "other sections still come back")
+	assert.NotNil(t, overview.NextEvent.Event)
+}
@@ -5,36 +5,76 @@ package server
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"net/mail"
 	"net/url"
 	"os"
 	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/harper/gsuite-mcp/pkg/auth"
 	"github.com/harper/gsuite-mcp/pkg/calendar"
+	"github.com/harper/gsuite-mcp/pkg/config"
 	"github.com/harper/gsuite-mcp/pkg/gmail"
 	"github.com/harper/gsuite-mcp/pkg/people"
+	"github.com/harper/gsuite-mcp/pkg/retry"
+	"github.com/harper/gsuite-mcp/pkg/timeutil"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
+	"golang.org/x/oauth2"
 	googlecalendar "google.golang.org/api/calendar/v3"
+	googlegmail "google.golang.org/api/gmail/v1"
 	googlepeople "google.golang.org/api/people/v1"
 )
 
 // Server is the MCP server for GSuite APIs
 type Server struct {
-	gmail    *gmail.Service
-	calendar *calendar.Service
-	people   *people.Service
-	mcp      *server.MCPServer
-	auth     *auth.Authenticator // For auth management tools
+	gmail          *gmail.Service
+	calendar       *calendar.Service
+	people         *people.Service
+	mcp            *server.MCPServer
+	auth           *auth.Authenticator  // For auth management tools
+	throttle       *throttle            // Bounds concurrent and per-second API-backed calls
+	loc            *time.Location       // Timezone for "today"/"this week" style date calculations
+	client         *http.Client         // Shared by the Gmail, Calendar, and People services
+	recentThreads  int                  // Contacts checked by the recent-threads resource
+	templates      calendar.Templates   // Meeting templates for calendar_create_event_from_template
+	scheduled      *gmail.ScheduleQueue // Drafts queued by gmail_schedule_send
+	workStart      string               // Working day start as HH:MM, from config
+	workEnd        string               // Working day end as HH:MM, from config
+	draftOnly      bool                 // Send tools are unregistered and nothing is sent
+	audit          *auditLog            // Records mutating tool calls; nil when no audit_log is set
+	maxResultBytes int                  // Tool results larger than this are trimmed; 0 means no limit
+	internal       map[string]bool      // Lowercase domains mail may reach without allow_external; empty allows all
+	logs           logger               // Filters stderr logging by log_level
+	httpAddr       string               // Serve over HTTP on this host:port instead of stdio; empty uses stdio
 }
 
 // NewServer creates a new MCP server
-func NewServer(ctx context.Context) (*Server, error) {
+// A nil cfg uses config.Default().
+func NewServer(ctx context.Context, cfg *config.Config) (*Server, error) {
+	if cfg == nil {
+		cfg = config.Default()
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+	loc, _ := cfg.Location()
+	policy, _ := cfg.RetryPolicy()
+	retry.SetDefaultPolicy(policy)
+	logs := newLogger(cfg.LogLevel)
+	retry.SetOnRetry(logs.logRetry)
+	timeout, _ := cfg.ClientTimeout()
+	workStart, workEnd := cfg.WorkDay()
+	internal, _ := gmail.InternalDomains(cfg.InternalDomains)
+
 	var client *http.Client
 	var authenticator *auth.Authenticator
 
@@ -44,10 +84,12 @@ func NewServer(ctx context.Context) (*Server, error) {
 	} else {
 		// Use real OAuth
 		var err error
-		authenticator, err = auth.NewAuthenticator(auth.GetCredentialsPath(), auth.GetTokenPath())
+		authenticator, err = auth.NewAuthenticator(auth.GetCredentialsPath(), auth.GetTokenPath(), cfg.Scopes...)
 		if err != nil {
 			return nil, err
 		}
+		// Token refreshes go through the client stored in ctx, so bound those too
+		ctx = context.WithValue(ctx, oauth2.HTTPClient, &http.Client{Timeout: timeout})
 		// Use non-interactive auth - if no token exists, client will be nil
 		// and API calls will fail gracefully. User can authenticate via auth_init/auth_complete tools.
 		client, err = authenticator.GetClientIfAuthenticated(ctx)
@@ -59,12 +101,20 @@ func NewServer(ctx context.Context) (*Server, error) {
 			client = &http.Client{}
 		}
 	}
+	// Bound every attempt; the retry layer can't help if a single attempt hangs
+	client.Timeout = timeout
 
 	// Create services
 	gmailSvc, err := gmail.NewService(ctx, client)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create Gmail service: %w", err)
 	}
+	if err := gmailSvc.SetDelegate(cfg.Delegate); err != nil {
+		return nil, fmt.Errorf("invalid delegate: %w", err)
+	}
+	if err := gmailSvc.SetFromName(cfg.FromName); err != nil {
+		return nil, fmt.Errorf("invalid from_name: %w", err)
+	}
 
 	calendarSvc, err := calendar.NewService(ctx, client)
 	if err != nil {
@@ -76,17 +126,52 @@ func NewServer(ctx context.Context) (*Server, error) {
 		return nil, fmt.Errorf("failed to create People service: %w", err)
 	}
 
+	// Each service gets its own breaker so a Calendar outage doesn't block Gmail
+	breakerPolicy, _ := cfg.BreakerPolicy()
+	gmailSvc.SetBreakerPolicy(breakerPolicy)
+	calendarSvc.SetBreakerPolicy(breakerPolicy)
+	peopleSvc.SetBreakerPolicy(breakerPolicy)
+	cacheTTL, _ := cfg.LookupCacheTTL()
+	gmailSvc.SetCacheTTL(cacheTTL)
+	calendarSvc.SetCacheTTL(cacheTTL)
+
+	// A malformed template file shouldn't keep the server from starting
+	templates, templateErrs := calendar.LoadTemplates(cfg.TemplateDir())
+	for _, err := range templateErrs {
+		logs.printf(levelWarn, "calendar templates: skipping: %v", err)
+	}
+
 	s := &Server{
-		gmail:    gmailSvc,
-		calendar: calendarSvc,
-		people:   peopleSvc,
-		auth:     authenticator,
+		gmail:          gmailSvc,
+		calendar:       calendarSvc,
+		people:         peopleSvc,
+		auth:           authenticator,
+		throttle:       newThrottleFromEnv(),
+		loc:            loc,
+		client:         client,
+		recentThreads:  cfg.RecentThreadLimit(),
+		templates:      templates,
+		scheduled:      gmail.NewScheduleQueue(auth.GetScheduledSendsPath()),
+		workStart:      workStart,
+		workEnd:        workEnd,
+		draftOnly:      cfg.DraftOnly,
+		audit:          newAuditLog(cfg.AuditLog),
+		maxResultBytes: cfg.MaxResultBytes,
+		internal:       internal,
+		logs:           logs,
+		httpAddr:       cfg.HTTPAddr,
 	}
 
 	// Create MCP server
 	mcpServer := server.NewMCPServer(
 		"gsuite-mcp",
 		"1.0.0",
+		server.WithToolHandlerMiddleware(s.limitToolResults),
+		server.WithToolHandlerMiddleware(s.validateToolArgs),
+		server.WithToolHandlerMiddleware(s.throttleTools),
+		server.WithToolHandlerMiddleware(s.auditTools),
+		server.WithToolHandlerMiddleware(s.logToolCalls),
+		server.WithResourceHandlerMiddleware(s.throttleResources),
 	)
 
 	s.mcp = mcpServer
@@ -94,9 +179,165 @@ func NewServer(ctx context.Context) (*Server, error) {
 	s.registerPrompts()
 	s.registerResources()
 
+	if len(cfg.DisabledTools) > 0 {
+		mcpServer.DeleteTools(cfg.DisabledTools...)
+	}
+	if cfg.DraftOnly {
+		mcpServer.DeleteTools(sendTools...)
+	}
+
 	return s, nil
 }
 
+// sendTools are the tools that deliver mail, left unregistered in draft-only mode
+var sendTools = []string{"gmail_send_message", "gmail_send_draft", "gmail_schedule_send"}
+
+// errDraftOnly is returned when a tool is asked to send while draft_only is set
+var errDraftOnly = errors.New("draft-only mode: sending is disabled; create a draft for human review instead")
+
+// inlineImagesSchema describes the inline_images parameter shared by send and draft tools
+var inlineImagesSchema = map[string]interface{}{
+	"type": "array",
+	"items": map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"content_id":     map[string]string{"type": "string", "description": "Content-ID referenced from the HTML as cid:<content_id>"},
+			"content_base64": map[string]string{"type": "string", "description": "Standard base64-encoded image data"},
+			"mime_type":      map[string]string{"type": "string", "description": "Image MIME type (e.g., image/png)"},
+		},
+		"required": []string{"content_id", "content_base64", "mime_type"},
+	},
+	"description": "Images embedded in an HTML body via cid: references (sent as multipart/related)",
+}
+
+// resolveLabelsSchema describes the resolve_labels flag shared by message read tools
+var resolveLabelsSchema = map[string]interface{}{
+	"type":        "boolean",
+	"description": "Also return labels as display names (e.g. \"Receipts\" for Label_42) alongside the raw IDs (default: false)",
+}
+
+// sendUpdatesSchema describes the send_updates parameter shared by event create and update tools
+var sendUpdatesSchema = map[string]interface{}{
+	"type":        "string",
+	"enum":        []string{calendar.SendUpdatesAll, calendar.SendUpdatesExternalOnly, calendar.SendUpdatesNone},
+	"description": "Who receives invite/update emails: all, externalOnly (guests outside your domain), or none. Overrides send_notifications",
+}
+
+// prioritySchema describes the priority parameter shared by the send and draft tools
+var prioritySchema = map[string]interface{}{
+	"type":        "string",
+	"enum":        []string{gmail.PriorityHigh, gmail.PriorityNormal, gmail.PriorityLow},
+	"description": "Sets the Importance and X-Priority headers some recipients filter on; normal omits them (default: normal)",
+}
+
+// allowExternalSchema describes the allow_external override of the internal_domains guard, shared by the send tools
+var allowExternalSchema = map[string]interface{}{
+	"type":        "boolean",
+	"description": "Send even when recipients are outside the configured internal_domains; without it such sends are refused and the external addresses listed (default: false)",
+}
+
+// extendedPropertiesSchema describes the private/shared key-value maps stored on events
+var extendedPropertiesSchema = map[string]interface{}{
+	"type": "object",
+	"properties": map[string]interface{}{
+		"private": map[string]interface{}{
+			"type":                 "object",
+			"additionalProperties": map[string]string{"type": "string"},
+			"description":          "Key-values visible only on your copy of the event",
+		},
+		"shared": map[string]interface{}{
+			"type":                 "object",
+			"additionalProperties": map[string]string{"type": "string"},
+			"description":          "Key-values visible on every attendee's copy",
+		},
+	},
+	"description": "Integration metadata, e.g. {\"private\": {\"ticket\": \"OPS-12\"}}",
+}
+
+// getExtendedProperties reads an extended_properties-shaped argument, or nil if absent
+func getExtendedProperties(request mcp.CallToolRequest, name string) (*calendar.ExtendedProperties, error) {
+	raw, ok := request.GetArguments()[name]
+	if !ok || raw == nil {
+		return nil, nil
+	}
+	obj, ok := raw.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%s must be an object with private and/or shared maps", name)
+	}
+
+	props := &calendar.ExtendedProperties{}
+	for scope, target := range map[string]*map[string]string{"private": &props.Private, "shared": &props.Shared} {
+		values, ok := obj[scope]
+		if !ok || values == nil {
+			continue
+		}
+		entries, ok := values.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%s.%s must be an object of string values", name, scope)
+		}
+		*target = make(map[string]string, len(entries))
+		for key, value := range entries {
+			str, ok := value.(string)
+			if !ok {
+				return nil, fmt.Errorf("%s.%s.%s must be a string", name, scope, key)
+			}
+			(*target)[key] = str
+		}
+	}
+	return props, nil
+}
+
+// eventSourceSchema is the JSON schema for the source parameter
+var eventSourceSchema = map[string]interface{}{
+	"type": "object",
+	"properties": map[string]interface{}{
+		"title": map[string]string{"type": "string", "description": "Name of the originating system or page"},
+		"url":   map[string]string{"type": "string", "description": "Absolute http(s) URL of the originating item"},
+	},
+	"required":    []string{"url"},
+	"description": "Provenance link shown on the event, e.g. {\"title\": \"OPS-12\", \"url\": \"https://tracker.example.com/OPS-12\"}",
+}
+
+// getEventSource reads a source-shaped argument, or nil if absent
+func getEventSource(request mcp.CallToolRequest, name string) (*calendar.EventSource, error) {
+	raw, ok := request.GetArguments()[name]
+	if !ok || raw == nil {
+		return nil, nil
+	}
+	obj, ok := raw.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%s must be an object with title and url", name)
+	}
+
+	src := &calendar.EventSource{}
+	for field, target := range map[string]*string{"title": &src.Title, "url": &src.URL} {
+		value, ok := obj[field]
+		if !ok || value == nil {
+			continue
+		}
+		str, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("%s.%s must be a string", name, field)
+		}
+		*target = str
+	}
+	if err := src.Validate(); err != nil {
+		return nil, err
+	}
+	return src, nil
+}
+
+// getSendUpdates resolves send_updates, falling back to the legacy send_notifications flag
+func getSendUpdates(request mcp.CallToolRequest) (string, error) {
+	if mode := request.GetString("send_updates", ""); mode != "" {
+		if err := calendar.ValidateSendUpdates(mode); err != nil {
+			return "", err
+		}
+		return mode, nil
+	}
+	return calendar.SendUpdatesFromBool(request.GetBool("send_notifications", true)), nil
+}
+
 // registerTools registers all available tools
 func (s *Server) registerTools() {
 	// Gmail tools
@@ -108,36 +349,88 @@ func (s *Server) registerTools() {
 			Properties: map[string]interface{}{
 				"query":       map[string]string{"type": "string", "description": "Gmail search query (e.g., 'from:me is:unread')"},
 				"max_results": map[string]string{"type": "integer", "description": "Maximum number of messages to return (default: 100)"},
+				"after":       map[string]string{"type": "string", "description": "Only messages after this time (" + timeutil.Expressions + "); merged into query"},
+				"before":      map[string]string{"type": "string", "description": "Only messages before this time (" + timeutil.Expressions + "); merged into query"},
 				"hydrate": map[string]interface{}{
 					"type":        "boolean",
 					"description": "When true, fetches full message details (from, subject, snippet, date). When false/omitted, returns only message IDs.",
 				},
+				"resolve_labels": resolveLabelsSchema,
+				"group_by_thread": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Return threads, most recently active first, each with its listed messages, instead of a flat message list (default: false)",
+				},
 			},
 		},
 	}, s.handleGmailListMessages)
 
 	s.mcp.AddTool(mcp.Tool{
 		Name:        "gmail_get_message",
-		Description: "Get a specific email message by ID",
+		Description: "Get a specific email message by ID. The result includes `parsed` with decoded headers, plain and HTML bodies, recipients, and attachment metadata",
 		InputSchema: mcp.ToolInputSchema{
 			Type: "object",
 			Properties: map[string]interface{}{
-				"message_id": map[string]string{"type": "string", "description": "The message ID to retrieve"},
+				"message_id":     map[string]string{"type": "string", "description": "The message ID to retrieve"},
+				"resolve_labels": resolveLabelsSchema,
 			},
 			Required: []string{"message_id"},
 		},
 	}, s.handleGmailGetMessage)
 
+	s.mcp.AddTool(mcp.Tool{
+		Name:        "gmail_download_eml",
+		Description: "Download a message as an RFC822 .eml file (base64-encoded) for archival",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"message_id": map[string]string{"type": "string", "description": "The message ID to export"},
+			},
+			Required: []string{"message_id"},
+		},
+	}, s.handleGmailDownloadEML)
+
+	s.mcp.AddTool(mcp.Tool{
+		Name:        "gmail_extract_contact_info",
+		Description: "Extract candidate emails, phone numbers, URLs, and signature name/title/company from a message body. Confirm before calling people_create_contact",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"message_id": map[string]string{"type": "string", "description": "The message ID to scan"},
+			},
+			Required: []string{"message_id"},
+		},
+	}, s.handleGmailExtractContactInfo)
+
+	s.mcp.AddTool(mcp.Tool{
+		Name:        "gmail_message_to_contact",
+		Description: "Create a contact from a message's sender: name and email from the From header, plus phone/title/company from their signature. Returns the existing contact instead if the email is already saved",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"message_id":     map[string]string{"type": "string", "description": "The message whose sender to add"},
+				"skip_if_exists": map[string]interface{}{"type": "boolean", "description": "Return the existing contact when the sender's email is already saved instead of creating a duplicate (default: true)"},
+				"notes":          map[string]string{"type": "string", "description": "Freeform notes for a new contact, e.g. how you met"},
+				"relations":      relationsSchema,
+			},
+			Required: []string{"message_id"},
+		},
+	}, s.handleGmailMessageToContact)
+
 	s.mcp.AddTool(mcp.Tool{
 		Name:        "gmail_send_message",
 		Description: "Send an email. Use in_reply_to to reply to an existing message (auto-fetches threading headers).",
 		InputSchema: mcp.ToolInputSchema{
 			Type: "object",
 			Properties: map[string]interface{}{
-				"to":          map[string]string{"type": "string", "description": "Recipient email address"},
-				"subject":     map[string]string{"type": "string", "description": "Email subject (auto-prefixed with Re: for replies)"},
-				"body":        map[string]string{"type": "string", "description": "Email body content"},
-				"in_reply_to": map[string]string{"type": "string", "description": "Message ID to reply to (auto-fetches threading headers)"},
+				"to":                   map[string]string{"type": "string", "description": "Recipient email address"},
+				"subject":              map[string]string{"type": "string", "description": "Email subject (auto-prefixed with Re: for replies)"},
+				"body":                 map[string]string{"type": "string", "description": "Email body content"},
+				"in_reply_to":          map[string]string{"type": "string", "description": "Message ID to reply to (auto-fetches threading headers)"},
+				"include_quote":        map[string]interface{}{"type": "boolean", "description": "When replying, quote the original message below the body (\"On <date>, <sender> wrote:\" with > lines, or a blockquote for HTML). Requires in_reply_to (default: false)"},
+				"priority":             prioritySchema,
+				"inline_images":        inlineImagesSchema,
+				"allow_external":       allowExternalSchema,
+				"request_read_receipt": map[string]interface{}{"type": "boolean", "description": "Ask recipients for a read receipt via a Disposition-Notification-To header naming your address; their mail client may ignore it or let them decline (default: false)"},
 			},
 			Required: []string{"to", "subject", "body"},
 		},
@@ -149,866 +442,3560 @@ func (s *Server) registerTools() {
 		InputSchema: mcp.ToolInputSchema{
 			Type: "object",
 			Properties: map[string]interface{}{
-				"to":          map[string]string{"type": "string", "description": "Recipient email address"},
-				"subject":     map[string]string{"type": "string", "description": "Email subject (auto-prefixed with Re: for replies)"},
-				"body":        map[string]string{"type": "string", "description": "Email body content"},
-				"in_reply_to": map[string]string{"type": "string", "description": "Message ID to reply to (auto-fetches threading headers)"},
+				"to":            map[string]string{"type": "string", "description": "Recipient email address"},
+				"subject":       map[string]string{"type": "string", "description": "Email subject (auto-prefixed with Re: for replies)"},
+				"body":          map[string]string{"type": "string", "description": "Email body content"},
+				"in_reply_to":   map[string]string{"type": "string", "description": "Message ID to reply to (auto-fetches threading headers)"},
+				"include_quote": map[string]interface{}{"type": "boolean", "description": "When replying, quote the original message below the body (\"On <date>, <sender> wrote:\" with > lines, or a blockquote for HTML). Requires in_reply_to (default: false)"},
+				"priority":      prioritySchema,
+				"thread_id":     map[string]string{"type": "string", "description": "Existing thread to file the draft in when there's no specific message to reply to. Gmail keeps it in the thread only if the subject matches"},
+				"inline_images": inlineImagesSchema,
+				"dedupe":        map[string]interface{}{"type": "boolean", "description": "Return an existing draft instead of creating a new one when one of the 25 most recent drafts has the same recipients, subject, and body (default: false)"},
 			},
 			Required: []string{"to", "subject", "body"},
 		},
 	}, s.handleGmailCreateDraft)
 
 	s.mcp.AddTool(mcp.Tool{
-		Name:        "gmail_send_draft",
-		Description: "Send an existing draft",
+		Name:        "gmail_schedule_send",
+		Description: "Create a draft now and send it at send_at. Delivery needs a running gsuite-mcp server or a `gsuite-mcp send-scheduled` run (e.g. from cron) at or after that time; until then the draft waits in Drafts.",
 		InputSchema: mcp.ToolInputSchema{
 			Type: "object",
 			Properties: map[string]interface{}{
-				"draft_id": map[string]string{"type": "string", "description": "The draft ID to send"},
+				"to":             map[string]string{"type": "string", "description": "Recipient email address"},
+				"subject":        map[string]string{"type": "string", "description": "Email subject (auto-prefixed with Re: for replies)"},
+				"body":           map[string]string{"type": "string", "description": "Email body content"},
+				"in_reply_to":    map[string]string{"type": "string", "description": "Message ID to reply to (auto-fetches threading headers)"},
+				"inline_images":  inlineImagesSchema,
+				"send_at":        map[string]string{"type": "string", "description": "When to send: RFC3339, or YYYY-MM-DDTHH:MM in the server's timezone"},
+				"allow_external": allowExternalSchema,
 			},
-			Required: []string{"draft_id"},
+			Required: []string{"to", "subject", "body", "send_at"},
 		},
-	}, s.handleGmailSendDraft)
+	}, s.handleGmailScheduleSend)
 
 	s.mcp.AddTool(mcp.Tool{
-		Name:        "gmail_modify_labels",
-		Description: "Add or remove labels from a message (archive, star, mark as read, etc.)",
+		Name:        "gmail_preview_reply",
+		Description: "Preview the recipients, threading headers, and MIME of a reply without creating anything. Use before gmail_create_draft to verify threading.",
 		InputSchema: mcp.ToolInputSchema{
 			Type: "object",
 			Properties: map[string]interface{}{
-				"message_id": map[string]string{"type": "string", "description": "The message ID to modify"},
-				"add_labels": map[string]interface{}{
-					"type":        "array",
-					"items":       map[string]string{"type": "string"},
-					"description": "Label IDs to add (e.g., STARRED, IMPORTANT)",
-				},
-				"remove_labels": map[string]interface{}{
-					"type":        "array",
-					"items":       map[string]string{"type": "string"},
-					"description": "Label IDs to remove (e.g., UNREAD, INBOX)",
-				},
+				"message_id":    map[string]string{"type": "string", "description": "Message ID being replied to"},
+				"body":          map[string]string{"type": "string", "description": "Reply body content"},
+				"to":            map[string]string{"type": "string", "description": "Recipient override (default: original Reply-To or From)"},
+				"subject":       map[string]string{"type": "string", "description": "Subject override (default: original subject, auto-prefixed with Re:)"},
+				"inline_images": inlineImagesSchema,
 			},
-			Required: []string{"message_id"},
+			Required: []string{"message_id", "body"},
 		},
-	}, s.handleGmailModifyLabels)
+	}, s.handleGmailPreviewReply)
 
 	s.mcp.AddTool(mcp.Tool{
-		Name:        "gmail_trash_message",
-		Description: "Move a message to trash",
+		Name:        "gmail_send_draft",
+		Description: "Send an existing draft",
 		InputSchema: mcp.ToolInputSchema{
 			Type: "object",
 			Properties: map[string]interface{}{
-				"message_id": map[string]string{"type": "string", "description": "The message ID to trash"},
+				"draft_id":       map[string]string{"type": "string", "description": "The draft ID to send"},
+				"allow_external": allowExternalSchema,
 			},
-			Required: []string{"message_id"},
+			Required: []string{"draft_id"},
 		},
-	}, s.handleGmailTrashMessage)
+	}, s.handleGmailSendDraft)
 
 	s.mcp.AddTool(mcp.Tool{
-		Name:        "gmail_delete_message",
-		Description: "Permanently delete a message",
+		Name:        "gmail_fix_draft_threading",
+		Description: "Repair a reply draft that was created without proper threading: rebuilds its In-Reply-To and References headers from the original message, adds a \"Re: \" subject prefix if missing, and moves it into the original's thread. Recipients and body are kept as they are",
 		InputSchema: mcp.ToolInputSchema{
 			Type: "object",
 			Properties: map[string]interface{}{
-				"message_id": map[string]string{"type": "string", "description": "The message ID to delete permanently"},
+				"draft_id":            map[string]string{"type": "string", "description": "The draft to repair"},
+				"original_message_id": map[string]string{"type": "string", "description": "The message the draft replies to"},
 			},
-			Required: []string{"message_id"},
+			Required: []string{"draft_id", "original_message_id"},
 		},
-	}, s.handleGmailDeleteMessage)
+	}, s.handleGmailFixDraftThreading)
 
-	// Calendar tools
 	s.mcp.AddTool(mcp.Tool{
-		Name:        "calendar_list_events",
-		Description: "List calendar events",
+		Name:        "gmail_thread_participants",
+		Description: "List the unique people in a thread (from every From, To, and Cc header) with how many messages each sent and received, most active senders first",
 		InputSchema: mcp.ToolInputSchema{
 			Type: "object",
 			Properties: map[string]interface{}{
-				"max_results": map[string]string{"type": "integer"},
-				"time_min":    map[string]string{"type": "string", "description": "RFC3339 timestamp for earliest event"},
-				"time_max":    map[string]string{"type": "string", "description": "RFC3339 timestamp for latest event"},
+				"thread_id": map[string]string{"type": "string", "description": "The thread ID to summarize"},
 			},
+			Required: []string{"thread_id"},
 		},
-	}, s.handleCalendarListEvents)
+	}, s.handleGmailThreadParticipants)
 
 	s.mcp.AddTool(mcp.Tool{
-		Name:        "calendar_get_event",
-		Description: "Get a specific calendar event by ID",
+		Name:        "gmail_search_drafts",
+		Description: "Find drafts whose subject, To/Cc/Bcc, or body contains the query text (case-insensitive). Gmail search covers drafts poorly, so drafts are fetched and matched here",
 		InputSchema: mcp.ToolInputSchema{
 			Type: "object",
 			Properties: map[string]interface{}{
-				"event_id": map[string]string{"type": "string", "description": "The event ID to retrieve"},
+				"query":    map[string]string{"type": "string", "description": "Text to look for, e.g. a recipient address or subject word"},
+				"max_scan": map[string]interface{}{"type": "integer", "description": fmt.Sprintf("Most drafts to examine, newest first (default: %d, max: %d)", defaultDraftScan, maxDraftScan)},
 			},
-			Required: []string{"event_id"},
+			Required: []string{"query"},
 		},
-	}, s.handleCalendarGetEvent)
+	}, s.handleGmailSearchDrafts)
 
 	s.mcp.AddTool(mcp.Tool{
-		Name:        "calendar_create_event",
-		Description: "Create a new calendar event",
+		Name:        "gmail_modify_labels",
+		Description: "Add or remove labels from a message (archive, star, mark as read, etc.)",
 		InputSchema: mcp.ToolInputSchema{
 			Type: "object",
 			Properties: map[string]interface{}{
-				"summary":     map[string]string{"type": "string", "description": "Event title/summary"},
-				"description": map[string]string{"type": "string", "description": "Event description"},
-				"start_time":  map[string]string{"type": "string", "description": "Start time in RFC3339 format"},
-				"end_time":    map[string]string{"type": "string", "description": "End time in RFC3339 format"},
-				"attendees": map[string]interface{}{
+				"message_id": map[string]string{"type": "string", "description": "The message ID to modify"},
+				"add_labels": map[string]interface{}{
 					"type":        "array",
 					"items":       map[string]string{"type": "string"},
-					"description": "Email addresses of required attendees",
+					"description": "Label IDs to add (e.g., STARRED, IMPORTANT)",
 				},
-				"optional_attendees": map[string]interface{}{
+				"remove_labels": map[string]interface{}{
 					"type":        "array",
 					"items":       map[string]string{"type": "string"},
-					"description": "Email addresses of optional attendees",
-				},
-				"send_notifications": map[string]interface{}{
-					"type":        "boolean",
-					"description": "Send invite emails to attendees (default: true)",
+					"description": "Label IDs to remove (e.g., UNREAD, INBOX)",
 				},
 			},
-			Required: []string{"summary", "start_time", "end_time"},
+			Required: []string{"message_id"},
 		},
-	}, s.handleCalendarCreateEvent)
+	}, s.handleGmailModifyLabels)
 
 	s.mcp.AddTool(mcp.Tool{
-		Name:        "calendar_update_event",
-		Description: "Update an existing calendar event",
+		Name:        "gmail_modify_labels_by_query",
+		Description: fmt.Sprintf("Add or remove labels on every message matching a Gmail search query in one batch call (e.g. archive by removing INBOX), up to max_messages (at most %d). Cannot add TRASH or SPAM; use gmail_trash_by_query to trash", maxModifyByQuery),
 		InputSchema: mcp.ToolInputSchema{
 			Type: "object",
 			Properties: map[string]interface{}{
-				"event_id":    map[string]string{"type": "string", "description": "The event ID to update"},
-				"summary":     map[string]string{"type": "string", "description": "New event title/summary"},
-				"description": map[string]string{"type": "string", "description": "New event description"},
-				"start_time":  map[string]string{"type": "string", "description": "New start time in RFC3339 format"},
-				"end_time":    map[string]string{"type": "string", "description": "New end time in RFC3339 format"},
-				"attendees": map[string]interface{}{
-					"type":        "array",
-					"items":       map[string]string{"type": "string"},
-					"description": "Full replacement - replaces ALL required attendees",
-				},
-				"optional_attendees": map[string]interface{}{
-					"type":        "array",
-					"items":       map[string]string{"type": "string"},
-					"description": "Full replacement - replaces ALL optional attendees",
-				},
-				"add_attendees": map[string]interface{}{
-					"type":        "array",
-					"items":       map[string]string{"type": "string"},
-					"description": "Incremental - add as required attendees",
-				},
-				"add_optional_attendees": map[string]interface{}{
+				"query": map[string]string{"type": "string", "description": "Gmail search query selecting the messages to change; must not be empty"},
+				"add_labels": map[string]interface{}{
 					"type":        "array",
 					"items":       map[string]string{"type": "string"},
-					"description": "Incremental - add as optional attendees",
+					"description": "Label IDs to add (e.g., STARRED, IMPORTANT)",
 				},
-				"remove_attendees": map[string]interface{}{
+				"remove_labels": map[string]interface{}{
 					"type":        "array",
 					"items":       map[string]string{"type": "string"},
-					"description": "Incremental - remove by email",
-				},
-				"send_notifications": map[string]interface{}{
-					"type":        "boolean",
-					"description": "Send update emails (default: true)",
+					"description": "Label IDs to remove (e.g., UNREAD, INBOX)",
 				},
+				"max_messages": map[string]interface{}{"type": "integer", "description": fmt.Sprintf("Most messages to change in this call, newest first (1-%d)", maxModifyByQuery)},
 			},
-			Required: []string{"event_id"},
+			Required: []string{"query", "max_messages"},
 		},
-	}, s.handleCalendarUpdateEvent)
+	}, s.handleGmailModifyLabelsByQuery)
 
 	s.mcp.AddTool(mcp.Tool{
-		Name:        "calendar_delete_event",
-		Description: "Delete a calendar event",
+		Name:        "gmail_file_message",
+		Description: "File a message under a label by name: removes it from the inbox and applies the label, creating the label (and any parents of a nested name like Projects/Acme) if it doesn't exist",
 		InputSchema: mcp.ToolInputSchema{
 			Type: "object",
 			Properties: map[string]interface{}{
-				"event_id": map[string]string{"type": "string", "description": "The event ID to delete"},
+				"message_id": map[string]string{"type": "string", "description": "The message ID to file"},
+				"label":      map[string]string{"type": "string", "description": "Label name, not ID; use / for nesting (e.g. Projects/Acme)"},
 			},
-			Required: []string{"event_id"},
+			Required: []string{"message_id", "label"},
 		},
-	}, s.handleCalendarDeleteEvent)
+	}, s.handleGmailFileMessage)
 
-	// People tools
 	s.mcp.AddTool(mcp.Tool{
-		Name:        "people_list_contacts",
-		Description: "List contacts",
+		Name:        "gmail_trash_message",
+		Description: "Move a message to trash",
 		InputSchema: mcp.ToolInputSchema{
 			Type: "object",
 			Properties: map[string]interface{}{
-				"page_size": map[string]string{"type": "integer"},
+				"message_id": map[string]string{"type": "string", "description": "The message ID to trash"},
 			},
+			Required: []string{"message_id"},
 		},
-	}, s.handlePeopleListContacts)
+	}, s.handleGmailTrashMessage)
 
 	s.mcp.AddTool(mcp.Tool{
-		Name:        "people_search_contacts",
-		Description: "Search contacts by name, email, or phone number",
+		Name:        "gmail_delete_message",
+		Description: "Permanently delete a message",
 		InputSchema: mcp.ToolInputSchema{
 			Type: "object",
 			Properties: map[string]interface{}{
-				"query":     map[string]string{"type": "string", "description": "Search query (name, email, phone, etc)"},
-				"page_size": map[string]string{"type": "integer"},
+				"message_id": map[string]string{"type": "string", "description": "The message ID to delete permanently"},
 			},
-			Required: []string{"query"},
+			Required: []string{"message_id"},
 		},
-	}, s.handlePeopleSearchContacts)
+	}, s.handleGmailDeleteMessage)
 
 	s.mcp.AddTool(mcp.Tool{
-		Name:        "people_get_contact",
-		Description: "Get detailed information about a specific contact",
+		Name:        "gmail_trash_by_query",
+		Description: fmt.Sprintf("Move every message matching a Gmail search query to trash (reversible for 30 days; never permanently deletes), up to max_messages (at most %d)", maxTrashByQuery),
 		InputSchema: mcp.ToolInputSchema{
 			Type: "object",
 			Properties: map[string]interface{}{
-				"resource_name": map[string]string{"type": "string", "description": "Resource name of the person (e.g., people/12345)"},
+				"query":        map[string]string{"type": "string", "description": "Gmail search query selecting the messages to trash; must not be empty"},
+				"max_messages": map[string]interface{}{"type": "integer", "description": fmt.Sprintf("Most messages to trash in this call (1-%d)", maxTrashByQuery)},
 			},
-			Required: []string{"resource_name"},
+			Required: []string{"query", "max_messages"},
 		},
-	}, s.handlePeopleGetContact)
+	}, s.handleGmailTrashByQuery)
 
 	s.mcp.AddTool(mcp.Tool{
-		Name:        "people_create_contact",
-		Description: "Create a new contact",
+		Name:        "gmail_recover_from_trash",
+		Description: fmt.Sprintf("Move messages from trash (or spam) back to the inbox in bulk, optionally narrowed by a Gmail search query, up to max_messages (at most %d)", maxRecoverMessages),
 		InputSchema: mcp.ToolInputSchema{
 			Type: "object",
 			Properties: map[string]interface{}{
-				"given_name":  map[string]string{"type": "string", "description": "First name"},
-				"family_name": map[string]string{"type": "string", "description": "Last name"},
-				"email":       map[string]string{"type": "string", "description": "Email address"},
-				"phone":       map[string]string{"type": "string", "description": "Phone number"},
+				"query":        map[string]string{"type": "string", "description": "Gmail search query narrowing which messages to recover, e.g. from:boss@example.com (default: all)"},
+				"max_messages": map[string]interface{}{"type": "integer", "description": fmt.Sprintf("Most messages to recover in this call, newest first (1-%d)", maxRecoverMessages)},
+				"from": map[string]interface{}{
+					"type":        "string",
+					"enum":        []string{recoverFromTrash, recoverFromSpam},
+					"description": "Where to recover from (default: trash)",
+				},
 			},
-			Required: []string{"given_name"},
+			Required: []string{"max_messages"},
 		},
-	}, s.handlePeopleCreateContact)
+	}, s.handleGmailRecoverFromTrash)
 
 	s.mcp.AddTool(mcp.Tool{
-		Name:        "people_update_contact",
-		Description: "Update an existing contact",
+		Name:        "gmail_label_by_sender",
+		Description: "Apply a label by name to the most recent messages from one sender, creating the label if needed, or remove it with remove. A one-off alternative to a Gmail filter; messages stay in the inbox",
 		InputSchema: mcp.ToolInputSchema{
 			Type: "object",
 			Properties: map[string]interface{}{
-				"resource_name": map[string]string{"type": "string", "description": "Resource name of the person (e.g., people/12345)"},
-				"given_name":    map[string]string{"type": "string", "description": "First name"},
-				"family_name":   map[string]string{"type": "string", "description": "Last name"},
-				"email":         map[string]string{"type": "string", "description": "Email address"},
-				"phone":         map[string]string{"type": "string", "description": "Phone number"},
+				"sender":       map[string]string{"type": "string", "description": "Sender email address, e.g. boss@example.com"},
+				"label":        map[string]string{"type": "string", "description": "Label name, not ID; use / for nesting (e.g. Projects/Acme)"},
+				"max_messages": map[string]interface{}{"type": "integer", "description": fmt.Sprintf("Most messages to change, newest first (default: %d, max: %d)", defaultLabelBySender, maxLabelBySender)},
+				"remove":       map[string]interface{}{"type": "boolean", "description": "Remove the label from the sender's messages instead of adding it (default: false)"},
 			},
-			Required: []string{"resource_name"},
+			Required: []string{"sender", "label"},
 		},
-	}, s.handlePeopleUpdateContact)
+	}, s.handleGmailLabelBySender)
 
 	s.mcp.AddTool(mcp.Tool{
-		Name:        "people_delete_contact",
-		Description: "Delete a contact",
+		Name:        "gmail_digest",
+		Description: "Summarize the mail received over the last few days: volume by sender domain and label, threads with many messages, and threads marked important",
 		InputSchema: mcp.ToolInputSchema{
 			Type: "object",
 			Properties: map[string]interface{}{
-				"resource_name": map[string]string{"type": "string", "description": "Resource name of the person (e.g., people/12345)"},
+				"days":         map[string]interface{}{"type": "integer", "description": fmt.Sprintf("How many days back to cover (default: %d, max: %d)", defaultDigestDays, maxDigestDays)},
+				"max_messages": map[string]interface{}{"type": "integer", "description": fmt.Sprintf("Most messages to read (default: %d, max: %d); the digest is marked truncated when the window holds more", defaultDigestMessages, maxDigestMessages)},
 			},
-			Required: []string{"resource_name"},
 		},
-	}, s.handlePeopleDeleteContact)
+	}, s.handleGmailDigest)
 
-	// Auth tools
 	s.mcp.AddTool(mcp.Tool{
-		Name:        "auth_status",
-		Description: "Check if OAuth authentication is valid by making a test API call",
+		Name:        "gmail_awaiting_reply",
+		Description: "List threads you sent mail on recently that nobody has replied to yet (the latest message is still yours), with the recipients and days since sent, longest waiting first. Useful for follow-up reminders",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"days":         map[string]interface{}{"type": "integer", "description": fmt.Sprintf("How many days of sent mail to check (default: %d, max: %d)", defaultAwaitingDays, maxAwaitingDays)},
+				"max_messages": map[string]interface{}{"type": "integer", "description": fmt.Sprintf("Most sent messages to read (default: %d, max: %d); the result is marked truncated when the window holds more", defaultAwaitingMessages, maxAwaitingMessages)},
+			},
+		},
+	}, s.handleGmailAwaitingReply)
+
+	s.mcp.AddTool(mcp.Tool{
+		Name:        "gmail_get_profile",
+		Description: "Get the mailbox profile: email address, total messages and threads, and the current history ID",
 		InputSchema: mcp.ToolInputSchema{
 			Type:       "object",
 			Properties: map[string]interface{}{},
 		},
-	}, s.handleAuthStatus)
+	}, s.handleGmailGetProfile)
 
 	s.mcp.AddTool(mcp.Tool{
-		Name:        "auth_info",
-		Description: "Get OAuth token metadata (expiry, scopes) without making API calls",
+		Name:        "gmail_get_settings",
+		Description: "Read Gmail account settings (auto-forwarding, IMAP, POP, language). Requires the gmail.settings.basic scope",
 		InputSchema: mcp.ToolInputSchema{
 			Type:       "object",
 			Properties: map[string]interface{}{},
 		},
-	}, s.handleAuthInfo)
+	}, s.handleGmailGetSettings)
 
 	s.mcp.AddTool(mcp.Tool{
-		Name:        "auth_init",
-		Description: "Start OAuth authentication flow. Returns an auth_url the USER must visit in their browser to authorize. After authorizing, the user receives a code to provide to auth_complete. Returns current status if already authenticated (use force=true to re-authenticate).",
+		Name:        "gmail_get_signature",
+		Description: "Get the Gmail signature for a send-as address (the primary address or an alias). Requires the gmail.settings.basic scope",
 		InputSchema: mcp.ToolInputSchema{
 			Type: "object",
 			Properties: map[string]interface{}{
-				"force": map[string]interface{}{
-					"type":        "boolean",
-					"description": "Force new auth flow even if current auth is valid",
-				},
+				"send_as_email": map[string]string{"type": "string", "description": "Send-as address whose signature to read (default: the primary address)"},
 			},
 		},
-	}, s.handleAuthInit)
+	}, s.handleGmailGetSignature)
 
 	s.mcp.AddTool(mcp.Tool{
-		Name:        "auth_complete",
-		Description: "Complete OAuth flow by exchanging authorization code for tokens. Call this after the user visits the auth_url from auth_init. The user should provide the FULL redirect URL from their browser (e.g., http://localhost/?code=4/0AfJohX...) - the code will be extracted automatically.",
+		Name:        "gmail_set_signature",
+		Description: "Replace the Gmail signature for a send-as address (the primary address or an alias). Requires the gmail.settings.basic scope",
 		InputSchema: mcp.ToolInputSchema{
 			Type: "object",
 			Properties: map[string]interface{}{
-				"code": map[string]string{"type": "string", "description": "The full redirect URL from the browser, or just the authorization code"},
+				"signature":     map[string]string{"type": "string", "description": "Signature HTML; must not be empty"},
+				"send_as_email": map[string]string{"type": "string", "description": "Send-as address whose signature to set (default: the primary address)"},
 			},
-			Required: []string{"code"},
+			Required: []string{"signature"},
 		},
-	}, s.handleAuthComplete)
+	}, s.handleGmailSetSignature)
 
+	// Calendar tools
 	s.mcp.AddTool(mcp.Tool{
-		Name:        "auth_revoke",
-		Description: "Delete cached OAuth token, forcing re-authentication on next API call",
+		Name:        "calendar_list_events",
+		Description: "List calendar events",
 		InputSchema: mcp.ToolInputSchema{
-			Type:       "object",
-			Properties: map[string]interface{}{},
+			Type: "object",
+			Properties: map[string]interface{}{
+				"max_results": map[string]string{"type": "integer"},
+				"time_min":    map[string]string{"type": "string", "description": "Earliest event time: " + timeutil.Expressions + "; dates mean midnight in the configured timezone"},
+				"time_max":    map[string]string{"type": "string", "description": "Latest event time: " + timeutil.Expressions + "; dates mean midnight in the configured timezone"},
+				"single_events": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Expand recurring series into individual instances (default: true)",
+				},
+				"order_by": map[string]interface{}{
+					"type":        "string",
+					"enum":        []string{calendar.OrderByStartTime, calendar.OrderByUpdated},
+					"description": "Sort order: startTime (requires single_events=true; the default when expanding) or updated",
+				},
+			},
 		},
-	}, s.handleAuthRevoke)
+	}, s.handleCalendarListEvents)
+
+	s.mcp.AddTool(mcp.Tool{
+		Name:        "calendar_sync_events",
+		Description: "Fetch only the events that changed since a previous sync. Call without sync_token for a full sync, then pass back next_sync_token. Deleted events come back with status \"cancelled\". If full_sync_required is true the token expired: discard cached events and sync again without a token",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"sync_token":  map[string]string{"type": "string", "description": "next_sync_token from the previous call; omit for a full sync"},
+				"calendar_id": map[string]string{"type": "string", "description": "Calendar to sync (default: primary)"},
+			},
+		},
+	}, s.handleCalendarSyncEvents)
+
+	s.mcp.AddTool(mcp.Tool{
+		Name:        "calendar_snapshot",
+		Description: "Capture a hashable snapshot of the events in a window (id, summary, start, end, attendees, updated). Keep it and pass it to calendar_diff later to see what changed; an unchanged hash means nothing did",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"time_min":    map[string]string{"type": "string", "description": "Window start in RFC3339 format (required)"},
+				"time_max":    map[string]string{"type": "string", "description": "Window end in RFC3339 format (required)"},
+				"calendar_id": map[string]string{"type": "string", "description": "Calendar to snapshot (default: primary)"},
+			},
+			Required: []string{"time_min", "time_max"},
+		},
+	}, s.handleCalendarSnapshot)
+
+	s.mcp.AddTool(mcp.Tool{
+		Name:        "calendar_diff",
+		Description: "Compare two calendar_snapshot results and report added, removed, and modified events. Events are matched by ID and count as modified when their updated time changed",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"before": map[string]string{"type": "object", "description": "The earlier snapshot, as returned by calendar_snapshot"},
+				"after":  map[string]string{"type": "object", "description": "The later snapshot, as returned by calendar_snapshot"},
+			},
+			Required: []string{"before", "after"},
+		},
+	}, s.handleCalendarDiff)
+
+	s.mcp.AddTool(mcp.Tool{
+		Name:        "calendar_export_ics",
+		Description: "Export events as an iCalendar (.ics) document for non-Google calendars. Pass event_ids, or time_min and time_max to export every event in a range",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"event_ids": map[string]interface{}{
+					"type":        "array",
+					"items":       map[string]string{"type": "string"},
+					"description": "IDs of events on the primary calendar to export (at most 50)",
+				},
+				"time_min":    map[string]string{"type": "string", "description": "Range start in RFC3339 format, used when event_ids is not given"},
+				"time_max":    map[string]string{"type": "string", "description": "Range end in RFC3339 format, used when event_ids is not given"},
+				"calendar_id": map[string]string{"type": "string", "description": "Calendar to export a range from (default: primary)"},
+			},
+		},
+	}, s.handleCalendarExportICS)
+
+	s.mcp.AddTool(mcp.Tool{
+		Name:        "calendar_get_timezone",
+		Description: "Get a calendar's IANA timezone (e.g. America/New_York), falling back to the account's timezone setting. Check this before proposing meeting times instead of assuming a zone",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"calendar_id": map[string]string{"type": "string", "description": "Calendar to check (default: primary)"},
+			},
+		},
+	}, s.handleCalendarGetTimezone)
+
+	s.mcp.AddTool(mcp.Tool{
+		Name:        "calendar_get_event",
+		Description: "Get a specific calendar event by ID",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"event_id": map[string]string{"type": "string", "description": "The event ID to retrieve"},
+				"resolve_names": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Fill in attendee display names the event lacks from your contacts and, on Workspace accounts, the directory; attendees nobody knows keep their email as the name (default: false)",
+				},
+			},
+			Required: []string{"event_id"},
+		},
+	}, s.handleCalendarGetEvent)
+
+	s.mcp.AddTool(mcp.Tool{
+		Name:        "calendar_list_event_attachments",
+		Description: "List the files attached to an event (title, file URL, MIME type), e.g. to find the agenda doc. Returns an empty list for events without attachments",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"event_id": map[string]string{"type": "string", "description": "The event ID"},
+			},
+			Required: []string{"event_id"},
+		},
+	}, s.handleCalendarListEventAttachments)
+
+	s.mcp.AddTool(mcp.Tool{
+		Name:        "calendar_create_event",
+		Description: "Create a new calendar event. The API always makes the calendar's owner the organizer; use source to record where the event came from",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"summary":     map[string]string{"type": "string", "description": "Event title/summary"},
+				"description": map[string]string{"type": "string", "description": "Event description, used exactly as given. Overrides agenda and meeting_notes_link"},
+				"start_time":  map[string]string{"type": "string", "description": "Start time in RFC3339 format"},
+				"end_time":    map[string]string{"type": "string", "description": "End time in RFC3339 format"},
+				"agenda": map[string]interface{}{
+					"type":        "array",
+					"items":       map[string]string{"type": "string"},
+					"description": "Agenda items. Without a description, the event gets a standard one with agenda bullets, the notes link, and the attendee list",
+				},
+				"meeting_notes_link": map[string]string{"type": "string", "description": "URL of the meeting notes doc, added to the standard description (see agenda)"},
+				"attendees": map[string]interface{}{
+					"type":        "array",
+					"items":       map[string]string{"type": "string"},
+					"description": "Email addresses of required attendees",
+				},
+				"optional_attendees": map[string]interface{}{
+					"type":        "array",
+					"items":       map[string]string{"type": "string"},
+					"description": "Email addresses of optional attendees",
+				},
+				"send_notifications": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Send invite emails to attendees (default: true). Ignored when send_updates is set",
+				},
+				"send_updates": sendUpdatesSchema,
+				"event_type": map[string]interface{}{
+					"type":        "string",
+					"enum":        []string{calendar.EventTypeDefault, calendar.EventTypeOutOfOffice, calendar.EventTypeFocusTime, calendar.EventTypeWorkingLocation},
+					"description": "Kind of event (default: default). outOfOffice auto-declines all conflicting invites, focusTime declines new ones; neither may have attendees",
+				},
+				"decline_message": map[string]string{"type": "string", "description": "Message sent when an outOfOffice or focusTime event declines an invite"},
+				"working_location_type": map[string]interface{}{
+					"type":        "string",
+					"enum":        []string{calendar.WorkingLocationHome, calendar.WorkingLocationOffice, calendar.WorkingLocationCustom},
+					"description": "Required for workingLocation events",
+				},
+				"working_location_label": map[string]string{"type": "string", "description": "Office or place name for officeLocation/customLocation (required for customLocation)"},
+				"extended_properties":    extendedPropertiesSchema,
+				"source":                 eventSourceSchema,
+				"event_id":               map[string]string{"type": "string", "description": "Custom event ID for idempotent, externally addressable events: 5-1024 characters of lowercase a-v and digits 0-9. Creating a second event with the same ID fails"},
+				"include_self_as_attendee": map[string]interface{}{
+					"type":        "boolean",
+					"description": "List yourself as an accepted attendee so attendee counts and RSVP checks include you (default: false)",
+				},
+				"room_email": map[string]string{"type": "string", "description": "Room resource calendar to book (see calendar_find_room). The room is added as a resource attendee and the response reports whether it accepted"},
+			},
+			Required: []string{"summary", "start_time", "end_time"},
+		},
+	}, s.handleCalendarCreateEvent)
+
+	s.mcp.AddTool(mcp.Tool{
+		Name:        "calendar_create_event_from_template",
+		Description: "Create a calendar event from a meeting template that fills in the agenda, duration, and reminders. Provided fields override the template's defaults",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"template": map[string]interface{}{
+					"type":        "string",
+					"enum":        s.templates.Names(),
+					"description": "Template name",
+				},
+				"start_time":       map[string]string{"type": "string", "description": "Start time in RFC3339 format"},
+				"end_time":         map[string]string{"type": "string", "description": "End time in RFC3339 format (default: start plus the template's duration)"},
+				"duration_minutes": map[string]string{"type": "integer", "description": "Event length in minutes, overriding the template's duration. Ignored when end_time is set"},
+				"summary":          map[string]string{"type": "string", "description": "Event title (default: the template's title)"},
+				"description":      map[string]string{"type": "string", "description": "Notes placed above the template's agenda"},
+				"reminder_minutes": map[string]interface{}{
+					"type":        "array",
+					"items":       map[string]string{"type": "integer"},
+					"description": "Popup reminders in minutes before start, replacing the template's reminders",
+				},
+				"attendees": map[string]interface{}{
+					"type":        "array",
+					"items":       map[string]string{"type": "string"},
+					"description": "Email addresses of required attendees",
+				},
+				"optional_attendees": map[string]interface{}{
+					"type":        "array",
+					"items":       map[string]string{"type": "string"},
+					"description": "Email addresses of optional attendees",
+				},
+				"send_notifications": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Send invite emails to attendees (default: true). Ignored when send_updates is set",
+				},
+				"send_updates": sendUpdatesSchema,
+			},
+			Required: []string{"template", "start_time"},
+		},
+	}, s.handleCalendarCreateEventFromTemplate)
+
+	s.mcp.AddTool(mcp.Tool{
+		Name:        "calendar_block_time",
+		Description: "Block whole days, e.g. a vacation, as one event from start_date through end_date inclusive. out_of_office makes it an out-of-office event that auto-declines conflicting invites",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"start_date":      map[string]string{"type": "string", "description": "First day of the block (YYYY-MM-DD)"},
+				"end_date":        map[string]string{"type": "string", "description": "Last day of the block, inclusive (YYYY-MM-DD); the same as start_date for a single day"},
+				"summary":         map[string]string{"type": "string", "description": "Event title, e.g. Vacation"},
+				"out_of_office":   map[string]interface{}{"type": "boolean", "description": "Create an out-of-office event that declines conflicting invites (default: false)"},
+				"decline_message": map[string]string{"type": "string", "description": "Message sent with declined invites when out_of_office is true"},
+			},
+			Required: []string{"start_date", "end_date", "summary"},
+		},
+	}, s.handleCalendarBlockTime)
+
+	s.mcp.AddTool(mcp.Tool{
+		Name:        "calendar_update_event",
+		Description: "Update an existing calendar event",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"event_id":    map[string]string{"type": "string", "description": "The event ID to update"},
+				"summary":     map[string]string{"type": "string", "description": "New event title/summary"},
+				"description": map[string]string{"type": "string", "description": "New event description"},
+				"start_time":  map[string]string{"type": "string", "description": "New start time in RFC3339 format"},
+				"end_time":    map[string]string{"type": "string", "description": "New end time in RFC3339 format"},
+				"attendees": map[string]interface{}{
+					"type":        "array",
+					"items":       map[string]string{"type": "string"},
+					"description": "Full replacement - replaces ALL required attendees",
+				},
+				"optional_attendees": map[string]interface{}{
+					"type":        "array",
+					"items":       map[string]string{"type": "string"},
+					"description": "Full replacement - replaces ALL optional attendees",
+				},
+				"add_attendees": map[string]interface{}{
+					"type":        "array",
+					"items":       map[string]string{"type": "string"},
+					"description": "Incremental - add as required attendees",
+				},
+				"add_optional_attendees": map[string]interface{}{
+					"type":        "array",
+					"items":       map[string]string{"type": "string"},
+					"description": "Incremental - add as optional attendees",
+				},
+				"remove_attendees": map[string]interface{}{
+					"type":        "array",
+					"items":       map[string]string{"type": "string"},
+					"description": "Incremental - remove by email",
+				},
+				"send_notifications": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Send update emails (default: true). Ignored when send_updates is set",
+				},
+				"send_updates":        sendUpdatesSchema,
+				"extended_properties": extendedPropertiesSchema,
+			},
+			Required: []string{"event_id"},
+		},
+	}, s.handleCalendarUpdateEvent)
+
+	s.mcp.AddTool(mcp.Tool{
+		Name:        "calendar_reschedule_event",
+		Description: "Move an event earlier or later by a relative offset, e.g. push a meeting back 30 minutes. Start and end shift together, keeping the duration and time zone",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"event_id": map[string]string{"type": "string", "description": "The event ID to move"},
+				"offset":   map[string]string{"type": "string", "description": "How far to move the event: a duration like 30m, -1h, 1d, or 1d2h; negative moves it earlier. All-day events move by whole days only"},
+				"send_notifications": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Send update emails (default: true). Ignored when send_updates is set",
+				},
+				"send_updates": sendUpdatesSchema,
+			},
+			Required: []string{"event_id", "offset"},
+		},
+	}, s.handleCalendarRescheduleEvent)
+
+	s.mcp.AddTool(mcp.Tool{
+		Name:        "calendar_validate_schedule",
+		Description: "Dry-run check of events you plan to create: reports proposed slots that overlap existing events on your calendar (external) or each other (internal). Creates nothing",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"slots": map[string]interface{}{
+					"type": "array",
+					"items": map[string]interface{}{
+						"type": "object",
+						"properties": map[string]interface{}{
+							"start":   map[string]string{"type": "string", "description": "Start time in RFC3339 format"},
+							"end":     map[string]string{"type": "string", "description": "End time in RFC3339 format"},
+							"summary": map[string]string{"type": "string", "description": "Event title, echoed in conflicts"},
+						},
+						"required": []string{"start", "end"},
+					},
+					"description": "Proposed events to check, up to 200; conflicts refer to them by index",
+				},
+			},
+			Required: []string{"slots"},
+		},
+	}, s.handleCalendarValidateSchedule)
+
+	s.mcp.AddTool(mcp.Tool{
+		Name:        "calendar_delete_event",
+		Description: "Delete a calendar event",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"event_id": map[string]string{"type": "string", "description": "The event ID to delete"},
+			},
+			Required: []string{"event_id"},
+		},
+	}, s.handleCalendarDeleteEvent)
+
+	s.mcp.AddTool(mcp.Tool{
+		Name:        "calendar_cancel_event",
+		Description: "Cancel a calendar event and notify every attendee. Unlike calendar_delete_event, the event stays on attendees' calendars marked as cancelled",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"event_id": map[string]string{"type": "string", "description": "The event ID to cancel"},
+				"note":     map[string]string{"type": "string", "description": "Cancellation note appended to the event description"},
+			},
+			Required: []string{"event_id"},
+		},
+	}, s.handleCalendarCancelEvent)
+
+	s.mcp.AddTool(mcp.Tool{
+		Name:        "calendar_find_by_property",
+		Description: "Find events tagged with extended properties, e.g. events an integration created. Events must match every given key-value",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"extended_properties": extendedPropertiesSchema,
+				"time_min":            map[string]string{"type": "string", "description": "Only events ending after this time (RFC3339)"},
+				"time_max":            map[string]string{"type": "string", "description": "Only events starting before this time (RFC3339)"},
+				"max_results":         map[string]string{"type": "integer", "description": "Maximum number of events to return (default: 50)"},
+			},
+			Required: []string{"extended_properties"},
+		},
+	}, s.handleCalendarFindByProperty)
+
+	s.mcp.AddTool(mcp.Tool{
+		Name:        "calendar_delete_events_bulk",
+		Description: "Permanently delete every event that lies entirely within a time window, optionally filtered by a text query. Without confirm=true, lists the matching events instead of deleting.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"time_min":    map[string]string{"type": "string", "description": "Window start in RFC3339 format (required)"},
+				"time_max":    map[string]string{"type": "string", "description": "Window end in RFC3339 format (required)"},
+				"query":       map[string]string{"type": "string", "description": "Free-text filter matched against event fields (e.g., 'test')"},
+				"calendar_id": map[string]string{"type": "string", "description": "Calendar to delete from (default: primary)"},
+				"confirm": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Must be true to actually delete (default: false returns a preview)",
+				},
+			},
+			Required: []string{"time_min", "time_max"},
+		},
+	}, s.handleCalendarDeleteEventsBulk)
+
+	s.mcp.AddTool(mcp.Tool{
+		Name:        "calendar_suggest_slots",
+		Description: "Suggest up to five meeting times that are free for you and all attendees, within business hours on weekdays",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"attendees": map[string]interface{}{
+					"type":        "array",
+					"items":       map[string]string{"type": "string"},
+					"description": "Email addresses of attendees whose availability must be checked",
+				},
+				"duration_minutes": map[string]string{"type": "integer", "description": "Meeting length in minutes (default: 30)"},
+				"time_min":         map[string]string{"type": "string", "description": "Start of the search window: " + timeutil.Expressions + " (default: now)"},
+				"time_max":         map[string]string{"type": "string", "description": "End of the search window: " + timeutil.Expressions + " (default: 7 days after time_min)"},
+				"work_start":       map[string]string{"type": "string", "description": "Start of business hours as HH:MM in time_min's offset (default: configured work_start, 09:00)"},
+				"work_end":         map[string]string{"type": "string", "description": "End of business hours as HH:MM in time_min's offset (default: configured work_end, 17:00)"},
+			},
+			Required: []string{"attendees"},
+		},
+	}, s.handleCalendarSuggestSlots)
+
+	s.mcp.AddTool(mcp.Tool{
+		Name:        "calendar_find_room",
+		Description: "Check which Workspace meeting rooms are free for a time. Pass the rooms' resource calendar emails; free rooms are listed in the order given, so the first is the suggestion",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"rooms": map[string]interface{}{
+					"type":        "array",
+					"items":       map[string]string{"type": "string"},
+					"description": "Room resource calendar emails (e.g. ...@resource.calendar.google.com), in order of preference",
+				},
+				"start_time": map[string]string{"type": "string", "description": "Meeting start in RFC3339 format"},
+				"end_time":   map[string]string{"type": "string", "description": "Meeting end in RFC3339 format"},
+			},
+			Required: []string{"rooms", "start_time", "end_time"},
+		},
+	}, s.handleCalendarFindRoom)
+
+	s.mcp.AddTool(mcp.Tool{
+		Name:        "calendar_email_agenda",
+		Description: "Email a day's calendar agenda (time, title, and location of each event) as HTML. Creates a draft unless send is true",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"date":           map[string]string{"type": "string", "description": "Day to summarize in the configured timezone: " + timeutil.Expressions + " (default: today)"},
+				"to":             map[string]string{"type": "string", "description": "Recipient (default: your own address)"},
+				"send":           map[string]interface{}{"type": "boolean", "description": "Send immediately instead of creating a draft (default: false)"},
+				"allow_external": allowExternalSchema,
+			},
+		},
+	}, s.handleCalendarEmailAgenda)
+
+	// People tools
+	s.mcp.AddTool(mcp.Tool{
+		Name:        "people_list_contacts",
+		Description: "List contacts",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"page_size": map[string]string{"type": "integer"},
+				"fields":    map[string]string{"type": "string", "description": "Comma-separated person fields to return (default: names,emailAddresses). Each extra field adds payload per contact; use people_get_contact for full details"},
+			},
+		},
+	}, s.handlePeopleListContacts)
+
+	s.mcp.AddTool(mcp.Tool{
+		Name:        "people_list_group_members",
+		Description: "List the contacts in one contact group (label), such as Clients. An empty group returns no contacts",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"group":     map[string]string{"type": "string", "description": "Group name (case-insensitive, e.g. Clients) or resource name (contactGroups/<id>)"},
+				"page_size": map[string]string{"type": "integer", "description": "Most members to return (default: 100)"},
+			},
+			Required: []string{"group"},
+		},
+	}, s.handlePeopleListGroupMembers)
+
+	s.mcp.AddTool(mcp.Tool{
+		Name:        "people_interaction_history",
+		Description: "Catch up on one person: recent mail exchanged in both directions, past and upcoming meetings with them, and when you last interacted. Mail and calendar are searched concurrently",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"email":        map[string]string{"type": "string", "description": "The person's email address"},
+				"max_emails":   map[string]interface{}{"type": "integer", "description": fmt.Sprintf("Most recent messages to return (default: %d, max: %d)", defaultHistoryItems, maxHistoryItems)},
+				"max_meetings": map[string]interface{}{"type": "integer", "description": fmt.Sprintf("Most past and most upcoming meetings to return, each (default: %d, max: %d)", defaultHistoryItems, maxHistoryItems)},
+				"days_back":    map[string]interface{}{"type": "integer", "description": fmt.Sprintf("How far back to look for meetings (default: %d, max: %d)", defaultHistoryDaysBack, maxHistoryDays)},
+				"days_ahead":   map[string]interface{}{"type": "integer", "description": fmt.Sprintf("How far ahead to look for meetings (default: %d, max: %d)", defaultHistoryDaysAhead, maxHistoryDays)},
+			},
+			Required: []string{"email"},
+		},
+	}, s.handlePeopleInteractionHistory)
+
+	s.mcp.AddTool(mcp.Tool{
+		Name:        "people_search_contacts",
+		Description: "Search contacts by name, email, or phone number. search_other also finds people you've emailed but never saved",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"query":     map[string]string{"type": "string", "description": "Search query (name, email, phone, etc)"},
+				"page_size": map[string]string{"type": "integer"},
+				"fields":    map[string]string{"type": "string", "description": "Comma-separated person fields to return (default: names,emailAddresses,phoneNumbers). Each extra field adds payload per result"},
+				"sort": map[string]interface{}{
+					"type":        "string",
+					"enum":        []string{"relevance", "name"},
+					"description": "Result order: relevance (API order, default) or name (alphabetical by display name; adds names to fields)",
+				},
+				"search_other": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Also search Other contacts (people Gmail saved from your mail), de-duplicated by email. Each result becomes {source: contact|other, person}. Needs the contacts.other.readonly scope; without it, saved contacts are returned with a warning (default: false)",
+				},
+			},
+			Required: []string{"query"},
+		},
+	}, s.handlePeopleSearchContacts)
+
+	s.mcp.AddTool(mcp.Tool{
+		Name:        "people_get_contact",
+		Description: "Get detailed information about a specific contact. The photo field gives the primary photo URL and whether it is a default avatar rather than an uploaded photo",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"resource_name": map[string]string{"type": "string", "description": "Resource name of the person (e.g., people/12345)"},
+				"include_photo": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Download a custom photo and return it base64-encoded in photo.content_base64; default avatars are never downloaded (default: false)",
+				},
+			},
+			Required: []string{"resource_name"},
+		},
+	}, s.handlePeopleGetContact)
+
+	s.mcp.AddTool(mcp.Tool{
+		Name:        "people_create_contact",
+		Description: "Create a new contact",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"given_name":   map[string]string{"type": "string", "description": "First name"},
+				"family_name":  map[string]string{"type": "string", "description": "Last name"},
+				"email":        map[string]string{"type": "string", "description": "Email address"},
+				"phone":        map[string]string{"type": "string", "description": "Phone number"},
+				"organization": map[string]string{"type": "string", "description": "Company or organization name"},
+				"notes":        map[string]string{"type": "string", "description": "Freeform notes, e.g. how you met (stored as the contact's biography)"},
+				"relations":    relationsSchema,
+				"infer_org_from_email": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Set organization from the email domain when none is given, e.g. jane@acme.com -> Acme (free-mail domains are skipped; default: false)",
+				},
+			},
+			Required: []string{"given_name"},
+		},
+	}, s.handlePeopleCreateContact)
+
+	s.mcp.AddTool(mcp.Tool{
+		Name:        "people_update_contact",
+		Description: "Update an existing contact",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"resource_name": map[string]string{"type": "string", "description": "Resource name of the person (e.g., people/12345)"},
+				"given_name":    map[string]string{"type": "string", "description": "First name"},
+				"family_name":   map[string]string{"type": "string", "description": "Last name"},
+				"email":         map[string]string{"type": "string", "description": "Email address"},
+				"phone":         map[string]string{"type": "string", "description": "Phone number"},
+				"notes":         map[string]string{"type": "string", "description": "Freeform notes, replacing any existing notes"},
+				"relations":     relationsSchema,
+				"clear_fields": map[string]interface{}{
+					"type":        "array",
+					"items":       map[string]interface{}{"type": "string", "enum": people.ClearableFields()},
+					"description": "Fields to remove entirely, e.g. [\"phoneNumbers\", \"organizations\"]. Empty strings elsewhere mean \"don't change\", so this is the only way to delete a value",
+				},
+			},
+			Required: []string{"resource_name"},
+		},
+	}, s.handlePeopleUpdateContact)
+
+	s.mcp.AddTool(mcp.Tool{
+		Name:        "people_delete_contact",
+		Description: "Permanently delete a contact. Without confirm=true, returns the contact's summary for review instead of deleting.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"resource_name": map[string]string{"type": "string", "description": "Resource name of the person (e.g., people/12345)"},
+				"confirm": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Must be true to actually delete (default: false returns a preview)",
+				},
+			},
+			Required: []string{"resource_name"},
+		},
+	}, s.handlePeopleDeleteContact)
+
+	s.mcp.AddTool(mcp.Tool{
+		Name:        "people_export_vcard",
+		Description: "Export one or more contacts as vCard text (names, typed emails and phones, organization, title, addresses, birthday)",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"resource_names": map[string]interface{}{
+					"type":        "array",
+					"items":       map[string]string{"type": "string"},
+					"description": "Resource names of the contacts to export (e.g., [\"people/12345\"])",
+				},
+				"version": map[string]interface{}{
+					"type":        "string",
+					"enum":        []string{people.VCard3, people.VCard4},
+					"description": "vCard version (default: 3.0)",
+				},
+			},
+			Required: []string{"resource_names"},
+		},
+	}, s.handlePeopleExportVCard)
+
+	s.mcp.AddTool(mcp.Tool{
+		Name:        "people_export_csv",
+		Description: "Export contacts as CSV with a Google Contacts-compatible header (name parts, emails, phones, organization, title). Multiple emails or phones share one cell, separated by semicolons",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"resource_names": map[string]interface{}{
+					"type":        "array",
+					"items":       map[string]string{"type": "string"},
+					"description": "Resource names of the contacts to export (e.g., [\"people/12345\"]); omit to export every contact",
+				},
+			},
+		},
+	}, s.handlePeopleExportCSV)
+
+	s.mcp.AddTool(mcp.Tool{
+		Name:        "people_list_directory",
+		Description: "List people in the Google Workspace domain directory (not personal contacts), e.g. to build an org chart or autocomplete. Returns a next_sync_token once the listing completes; pass it back as sync_token to get only changes since. Requires a Workspace account and the directory.readonly scope",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"fields":      map[string]string{"type": "string", "description": "Comma-separated person fields to return (default: " + people.DirectoryReadMask + ")"},
+				"max_results": map[string]interface{}{"type": "integer", "description": fmt.Sprintf("Most people to return in this call (default: %d, max: %d); a next_page_token continues from there", defaultDirectoryResults, maxDirectoryResults)},
+				"page_token":  map[string]string{"type": "string", "description": "next_page_token from a previous call, to continue the listing"},
+				"sync_token":  map[string]string{"type": "string", "description": "next_sync_token from a completed listing, to return only people changed since; deleted people have metadata.deleted set. Other arguments must match the call that issued it"},
+			},
+		},
+	}, s.handlePeopleListDirectory)
+
+	s.mcp.AddTool(mcp.Tool{
+		Name:        "people_import_vcard",
+		Description: "Create contacts from vCard text (versions 2.1, 3.0, and 4.0). Malformed cards are skipped and reported; the rest are still imported.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"vcard": map[string]string{"type": "string", "description": "vCard text containing one or more BEGIN:VCARD ... END:VCARD blocks"},
+				"skip_duplicates": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Skip cards whose email matches an existing contact or an earlier card in the same import (default: false)",
+				},
+			},
+			Required: []string{"vcard"},
+		},
+	}, s.handlePeopleImportVCard)
+
+	s.mcp.AddTool(mcp.Tool{
+		Name:        "people_batch_create",
+		Description: fmt.Sprintf("Create up to %d contacts in one call, sent to the API in batches of 200. Returns a result per input contact, in input order.", maxBatchCreateContacts),
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"contacts": map[string]interface{}{
+					"type": "array",
+					"items": map[string]interface{}{
+						"type": "object",
+						"properties": map[string]interface{}{
+							"given_name":   map[string]string{"type": "string", "description": "First name"},
+							"family_name":  map[string]string{"type": "string", "description": "Last name"},
+							"email":        map[string]string{"type": "string", "description": "Email address"},
+							"phone":        map[string]string{"type": "string", "description": "Phone number"},
+							"organization": map[string]string{"type": "string", "description": "Company or organization name"},
+							"notes":        map[string]string{"type": "string", "description": "Freeform notes"},
+						},
+						"required": []string{"given_name"},
+					},
+					"description": "Contacts to create",
+				},
+				"skip_existing": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Skip contacts whose email matches an existing contact or an earlier entry in the same call (default: false)",
+				},
+			},
+			Required: []string{"contacts"},
+		},
+	}, s.handlePeopleBatchCreate)
+
+	// Auth tools
+	s.mcp.AddTool(mcp.Tool{
+		Name:        "auth_status",
+		Description: "Check if OAuth authentication is valid by making a test API call",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"detailed": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Probe Gmail, Calendar, and People separately and report each (default: false, Gmail only)",
+				},
+			},
+		},
+	}, s.handleAuthStatus)
+
+	s.mcp.AddTool(mcp.Tool{
+		Name:        "auth_info",
+		Description: "Get OAuth token metadata (expiry, scopes) without making API calls",
+		InputSchema: mcp.ToolInputSchema{
+			Type:       "object",
+			Properties: map[string]interface{}{},
+		},
+	}, s.handleAuthInfo)
+
+	s.mcp.AddTool(mcp.Tool{
+		Name:        "gsuite_selftest",
+		Description: "Confirm the environment before a larger workflow: reads 1 message, 1 event in the next day, and 1 contact, and reports ok, latency, and any error per service",
+		InputSchema: mcp.ToolInputSchema{
+			Type:       "object",
+			Properties: map[string]interface{}{},
+		},
+	}, s.handleSelfTest)
+
+	s.mcp.AddTool(mcp.Tool{
+		Name:        "auth_init",
+		Description: "Start OAuth authentication flow. Returns an auth_url the USER must visit in their browser to authorize. After authorizing, the user receives a code to provide to auth_complete. Returns current status if already authenticated (use force=true to re-authenticate).",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"force": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Force new auth flow even if current auth is valid",
+				},
+			},
+		},
+	}, s.handleAuthInit)
+
+	s.mcp.AddTool(mcp.Tool{
+		Name:        "auth_complete",
+		Description: "Complete OAuth flow by exchanging authorization code for tokens. Call this after the user visits the auth_url from auth_init. The user should provide the FULL redirect URL from their browser (e.g., http://localhost/?code=4/0AfJohX...) - the code will be extracted automatically.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"code": map[string]string{"type": "string", "description": "The full redirect URL from the browser, or just the authorization code"},
+			},
+			Required: []string{"code"},
+		},
+	}, s.handleAuthComplete)
+
+	s.mcp.AddTool(mcp.Tool{
+		Name:        "auth_revoke",
+		Description: "Delete cached OAuth token, forcing re-authentication on next API call",
+		InputSchema: mcp.ToolInputSchema{
+			Type:       "object",
+			Properties: map[string]interface{}{},
+		},
+	}, s.handleAuthRevoke)
+}
+
+// HydratedMessage is a summary of a Gmail message with common fields extracted
+type HydratedMessage struct {
+	ID       string   `json:"id"`
+	ThreadID string   `json:"threadId"`
+	From     string   `json:"from,omitempty"`
+	To       string   `json:"to,omitempty"`
+	Subject  string   `json:"subject,omitempty"`
+	Snippet  string   `json:"snippet,omitempty"`
+	Date     string   `json:"date,omitempty"`
+	LabelIDs []string `json:"labelIds,omitempty"`
+	Labels   []string `json:"labels,omitempty"` // Display names for LabelIDs, only with resolve_labels
+}
+
+// ListMessagesResponse wraps message list results for MCP structuredContent
+type ListMessagesResponse struct {
+	Messages []HydratedMessage `json:"messages"`
+	Count    int               `json:"count"`
+}
+
+// ListThreadsResponse is gmail_list_messages output with group_by_thread
+type ListThreadsResponse struct {
+	Threads      []MessageThread `json:"threads"`
+	Count        int             `json:"count"`         // Threads
+	MessageCount int             `json:"message_count"` // Messages across all threads
+}
+
+// MessageThread is the listed messages belonging to one thread
+type MessageThread struct {
+	ThreadID string            `json:"threadId"`
+	Messages []HydratedMessage `json:"messages"`
+	Count    int               `json:"count"`
+}
+
+// ListEventsResponse wraps calendar event list results for MCP structuredContent
+type ListEventsResponse struct {
+	Events any `json:"events"`
+	Count  int `json:"count"`
+}
+
+// ListContactsResponse wraps contact list results for MCP structuredContent
+type ListContactsResponse struct {
+	Contacts any `json:"contacts"`
+	Count    int `json:"count"`
+}
+
+// Tool handlers
+// parseDateParam reads an optional time expression resolved by
+// timeutil.ParseRelativeTime: an RFC3339 timestamp, or a date such as
+// YYYY-MM-DD, today, or friday meaning midnight in the server's timezone. A
+// missing param returns the zero time.
+func (s *Server) parseDateParam(request mcp.CallToolRequest, name string) (time.Time, error) {
+	value := request.GetString(name, "")
+	if value == "" {
+		return time.Time{}, nil
+	}
+	t, err := timeutil.ParseRelativeTime(value, time.Now(), s.loc)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid %s format: expected %s, got %q", name, timeutil.Expressions, value)
+	}
+	return t, nil
+}
+
+func (s *Server) handleGmailListMessages(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	query := request.GetString("query", "")
+	maxResults := int64(request.GetInt("max_results", 100))
+	hydrate := request.GetBool("hydrate", false)
+	resolveLabels := request.GetBool("resolve_labels", false)
+	groupByThread := request.GetBool("group_by_thread", false)
+
+	after, err := s.parseDateParam(request, "after")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	before, err := s.parseDateParam(request, "before")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if !after.IsZero() && !before.IsZero() && !after.Before(before) {
+		return mcp.NewToolResultError("after must be earlier than before"), nil
+	}
+	query = gmail.WithDateRange(query, after, before)
+
+	messages, err := s.gmail.ListMessages(ctx, query, maxResults)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	if !hydrate {
+		// Wrap in object for MCP structuredContent compatibility
+		result := make([]HydratedMessage, len(messages))
+		for i, msg := range messages {
+			result[i] = HydratedMessage{
+				ID:       msg.Id,
+				ThreadID: msg.ThreadId,
+			}
+		}
+		if groupByThread {
+			return mcp.NewToolResultJSON(groupMessagesByThread(result, nil))
+		}
+		return mcp.NewToolResultJSON(ListMessagesResponse{
+			Messages: result,
+			Count:    len(result),
+		})
+	}
+
+	// Hydrate: fetch only the headers we surface, concurrently, preserving order
+	ids := make([]string, len(messages))
+	for i, msg := range messages {
+		ids[i] = msg.Id
+	}
+	fullMsgs, errs := s.gmail.GetMessagesMetadata(ctx, ids, "From", "To", "Subject", "Date")
+
+	hydrated := make([]HydratedMessage, 0, len(messages))
+	dates := make([]int64, 0, len(messages))
+	for i, msg := range messages {
+		fullMsg := fullMsgs[i]
+		if errs[i] != nil || fullMsg == nil {
+			// If we can't get one message, include basic info and continue
+			hydrated = append(hydrated, HydratedMessage{
+				ID:       msg.Id,
+				ThreadID: msg.ThreadId,
+			})
+			dates = append(dates, 0)
+			continue
+		}
+
+		hm := HydratedMessage{
+			ID:       fullMsg.Id,
+			ThreadID: fullMsg.ThreadId,
+			Snippet:  fullMsg.Snippet,
+			LabelIDs: fullMsg.LabelIds,
+		}
+		if resolveLabels {
+			hm.Labels, err = s.gmail.ResolveLabels(ctx, fullMsg.LabelIds)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+		}
+
+		if parsed, err := gmail.ParseMessage(fullMsg); err == nil {
+			hm.From = parsed.Header("From")
+			hm.To = parsed.Header("To")
+			hm.Subject = parsed.Header("Subject")
+			hm.Date = parsed.Header("Date")
+		}
+
+		hydrated = append(hydrated, hm)
+		dates = append(dates, fullMsg.InternalDate)
+	}
+
+	if groupByThread {
+		return mcp.NewToolResultJSON(groupMessagesByThread(hydrated, dates))
+	}
+	return mcp.NewToolResultJSON(ListMessagesResponse{
+		Messages: hydrated,
+		Count:    len(hydrated),
+	})
+}
+
+// groupMessagesByThread groups listed messages by thread. dates holds each
+// message's internalDate (0 if unknown) and may be nil. Threads are ordered by
+// their newest known message; ties, including threads with no dates, keep
+// list order, which Gmail returns newest first. Messages within a thread keep
+// list order.
+func groupMessagesByThread(messages []HydratedMessage, dates []int64) ListThreadsResponse {
+	var threads []*MessageThread
+	latest := make(map[string]int64)
+	byID := make(map[string]*MessageThread)
+	for i, msg := range messages {
+		thread, ok := byID[msg.ThreadID]
+		if !ok {
+			thread = &MessageThread{ThreadID: msg.ThreadID}
+			byID[msg.ThreadID] = thread
+			threads = append(threads, thread)
+		}
+		thread.Messages = append(thread.Messages, msg)
+		if dates != nil && dates[i] > latest[msg.ThreadID] {
+			latest[msg.ThreadID] = dates[i]
+		}
+	}
+
+	sort.SliceStable(threads, func(i, j int) bool {
+		return latest[threads[i].ThreadID] > latest[threads[j].ThreadID]
+	})
+
+	resp := ListThreadsResponse{Threads: make([]MessageThread, len(threads)), MessageCount: len(messages)}
+	for i, thread := range threads {
+		thread.Count = len(thread.Messages)
+		resp.Threads[i] = *thread
+	}
+	resp.Count = len(resp.Threads)
+	return resp
+}
+
+func (s *Server) handleGmailGetMessage(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	messageID, err := request.RequireString("message_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	msg, err := s.gmail.GetMessage(ctx, messageID)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	// Keep the API message shape and add the decoded view (and label names) alongside it
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+	var result map[string]interface{}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, err
+	}
+
+	// A message that doesn't decode is still returned as the API gave it
+	var warnings []string
+	if parsed, err := gmail.ParseMessage(msg); err != nil {
+		warnings = append(warnings, fmt.Sprintf("unable to decode message: %v; parsed is omitted", err))
+	} else {
+		result["parsed"] = parsed
+	}
+
+	if request.GetBool("resolve_labels", false) {
+		labels, err := s.gmail.ResolveLabels(ctx, msg.LabelIds)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		result["labels"] = labels
+	}
+
+	toolResult, err := mcp.NewToolResultJSON(result)
+	if err != nil {
+		return nil, err
+	}
+	return withWarnings(toolResult, warnings), nil
+}
+
+// DownloadEMLResponse is the response for gmail_download_eml
+type DownloadEMLResponse struct {
+	MessageID     string `json:"message_id"`
+	Filename      string `json:"filename"`
+	SizeBytes     int    `json:"size_bytes"`
+	ContentBase64 string `json:"content_base64"` // Standard base64 of the exact RFC822 bytes
+	Note          string `json:"note,omitempty"`
+}
+
+func (s *Server) handleGmailDownloadEML(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	messageID, err := request.RequireString("message_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	eml, err := s.gmail.GetRawMessage(ctx, messageID)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	resp := DownloadEMLResponse{
+		MessageID:     eml.MessageID,
+		Filename:      eml.Filename,
+		SizeBytes:     len(eml.Content),
+		ContentBase64: base64.StdEncoding.EncodeToString(eml.Content),
+	}
+	if resp.SizeBytes > gmail.LargeEMLBytes {
+		resp.Note = fmt.Sprintf("large message (%.1f MB); attachments are included inline", float64(resp.SizeBytes)/(1024*1024))
+	}
+
+	return mcp.NewToolResultJSON(resp)
+}
+
+func (s *Server) handleGmailExtractContactInfo(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	messageID, err := request.RequireString("message_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	info, err := s.gmail.ExtractContactInfo(ctx, messageID)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return mcp.NewToolResultJSON(info)
+}
+
+// Statuses reported by gmail_message_to_contact
+const (
+	senderContactCreated  = "created"
+	senderContactExisting = "existing"
+)
+
+// MessageToContactResponse reports the contact created for, or already saved for, a message's sender
+type MessageToContactResponse struct {
+	Status  string               `json:"status"` // "created" or "existing"
+	Email   string               `json:"email"`
+	Contact *googlepeople.Person `json:"contact"`
+}
+
+func (s *Server) handleGmailMessageToContact(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	messageID, err := request.RequireString("message_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	skipIfExists := request.GetBool("skip_if_exists", true)
+
+	info, err := s.gmail.ExtractContactInfo(ctx, messageID)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	person, email, err := contactFromSender(info)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	if skipIfExists {
+		existing, err := s.people.FindContactByEmail(ctx, email)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		if existing != nil {
+			// Search results carry only names and emails, so fetch the full record
+			contact, err := s.people.GetPerson(ctx, existing.ResourceName)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			return mcp.NewToolResultJSON(MessageToContactResponse{Status: senderContactExisting, Email: email, Contact: contact})
+		}
+	}
+
+	if _, err := applyNotesAndRelations(request, person); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	created, err := s.people.CreateContact(ctx, person)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return mcp.NewToolResultJSON(MessageToContactResponse{Status: senderContactCreated, Email: email, Contact: created})
+}
+
+// contactFromSender builds a contact from a message's From header. Signature
+// details are used only when the signature's name matches the sender (or the
+// header has no name), since the last signature may belong to a quoted message.
+func contactFromSender(info *gmail.ContactInfo) (*googlepeople.Person, string, error) {
+	if info.From == "" {
+		return nil, "", fmt.Errorf("message %s has no From header", info.MessageID)
+	}
+	addr, err := mail.ParseAddress(info.From)
+	if err != nil {
+		return nil, "", fmt.Errorf("unable to parse sender %q: %w", info.From, err)
+	}
+
+	email := strings.ToLower(addr.Address)
+	name := strings.TrimSpace(addr.Name)
+	// "Last, First" display names
+	if family, given, ok := strings.Cut(name, ", "); ok && !strings.ContainsAny(family+given, " ,") {
+		name = given + " " + family
+	}
+	fromSignature := info.Name != "" && (name == "" || strings.EqualFold(name, info.Name))
+	if name == "" {
+		name = info.Name
+	}
+
+	person := &googlepeople.Person{
+		EmailAddresses: []*googlepeople.EmailAddress{{Value: email}},
+	}
+	if name != "" {
+		given, family, _ := strings.Cut(name, " ")
+		person.Names = []*googlepeople.Name{{GivenName: given, FamilyName: strings.TrimSpace(family)}}
+	}
+	if !fromSignature {
+		return person, email, nil
+	}
+
+	if info.Phone != "" {
+		person.PhoneNumbers = []*googlepeople.PhoneNumber{{Value: info.Phone}}
+	}
+	if info.Company != "" || info.Title != "" {
+		person.Organizations = []*googlepeople.Organization{{Name: info.Company, Title: info.Title}}
+	}
+	return person, email, nil
+}
+
+func (s *Server) handleGmailSendMessage(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	to, err := request.RequireString("to")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	subject, err := request.RequireString("subject")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	body, err := request.RequireString("body")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	if refused := s.refuseExternal(request, to); refused != nil {
+		return refused, nil
+	}
+
+	inReplyTo := request.GetString("in_reply_to", "")
+
+	inlineImages, err := getInlineImages(request)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	msg, err := s.gmail.SendMessage(ctx, to, subject, body, inReplyTo, &gmail.MessageOptions{
+		InlineImages: inlineImages,
+		IncludeQuote: request.GetBool("include_quote", false),
+		Priority:     request.GetString("priority", ""),
+		ReadReceipt:  request.GetBool("request_read_receipt", false),
+	})
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	result, err := mcp.NewToolResultJSON(msg)
+	if err != nil {
+		return nil, err
+	}
+	return withWarnings(result, inlineImageWarnings(body, inlineImages)), nil
+}
+
+func (s *Server) handleGmailCreateDraft(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	to, err := request.RequireString("to")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	subject, err := request.RequireString("subject")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	body, err := request.RequireString("body")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	inReplyTo := request.GetString("in_reply_to", "")
+
+	inlineImages, err := getInlineImages(request)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	opts := &gmail.MessageOptions{
+		InlineImages: inlineImages,
+		ThreadID:     request.GetString("thread_id", ""),
+		IncludeQuote: request.GetBool("include_quote", false),
+		Priority:     request.GetString("priority", ""),
+	}
+
+	var draft *googlegmail.Draft
+	var existing bool
+	if request.GetBool("dedupe", false) {
+		draft, existing, err = s.gmail.CreateDraftOnce(ctx, to, subject, body, inReplyTo, opts)
+	} else {
+		draft, err = s.gmail.CreateDraft(ctx, to, subject, body, inReplyTo, opts)
+	}
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	result, err := mcp.NewToolResultJSON(draft)
+	if err != nil {
+		return nil, err
+	}
+	warnings := inlineImageWarnings(body, inlineImages)
+	if existing {
+		warnings = append(warnings, "an identical draft already exists ("+draft.Id+"); no new draft was created")
+	}
+	return withWarnings(result, warnings), nil
+}
+
+// ScheduleSendResponse is the response for gmail_schedule_send
+type ScheduleSendResponse struct {
+	DraftID string `json:"draft_id"`
+	SendAt  string `json:"send_at"`
+	Message string `json:"message"`
+}
+
+func (s *Server) handleGmailScheduleSend(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	to, err := request.RequireString("to")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	subject, err := request.RequireString("subject")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	body, err := request.RequireString("body")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	sendAtValue, err := request.RequireString("send_at")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	sendAt, err := time.Parse(time.RFC3339, sendAtValue)
+	if err != nil {
+		sendAt, err = time.ParseInLocation("2006-01-02T15:04", sendAtValue, s.loc)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("invalid send_at format: expected RFC3339 or YYYY-MM-DDTHH:MM, got %q", sendAtValue)), nil
+		}
+	}
+	if !sendAt.After(time.Now()) {
+		return mcp.NewToolResultError("send_at must be in the future; use gmail_send_message to send now"), nil
+	}
+
+	if refused := s.refuseExternal(request, to); refused != nil {
+		return refused, nil
+	}
+
+	inlineImages, err := getInlineImages(request)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	draft, err := s.gmail.CreateDraft(ctx, to, subject, body, request.GetString("in_reply_to", ""), &gmail.MessageOptions{
+		InlineImages: inlineImages,
+	})
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	err = s.scheduled.Add(gmail.ScheduledSend{DraftID: draft.Id, SendAt: sendAt, To: to, Subject: subject})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("draft %s was created but could not be scheduled: %v", draft.Id, err)), nil
+	}
+
+	result, err := mcp.NewToolResultJSON(ScheduleSendResponse{
+		DraftID: draft.Id,
+		SendAt:  sendAt.In(s.loc).Format(time.RFC3339),
+		Message: "draft created and queued; it is sent only while a gsuite-mcp server is running or when `gsuite-mcp send-scheduled` runs at or after send_at",
+	})
+	if err != nil {
+		return nil, err
+	}
+	return withWarnings(result, inlineImageWarnings(body, inlineImages)), nil
+}
+
+func (s *Server) handleGmailPreviewReply(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	messageID, err := request.RequireString("message_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	body, err := request.RequireString("body")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	inlineImages, err := getInlineImages(request)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	preview, err := s.gmail.PreviewReply(ctx, messageID,
+		request.GetString("to", ""), request.GetString("subject", ""), body,
+		&gmail.MessageOptions{InlineImages: inlineImages})
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	result, err := mcp.NewToolResultJSON(preview)
+	if err != nil {
+		return nil, err
+	}
+	return withWarnings(result, inlineImageWarnings(body, inlineImages)), nil
+}
+
+// getInlineImages reads the optional inline_images array of objects
+func getInlineImages(request mcp.CallToolRequest) ([]gmail.InlineImage, error) {
+	args, ok := request.Params.Arguments.(map[string]interface{})
+	if !ok {
+		return nil, nil
+	}
+
+	raw, ok := args["inline_images"]
+	if !ok || raw == nil {
+		return nil, nil
+	}
+
+	arr, ok := raw.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("inline_images must be an array")
+	}
+
+	images := make([]gmail.InlineImage, 0, len(arr))
+	for i, item := range arr {
+		obj, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("inline_images[%d] must be an object", i)
+		}
+		img := gmail.InlineImage{}
+		img.ContentID, _ = obj["content_id"].(string)
+		img.ContentBase64, _ = obj["content_base64"].(string)
+		img.MIMEType, _ = obj["mime_type"].(string)
+		images = append(images, img)
+	}
+
+	return images, nil
+}
+
+// inlineImageWarnings reports cid: mismatches only when inline images were supplied
+func inlineImageWarnings(body string, images []gmail.InlineImage) []string {
+	if len(images) == 0 {
+		return nil
+	}
+	return gmail.CheckInlineImages(body, images)
+}
+
+// withWarnings appends each warning to the result as an additional text content block
+func withWarnings(result *mcp.CallToolResult, warnings []string) *mcp.CallToolResult {
+	for _, warning := range warnings {
+		result.Content = append(result.Content, mcp.NewTextContent("warning: "+warning))
+	}
+	return result
+}
+
+// DeleteResponse is the response for tools that permanently delete an item
+type DeleteResponse struct {
+	Deleted bool   `json:"deleted"`
+	ID      string `json:"id"`
+	Type    string `json:"type"` // message, event, or contact
+}
+
+// deletedResult builds a structured delete result with a human-readable summary alongside it
+func deletedResult(itemType, id string) (*mcp.CallToolResult, error) {
+	result, err := mcp.NewToolResultJSON(DeleteResponse{Deleted: true, ID: id, Type: itemType})
+	if err != nil {
+		return nil, err
+	}
+	summary := fmt.Sprintf("%s%s %s deleted successfully", strings.ToUpper(itemType[:1]), itemType[1:], id)
+	result.Content = append(result.Content, mcp.NewTextContent(summary))
+	return result, nil
+}
+
+func (s *Server) handleGmailSendDraft(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	draftID, err := request.RequireString("draft_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	if len(s.internal) > 0 && !request.GetBool("allow_external", false) {
+		draft, err := s.gmail.GetDraft(ctx, draftID)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		parsed, err := gmail.ParseMessage(draft.Message)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("unable to read draft %s recipients: %v", draftID, err)), nil
+		}
+		if refused := s.refuseExternal(request, parsed.Recipients...); refused != nil {
+			return refused, nil
+		}
+	}
+
+	msg, err := s.gmail.SendDraft(ctx, draftID)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return mcp.NewToolResultJSON(msg)
+}
+
+// refuseExternal returns an error result listing the recipients outside the
+// internal domains, or nil when there are none, no internal domains are
+// configured, or the caller passed allow_external
+func (s *Server) refuseExternal(request mcp.CallToolRequest, recipients ...string) *mcp.CallToolResult {
+	if len(s.internal) == 0 || request.GetBool("allow_external", false) {
+		return nil
+	}
+	external := gmail.ExternalRecipients(s.internal, recipients...)
+	if len(external) == 0 {
+		return nil
+	}
+	return mcp.NewToolResultError(fmt.Sprintf("not sent: %s outside the internal domains; confirm and retry with allow_external=true to send anyway", strings.Join(external, ", ")))
+}
+
+func (s *Server) handleGmailFixDraftThreading(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	draftID, err := request.RequireString("draft_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	originalID, err := request.RequireString("original_message_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	draft, err := s.gmail.FixDraftThreading(ctx, draftID, originalID)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return mcp.NewToolResultJSON(draft)
+}
+
+func (s *Server) handleGmailThreadParticipants(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	threadID, err := request.RequireString("thread_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	participants, err := s.gmail.GetThreadParticipants(ctx, threadID)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return mcp.NewToolResultJSON(participants)
+}
+
+// Bounds for gmail_search_drafts; each scanned draft costs one API call
+const (
+	defaultDraftScan = 100
+	maxDraftScan     = 500
+)
+
+// SearchDraftsResponse is the response for gmail_search_drafts
+type SearchDraftsResponse struct {
+	Query      string             `json:"query"`
+	Drafts     []gmail.DraftMatch `json:"drafts"`
+	Count      int                `json:"count"`
+	Scanned    int                `json:"scanned"`
+	CapReached bool               `json:"cap_reached"` // Older drafts weren't examined; raise max_scan to include them
+}
+
+func (s *Server) handleGmailSearchDrafts(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	query, err := request.RequireString("query")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return mcp.NewToolResultError("query must not be empty"), nil
+	}
+
+	maxScan := request.GetInt("max_scan", defaultDraftScan)
+	if maxScan < 1 || maxScan > maxDraftScan {
+		return mcp.NewToolResultError(fmt.Sprintf("max_scan must be between 1 and %d", maxDraftScan)), nil
+	}
+
+	matches, scanned, err := s.gmail.SearchDrafts(ctx, query, int64(maxScan))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return mcp.NewToolResultJSON(SearchDraftsResponse{
+		Query:      query,
+		Drafts:     matches,
+		Count:      len(matches),
+		Scanned:    scanned,
+		CapReached: scanned == maxScan,
+	})
+}
+
+func (s *Server) handleGmailModifyLabels(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	messageID, err := request.RequireString("message_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	// Get array parameters - these come as []interface{} from MCP
+	// Need to cast Arguments to map first
+	args, ok := request.Params.Arguments.(map[string]interface{})
+	if !ok {
+		return mcp.NewToolResultError("invalid arguments format"), nil
+	}
+
+	addLabelsRaw := args["add_labels"]
+	removeLabelsRaw := args["remove_labels"]
+
+	var addLabels, removeLabels []string
+
+	if addLabelsRaw != nil {
+		if arr, ok := addLabelsRaw.([]interface{}); ok {
+			for _, v := range arr {
+				if str, ok := v.(string); ok {
+					addLabels = append(addLabels, str)
+				}
+			}
+		}
+	}
+
+	if removeLabelsRaw != nil {
+		if arr, ok := removeLabelsRaw.([]interface{}); ok {
+			for _, v := range arr {
+				if str, ok := v.(string); ok {
+					removeLabels = append(removeLabels, str)
+				}
+			}
+		}
+	}
+
+	modified, err := s.gmail.ModifyLabels(ctx, messageID, addLabels, removeLabels)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return mcp.NewToolResultJSON(modified)
+}
+
+// FileMessageResponse reports where a message was filed and any labels created for it
+type FileMessageResponse struct {
+	MessageID     string   `json:"message_id"`
+	Label         string   `json:"label"`
+	LabelID       string   `json:"label_id"`
+	CreatedLabels []string `json:"created_labels,omitempty"`
+	LabelIDs      []string `json:"label_ids"` // The message's labels after filing
+}
+
+func (s *Server) handleGmailFileMessage(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	messageID, err := request.RequireString("message_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	label, err := request.RequireString("label")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	label = strings.TrimSpace(label)
+	if strings.EqualFold(label, "INBOX") {
+		return mcp.NewToolResultError("filing removes a message from INBOX, so INBOX can't be the label; use gmail_modify_labels to move a message back"), nil
+	}
+
+	labelID, created, err := s.gmail.EnsureLabel(ctx, label)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	modified, err := s.gmail.ModifyLabels(ctx, messageID, []string{labelID}, []string{"INBOX"})
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return mcp.NewToolResultJSON(FileMessageResponse{
+		MessageID:     messageID,
+		Label:         label,
+		LabelID:       labelID,
+		CreatedLabels: created,
+		LabelIDs:      modified.LabelIds,
+	})
+}
+
+func (s *Server) handleGmailTrashMessage(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	messageID, err := request.RequireString("message_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	trashed, err := s.gmail.TrashMessage(ctx, messageID)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return mcp.NewToolResultJSON(trashed)
+}
+
+func (s *Server) handleGmailDeleteMessage(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	messageID, err := request.RequireString("message_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	err = s.gmail.DeleteMessage(ctx, messageID)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return deletedResult("message", messageID)
+}
+
+// maxTrashByQuery caps gmail_trash_by_query so one call can't empty a mailbox.
+// Gmail returns at most 500 messages per list page, so one page covers the cap.
+const maxTrashByQuery = 500
+
+// TrashByQueryResponse reports the result of gmail_trash_by_query
+type TrashByQueryResponse struct {
+	Query      string `json:"query"`
+	Trashed    int    `json:"trashed"`
+	CapReached bool   `json:"cap_reached"` // More messages may match; call again to continue
+}
+
+func (s *Server) handleGmailTrashByQuery(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	query, err := request.RequireString("query")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return mcp.NewToolResultError("query must not be empty"), nil
+	}
+
+	maxMessages := request.GetInt("max_messages", 0)
+	if maxMessages < 1 || maxMessages > maxTrashByQuery {
+		return mcp.NewToolResultError(fmt.Sprintf("max_messages must be between 1 and %d", maxTrashByQuery)), nil
+	}
+
+	messages, err := s.gmail.ListMessages(ctx, query, int64(maxMessages))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	ids := make([]string, 0, len(messages))
+	for _, msg := range messages {
+		ids = append(ids, msg.Id)
+	}
+	if len(ids) > maxMessages {
+		ids = ids[:maxMessages]
+	}
+
+	// Labeling TRASH is reversible, unlike batchDelete
+	if err := s.gmail.BatchModifyLabels(ctx, ids, []string{"TRASH"}, []string{"INBOX"}); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return mcp.NewToolResultJSON(TrashByQueryResponse{
+		Query:      query,
+		Trashed:    len(ids),
+		CapReached: len(ids) == maxMessages,
+	})
+}
+
+// maxModifyByQuery caps gmail_modify_labels_by_query like maxTrashByQuery
+const maxModifyByQuery = 500
+
+// ModifyByQueryResponse reports the result of gmail_modify_labels_by_query
+type ModifyByQueryResponse struct {
+	Query      string `json:"query"`
+	Modified   int    `json:"modified"`
+	CapReached bool   `json:"cap_reached"` // More messages may match; call again to continue
+}
+
+func (s *Server) handleGmailModifyLabelsByQuery(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	query, err := request.RequireString("query")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return mcp.NewToolResultError("query must not be empty"), nil
+	}
+
+	addLabels := request.GetStringSlice("add_labels", nil)
+	removeLabels := request.GetStringSlice("remove_labels", nil)
+	if len(addLabels) == 0 && len(removeLabels) == 0 {
+		return mcp.NewToolResultError("add_labels or remove_labels is required"), nil
+	}
+	for _, label := range addLabels {
+		if label == "TRASH" || label == "SPAM" {
+			return mcp.NewToolResultError(fmt.Sprintf("cannot add %s here; use gmail_trash_by_query to trash messages", label)), nil
+		}
+	}
+
+	maxMessages := request.GetInt("max_messages", 0)
+	if maxMessages < 1 || maxMessages > maxModifyByQuery {
+		return mcp.NewToolResultError(fmt.Sprintf("max_messages must be between 1 and %d", maxModifyByQuery)), nil
+	}
+
+	messages, err := s.gmail.ListMessages(ctx, query, int64(maxMessages))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	ids := make([]string, 0, len(messages))
+	for _, msg := range messages {
+		ids = append(ids, msg.Id)
+	}
+	if len(ids) > maxMessages {
+		ids = ids[:maxMessages]
+	}
+
+	if err := s.gmail.BatchModifyLabels(ctx, ids, addLabels, removeLabels); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return mcp.NewToolResultJSON(ModifyByQueryResponse{
+		Query:      query,
+		Modified:   len(ids),
+		CapReached: len(ids) == maxMessages,
+	})
+}
+
+// maxRecoverMessages caps gmail_recover_from_trash like maxTrashByQuery
+const maxRecoverMessages = 500
+
+// Places gmail_recover_from_trash can recover from
+const (
+	recoverFromTrash = "trash"
+	recoverFromSpam  = "spam"
+)
+
+// RecoverResponse reports the result of gmail_recover_from_trash
+type RecoverResponse struct {
+	Query      string `json:"query"` // The full search run, including in:trash or in:spam
+	From       string `json:"from"`
+	Recovered  int    `json:"recovered"`
+	CapReached bool   `json:"cap_reached"` // More messages may match; call again to continue
+}
+
+func (s *Server) handleGmailRecoverFromTrash(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	from := request.GetString("from", recoverFromTrash)
+	if from != recoverFromTrash && from != recoverFromSpam {
+		return mcp.NewToolResultError(fmt.Sprintf("from must be %s or %s", recoverFromTrash, recoverFromSpam)), nil
+	}
+
+	maxMessages := request.GetInt("max_messages", 0)
+	if maxMessages < 1 || maxMessages > maxRecoverMessages {
+		return mcp.NewToolResultError(fmt.Sprintf("max_messages must be between 1 and %d", maxRecoverMessages)), nil
+	}
+
+	query := "in:" + from
+	if q := strings.TrimSpace(request.GetString("query", "")); q != "" {
+		query += " (" + q + ")"
+	}
+
+	messages, err := s.gmail.ListMessages(ctx, query, int64(maxMessages))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	ids := make([]string, 0, len(messages))
+	for _, msg := range messages {
+		ids = append(ids, msg.Id)
+	}
+	if len(ids) > maxMessages {
+		ids = ids[:maxMessages]
+	}
+
+	// Dropping TRASH or SPAM undoes the move; INBOX puts the mail back in view
+	if err := s.gmail.BatchModifyLabels(ctx, ids, []string{"INBOX"}, []string{strings.ToUpper(from)}); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return mcp.NewToolResultJSON(RecoverResponse{
+		Query:      query,
+		From:       from,
+		Recovered:  len(ids),
+		CapReached: len(ids) == maxMessages,
+	})
+}
+
+// Defaults and limits for gmail_label_by_sender. Gmail returns at most 500
+// messages per list page, so one page covers the cap.
+const (
+	defaultLabelBySender = 100
+	maxLabelBySender     = 500
+)
+
+// LabelBySenderResponse reports the result of gmail_label_by_sender
+type LabelBySenderResponse struct {
+	Query         string   `json:"query"`
+	Label         string   `json:"label"`
+	LabelID       string   `json:"label_id"`
+	Action        string   `json:"action"` // "added" or "removed"
+	CreatedLabels []string `json:"created_labels,omitempty"`
+	Messages      int      `json:"messages"`
+	CapReached    bool     `json:"cap_reached"` // Older messages from the sender may be unchanged
+}
+
+func (s *Server) handleGmailLabelBySender(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	sender, err := request.RequireString("sender")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	query, err := gmail.FromSender(sender)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	label, err := request.RequireString("label")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	label = strings.TrimSpace(label)
+	maxMessages := request.GetInt("max_messages", defaultLabelBySender)
+	if maxMessages < 1 || maxMessages > maxLabelBySender {
+		return mcp.NewToolResultError(fmt.Sprintf("max_messages must be between 1 and %d", maxLabelBySender)), nil
+	}
+	remove := request.GetBool("remove", false)
+
+	resp := LabelBySenderResponse{Query: query, Label: label, Action: "added"}
+	if remove {
+		resp.Action = "removed"
+		resp.LabelID, err = s.gmail.LabelID(ctx, label)
+		if err == nil && resp.LabelID == "" {
+			err = fmt.Errorf("label %q does not exist", label)
+		}
+	} else {
+		resp.LabelID, resp.CreatedLabels, err = s.gmail.EnsureLabel(ctx, label)
+	}
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	messages, err := s.gmail.ListMessages(ctx, query, int64(maxMessages))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	ids := make([]string, 0, len(messages))
+	for _, msg := range messages {
+		ids = append(ids, msg.Id)
+	}
+	if len(ids) > maxMessages {
+		ids = ids[:maxMessages]
+	}
+
+	if len(ids) > 0 {
+		var add, del []string
+		if remove {
+			del = []string{resp.LabelID}
+		} else {
+			add = []string{resp.LabelID}
+		}
+		if err := s.gmail.BatchModifyLabels(ctx, ids, add, del); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+	}
+
+	resp.Messages = len(ids)
+	resp.CapReached = len(ids) == maxMessages
+	return mcp.NewToolResultJSON(resp)
+}
+
+// Defaults and limits for gmail_digest. Messages come from a single list page,
+// which Gmail caps at 500.
+const (
+	defaultDigestDays     = 7
+	maxDigestDays         = 31
+	defaultDigestMessages = 200
+	maxDigestMessages     = 500
+)
+
+func (s *Server) handleGmailDigest(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	days := request.GetInt("days", defaultDigestDays)
+	if days < 1 || days > maxDigestDays {
+		return mcp.NewToolResultError(fmt.Sprintf("days must be between 1 and %d", maxDigestDays)), nil
+	}
+	maxMessages := request.GetInt("max_messages", defaultDigestMessages)
+	if maxMessages < 1 || maxMessages > maxDigestMessages {
+		return mcp.NewToolResultError(fmt.Sprintf("max_messages must be between 1 and %d", maxDigestMessages)), nil
+	}
+
+	now := time.Now().In(s.loc)
+	digest, err := s.gmail.Digest(ctx, now.AddDate(0, 0, -days), now, int64(maxMessages))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return mcp.NewToolResultJSON(digest)
+}
+
+// Defaults and limits for gmail_awaiting_reply. Messages come from a single
+// list page, which Gmail caps at 500.
+const (
+	defaultAwaitingDays     = 14
+	maxAwaitingDays         = 90
+	defaultAwaitingMessages = 100
+	maxAwaitingMessages     = 500
+)
+
+func (s *Server) handleGmailAwaitingReply(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	days := request.GetInt("days", defaultAwaitingDays)
+	if days < 1 || days > maxAwaitingDays {
+		return mcp.NewToolResultError(fmt.Sprintf("days must be between 1 and %d", maxAwaitingDays)), nil
+	}
+	maxMessages := request.GetInt("max_messages", defaultAwaitingMessages)
+	if maxMessages < 1 || maxMessages > maxAwaitingMessages {
+		return mcp.NewToolResultError(fmt.Sprintf("max_messages must be between 1 and %d", maxAwaitingMessages)), nil
+	}
+
+	now := time.Now().In(s.loc)
+	report, err := s.gmail.AwaitingReply(ctx, now.AddDate(0, 0, -days), now, int64(maxMessages))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return mcp.NewToolResultJSON(report)
+}
+
+// ProfileResponse is the response for gmail_get_profile
+type ProfileResponse struct {
+	EmailAddress  string `json:"email_address"`
+	MessagesTotal int64  `json:"messages_total"`
+	ThreadsTotal  int64  `json:"threads_total"`
+	HistoryID     uint64 `json:"history_id"`          // Mailbox's current history record ID
+	Simulated     bool   `json:"simulated,omitempty"` // ISH mode: no API calls were made
+}
+
+func (s *Server) handleGmailGetProfile(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	// In ISH mode, return a fixed profile without touching the fake API
+	if os.Getenv("ISH_MODE") == "true" {
+		return mcp.NewToolResultJSON(ProfileResponse{
+			EmailAddress:  "ish-user@example.com",
+			MessagesTotal: 42,
+			ThreadsTotal:  17,
+			HistoryID:     1000,
+			Simulated:     true,
+		})
+	}
+
+	profile, err := s.gmail.GetProfile(ctx)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return mcp.NewToolResultJSON(ProfileResponse{
+		EmailAddress:  profile.EmailAddress,
+		MessagesTotal: profile.MessagesTotal,
+		ThreadsTotal:  profile.ThreadsTotal,
+		HistoryID:     profile.HistoryId,
+	})
+}
+
+func (s *Server) handleGmailGetSettings(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	settings, err := s.gmail.GetSettings(ctx)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return mcp.NewToolResultJSON(settings)
+}
+
+func (s *Server) handleGmailGetSignature(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	signature, err := s.gmail.GetSendAsSignature(ctx, request.GetString("send_as_email", ""))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return mcp.NewToolResultJSON(signature)
+}
+
+func (s *Server) handleGmailSetSignature(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	html, err := request.RequireString("signature")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	signature, err := s.gmail.UpdateSendAsSignature(ctx, request.GetString("send_as_email", ""), html)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return mcp.NewToolResultJSON(signature)
+}
+
+func (s *Server) handleCalendarListEvents(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	maxResults := int64(request.GetInt("max_results", 100))
+
+	timeMin, err := s.parseDateParam(request, "time_min")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	timeMax, err := s.parseDateParam(request, "time_max")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	opts := &calendar.ListEventsOptions{
+		SingleEvents: request.GetBool("single_events", true),
+		OrderBy:      request.GetString("order_by", ""),
+	}
+	if opts.SingleEvents && opts.OrderBy == "" {
+		opts.OrderBy = calendar.OrderByStartTime
+	}
+	if err := opts.Validate(); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	events, err := s.calendar.ListEvents(ctx, maxResults, timeMin, timeMax, opts)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return mcp.NewToolResultJSON(ListEventsResponse{
+		Events: events,
+		Count:  len(events),
+	})
+}
+
+// SyncEventsResponse is the response for calendar_sync_events
+type SyncEventsResponse struct {
+	Events           []*googlecalendar.Event `json:"events"`
+	Count            int                     `json:"count"`
+	NextSyncToken    string                  `json:"next_sync_token,omitempty"`
+	FullSyncRequired bool                    `json:"full_sync_required,omitempty"` // The sync token expired; sync again without one
+}
+
+func (s *Server) handleCalendarSyncEvents(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	page, err := s.calendar.ListEventsSince(ctx, request.GetString("sync_token", ""), request.GetString("calendar_id", ""))
+	if errors.Is(err, calendar.ErrSyncTokenExpired) {
+		return mcp.NewToolResultJSON(SyncEventsResponse{Events: []*googlecalendar.Event{}, FullSyncRequired: true})
+	}
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return mcp.NewToolResultJSON(SyncEventsResponse{
+		Events:        page.Events,
+		Count:         len(page.Events),
+		NextSyncToken: page.NextSyncToken,
+	})
+}
+
+func (s *Server) handleCalendarSnapshot(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var bounds [2]time.Time
+	for i, name := range []string{"time_min", "time_max"} {
+		value, err := request.RequireString(name)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		bounds[i], err = time.Parse(time.RFC3339, value)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("invalid %s format: %v", name, err)), nil
+		}
+	}
+
+	snapshot, err := s.calendar.SnapshotEvents(ctx, bounds[0], bounds[1], request.GetString("calendar_id", ""))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return mcp.NewToolResultJSON(snapshot)
+}
+
+func (s *Server) handleCalendarDiff(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var snapshots [2]*calendar.Snapshot
+	for i, name := range []string{"before", "after"} {
+		snapshot, err := getSnapshot(request, name)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		snapshots[i] = snapshot
+	}
+
+	return mcp.NewToolResultJSON(calendar.DiffSnapshots(snapshots[0], snapshots[1]))
+}
+
+// ExportICSResponse is the response for calendar_export_ics
+type ExportICSResponse struct {
+	Count int    `json:"count"`
+	ICS   string `json:"ics"`
+}
+
+func (s *Server) handleCalendarExportICS(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var events []*googlecalendar.Event
+	var err error
+	if eventIDs := request.GetStringSlice("event_ids", nil); len(eventIDs) > 0 {
+		events, err = s.calendar.GetEvents(ctx, eventIDs)
+	} else {
+		var bounds [2]time.Time
+		for i, name := range []string{"time_min", "time_max"} {
+			value := request.GetString(name, "")
+			if value == "" {
+				return mcp.NewToolResultError("pass event_ids, or both time_min and time_max"), nil
+			}
+			bounds[i], err = time.Parse(time.RFC3339, value)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("invalid %s format: %v", name, err)), nil
+			}
+		}
+		events, err = s.calendar.ListEventsInRange(ctx, bounds[0], bounds[1], request.GetString("calendar_id", ""))
+	}
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return mcp.NewToolResultJSON(ExportICSResponse{
+		Count: len(events),
+		ICS:   calendar.EncodeICS(events),
+	})
 }
 
-// HydratedMessage is a summary of a Gmail message with common fields extracted
-type HydratedMessage struct {
-	ID       string   `json:"id"`
-	ThreadID string   `json:"threadId"`
-	From     string   `json:"from,omitempty"`
-	To       string   `json:"to,omitempty"`
-	Subject  string   `json:"subject,omitempty"`
-	Snippet  string   `json:"snippet,omitempty"`
-	Date     string   `json:"date,omitempty"`
-	LabelIDs []string `json:"labelIds,omitempty"`
+// getSnapshot decodes a calendar_snapshot result passed back as an argument
+func getSnapshot(request mcp.CallToolRequest, name string) (*calendar.Snapshot, error) {
+	raw, ok := request.GetArguments()[name]
+	if !ok || raw == nil {
+		return nil, fmt.Errorf("required argument %q not found", name)
+	}
+	if _, ok := raw.(map[string]interface{}); !ok {
+		return nil, fmt.Errorf("%s must be a snapshot object from calendar_snapshot", name)
+	}
+
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read %s: %w", name, err)
+	}
+	var snapshot calendar.Snapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, fmt.Errorf("%s is not a valid snapshot: %w", name, err)
+	}
+	return &snapshot, nil
 }
 
-// ListMessagesResponse wraps message list results for MCP structuredContent
-type ListMessagesResponse struct {
-	Messages []HydratedMessage `json:"messages"`
-	Count    int               `json:"count"`
+func (s *Server) handleCalendarGetEvent(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	eventID, err := request.RequireString("event_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	event, err := s.calendar.GetEvent(ctx, eventID)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	if request.GetBool("resolve_names", false) {
+		resolver := s.people.NewNameResolver()
+		for _, attendee := range event.Attendees {
+			if attendee.DisplayName != "" || attendee.Email == "" {
+				continue
+			}
+			attendee.DisplayName = resolver.Resolve(ctx, attendee.Email)
+			if attendee.DisplayName == "" {
+				attendee.DisplayName = attendee.Email
+			}
+		}
+	}
+
+	return mcp.NewToolResultJSON(event)
 }
 
-// ListEventsResponse wraps calendar event list results for MCP structuredContent
-type ListEventsResponse struct {
-	Events any `json:"events"`
-	Count  int `json:"count"`
+// EventAttachmentsResponse is the response for calendar_list_event_attachments
+type EventAttachmentsResponse struct {
+	EventID     string                     `json:"event_id"`
+	Count       int                        `json:"count"`
+	Attachments []calendar.EventAttachment `json:"attachments"`
 }
 
-// ListContactsResponse wraps contact list results for MCP structuredContent
-type ListContactsResponse struct {
-	Contacts any `json:"contacts"`
-	Count    int `json:"count"`
+func (s *Server) handleCalendarListEventAttachments(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	eventID, err := request.RequireString("event_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	attachments, err := s.calendar.ListEventAttachments(ctx, eventID)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return mcp.NewToolResultJSON(EventAttachmentsResponse{
+		EventID:     eventID,
+		Count:       len(attachments),
+		Attachments: attachments,
+	})
 }
 
-// Tool handlers
-func (s *Server) handleGmailListMessages(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	query := request.GetString("query", "")
-	maxResults := int64(request.GetInt("max_results", 100))
-	hydrate := request.GetBool("hydrate", false)
+func (s *Server) handleCalendarCreateEvent(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	summary, err := request.RequireString("summary")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
 
-	messages, err := s.gmail.ListMessages(ctx, query, maxResults)
+	description := request.GetString("description", "")
+
+	startTimeStr, err := request.RequireString("start_time")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	endTimeStr, err := request.RequireString("end_time")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	startTime, err := time.Parse(time.RFC3339, startTimeStr)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("invalid start_time format: %v", err)), nil
+	}
+
+	endTime, err := time.Parse(time.RFC3339, endTimeStr)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("invalid end_time format: %v", err)), nil
+	}
+
+	// Get optional attendee parameters
+	attendees := request.GetStringSlice("attendees", []string{})
+	optionalAttendees := request.GetStringSlice("optional_attendees", []string{})
+	sendUpdates, err := getSendUpdates(request)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	extendedProps, err := getExtendedProperties(request, "extended_properties")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	source, err := getEventSource(request, "source")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	opts := &calendar.EventOptions{
+		ExtendedProperties:   extendedProps,
+		Source:               source,
+		EventType:            request.GetString("event_type", calendar.EventTypeDefault),
+		DeclineMessage:       request.GetString("decline_message", ""),
+		WorkingLocationType:  request.GetString("working_location_type", ""),
+		WorkingLocationLabel: request.GetString("working_location_label", ""),
+		EventID:              request.GetString("event_id", ""),
+		IncludeSelf:          request.GetBool("include_self_as_attendee", false),
+		RoomEmail:            strings.TrimSpace(request.GetString("room_email", "")),
+		Agenda:               request.GetStringSlice("agenda", nil),
+		NotesLink:            request.GetString("meeting_notes_link", ""),
+	}
+
+	event, err := s.calendar.CreateEvent(ctx, summary, description, startTime, endTime, attendees, optionalAttendees, sendUpdates, opts)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	if opts.RoomEmail != "" {
+		status := calendar.RoomResponseStatus(event, opts.RoomEmail)
+		return mcp.NewToolResultJSON(RoomBookingResponse{
+			Event:        event,
+			RoomEmail:    opts.RoomEmail,
+			RoomStatus:   status,
+			RoomAccepted: status == "accepted",
+		})
+	}
+
+	return mcp.NewToolResultJSON(event)
+}
+
+// RoomBookingResponse is the response for calendar_create_event when a room
+// is booked. Rooms may answer after the event is created, so a needsAction
+// status means the booking is still pending rather than refused.
+type RoomBookingResponse struct {
+	Event        *googlecalendar.Event `json:"event"`
+	RoomEmail    string                `json:"room_email"`
+	RoomStatus   string                `json:"room_status"` // accepted, declined, tentative, or needsAction
+	RoomAccepted bool                  `json:"room_accepted"`
+}
+
+func (s *Server) handleCalendarCreateEventFromTemplate(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	name, err := request.RequireString("template")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	tmpl, err := s.templates.Get(name)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	startTimeStr, err := request.RequireString("start_time")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	startTime, err := time.Parse(time.RFC3339, startTimeStr)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("invalid start_time format: %v", err)), nil
+	}
+
+	duration := request.GetInt("duration_minutes", tmpl.DurationMinutes)
+	if duration <= 0 {
+		return mcp.NewToolResultError("duration_minutes must be positive"), nil
+	}
+	endTime := startTime.Add(time.Duration(duration) * time.Minute)
+	if endTimeStr := request.GetString("end_time", ""); endTimeStr != "" {
+		endTime, err = time.Parse(time.RFC3339, endTimeStr)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("invalid end_time format: %v", err)), nil
+		}
+	}
+	if !endTime.After(startTime) {
+		return mcp.NewToolResultError("end_time must be after start_time"), nil
+	}
+
+	reminders := tmpl.ReminderMinutes
+	if values := request.GetIntSlice("reminder_minutes", nil); values != nil {
+		reminders = make([]int64, len(values))
+		for i, v := range values {
+			reminders[i] = int64(v)
+		}
+	}
+
+	sendUpdates, err := getSendUpdates(request)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	event, err := s.calendar.CreateEvent(ctx,
+		request.GetString("summary", tmpl.Summary),
+		tmpl.Description(request.GetString("description", "")),
+		startTime,
+		endTime,
+		request.GetStringSlice("attendees", []string{}),
+		request.GetStringSlice("optional_attendees", []string{}),
+		sendUpdates,
+		&calendar.EventOptions{ReminderMinutes: reminders},
+	)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return mcp.NewToolResultJSON(event)
+}
+
+// CalendarTimezoneResponse is the result of calendar_get_timezone
+type CalendarTimezoneResponse struct {
+	CalendarID string `json:"calendar_id"`
+	TimeZone   string `json:"time_zone"`
+}
+
+func (s *Server) handleCalendarGetTimezone(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	calendarID := request.GetString("calendar_id", "primary")
+	zone, err := s.calendar.GetCalendarTimezone(ctx, calendarID)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	return mcp.NewToolResultJSON(CalendarTimezoneResponse{CalendarID: calendarID, TimeZone: zone})
+}
+
+func (s *Server) handleCalendarBlockTime(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	summary, err := request.RequireString("summary")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	var days [2]time.Time
+	for i, name := range []string{"start_date", "end_date"} {
+		value, err := request.RequireString(name)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		days[i], err = time.ParseInLocation("2006-01-02", value, s.loc)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("invalid %s format: expected YYYY-MM-DD, got %q", name, value)), nil
+		}
+	}
+
+	outOfOffice := request.GetBool("out_of_office", false)
+	declineMessage := request.GetString("decline_message", "")
+	if declineMessage != "" && !outOfOffice {
+		return mcp.NewToolResultError("decline_message requires out_of_office"), nil
+	}
+
+	event, err := s.calendar.BlockTime(ctx, summary, days[0], days[1], s.loc, outOfOffice, declineMessage)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return mcp.NewToolResultJSON(event)
+}
+
+func (s *Server) handleCalendarUpdateEvent(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	eventID, err := request.RequireString("event_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	// Validate attendee parameters before fetching event
+	attendees := request.GetStringSlice("attendees", nil)
+	optionalAttendees := request.GetStringSlice("optional_attendees", nil)
+	addAttendees := request.GetStringSlice("add_attendees", nil)
+	addOptionalAttendees := request.GetStringSlice("add_optional_attendees", nil)
+	removeAttendees := request.GetStringSlice("remove_attendees", nil)
+
+	// Detect which mode is being used
+	hasFullReplacement := attendees != nil || optionalAttendees != nil
+	hasIncremental := addAttendees != nil || addOptionalAttendees != nil || removeAttendees != nil
+
+	// Error if mixing modes
+	if hasFullReplacement && hasIncremental {
+		return mcp.NewToolResultError("cannot mix full replacement (attendees/optional_attendees) with incremental updates (add_attendees/add_optional_attendees/remove_attendees)"), nil
+	}
+
+	extendedProps, err := getExtendedProperties(request, "extended_properties")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	// Only the text being set is checked; existing text may predate the limits
+	if err := calendar.ValidateEventText(request.GetString("summary", ""), request.GetString("description", "")); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	// Get existing event
+	event, err := s.calendar.GetEvent(ctx, eventID)
 	if err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	if !hydrate {
-		// Wrap in object for MCP structuredContent compatibility
-		result := make([]HydratedMessage, len(messages))
-		for i, msg := range messages {
-			result[i] = HydratedMessage{
-				ID:       msg.Id,
-				ThreadID: msg.ThreadId,
+	// Merge rather than replace so other integrations' keys survive
+	if extendedProps != nil {
+		if err := calendar.MergeExtendedProperties(event, extendedProps); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+	}
+
+	// Update fields if provided
+	if summary := request.GetString("summary", ""); summary != "" {
+		event.Summary = summary
+	}
+
+	if description := request.GetString("description", ""); description != "" {
+		event.Description = description
+	}
+
+	if startTimeStr := request.GetString("start_time", ""); startTimeStr != "" {
+		startTime, err := time.Parse(time.RFC3339, startTimeStr)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("invalid start_time format: %v", err)), nil
+		}
+		if event.Start == nil {
+			event.Start = &googlecalendar.EventDateTime{}
+		}
+		event.Start.DateTime = startTime.Format(time.RFC3339)
+	}
+
+	if endTimeStr := request.GetString("end_time", ""); endTimeStr != "" {
+		endTime, err := time.Parse(time.RFC3339, endTimeStr)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("invalid end_time format: %v", err)), nil
+		}
+		if event.End == nil {
+			event.End = &googlecalendar.EventDateTime{}
+		}
+		event.End.DateTime = endTime.Format(time.RFC3339)
+	}
+
+	// Handle attendee updates
+
+	// Apply attendee updates
+	if hasFullReplacement {
+		// Full replacement mode - rebuild attendee list with deduplication
+		// Use map to deduplicate by email (case-insensitive)
+		// If same email in both lists, optional_attendees wins (processed second)
+		seen := make(map[string]*googlecalendar.EventAttendee)
+
+		// Add required attendees
+		for _, email := range attendees {
+			if email == "" {
+				continue
+			}
+			emailLower := strings.ToLower(email)
+			seen[emailLower] = &googlecalendar.EventAttendee{
+				Email:    email,
+				Optional: false,
 			}
 		}
-		return mcp.NewToolResultJSON(ListMessagesResponse{
-			Messages: result,
-			Count:    len(result),
+
+		// Add optional attendees (overwrites if duplicate)
+		for _, email := range optionalAttendees {
+			if email == "" {
+				continue
+			}
+			emailLower := strings.ToLower(email)
+			seen[emailLower] = &googlecalendar.EventAttendee{
+				Email:    email,
+				Optional: true,
+			}
+		}
+
+		// Convert map to slice with deterministic order
+		newAttendees := make([]*googlecalendar.EventAttendee, 0, len(seen))
+		for _, att := range seen {
+			newAttendees = append(newAttendees, att)
+		}
+		sort.Slice(newAttendees, func(i, j int) bool {
+			return newAttendees[i].Email < newAttendees[j].Email
 		})
-	}
 
-	// Hydrate: fetch full details for each message
-	hydrated := make([]HydratedMessage, 0, len(messages))
-	for _, msg := range messages {
-		fullMsg, err := s.gmail.GetMessage(ctx, msg.Id)
-		if err != nil {
-			// If we can't get one message, include basic info and continue
-			hydrated = append(hydrated, HydratedMessage{
-				ID:       msg.Id,
-				ThreadID: msg.ThreadId,
-			})
-			continue
+		event.Attendees = newAttendees
+	} else if hasIncremental {
+		// Incremental mode - modify existing attendee list
+		existingAttendees := event.Attendees
+		if existingAttendees == nil {
+			existingAttendees = []*googlecalendar.EventAttendee{}
 		}
 
-		hm := HydratedMessage{
-			ID:       fullMsg.Id,
-			ThreadID: fullMsg.ThreadId,
-			Snippet:  fullMsg.Snippet,
-			LabelIDs: fullMsg.LabelIds,
+		// Build a map for quick lookup
+		attendeeMap := make(map[string]*googlecalendar.EventAttendee)
+		for _, att := range existingAttendees {
+			attendeeMap[strings.ToLower(att.Email)] = att
+		}
+
+		// Add required attendees
+		for _, email := range addAttendees {
+			emailLower := strings.ToLower(email)
+			if _, exists := attendeeMap[emailLower]; !exists {
+				attendeeMap[emailLower] = &googlecalendar.EventAttendee{
+					Email:    email,
+					Optional: false,
+				}
+			}
 		}
 
-		// Extract headers
-		if fullMsg.Payload != nil {
-			for _, header := range fullMsg.Payload.Headers {
-				switch strings.ToLower(header.Name) {
-				case "from":
-					hm.From = header.Value
-				case "to":
-					hm.To = header.Value
-				case "subject":
-					hm.Subject = header.Value
-				case "date":
-					hm.Date = header.Value
+		// Add optional attendees
+		for _, email := range addOptionalAttendees {
+			emailLower := strings.ToLower(email)
+			if _, exists := attendeeMap[emailLower]; !exists {
+				attendeeMap[emailLower] = &googlecalendar.EventAttendee{
+					Email:    email,
+					Optional: true,
 				}
 			}
 		}
 
-		hydrated = append(hydrated, hm)
+		// Remove attendees
+		for _, email := range removeAttendees {
+			emailLower := strings.ToLower(email)
+			delete(attendeeMap, emailLower)
+		}
+
+		// Convert map back to slice with deterministic order
+		finalAttendees := make([]*googlecalendar.EventAttendee, 0, len(attendeeMap))
+		for _, att := range attendeeMap {
+			finalAttendees = append(finalAttendees, att)
+		}
+		sort.Slice(finalAttendees, func(i, j int) bool {
+			return finalAttendees[i].Email < finalAttendees[j].Email
+		})
+
+		event.Attendees = finalAttendees
 	}
 
-	return mcp.NewToolResultJSON(ListMessagesResponse{
-		Messages: hydrated,
-		Count:    len(hydrated),
-	})
+	// send_updates wins over send_notifications (defaults to notifying all)
+	sendUpdates, err := getSendUpdates(request)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	updated, err := s.calendar.UpdateEvent(ctx, eventID, event, sendUpdates)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return mcp.NewToolResultJSON(updated)
 }
 
-func (s *Server) handleGmailGetMessage(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	messageID, err := request.RequireString("message_id")
+func (s *Server) handleCalendarFindByProperty(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	props, err := getExtendedProperties(request, "extended_properties")
 	if err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	msg, err := s.gmail.GetMessage(ctx, messageID)
+	var bounds [2]time.Time
+	for i, name := range []string{"time_min", "time_max"} {
+		if value := request.GetString(name, ""); value != "" {
+			bounds[i], err = time.Parse(time.RFC3339, value)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("invalid %s format: %v", name, err)), nil
+			}
+		}
+	}
+
+	maxResults := int64(request.GetInt("max_results", 50))
+	events, err := s.calendar.FindEventsByProperty(ctx, props, bounds[0], bounds[1], maxResults)
 	if err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	return mcp.NewToolResultJSON(msg)
+	return mcp.NewToolResultJSON(ListEventsResponse{
+		Events: events,
+		Count:  len(events),
+	})
 }
 
-func (s *Server) handleGmailSendMessage(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	to, err := request.RequireString("to")
+func (s *Server) handleCalendarDeleteEvent(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	eventID, err := request.RequireString("event_id")
 	if err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	subject, err := request.RequireString("subject")
+	err = s.calendar.DeleteEvent(ctx, eventID)
 	if err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	body, err := request.RequireString("body")
+	return deletedResult("event", eventID)
+}
+
+func (s *Server) handleCalendarCancelEvent(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	eventID, err := request.RequireString("event_id")
 	if err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	inReplyTo := request.GetString("in_reply_to", "")
-
-	msg, err := s.gmail.SendMessage(ctx, to, subject, body, inReplyTo)
+	event, err := s.calendar.CancelEvent(ctx, eventID, request.GetString("note", ""))
 	if err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	return mcp.NewToolResultJSON(msg)
+	return mcp.NewToolResultJSON(event)
 }
 
-func (s *Server) handleGmailCreateDraft(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	to, err := request.RequireString("to")
+func (s *Server) handleCalendarRescheduleEvent(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	eventID, err := request.RequireString("event_id")
 	if err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	subject, err := request.RequireString("subject")
+	offsetStr, err := request.RequireString("offset")
 	if err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
 	}
-
-	body, err := request.RequireString("body")
+	offset, err := calendar.ParseOffset(offsetStr)
 	if err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	inReplyTo := request.GetString("in_reply_to", "")
+	sendUpdates, err := getSendUpdates(request)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
 
-	draft, err := s.gmail.CreateDraft(ctx, to, subject, body, inReplyTo)
+	event, err := s.calendar.RescheduleEvent(ctx, eventID, offset, sendUpdates)
 	if err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	return mcp.NewToolResultJSON(draft)
+	return mcp.NewToolResultJSON(event)
 }
 
-func (s *Server) handleGmailSendDraft(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	draftID, err := request.RequireString("draft_id")
+// ValidateScheduleResponse is the response for calendar_validate_schedule
+type ValidateScheduleResponse struct {
+	Slots     int                         `json:"slots"`
+	Clear     bool                        `json:"clear"` // No slot conflicts with anything
+	Conflicts []calendar.ScheduleConflict `json:"conflicts"`
+}
+
+func (s *Server) handleCalendarValidateSchedule(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	slots, err := getProposedSlots(request)
 	if err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	msg, err := s.gmail.SendDraft(ctx, draftID)
+	conflicts, err := s.calendar.ValidateSchedule(ctx, slots)
 	if err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	return mcp.NewToolResultJSON(msg)
+	return mcp.NewToolResultJSON(ValidateScheduleResponse{
+		Slots:     len(slots),
+		Clear:     len(conflicts) == 0,
+		Conflicts: conflicts,
+	})
 }
 
-func (s *Server) handleGmailModifyLabels(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	messageID, err := request.RequireString("message_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
+// getProposedSlots parses the slots array of calendar_validate_schedule
+func getProposedSlots(request mcp.CallToolRequest) ([]calendar.ProposedSlot, error) {
+	args, _ := request.Params.Arguments.(map[string]interface{})
+	arr, ok := args["slots"].([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("slots must be an array")
 	}
 
-	// Get array parameters - these come as []interface{} from MCP
-	// Need to cast Arguments to map first
-	args, ok := request.Params.Arguments.(map[string]interface{})
-	if !ok {
-		return mcp.NewToolResultError("invalid arguments format"), nil
+	slots := make([]calendar.ProposedSlot, 0, len(arr))
+	for i, item := range arr {
+		obj, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("slots[%d] must be an object", i)
+		}
+		parse := func(field string) (time.Time, error) {
+			value, _ := obj[field].(string)
+			t, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				return time.Time{}, fmt.Errorf("invalid slots[%d].%s: expected RFC3339, got %q", i, field, value)
+			}
+			return t, nil
+		}
+		start, err := parse("start")
+		if err != nil {
+			return nil, err
+		}
+		end, err := parse("end")
+		if err != nil {
+			return nil, err
+		}
+		summary, _ := obj["summary"].(string)
+		slots = append(slots, calendar.ProposedSlot{Start: start, End: end, Summary: summary})
 	}
+	return slots, nil
+}
 
-	addLabelsRaw := args["add_labels"]
-	removeLabelsRaw := args["remove_labels"]
+// BulkEventSummary identifies an event matched by calendar_delete_events_bulk
+type BulkEventSummary struct {
+	ID      string `json:"id"`
+	Summary string `json:"summary,omitempty"`
+	Start   string `json:"start"`
+}
 
-	var addLabels, removeLabels []string
+// DeleteEventsBulkResponse is the response for calendar_delete_events_bulk
+type DeleteEventsBulkResponse struct {
+	Deleted bool               `json:"deleted"`
+	Count   int                `json:"count"`            // Events deleted, or matched when previewing
+	Events  []BulkEventSummary `json:"events,omitempty"` // Matches, only when previewing
+	Message string             `json:"message,omitempty"`
+}
 
-	if addLabelsRaw != nil {
-		if arr, ok := addLabelsRaw.([]interface{}); ok {
-			for _, v := range arr {
-				if str, ok := v.(string); ok {
-					addLabels = append(addLabels, str)
-				}
-			}
+func (s *Server) handleCalendarDeleteEventsBulk(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var bounds [2]time.Time
+	for i, name := range []string{"time_min", "time_max"} {
+		value, err := request.RequireString(name)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		bounds[i], err = time.Parse(time.RFC3339, value)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("invalid %s format: %v", name, err)), nil
 		}
 	}
+	timeMin, timeMax := bounds[0], bounds[1]
+	query := request.GetString("query", "")
+	calendarID := request.GetString("calendar_id", "")
 
-	if removeLabelsRaw != nil {
-		if arr, ok := removeLabelsRaw.([]interface{}); ok {
-			for _, v := range arr {
-				if str, ok := v.(string); ok {
-					removeLabels = append(removeLabels, str)
-				}
-			}
+	// Deletion is permanent, so require explicit confirmation
+	if !request.GetBool("confirm", false) {
+		events, err := s.calendar.FindEventsInWindow(ctx, query, timeMin, timeMax, calendarID)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		summaries := make([]BulkEventSummary, 0, len(events))
+		for _, event := range events {
+			summaries = append(summaries, BulkEventSummary{ID: event.Id, Summary: event.Summary, Start: eventStart(event)})
 		}
+
+		return mcp.NewToolResultJSON(DeleteEventsBulkResponse{
+			Deleted: false,
+			Count:   len(summaries),
+			Events:  summaries,
+			Message: "events NOT deleted - deletion is permanent; call calendar_delete_events_bulk again with confirm=true to delete these events",
+		})
 	}
 
-	modified, err := s.gmail.ModifyLabels(ctx, messageID, addLabels, removeLabels)
+	count, err := s.calendar.DeleteEventsByQuery(ctx, query, timeMin, timeMax, calendarID)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("%v (%d events deleted before the failure)", err, count)), nil
+	}
+
+	return mcp.NewToolResultJSON(DeleteEventsBulkResponse{
+		Deleted: true,
+		Count:   count,
+	})
+}
+
+// SuggestSlotsResponse wraps meeting slot suggestions for MCP structuredContent
+type SuggestSlotsResponse struct {
+	Slots []calendar.TimeSlot `json:"slots"`
+	Count int                 `json:"count"`
+}
+
+func (s *Server) handleCalendarSuggestSlots(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	attendees := request.GetStringSlice("attendees", nil)
+	if len(attendees) == 0 {
+		return mcp.NewToolResultError("at least one attendee is required"), nil
+	}
+
+	duration := request.GetInt("duration_minutes", 30)
+
+	windowStart, err := s.parseDateParam(request, "time_min")
 	if err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
 	}
+	if windowStart.IsZero() {
+		windowStart = time.Now().In(s.loc)
+	}
 
-	return mcp.NewToolResultJSON(modified)
+	windowEnd, err := s.parseDateParam(request, "time_max")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if windowEnd.IsZero() {
+		windowEnd = windowStart.Add(7 * 24 * time.Hour)
+	}
+
+	workStart := request.GetString("work_start", s.workStart)
+	workEnd := request.GetString("work_end", s.workEnd)
+
+	slots, err := s.calendar.SuggestMeetingSlots(ctx, attendees, duration, windowStart, windowEnd, workStart, workEnd)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return mcp.NewToolResultJSON(SuggestSlotsResponse{
+		Slots: slots,
+		Count: len(slots),
+	})
 }
 
-func (s *Server) handleGmailTrashMessage(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	messageID, err := request.RequireString("message_id")
+// FindRoomResponse is the response for calendar_find_room
+type FindRoomResponse struct {
+	FreeRooms []string                    `json:"free_rooms"` // In the order given; the first is the suggestion
+	Rooms     []calendar.RoomAvailability `json:"rooms"`
+}
+
+func (s *Server) handleCalendarFindRoom(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	rooms := request.GetStringSlice("rooms", nil)
+	if len(rooms) == 0 {
+		return mcp.NewToolResultError("at least one room is required"), nil
+	}
+
+	startStr, err := request.RequireString("start_time")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	start, err := time.Parse(time.RFC3339, startStr)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("invalid start_time format: %v", err)), nil
+	}
+
+	endStr, err := request.RequireString("end_time")
 	if err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
 	}
+	end, err := time.Parse(time.RFC3339, endStr)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("invalid end_time format: %v", err)), nil
+	}
 
-	trashed, err := s.gmail.TrashMessage(ctx, messageID)
+	availability, err := s.calendar.FindAvailableRoom(ctx, rooms, start, end)
 	if err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	return mcp.NewToolResultJSON(trashed)
+	resp := FindRoomResponse{FreeRooms: []string{}, Rooms: availability}
+	for _, room := range availability {
+		if room.Free {
+			resp.FreeRooms = append(resp.FreeRooms, room.Email)
+		}
+	}
+	return mcp.NewToolResultJSON(resp)
 }
 
-func (s *Server) handleGmailDeleteMessage(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	messageID, err := request.RequireString("message_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
+// EmailAgendaResponse is the response for calendar_email_agenda
+type EmailAgendaResponse struct {
+	Date       string `json:"date"`
+	EventCount int    `json:"event_count"`
+	To         string `json:"to"`
+	Subject    string `json:"subject"`
+	Sent       bool   `json:"sent"`
+	DraftID    string `json:"draft_id,omitempty"`
+	MessageID  string `json:"message_id,omitempty"`
+}
+
+func (s *Server) handleCalendarEmailAgenda(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	send := request.GetBool("send", false)
+	if send && s.draftOnly {
+		return mcp.NewToolResultError(errDraftOnly.Error()), nil
 	}
 
-	err = s.gmail.DeleteMessage(ctx, messageID)
+	day, err := s.parseDateParam(request, "date")
 	if err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
 	}
+	if day.IsZero() {
+		day = time.Now()
+	}
 
-	return mcp.NewToolResultText(fmt.Sprintf("Message %s deleted successfully", messageID)), nil
-}
-
-func (s *Server) handleCalendarListEvents(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	maxResults := int64(request.GetInt("max_results", 100))
-
-	var timeMin, timeMax time.Time
-	if tm := request.GetString("time_min", ""); tm != "" {
-		parsed, err := time.Parse(time.RFC3339, tm)
+	to := strings.TrimSpace(request.GetString("to", ""))
+	if to == "" {
+		to, err = s.gmail.SendingAddress(ctx)
 		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("invalid time_min format: %v", err)), nil
+			return mcp.NewToolResultError(err.Error()), nil
 		}
-		timeMin = parsed
 	}
-
-	if tm := request.GetString("time_max", ""); tm != "" {
-		parsed, err := time.Parse(time.RFC3339, tm)
-		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("invalid time_max format: %v", err)), nil
+	if send {
+		if refused := s.refuseExternal(request, to); refused != nil {
+			return refused, nil
 		}
-		timeMax = parsed
 	}
 
-	events, err := s.calendar.ListEvents(ctx, maxResults, timeMin, timeMax)
+	startOfDay, events, err := s.dayEvents(ctx, day)
 	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
+		return mcp.NewToolResultError(fmt.Sprintf("failed to fetch events: %v", err)), nil
 	}
 
-	return mcp.NewToolResultJSON(ListEventsResponse{
-		Events: events,
-		Count:  len(events),
-	})
-}
-
-func (s *Server) handleCalendarGetEvent(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	eventID, err := request.RequireString("event_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
+	resp := EmailAgendaResponse{
+		Date:       startOfDay.Format("2006-01-02"),
+		EventCount: len(events),
+		To:         to,
+		Subject:    "Agenda for " + startOfDay.Format("Monday, January 2, 2006"),
 	}
+	body := calendar.AgendaHTML(startOfDay, events, s.loc)
 
-	event, err := s.calendar.GetEvent(ctx, eventID)
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
+	if send {
+		msg, err := s.gmail.SendMessage(ctx, to, resp.Subject, body, "", nil)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		resp.Sent = true
+		resp.MessageID = msg.Id
+	} else {
+		draft, err := s.gmail.CreateDraft(ctx, to, resp.Subject, body, "", nil)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		resp.DraftID = draft.Id
 	}
 
-	return mcp.NewToolResultJSON(event)
+	return mcp.NewToolResultJSON(resp)
 }
 
-func (s *Server) handleCalendarCreateEvent(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	summary, err := request.RequireString("summary")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-
-	description := request.GetString("description", "")
+func (s *Server) handlePeopleListContacts(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	pageSize := int64(request.GetInt("page_size", 100))
 
-	startTimeStr, err := request.RequireString("start_time")
+	readMask, err := readMaskParam(request)
 	if err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	endTimeStr, err := request.RequireString("end_time")
+	contacts, err := s.people.ListContacts(ctx, pageSize, readMask)
 	if err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	startTime, err := time.Parse(time.RFC3339, startTimeStr)
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("invalid start_time format: %v", err)), nil
-	}
+	return mcp.NewToolResultJSON(ListContactsResponse{
+		Contacts: contacts,
+		Count:    len(contacts),
+	})
+}
 
-	endTime, err := time.Parse(time.RFC3339, endTimeStr)
+func (s *Server) handlePeopleListGroupMembers(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	group, err := request.RequireString("group")
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("invalid end_time format: %v", err)), nil
+		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	// Get optional attendee parameters
-	attendees := request.GetStringSlice("attendees", []string{})
-	optionalAttendees := request.GetStringSlice("optional_attendees", []string{})
-	sendNotifications := request.GetBool("send_notifications", true)
-
-	event, err := s.calendar.CreateEvent(ctx, summary, description, startTime, endTime, attendees, optionalAttendees, sendNotifications)
+	members, err := s.people.ListContactsInGroup(ctx, group, int64(request.GetInt("page_size", 100)))
 	if err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	return mcp.NewToolResultJSON(event)
+	return mcp.NewToolResultJSON(members)
 }
 
-func (s *Server) handleCalendarUpdateEvent(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	eventID, err := request.RequireString("event_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
+// Defaults and limits for people_interaction_history. Mail is one bounded
+// list and meetings are searched by the contact's address within at most
+// maxHistoryDays each way, so a busy contact can't fan out into unbounded lookups.
+const (
+	defaultHistoryItems     = 10
+	maxHistoryItems         = 50
+	defaultHistoryDaysBack  = 90
+	defaultHistoryDaysAhead = 30
+	maxHistoryDays          = 365
+)
 
-	// Validate attendee parameters before fetching event
-	attendees := request.GetStringSlice("attendees", nil)
-	optionalAttendees := request.GetStringSlice("optional_attendees", nil)
-	addAttendees := request.GetStringSlice("add_attendees", nil)
-	addOptionalAttendees := request.GetStringSlice("add_optional_attendees", nil)
-	removeAttendees := request.GetStringSlice("remove_attendees", nil)
+// InteractionHistory is the response for people_interaction_history
+type InteractionHistory struct {
+	Email                 string           `json:"email"`
+	LastInteraction       string           `json:"last_interaction,omitempty"`        // RFC3339; newest message or past meeting, omitted with no history
+	LastInteractionSource string           `json:"last_interaction_source,omitempty"` // email or meeting
+	Emails                []HistoryEmail   `json:"emails"`                            // Newest first
+	PastMeetings          []HistoryMeeting `json:"past_meetings"`                     // Most recent first
+	UpcomingMeetings      []HistoryMeeting `json:"upcoming_meetings"`                 // Soonest first
+}
 
-	// Detect which mode is being used
-	hasFullReplacement := attendees != nil || optionalAttendees != nil
-	hasIncremental := addAttendees != nil || addOptionalAttendees != nil || removeAttendees != nil
+// HistoryEmail is one message exchanged with the contact
+type HistoryEmail struct {
+	MessageID string `json:"message_id"`
+	ThreadID  string `json:"thread_id"`
+	Date      string `json:"date"`      // RFC3339 in the configured timezone
+	Direction string `json:"direction"` // received (from the contact), sent (from you), or other (e.g. from a third party with the contact copied)
+	Subject   string `json:"subject"`
+	Snippet   string `json:"snippet"`
+}
 
-	// Error if mixing modes
-	if hasFullReplacement && hasIncremental {
-		return mcp.NewToolResultError("cannot mix full replacement (attendees/optional_attendees) with incremental updates (add_attendees/add_optional_attendees/remove_attendees)"), nil
+// HistoryMeeting is one event the contact organizes or attends
+type HistoryMeeting struct {
+	EventID string `json:"event_id"`
+	Summary string `json:"summary"`
+	Start   string `json:"start"`
+	End     string `json:"end"`
+	Link    string `json:"link,omitempty"`
+}
+
+// historyLimit reads a positive integer argument capped at max
+func historyLimit(request mcp.CallToolRequest, name string, def, max int) (int, error) {
+	value := request.GetInt(name, def)
+	if value < 1 || value > max {
+		return 0, fmt.Errorf("%s must be between 1 and %d", name, max)
 	}
+	return value, nil
+}
 
-	// Get existing event
-	event, err := s.calendar.GetEvent(ctx, eventID)
+func (s *Server) handlePeopleInteractionHistory(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	email, err := request.RequireString("email")
 	if err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
 	}
-
-	// Update fields if provided
-	if summary := request.GetString("summary", ""); summary != "" {
-		event.Summary = summary
-	}
-
-	if description := request.GetString("description", ""); description != "" {
-		event.Description = description
+	addr, err := mail.ParseAddress(strings.TrimSpace(email))
+	if err != nil || strings.ContainsAny(addr.Address, " \t\"") {
+		return mcp.NewToolResultError(fmt.Sprintf("invalid email %q", email)), nil
 	}
-
-	if startTimeStr := request.GetString("start_time", ""); startTimeStr != "" {
-		startTime, err := time.Parse(time.RFC3339, startTimeStr)
-		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("invalid start_time format: %v", err)), nil
-		}
-		if event.Start == nil {
-			event.Start = &googlecalendar.EventDateTime{}
+	email = addr.Address
+
+	var limits [4]int
+	for i, arg := range []struct {
+		name string
+		def  int
+		max  int
+	}{
+		{"max_emails", defaultHistoryItems, maxHistoryItems},
+		{"max_meetings", defaultHistoryItems, maxHistoryItems},
+		{"days_back", defaultHistoryDaysBack, maxHistoryDays},
+		{"days_ahead", defaultHistoryDaysAhead, maxHistoryDays},
+	} {
+		if limits[i], err = historyLimit(request, arg.name, arg.def, arg.max); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
 		}
-		event.Start.DateTime = startTime.Format(time.RFC3339)
+	}
+	maxEmails, maxMeetings, daysBack, daysAhead := limits[0], limits[1], limits[2], limits[3]
+
+	now := time.Now()
+	history := InteractionHistory{
+		Email:            email,
+		Emails:           []HistoryEmail{},
+		PastMeetings:     []HistoryMeeting{},
+		UpcomingMeetings: []HistoryMeeting{},
 	}
 
-	if endTimeStr := request.GetString("end_time", ""); endTimeStr != "" {
-		endTime, err := time.Parse(time.RFC3339, endTimeStr)
-		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("invalid end_time format: %v", err)), nil
-		}
-		if event.End == nil {
-			event.End = &googlecalendar.EventDateTime{}
+	var messages []*googlegmail.Message
+	var events []*googlecalendar.Event
+	var mailErr, calendarErr error
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		messages, mailErr = s.historyMessages(ctx, email, maxEmails)
+	}()
+	go func() {
+		defer wg.Done()
+		events, calendarErr = s.calendar.MeetingsWith(ctx, email, now.AddDate(0, 0, -daysBack), now.AddDate(0, 0, daysAhead))
+	}()
+	wg.Wait()
+
+	var last time.Time
+	var warnings []string
+	if mailErr != nil {
+		warnings = append(warnings, "mail history unavailable: "+mailErr.Error())
+	}
+	var self string
+	if len(messages) > 0 {
+		if self, err = s.gmail.SendingAddress(ctx); err != nil {
+			warnings = append(warnings, "sent mail can't be told apart: "+err.Error())
 		}
-		event.End.DateTime = endTime.Format(time.RFC3339)
 	}
-
-	// Handle attendee updates
-
-	// Apply attendee updates
-	if hasFullReplacement {
-		// Full replacement mode - rebuild attendee list with deduplication
-		// Use map to deduplicate by email (case-insensitive)
-		// If same email in both lists, optional_attendees wins (processed second)
-		seen := make(map[string]*googlecalendar.EventAttendee)
-
-		// Add required attendees
-		for _, email := range attendees {
-			if email == "" {
-				continue
-			}
-			emailLower := strings.ToLower(email)
-			seen[emailLower] = &googlecalendar.EventAttendee{
-				Email:    email,
-				Optional: false,
-			}
+	for _, msg := range messages {
+		sent := time.UnixMilli(msg.InternalDate)
+		entry := HistoryEmail{
+			MessageID: msg.Id,
+			ThreadID:  msg.ThreadId,
+			Date:      sent.In(s.loc).Format(time.RFC3339),
+			Direction: "other",
+			Snippet:   msg.Snippet,
 		}
-
-		// Add optional attendees (overwrites if duplicate)
-		for _, email := range optionalAttendees {
-			if email == "" {
-				continue
-			}
-			emailLower := strings.ToLower(email)
-			seen[emailLower] = &googlecalendar.EventAttendee{
-				Email:    email,
-				Optional: true,
+		if parsed, err := gmail.ParseMessage(msg); err == nil {
+			entry.Subject = parsed.Header("Subject")
+			if from, err := mail.ParseAddress(parsed.Header("From")); err == nil {
+				switch {
+				case strings.EqualFold(from.Address, email):
+					entry.Direction = "received"
+				case self != "" && strings.EqualFold(from.Address, self):
+					entry.Direction = "sent"
+				}
 			}
 		}
-
-		// Convert map to slice with deterministic order
-		newAttendees := make([]*googlecalendar.EventAttendee, 0, len(seen))
-		for _, att := range seen {
-			newAttendees = append(newAttendees, att)
+		history.Emails = append(history.Emails, entry)
+		if sent.After(last) {
+			last, history.LastInteractionSource = sent, "email"
 		}
-		sort.Slice(newAttendees, func(i, j int) bool {
-			return newAttendees[i].Email < newAttendees[j].Email
-		})
+	}
 
-		event.Attendees = newAttendees
-	} else if hasIncremental {
-		// Incremental mode - modify existing attendee list
-		existingAttendees := event.Attendees
-		if existingAttendees == nil {
-			existingAttendees = []*googlecalendar.EventAttendee{}
+	if calendarErr != nil {
+		warnings = append(warnings, "meeting history unavailable: "+calendarErr.Error())
+	}
+	for _, event := range events {
+		if event.Start == nil {
+			continue
 		}
-
-		// Build a map for quick lookup
-		attendeeMap := make(map[string]*googlecalendar.EventAttendee)
-		for _, att := range existingAttendees {
-			attendeeMap[strings.ToLower(att.Email)] = att
+		start, err := time.Parse(time.RFC3339, event.Start.DateTime)
+		if err != nil {
+			// All-day events start at midnight in the configured timezone
+			start, err = time.ParseInLocation("2006-01-02", event.Start.Date, s.loc)
 		}
-
-		// Add required attendees
-		for _, email := range addAttendees {
-			emailLower := strings.ToLower(email)
-			if _, exists := attendeeMap[emailLower]; !exists {
-				attendeeMap[emailLower] = &googlecalendar.EventAttendee{
-					Email:    email,
-					Optional: false,
-				}
-			}
+		if err != nil {
+			continue
 		}
-
-		// Add optional attendees
-		for _, email := range addOptionalAttendees {
-			emailLower := strings.ToLower(email)
-			if _, exists := attendeeMap[emailLower]; !exists {
-				attendeeMap[emailLower] = &googlecalendar.EventAttendee{
-					Email:    email,
-					Optional: true,
-				}
+		meeting := HistoryMeeting{
+			EventID: event.Id,
+			Summary: event.Summary,
+			Start:   eventStart(event),
+			Link:    event.HtmlLink,
+		}
+		if event.End != nil {
+			meeting.End = event.End.DateTime
+			if meeting.End == "" {
+				meeting.End = event.End.Date
 			}
 		}
-
-		// Remove attendees
-		for _, email := range removeAttendees {
-			emailLower := strings.ToLower(email)
-			delete(attendeeMap, emailLower)
+		if start.After(now) {
+			history.UpcomingMeetings = append(history.UpcomingMeetings, meeting)
+			continue
 		}
-
-		// Convert map back to slice with deterministic order
-		finalAttendees := make([]*googlecalendar.EventAttendee, 0, len(attendeeMap))
-		for _, att := range attendeeMap {
-			finalAttendees = append(finalAttendees, att)
+		history.PastMeetings = append(history.PastMeetings, meeting)
+		if start.After(last) {
+			last, history.LastInteractionSource = start, "meeting"
 		}
-		sort.Slice(finalAttendees, func(i, j int) bool {
-			return finalAttendees[i].Email < finalAttendees[j].Email
-		})
+	}
 
-		event.Attendees = finalAttendees
+	// Events arrive in start order; past meetings read best newest first
+	for i, j := 0, len(history.PastMeetings)-1; i < j; i, j = i+1, j-1 {
+		history.PastMeetings[i], history.PastMeetings[j] = history.PastMeetings[j], history.PastMeetings[i]
+	}
+	if len(history.PastMeetings) > maxMeetings {
+		history.PastMeetings = history.PastMeetings[:maxMeetings]
+	}
+	if len(history.UpcomingMeetings) > maxMeetings {
+		history.UpcomingMeetings = history.UpcomingMeetings[:maxMeetings]
+	}
+	if !last.IsZero() {
+		history.LastInteraction = last.In(s.loc).Format(time.RFC3339)
 	}
 
-	// Get send_notifications parameter (defaults to true)
-	sendNotifications := request.GetBool("send_notifications", true)
+	result, err := mcp.NewToolResultJSON(history)
+	if err != nil {
+		return nil, err
+	}
+	return withWarnings(result, warnings), nil
+}
 
-	updated, err := s.calendar.UpdateEvent(ctx, eventID, event, sendNotifications)
+// historyMessages lists the newest messages from or to email with the
+// headers people_interaction_history reports. Messages whose metadata can't
+// be fetched are left out.
+func (s *Server) historyMessages(ctx context.Context, email string, maxEmails int) ([]*googlegmail.Message, error) {
+	listed, err := s.gmail.ListMessages(ctx, fmt.Sprintf("from:%s OR to:%s", email, email), int64(maxEmails))
 	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
+		return nil, err
 	}
 
-	return mcp.NewToolResultJSON(updated)
+	ids := make([]string, 0, len(listed))
+	for _, msg := range listed {
+		ids = append(ids, msg.Id)
+	}
+	fetched, errs := s.gmail.GetMessagesMetadata(ctx, ids, "From", "Subject")
+
+	messages := make([]*googlegmail.Message, 0, len(fetched))
+	for i, msg := range fetched {
+		if errs[i] == nil && msg != nil {
+			messages = append(messages, msg)
+		}
+	}
+	return messages, nil
 }
 
-func (s *Server) handleCalendarDeleteEvent(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	eventID, err := request.RequireString("event_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
+// readMaskParam returns the validated "fields" argument, or "" to use the service default
+func readMaskParam(request mcp.CallToolRequest) (string, error) {
+	fields := request.GetString("fields", "")
+	if fields == "" {
+		return "", nil
 	}
+	return people.NormalizeReadMask(fields)
+}
 
-	err = s.calendar.DeleteEvent(ctx, eventID)
+func (s *Server) handlePeopleSearchContacts(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	query, err := request.RequireString("query")
 	if err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	return mcp.NewToolResultText(fmt.Sprintf("Event %s deleted successfully", eventID)), nil
-}
-
-func (s *Server) handlePeopleListContacts(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	pageSize := int64(request.GetInt("page_size", 100))
+	pageSize := int64(request.GetInt("page_size", 10))
 
-	contacts, err := s.people.ListContacts(ctx, pageSize)
+	readMask, err := readMaskParam(request)
 	if err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	return mcp.NewToolResultJSON(ListContactsResponse{
-		Contacts: contacts,
-		Count:    len(contacts),
-	})
-}
+	sortBy := request.GetString("sort", "relevance")
+	if sortBy != "relevance" && sortBy != "name" {
+		return mcp.NewToolResultError(fmt.Sprintf("invalid sort %q: must be relevance or name", sortBy)), nil
+	}
+	// Sorting by name needs the names, so request them even when fields omits them
+	if sortBy == "name" && readMask != "" && !strings.Contains(","+readMask+",", ",names,") {
+		readMask += ",names"
+	}
 
-func (s *Server) handlePeopleSearchContacts(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	query, err := request.RequireString("query")
+	contacts, err := s.people.SearchContacts(ctx, query, pageSize, readMask)
 	if err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	pageSize := int64(request.GetInt("page_size", 10))
-
-	contacts, err := s.people.SearchContacts(ctx, query, pageSize)
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
+	if request.GetBool("search_other", false) {
+		// Other contacts need a scope older tokens may lack, so a failure
+		// there still returns the saved contacts
+		var warnings []string
+		others, err := s.people.SearchOtherContacts(ctx, query, pageSize, readMask)
+		if err != nil {
+			warnings = append(warnings, err.Error())
+		}
+		matches := people.MergeSearchMatches(contacts, others)
+		if sortBy == "name" {
+			people.SortMatchesByName(matches)
+		}
+		result, err := mcp.NewToolResultJSON(ListContactsResponse{
+			Contacts: matches,
+			Count:    len(matches),
+		})
+		if err != nil {
+			return nil, err
+		}
+		return withWarnings(result, warnings), nil
+	}
+
+	// The search API has no ordering control, so name sorting happens client-side
+	if sortBy == "name" {
+		people.SortByName(contacts)
 	}
 
 	return mcp.NewToolResultJSON(ListContactsResponse{
@@ -1028,7 +4015,30 @@ func (s *Server) handlePeopleGetContact(ctx context.Context, request mcp.CallToo
 		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	return mcp.NewToolResultJSON(person)
+	// Add the primary photo alongside the raw person fields
+	data, err := json.Marshal(person)
+	if err != nil {
+		return nil, err
+	}
+	var contact map[string]interface{}
+	if err := json.Unmarshal(data, &contact); err != nil {
+		return nil, err
+	}
+
+	var warnings []string
+	photo := people.PrimaryPhoto(person)
+	if photo != nil && !photo.Default && request.GetBool("include_photo", false) {
+		if err := s.people.DownloadPhoto(ctx, photo); err != nil {
+			warnings = append(warnings, err.Error())
+		}
+	}
+	contact["photo"] = photo
+
+	result, err := mcp.NewToolResultJSON(contact)
+	if err != nil {
+		return nil, err
+	}
+	return withWarnings(result, warnings), nil
 }
 
 func (s *Server) handlePeopleCreateContact(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -1037,30 +4047,20 @@ func (s *Server) handlePeopleCreateContact(ctx context.Context, request mcp.Call
 		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	familyName := request.GetString("family_name", "")
-	email := request.GetString("email", "")
-	phone := request.GetString("phone", "")
-
-	// Build Person object
-	person := &googlepeople.Person{
-		Names: []*googlepeople.Name{
-			{
-				GivenName:  givenName,
-				FamilyName: familyName,
-			},
-		},
+	fields := contactFields{
+		GivenName:    givenName,
+		FamilyName:   request.GetString("family_name", ""),
+		Email:        request.GetString("email", ""),
+		Phone:        request.GetString("phone", ""),
+		Organization: request.GetString("organization", ""),
 	}
-
-	if email != "" {
-		person.EmailAddresses = []*googlepeople.EmailAddress{
-			{Value: email},
-		}
+	if fields.Organization == "" && request.GetBool("infer_org_from_email", false) {
+		fields.Organization = people.InferOrganization(fields.Email)
 	}
+	person := fields.person()
 
-	if phone != "" {
-		person.PhoneNumbers = []*googlepeople.PhoneNumber{
-			{Value: phone},
-		}
+	if _, err := applyNotesAndRelations(request, person); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
 	}
 
 	created, err := s.people.CreateContact(ctx, person)
@@ -1071,6 +4071,37 @@ func (s *Server) handlePeopleCreateContact(ctx context.Context, request mcp.Call
 	return mcp.NewToolResultJSON(created)
 }
 
+// contactFields are the simple contact fields accepted by people_create_contact
+// and each entry of people_batch_create
+type contactFields struct {
+	GivenName    string `json:"given_name"`
+	FamilyName   string `json:"family_name"`
+	Email        string `json:"email"`
+	Phone        string `json:"phone"`
+	Organization string `json:"organization"`
+	Notes        string `json:"notes"`
+}
+
+// person builds the contact to create, leaving out empty fields
+func (f contactFields) person() *googlepeople.Person {
+	person := &googlepeople.Person{
+		Names: []*googlepeople.Name{{GivenName: f.GivenName, FamilyName: f.FamilyName}},
+	}
+	if f.Email != "" {
+		person.EmailAddresses = []*googlepeople.EmailAddress{{Value: f.Email}}
+	}
+	if f.Phone != "" {
+		person.PhoneNumbers = []*googlepeople.PhoneNumber{{Value: f.Phone}}
+	}
+	if f.Organization != "" {
+		person.Organizations = []*googlepeople.Organization{{Name: f.Organization}}
+	}
+	if f.Notes != "" {
+		people.SetNotes(person, f.Notes)
+	}
+	return person
+}
+
 func (s *Server) handlePeopleUpdateContact(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	resourceName, err := request.RequireString("resource_name")
 	if err != nil {
@@ -1123,6 +4154,32 @@ func (s *Server) handlePeopleUpdateContact(ctx context.Context, request mcp.Call
 		updateFields = append(updateFields, "phoneNumbers")
 	}
 
+	applied, err := applyNotesAndRelations(request, person)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	updateFields = append(updateFields, applied...)
+
+	clearFields := request.GetStringSlice("clear_fields", nil)
+	masked := make(map[string]bool, len(updateFields))
+	for _, field := range updateFields {
+		masked[field] = true
+	}
+	for _, field := range clearFields {
+		if masked[field] {
+			return mcp.NewToolResultError(fmt.Sprintf("%s cannot be both set and cleared", field)), nil
+		}
+	}
+	if err := people.ClearFields(person, clearFields); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	for _, field := range clearFields {
+		if !masked[field] {
+			masked[field] = true
+			updateFields = append(updateFields, field)
+		}
+	}
+
 	if len(updateFields) == 0 {
 		return mcp.NewToolResultError("no fields to update"), nil
 	}
@@ -1138,18 +4195,462 @@ func (s *Server) handlePeopleUpdateContact(ctx context.Context, request mcp.Call
 	return mcp.NewToolResultJSON(updated)
 }
 
+// applyNotesAndRelations sets the notes and relations arguments on person and
+// returns the update mask fields it touched. Relations are applied whenever the
+// argument is present, so an empty array clears them.
+func applyNotesAndRelations(request mcp.CallToolRequest, person *googlepeople.Person) ([]string, error) {
+	var fields []string
+	if notes := request.GetString("notes", ""); notes != "" {
+		people.SetNotes(person, notes)
+		fields = append(fields, "biographies")
+	}
+
+	relations, err := getRelations(request)
+	if err != nil {
+		return nil, err
+	}
+	if relations != nil {
+		if err := people.SetRelations(person, relations); err != nil {
+			return nil, err
+		}
+		fields = append(fields, "relations")
+	}
+	return fields, nil
+}
+
+// relationsSchema describes the relations parameter of the contact tools
+var relationsSchema = map[string]interface{}{
+	"type": "array",
+	"items": map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"person": map[string]string{"type": "string", "description": "Name of the related person"},
+			"type":   map[string]string{"type": "string", "description": "Relationship, e.g. assistant, manager, spouse, domesticPartner, referredBy, or a custom label"},
+		},
+		"required": []string{"person"},
+	},
+	"description": "People related to the contact. On update, replaces all existing relations; pass [] to clear them",
+}
+
+// getRelations reads the relations argument. It returns nil when the argument
+// is absent and an empty slice when it is an empty array.
+func getRelations(request mcp.CallToolRequest) ([]people.Relation, error) {
+	raw, ok := request.GetArguments()["relations"]
+	if !ok || raw == nil {
+		return nil, nil
+	}
+
+	arr, ok := raw.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("relations must be an array")
+	}
+
+	relations := make([]people.Relation, 0, len(arr))
+	for i, item := range arr {
+		obj, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("relations[%d] must be an object", i)
+		}
+		rel := people.Relation{}
+		rel.Person, _ = obj["person"].(string)
+		rel.Type, _ = obj["type"].(string)
+		relations = append(relations, rel)
+	}
+
+	return relations, nil
+}
+
+// ContactSummary is a compact view of a contact for confirmation prompts
+type ContactSummary struct {
+	ResourceName string   `json:"resourceName"`
+	Name         string   `json:"name,omitempty"`
+	Emails       []string `json:"emails,omitempty"`
+	Phones       []string `json:"phones,omitempty"`
+}
+
+// DeleteContactPreviewResponse is returned by people_delete_contact when confirm is not set
+type DeleteContactPreviewResponse struct {
+	Deleted bool           `json:"deleted"`
+	Contact ContactSummary `json:"contact"`
+	Message string         `json:"message"`
+}
+
+// summarizeContact extracts the identifying fields of a person
+func summarizeContact(person *googlepeople.Person) ContactSummary {
+	summary := ContactSummary{ResourceName: person.ResourceName}
+	if len(person.Names) > 0 {
+		summary.Name = person.Names[0].DisplayName
+		if summary.Name == "" {
+			summary.Name = strings.TrimSpace(person.Names[0].GivenName + " " + person.Names[0].FamilyName)
+		}
+	}
+	for _, email := range person.EmailAddresses {
+		summary.Emails = append(summary.Emails, email.Value)
+	}
+	for _, phone := range person.PhoneNumbers {
+		summary.Phones = append(summary.Phones, phone.Value)
+	}
+	return summary
+}
+
 func (s *Server) handlePeopleDeleteContact(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	resourceName, err := request.RequireString("resource_name")
 	if err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
 	}
 
+	if err := people.ValidateContactResourceName(resourceName); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	// Deletion is permanent, so require explicit confirmation
+	if !request.GetBool("confirm", false) {
+		person, err := s.people.GetPerson(ctx, resourceName)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		return mcp.NewToolResultJSON(DeleteContactPreviewResponse{
+			Deleted: false,
+			Contact: summarizeContact(person),
+			Message: "contact NOT deleted - deletion is permanent; call people_delete_contact again with confirm=true to delete this contact",
+		})
+	}
+
 	err = s.people.DeleteContact(ctx, resourceName)
 	if err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	return mcp.NewToolResultText(fmt.Sprintf("Contact %s deleted successfully", resourceName)), nil
+	return deletedResult("contact", resourceName)
+}
+
+// ExportVCardResponse is the response for people_export_vcard
+type ExportVCardResponse struct {
+	Version string `json:"version"`
+	Count   int    `json:"count"`
+	VCard   string `json:"vcard"`
+}
+
+func (s *Server) handlePeopleExportVCard(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	resourceNames := request.GetStringSlice("resource_names", nil)
+	if len(resourceNames) == 0 {
+		return mcp.NewToolResultError("resource_names must contain at least one contact"), nil
+	}
+
+	version := request.GetString("version", people.VCard3)
+	if err := people.ValidateVCardVersion(version); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	persons, err := s.people.GetPeople(ctx, resourceNames)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	vcard, err := people.EncodeVCards(persons, version)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return mcp.NewToolResultJSON(ExportVCardResponse{
+		Version: version,
+		Count:   len(persons),
+		VCard:   vcard,
+	})
+}
+
+// ExportCSVResponse is the response for people_export_csv
+type ExportCSVResponse struct {
+	Count int    `json:"count"`
+	CSV   string `json:"csv"`
+}
+
+func (s *Server) handlePeopleExportCSV(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	resourceNames := request.GetStringSlice("resource_names", nil)
+
+	var persons []*googlepeople.Person
+	var err error
+	if len(resourceNames) > 0 {
+		persons, err = s.people.GetPeople(ctx, resourceNames)
+	} else {
+		persons, err = s.people.ListAllContacts(ctx)
+	}
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	text, err := people.EncodeCSV(persons)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return mcp.NewToolResultJSON(ExportCSVResponse{
+		Count: len(persons),
+		CSV:   text,
+	})
+}
+
+// Bounds for people_list_directory results per call
+const (
+	defaultDirectoryResults = 500
+	maxDirectoryResults     = 5000
+)
+
+// ListDirectoryResponse is the response for people_list_directory
+type ListDirectoryResponse struct {
+	People        []*googlepeople.Person `json:"people"`
+	Count         int                    `json:"count"`
+	NextPageToken string                 `json:"next_page_token,omitempty"`
+	NextSyncToken string                 `json:"next_sync_token,omitempty"`
+}
+
+func (s *Server) handlePeopleListDirectory(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	readMask, err := readMaskParam(request)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	maxResults := request.GetInt("max_results", defaultDirectoryResults)
+	if maxResults < 1 || maxResults > maxDirectoryResults {
+		return mcp.NewToolResultError(fmt.Sprintf("max_results must be between 1 and %d", maxDirectoryResults)), nil
+	}
+
+	page, err := s.people.ListDirectory(ctx, people.DirectoryOptions{
+		ReadMask:   readMask,
+		MaxResults: maxResults,
+		PageToken:  request.GetString("page_token", ""),
+		SyncToken:  request.GetString("sync_token", ""),
+	})
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return mcp.NewToolResultJSON(ListDirectoryResponse{
+		People:        page.People,
+		Count:         len(page.People),
+		NextPageToken: page.NextPageToken,
+		NextSyncToken: page.NextSyncToken,
+	})
+}
+
+// Import statuses reported per card by people_import_vcard
+const (
+	importCreated = "created"
+	importSkipped = "skipped"
+	importFailed  = "failed"
+)
+
+// ImportVCardResult is the outcome for one card
+type ImportVCardResult struct {
+	Index        int    `json:"index"` // 1-based position of the card in the input
+	Name         string `json:"name,omitempty"`
+	Status       string `json:"status"` // created, skipped, or failed
+	ResourceName string `json:"resource_name,omitempty"`
+	Reason       string `json:"reason,omitempty"`
+}
+
+// ImportVCardResponse is the response for people_import_vcard
+type ImportVCardResponse struct {
+	Created int                 `json:"created"`
+	Skipped int                 `json:"skipped"`
+	Failed  int                 `json:"failed"`
+	Results []ImportVCardResult `json:"results"`
+}
+
+func (s *Server) handlePeopleImportVCard(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	text, err := request.RequireString("vcard")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	skipDuplicates := request.GetBool("skip_duplicates", false)
+
+	cards := people.ParseVCards(text)
+	if len(cards) == 0 {
+		return mcp.NewToolResultError("no BEGIN:VCARD ... END:VCARD blocks found"), nil
+	}
+
+	resp := ImportVCardResponse{Results: make([]ImportVCardResult, 0, len(cards))}
+	seen := make(map[string]bool) // lower-cased emails imported so far
+	for _, card := range cards {
+		result := s.importVCard(ctx, card, skipDuplicates, seen)
+		switch result.Status {
+		case importCreated:
+			resp.Created++
+		case importSkipped:
+			resp.Skipped++
+		default:
+			resp.Failed++
+		}
+		resp.Results = append(resp.Results, result)
+	}
+
+	return mcp.NewToolResultJSON(resp)
+}
+
+// importVCard creates the contact for one parsed card. A failure is recorded
+// in the result so the remaining cards are still imported.
+func (s *Server) importVCard(ctx context.Context, card people.ParsedVCard, skipDuplicates bool, seen map[string]bool) ImportVCardResult {
+	result := ImportVCardResult{Index: card.Index}
+	if card.Err != nil {
+		result.Status = importFailed
+		result.Reason = card.Err.Error()
+		return result
+	}
+	result.Name = importedName(card.Person)
+
+	if skipDuplicates && s.checkDuplicate(ctx, card.Person, "card", seen, &result) {
+		return result
+	}
+
+	created, err := s.people.CreateContact(ctx, card.Person)
+	if err != nil {
+		result.Status = importFailed
+		result.Reason = err.Error()
+		return result
+	}
+	markSeen(card.Person, seen)
+
+	result.Status = importCreated
+	result.ResourceName = created.ResourceName
+	return result
+}
+
+// checkDuplicate looks for an earlier entry of the same import (an entry of
+// kind "card" or "contact") or an existing contact sharing one of person's
+// emails. It reports true and fills result when person should not be created.
+func (s *Server) checkDuplicate(ctx context.Context, person *googlepeople.Person, kind string, seen map[string]bool, result *ImportVCardResult) bool {
+	for _, email := range person.EmailAddresses {
+		if seen[strings.ToLower(email.Value)] {
+			result.Status = importSkipped
+			result.Reason = fmt.Sprintf("duplicate of an earlier %s (%s)", kind, email.Value)
+			return true
+		}
+		existing, err := s.people.FindContactByEmail(ctx, email.Value)
+		if err != nil {
+			result.Status = importFailed
+			result.Reason = err.Error()
+			return true
+		}
+		if existing != nil {
+			result.Status = importSkipped
+			result.ResourceName = existing.ResourceName
+			result.Reason = fmt.Sprintf("duplicate of existing contact (%s)", email.Value)
+			return true
+		}
+	}
+	return false
+}
+
+// markSeen records person's emails so later entries of the same import count as duplicates
+func markSeen(person *googlepeople.Person, seen map[string]bool) {
+	for _, email := range person.EmailAddresses {
+		seen[strings.ToLower(email.Value)] = true
+	}
+}
+
+// maxBatchCreateContacts bounds people_batch_create; the API takes 200 per
+// request, so this is at most three requests plus any duplicate lookups
+const maxBatchCreateContacts = 500
+
+// BatchCreateResponse is the response for people_batch_create. Results use
+// the same shape as people_import_vcard, with Index counting input contacts.
+type BatchCreateResponse struct {
+	Created int                 `json:"created"`
+	Skipped int                 `json:"skipped"`
+	Failed  int                 `json:"failed"`
+	Results []ImportVCardResult `json:"results"`
+}
+
+func (s *Server) handlePeopleBatchCreate(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	entries, err := getContactFields(request)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	skipExisting := request.GetBool("skip_existing", false)
+
+	results := make([]ImportVCardResult, len(entries))
+	var persons []*googlepeople.Person
+	var positions []int // Index into results for each entry of persons
+	seen := make(map[string]bool)
+	for i, entry := range entries {
+		person := entry.person()
+		results[i] = ImportVCardResult{Index: i + 1, Name: importedName(person)}
+		if skipExisting {
+			if s.checkDuplicate(ctx, person, "contact", seen, &results[i]) {
+				continue
+			}
+			markSeen(person, seen)
+		}
+		persons = append(persons, person)
+		positions = append(positions, i)
+	}
+
+	for j, created := range s.people.BatchCreateContacts(ctx, persons) {
+		result := &results[positions[j]]
+		if created.Err != nil {
+			result.Status = importFailed
+			result.Reason = created.Err.Error()
+			continue
+		}
+		result.Status = importCreated
+		result.ResourceName = created.Person.ResourceName
+	}
+
+	resp := BatchCreateResponse{Results: results}
+	for _, result := range results {
+		switch result.Status {
+		case importCreated:
+			resp.Created++
+		case importSkipped:
+			resp.Skipped++
+		default:
+			resp.Failed++
+		}
+	}
+	return mcp.NewToolResultJSON(resp)
+}
+
+// getContactFields reads the contacts argument of people_batch_create. Every
+// entry needs a given_name.
+func getContactFields(request mcp.CallToolRequest) ([]contactFields, error) {
+	args, _ := request.Params.Arguments.(map[string]interface{})
+	raw, ok := args["contacts"].([]interface{})
+	if !ok || len(raw) == 0 {
+		return nil, fmt.Errorf("contacts must be a non-empty array")
+	}
+	if len(raw) > maxBatchCreateContacts {
+		return nil, fmt.Errorf("at most %d contacts can be created at once (got %d)", maxBatchCreateContacts, len(raw))
+	}
+
+	entries := make([]contactFields, len(raw))
+	for i, item := range raw {
+		data, err := json.Marshal(item)
+		if err != nil {
+			return nil, fmt.Errorf("contacts[%d]: %w", i, err)
+		}
+		if err := json.Unmarshal(data, &entries[i]); err != nil {
+			return nil, fmt.Errorf("contacts[%d] must be an object of string fields", i)
+		}
+		if strings.TrimSpace(entries[i].GivenName) == "" {
+			return nil, fmt.Errorf("contacts[%d] is missing given_name", i)
+		}
+	}
+	return entries, nil
+}
+
+// importedName labels an import result with the card's name, falling back to its first email
+func importedName(person *googlepeople.Person) string {
+	if name := summarizeContact(person).Name; name != "" {
+		return name
+	}
+	if len(person.Names) > 0 && person.Names[0].UnstructuredName != "" {
+		return person.Names[0].UnstructuredName
+	}
+	if len(person.EmailAddresses) > 0 {
+		return person.EmailAddresses[0].Value
+	}
+	return ""
 }
 
 // Auth tool handlers
@@ -1171,11 +4672,22 @@ func extractAuthCode(codeOrURL string) string {
 
 // AuthStatusResponse is the response for auth_status tool
 type AuthStatusResponse struct {
-	Valid   bool   `json:"valid"`
-	Message string `json:"message"`
+	Valid    bool                     `json:"valid"`
+	Message  string                   `json:"message"`
+	Services map[string]ServiceStatus `json:"services,omitempty"` // Per-API results, only in detailed mode
+}
+
+// ServiceStatus is the result of probing a single Google API
+type ServiceStatus struct {
+	Valid bool   `json:"valid"`
+	Error string `json:"error,omitempty"`
 }
 
 func (s *Server) handleAuthStatus(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if request.GetBool("detailed", false) {
+		return mcp.NewToolResultJSON(s.detailedAuthStatus(ctx))
+	}
+
 	// In ISH mode, always return valid
 	if os.Getenv("ISH_MODE") == "true" {
 		return mcp.NewToolResultJSON(AuthStatusResponse{
@@ -1199,14 +4711,110 @@ func (s *Server) handleAuthStatus(ctx context.Context, request mcp.CallToolReque
 	})
 }
 
+// detailedAuthStatus runs a minimal read against each API so a project that
+// enabled only some of them gets an accurate per-service answer
+func (s *Server) detailedAuthStatus(ctx context.Context) AuthStatusResponse {
+	probes := s.serviceProbes()
+	resp := AuthStatusResponse{Valid: true, Services: make(map[string]ServiceStatus, len(probes))}
+	var failed []string
+	for _, p := range probes {
+		if err := p.probe(ctx); err != nil {
+			resp.Services[p.service] = ServiceStatus{Valid: false, Error: err.Error()}
+			failed = append(failed, p.service)
+			continue
+		}
+		resp.Services[p.service] = ServiceStatus{Valid: true}
+	}
+
+	if len(failed) > 0 {
+		sort.Strings(failed)
+		resp.Valid = false
+		resp.Message = fmt.Sprintf("auth check failed for: %s", strings.Join(failed, ", "))
+	} else {
+		resp.Message = "authentication is valid for all services"
+	}
+	return resp
+}
+
+// SelfTestResponse is the response for gsuite_selftest
+type SelfTestResponse struct {
+	OK        bool             `json:"ok"`
+	Simulated bool             `json:"simulated,omitempty"` // ISH mode: no API calls were made
+	Services  []SelfTestResult `json:"services"`
+}
+
+// SelfTestResult is the outcome of one service's read
+type SelfTestResult struct {
+	Service   string `json:"service"`
+	OK        bool   `json:"ok"`
+	LatencyMS int64  `json:"latency_ms"`
+	Error     string `json:"error,omitempty"`
+}
+
+// serviceProbe is one harmless read that shows whether a service accepts the credentials
+type serviceProbe struct {
+	service string
+	probe   func(ctx context.Context) error
+}
+
+// serviceProbes lists the reads auth_status (detailed) and gsuite_selftest
+// make against each service, in report order
+func (s *Server) serviceProbes() []serviceProbe {
+	return []serviceProbe{
+		{service: "gmail", probe: func(ctx context.Context) error {
+			_, err := s.gmail.ListMessages(ctx, "", 1)
+			return err
+		}},
+		{service: "calendar", probe: func(ctx context.Context) error {
+			now := time.Now()
+			_, err := s.calendar.ListEvents(ctx, 1, now, now.Add(24*time.Hour), calendar.DefaultListEventsOptions())
+			return err
+		}},
+		{service: "people", probe: func(ctx context.Context) error {
+			_, err := s.people.ListContacts(ctx, 1, "")
+			return err
+		}},
+	}
+}
+
+func (s *Server) handleSelfTest(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	// In ISH mode, report success without touching the fake API
+	if os.Getenv("ISH_MODE") == "true" {
+		resp := SelfTestResponse{OK: true, Simulated: true}
+		for _, p := range s.serviceProbes() {
+			resp.Services = append(resp.Services, SelfTestResult{Service: p.service, OK: true})
+		}
+		return mcp.NewToolResultJSON(resp)
+	}
+
+	return mcp.NewToolResultJSON(s.runSelfTest(ctx))
+}
+
+// runSelfTest makes one harmless read per service, timing each call
+func (s *Server) runSelfTest(ctx context.Context) SelfTestResponse {
+	resp := SelfTestResponse{OK: true}
+	for _, p := range s.serviceProbes() {
+		start := time.Now()
+		err := p.probe(ctx)
+		result := SelfTestResult{Service: p.service, OK: err == nil, LatencyMS: time.Since(start).Milliseconds()}
+		if err != nil {
+			result.Error = err.Error()
+			resp.OK = false
+		}
+		resp.Services = append(resp.Services, result)
+	}
+	return resp
+}
+
 // AuthInfoResponse is the response for auth_info tool
 type AuthInfoResponse struct {
-	Valid       bool   `json:"valid"`
-	AccessToken string `json:"access_token,omitempty"`
-	Expiry      string `json:"expiry,omitempty"`
-	ExpiresIn   string `json:"expires_in,omitempty"`
-	HasRefresh  bool   `json:"has_refresh"`
-	Message     string `json:"message,omitempty"`
+	Valid       bool     `json:"valid"`
+	AccessToken string   `json:"access_token,omitempty"`
+	Expiry      string   `json:"expiry,omitempty"`
+	ExpiresIn   string   `json:"expires_in,omitempty"`
+	HasRefresh  bool     `json:"has_refresh"`
+	Scopes      []string `json:"scopes,omitempty"` // Granted OAuth scopes, if recorded with the token
+	Message     string   `json:"message,omitempty"`
 }
 
 func (s *Server) handleAuthInfo(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -1238,6 +4846,7 @@ func (s *Server) handleAuthInfo(ctx context.Context, request mcp.CallToolRequest
 		Valid:       info.Valid,
 		AccessToken: info.AccessToken,
 		HasRefresh:  info.HasRefresh,
+		Scopes:      info.Scopes,
 	}
 
 	if !info.Expiry.IsZero() {
@@ -1383,7 +4992,45 @@ func (s *Server) ListTools() []mcp.Tool {
 	return tools
 }
 
-// Serve starts the MCP server with stdio transport
+// scheduledSendInterval is how often a running server checks for due scheduled sends
+const scheduledSendInterval = time.Minute
+
+// Serve starts the MCP server with stdio transport, or over HTTP when http_addr
+// is set. While it runs, drafts queued by gmail_schedule_send are sent when
+// due, unless in draft-only mode.
 func (s *Server) Serve(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	if !s.draftOnly {
+		go s.runScheduledSends(ctx, scheduledSendInterval)
+	}
+
+	if s.httpAddr != "" {
+		return s.serveHTTP(ctx, s.httpAddr)
+	}
 	return server.ServeStdio(s.mcp)
 }
+
+// runScheduledSends sends due drafts now and then every interval until ctx is done
+func (s *Server) runScheduledSends(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		results, err := s.scheduled.SendDue(ctx, s.gmail, time.Now())
+		if err != nil {
+			s.logs.printf(levelError, "scheduled send: %v", err)
+		}
+		for _, result := range results {
+			if result.Error != "" {
+				s.logs.printf(levelError, "scheduled send: draft %s: %s", result.DraftID, result.Error)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
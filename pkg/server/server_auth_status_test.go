@@ -0,0 +1,76 @@
+// ABOUTME: Tests for the auth_status tool's detailed mode
+// ABOUTME: Validates per-service probes and that one failing API doesn't mark the others invalid
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func authStatus(t *testing.T, srv *Server, args map[string]interface{}) AuthStatusResponse {
+	t.Helper()
+
+	result, err := srv.handleAuthStatus(context.Background(), createMockRequest("auth_status", args))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var resp AuthStatusResponse
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &resp))
+	return resp
+}
+
+func TestHandleAuthStatus_Detailed(t *testing.T) {
+	t.Run("all services reachable", func(t *testing.T) {
+		srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{}`))
+		})
+
+		resp := authStatus(t, srv, map[string]interface{}{"detailed": true})
+		assert.True(t, resp.Valid)
+		require.Len(t, resp.Services, 3)
+		for _, name := range []string{"gmail", "calendar", "people"} {
+			assert.True(t, resp.Services[name].Valid, name)
+		}
+	})
+
+	t.Run("one disabled API only fails its own entry", func(t *testing.T) {
+		srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+			if strings.HasSuffix(r.URL.Path, "/connections") {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusForbidden)
+				_, _ = w.Write([]byte(`{"error": {"code": 403, "message": "People API has not been used in project"}}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{}`))
+		})
+
+		resp := authStatus(t, srv, map[string]interface{}{"detailed": true})
+		assert.False(t, resp.Valid)
+		assert.Contains(t, resp.Message, "people")
+		assert.True(t, resp.Services["gmail"].Valid)
+		assert.True(t, resp.Services["calendar"].Valid)
+		assert.False(t, resp.Services["people"].Valid)
+		assert.Contains(t, resp.Services["people"].Error, "People API has not been used")
+	})
+}
+
+func TestHandleAuthStatus_DefaultSkipsPerServiceProbes(t *testing.T) {
+	var calls int
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		_, _ = w.Write([]byte(`{}`))
+	})
+
+	resp := authStatus(t, srv, nil)
+	assert.True(t, resp.Valid)
+	assert.Nil(t, resp.Services)
+	assert.Zero(t, calls, "default mode keeps the simulated single check in ISH mode")
+}
@@ -0,0 +1,153 @@
+// ABOUTME: Tests for the calendar_email_agenda tool
+// ABOUTME: Verifies the agenda body lists each event, drafts unless send is set, and the internal_domains guard
+
+package server
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"mime/quotedprintable"
+	"net/http"
+	"net/mail"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// agendaAPI serves two events, the profile, and draft/send calls, recording what was posted where
+func agendaAPI(t *testing.T, posts map[string]string, eventsTimeMin *string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/events"):
+			*eventsTimeMin = r.URL.Query().Get("timeMin")
+			_, _ = w.Write([]byte(`{"items": [
+				{"id": "e1", "summary": "Standup", "start": {"dateTime": "2025-01-06T09:00:00Z"}, "end": {"dateTime": "2025-01-06T09:15:00Z"}},
+				{"id": "e2", "summary": "Budget review", "location": "Room 4", "start": {"dateTime": "2025-01-06T14:00:00Z"}, "end": {"dateTime": "2025-01-06T15:00:00Z"}}
+			]}`))
+		case strings.HasSuffix(r.URL.Path, "/profile"):
+			_, _ = w.Write([]byte(`{"emailAddress": "me@example.com"}`))
+		case r.Method == http.MethodPost:
+			var req struct {
+				Raw     string `json:"raw"`
+				Message struct {
+					Raw string `json:"raw"`
+				} `json:"message"`
+			}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			raw := req.Raw + req.Message.Raw
+			posts[r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]] = raw
+			_, _ = w.Write([]byte(`{"id": "created-1"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}
+}
+
+// decodeAgendaBody returns the HTML body of a base64url-encoded message
+func decodeAgendaBody(t *testing.T, raw string) (*mail.Message, string) {
+	data, err := base64.URLEncoding.DecodeString(raw)
+	require.NoError(t, err)
+	msg, err := mail.ReadMessage(strings.NewReader(string(data)))
+	require.NoError(t, err)
+	body, err := io.ReadAll(quotedprintable.NewReader(msg.Body))
+	require.NoError(t, err)
+	return msg, string(body)
+}
+
+func TestHandleCalendarEmailAgenda_Draft(t *testing.T) {
+	posts := map[string]string{}
+	var timeMin string
+	srv := newTestServer(t, agendaAPI(t, posts, &timeMin))
+	srv.loc = time.UTC
+
+	result, err := srv.handleCalendarEmailAgenda(context.Background(), createMockRequest("calendar_email_agenda", map[string]interface{}{
+		"date": "2025-01-06",
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError, result.Content)
+
+	var resp EmailAgendaResponse
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &resp))
+	assert.Equal(t, EmailAgendaResponse{
+		Date:       "2025-01-06",
+		EventCount: 2,
+		To:         "me@example.com",
+		Subject:    "Agenda for Monday, January 6, 2025",
+		DraftID:    "created-1",
+	}, resp)
+	assert.Equal(t, "2025-01-06T00:00:00Z", timeMin)
+
+	require.Contains(t, posts, "drafts", "draft mode creates a draft")
+	assert.NotContains(t, posts, "send")
+
+	msg, body := decodeAgendaBody(t, posts["drafts"])
+	assert.Equal(t, "me@example.com", msg.Header.Get("To"))
+	assert.Contains(t, msg.Header.Get("Content-Type"), "text/html")
+	assert.Contains(t, body, "<b>9:00 AM – 9:15 AM</b></td><td>Standup</td>")
+	assert.Contains(t, body, "<b>2:00 PM – 3:00 PM</b></td><td>Budget review<br><small>Room 4</small></td>")
+}
+
+func TestHandleCalendarEmailAgenda_Send(t *testing.T) {
+	posts := map[string]string{}
+	var timeMin string
+	srv := newTestServer(t, agendaAPI(t, posts, &timeMin))
+	srv.loc = time.UTC
+
+	result, err := srv.handleCalendarEmailAgenda(context.Background(), createMockRequest("calendar_email_agenda", map[string]interface{}{
+		"date": "2025-01-06",
+		"to":   "assistant@example.com",
+		"send": true,
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError, result.Content)
+
+	var resp EmailAgendaResponse
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &resp))
+	assert.True(t, resp.Sent)
+	assert.Equal(t, "created-1", resp.MessageID)
+	assert.Empty(t, resp.DraftID)
+
+	require.Contains(t, posts, "send")
+	assert.NotContains(t, posts, "drafts")
+	msg, _ := decodeAgendaBody(t, posts["send"])
+	assert.Equal(t, "assistant@example.com", msg.Header.Get("To"))
+
+	result, err = srv.handleCalendarEmailAgenda(context.Background(), createMockRequest("calendar_email_agenda", map[string]interface{}{"date": "someday"}))
+	require.NoError(t, err)
+	assert.True(t, result.IsError)
+}
+
+func TestHandleCalendarEmailAgenda_RefusesExternal(t *testing.T) {
+	posts := map[string]string{}
+	var timeMin string
+	srv := newTestServer(t, agendaAPI(t, posts, &timeMin))
+	srv.loc = time.UTC
+	srv.internal = map[string]bool{"example.com": true}
+
+	args := map[string]interface{}{"date": "2025-01-06", "to": "partner@other.com", "send": true}
+	result, err := srv.handleCalendarEmailAgenda(context.Background(), createMockRequest("calendar_email_agenda", args))
+	require.NoError(t, err)
+	require.True(t, result.IsError)
+	assert.Contains(t, result.Content[0].(mcp.TextContent).Text, "partner@other.com")
+	assert.Empty(t, posts, "nothing is sent")
+
+	// A draft is reviewed before it leaves, so it isn't guarded
+	args["send"] = false
+	result, err = srv.handleCalendarEmailAgenda(context.Background(), createMockRequest("calendar_email_agenda", args))
+	require.NoError(t, err)
+	require.False(t, result.IsError, result.Content)
+	assert.Contains(t, posts, "drafts")
+
+	args["send"] = true
+	args["allow_external"] = true
+	result, err = srv.handleCalendarEmailAgenda(context.Background(), createMockRequest("calendar_email_agenda", args))
+	require.NoError(t, err)
+	require.False(t, result.IsError, result.Content)
+	assert.Contains(t, posts, "send")
+}
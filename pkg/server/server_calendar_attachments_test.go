@@ -0,0 +1,56 @@
+// ABOUTME: Tests for the calendar_list_event_attachments tool
+// ABOUTME: Verifies attachments are listed and that events without any return an empty array
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/harper/gsuite-mcp/pkg/calendar"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandleCalendarListEventAttachments(t *testing.T) {
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/events/evt-plain") {
+			_, _ = w.Write([]byte(`{"id": "evt-plain", "summary": "Coffee"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"id": "evt-1", "summary": "Planning", "attachments": [
+			{"title": "Agenda", "fileUrl": "https://docs.google.com/document/d/doc1/edit", "mimeType": "application/vnd.google-apps.document", "fileId": "doc1"},
+			{"title": "Budget.xlsx", "fileUrl": "https://drive.google.com/file/d/file2/view", "mimeType": "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", "fileId": "file2"}
+		]}`))
+	})
+
+	list := func(eventID string) (string, EventAttachmentsResponse) {
+		result, err := srv.handleCalendarListEventAttachments(context.Background(), createMockRequest("calendar_list_event_attachments", map[string]interface{}{
+			"event_id": eventID,
+		}))
+		require.NoError(t, err)
+		require.False(t, result.IsError, result.Content)
+		text := result.Content[0].(mcp.TextContent).Text
+		var resp EventAttachmentsResponse
+		require.NoError(t, json.Unmarshal([]byte(text), &resp))
+		return text, resp
+	}
+
+	_, resp := list("evt-1")
+	assert.Equal(t, 2, resp.Count)
+	assert.Equal(t, calendar.EventAttachment{
+		Title:    "Agenda",
+		FileURL:  "https://docs.google.com/document/d/doc1/edit",
+		MimeType: "application/vnd.google-apps.document",
+		FileID:   "doc1",
+	}, resp.Attachments[0])
+	assert.Equal(t, "Budget.xlsx", resp.Attachments[1].Title)
+
+	text, resp := list("evt-plain")
+	assert.Zero(t, resp.Count)
+	assert.Contains(t, text, `"attachments":[]`, "no attachments is an empty array, not null")
+}
@@ -17,7 +17,7 @@ import (
 func TestHandleCalendarUpdateEvent_FullReplacementAttendees(t *testing.T) {
 	t.Setenv("ISH_MODE", "true")
 
-	srv, err := NewServer(context.Background())
+	srv, err := NewServer(context.Background(), nil)
 	require.NoError(t, err)
 
 	eventID := "test-event-attendees-replace"
@@ -38,7 +38,7 @@ func TestHandleCalendarUpdateEvent_FullReplacementAttendees(t *testing.T) {
 func TestHandleCalendarUpdateEvent_FullReplacementOptionalAttendees(t *testing.T) {
 	t.Setenv("ISH_MODE", "true")
 
-	srv, err := NewServer(context.Background())
+	srv, err := NewServer(context.Background(), nil)
 	require.NoError(t, err)
 
 	eventID := "test-event-optional-replace"
@@ -59,7 +59,7 @@ func TestHandleCalendarUpdateEvent_FullReplacementOptionalAttendees(t *testing.T
 func TestHandleCalendarUpdateEvent_FullReplacementBoth(t *testing.T) {
 	t.Setenv("ISH_MODE", "true")
 
-	srv, err := NewServer(context.Background())
+	srv, err := NewServer(context.Background(), nil)
 	require.NoError(t, err)
 
 	eventID := "test-event-both-replace"
@@ -81,7 +81,7 @@ func TestHandleCalendarUpdateEvent_FullReplacementBoth(t *testing.T) {
 func TestHandleCalendarUpdateEvent_IncrementalAddAttendees(t *testing.T) {
 	t.Setenv("ISH_MODE", "true")
 
-	srv, err := NewServer(context.Background())
+	srv, err := NewServer(context.Background(), nil)
 	require.NoError(t, err)
 
 	eventID := "test-event-add-attendees"
@@ -102,7 +102,7 @@ func TestHandleCalendarUpdateEvent_IncrementalAddAttendees(t *testing.T) {
 func TestHandleCalendarUpdateEvent_IncrementalAddOptionalAttendees(t *testing.T) {
 	t.Setenv("ISH_MODE", "true")
 
-	srv, err := NewServer(context.Background())
+	srv, err := NewServer(context.Background(), nil)
 	require.NoError(t, err)
 
 	eventID := "test-event-add-optional"
@@ -123,7 +123,7 @@ func TestHandleCalendarUpdateEvent_IncrementalAddOptionalAttendees(t *testing.T)
 func TestHandleCalendarUpdateEvent_IncrementalRemoveAttendees(t *testing.T) {
 	t.Setenv("ISH_MODE", "true")
 
-	srv, err := NewServer(context.Background())
+	srv, err := NewServer(context.Background(), nil)
 	require.NoError(t, err)
 
 	eventID := "test-event-remove-attendees"
@@ -144,7 +144,7 @@ func TestHandleCalendarUpdateEvent_IncrementalRemoveAttendees(t *testing.T) {
 func TestHandleCalendarUpdateEvent_IncrementalMultiple(t *testing.T) {
 	t.Setenv("ISH_MODE", "true")
 
-	srv, err := NewServer(context.Background())
+	srv, err := NewServer(context.Background(), nil)
 	require.NoError(t, err)
 
 	eventID := "test-event-incremental-multi"
@@ -167,7 +167,7 @@ func TestHandleCalendarUpdateEvent_IncrementalMultiple(t *testing.T) {
 func TestHandleCalendarUpdateEvent_MixingModesError(t *testing.T) {
 	t.Setenv("ISH_MODE", "true")
 
-	srv, err := NewServer(context.Background())
+	srv, err := NewServer(context.Background(), nil)
 	require.NoError(t, err)
 
 	eventID := "test-event-mixing-modes"
@@ -198,7 +198,7 @@ func TestHandleCalendarUpdateEvent_MixingModesError(t *testing.T) {
 func TestHandleCalendarUpdateEvent_NoAttendeeParamsUnchanged(t *testing.T) {
 	t.Setenv("ISH_MODE", "true")
 
-	srv, err := NewServer(context.Background())
+	srv, err := NewServer(context.Background(), nil)
 	require.NoError(t, err)
 
 	eventID := "test-event-no-attendee-changes"
@@ -220,7 +220,7 @@ func TestHandleCalendarUpdateEvent_NoAttendeeParamsUnchanged(t *testing.T) {
 func TestHandleCalendarUpdateEvent_SendNotifications(t *testing.T) {
 	t.Setenv("ISH_MODE", "true")
 
-	srv, err := NewServer(context.Background())
+	srv, err := NewServer(context.Background(), nil)
 	require.NoError(t, err)
 
 	eventID := "test-event-send-notifications"
@@ -242,7 +242,7 @@ func TestHandleCalendarUpdateEvent_SendNotifications(t *testing.T) {
 func TestHandleCalendarUpdateEvent_SendNotificationsDefault(t *testing.T) {
 	t.Setenv("ISH_MODE", "true")
 
-	srv, err := NewServer(context.Background())
+	srv, err := NewServer(context.Background(), nil)
 	require.NoError(t, err)
 
 	eventID := "test-event-default-notifications"
@@ -264,7 +264,7 @@ func TestHandleCalendarUpdateEvent_SendNotificationsDefault(t *testing.T) {
 func TestHandleCalendarUpdateEvent_EmptyArrays(t *testing.T) {
 	t.Setenv("ISH_MODE", "true")
 
-	srv, err := NewServer(context.Background())
+	srv, err := NewServer(context.Background(), nil)
 	require.NoError(t, err)
 
 	eventID := "test-event-empty-arrays"
@@ -286,7 +286,7 @@ func TestHandleCalendarUpdateEvent_EmptyArrays(t *testing.T) {
 func TestHandleCalendarUpdateEvent_RemoveNonExistent(t *testing.T) {
 	t.Setenv("ISH_MODE", "true")
 
-	srv, err := NewServer(context.Background())
+	srv, err := NewServer(context.Background(), nil)
 	require.NoError(t, err)
 
 	eventID := "test-event-remove-nonexistent"
@@ -308,7 +308,7 @@ func TestHandleCalendarUpdateEvent_RemoveNonExistent(t *testing.T) {
 func TestHandleCalendarUpdateEvent_AttendeesWithOtherFields(t *testing.T) {
 	t.Setenv("ISH_MODE", "true")
 
-	srv, err := NewServer(context.Background())
+	srv, err := NewServer(context.Background(), nil)
 	require.NoError(t, err)
 
 	eventID := "test-event-attendees-and-summary"
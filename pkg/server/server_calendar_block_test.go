@@ -0,0 +1,48 @@
+// ABOUTME: Tests for the calendar_block_time tool
+// ABOUTME: Verifies inclusive dates become an exclusive end and the out-of-office option
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	googlecalendar "google.golang.org/api/calendar/v3"
+)
+
+func TestHandleCalendarBlockTime(t *testing.T) {
+	var inserted googlecalendar.Event
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&inserted)
+		_ = json.NewEncoder(w).Encode(inserted)
+	})
+
+	result, err := srv.handleCalendarBlockTime(context.Background(), createMockRequest("calendar_block_time", map[string]interface{}{
+		"start_date":    "2025-03-03",
+		"end_date":      "2025-03-07",
+		"summary":       "Vacation",
+		"out_of_office": true,
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError, result.Content)
+
+	var event googlecalendar.Event
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &event))
+	assert.Equal(t, "outOfOffice", event.EventType)
+	assert.Contains(t, inserted.End.DateTime, "2025-03-08T00:00:00", "Friday's block ends at the start of Saturday")
+
+	for _, args := range []map[string]interface{}{
+		{"start_date": "2025-03-07", "end_date": "2025-03-03", "summary": "Vacation"},
+		{"start_date": "March 3", "end_date": "2025-03-07", "summary": "Vacation"},
+		{"start_date": "2025-03-03", "end_date": "2025-03-07", "summary": "Vacation", "decline_message": "Away"},
+	} {
+		result, err := srv.handleCalendarBlockTime(context.Background(), createMockRequest("calendar_block_time", args))
+		require.NoError(t, err)
+		assert.True(t, result.IsError, "args %v", args)
+	}
+}
@@ -0,0 +1,84 @@
+// ABOUTME: Tests for bulk calendar event deletion
+// ABOUTME: Validates the confirm gate preview and the deleted count
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandleCalendarDeleteEventsBulk(t *testing.T) {
+	var deletes int
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			deletes++
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"items": []map[string]interface{}{
+			{
+				"id":      "evt-1",
+				"summary": "Test event",
+				"start":   map[string]string{"dateTime": "2025-01-06T09:00:00Z"},
+				"end":     map[string]string{"dateTime": "2025-01-06T10:00:00Z"},
+			},
+		}})
+	})
+
+	args := map[string]interface{}{
+		"time_min": "2025-01-06T00:00:00Z",
+		"time_max": "2025-01-07T00:00:00Z",
+		"query":    "Test",
+	}
+
+	decode := func(result *mcp.CallToolResult) DeleteEventsBulkResponse {
+		t.Helper()
+		require.False(t, result.IsError)
+		var resp DeleteEventsBulkResponse
+		require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &resp))
+		return resp
+	}
+
+	t.Run("without confirm nothing is deleted", func(t *testing.T) {
+		result, err := srv.handleCalendarDeleteEventsBulk(context.Background(), createMockRequest("calendar_delete_events_bulk", args))
+		require.NoError(t, err)
+
+		resp := decode(result)
+		assert.False(t, resp.Deleted)
+		assert.Equal(t, 1, resp.Count)
+		require.Len(t, resp.Events, 1)
+		assert.Equal(t, "Test event", resp.Events[0].Summary)
+		assert.Zero(t, deletes)
+	})
+
+	t.Run("confirm deletes and reports the count", func(t *testing.T) {
+		confirmed := map[string]interface{}{"confirm": true}
+		for k, v := range args {
+			confirmed[k] = v
+		}
+
+		result, err := srv.handleCalendarDeleteEventsBulk(context.Background(), createMockRequest("calendar_delete_events_bulk", confirmed))
+		require.NoError(t, err)
+
+		resp := decode(result)
+		assert.True(t, resp.Deleted)
+		assert.Equal(t, 1, resp.Count)
+		assert.Equal(t, 1, deletes)
+	})
+
+	t.Run("missing window is rejected", func(t *testing.T) {
+		result, err := srv.handleCalendarDeleteEventsBulk(context.Background(), createMockRequest("calendar_delete_events_bulk", map[string]interface{}{
+			"time_min": "2025-01-06T00:00:00Z",
+			"confirm":  true,
+		}))
+		require.NoError(t, err)
+		assert.True(t, result.IsError)
+	})
+}
@@ -0,0 +1,63 @@
+// ABOUTME: Tests for calendar event types on calendar_create_event
+// ABOUTME: Validates event_type forwarding and rejection of unsupported types
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandleCalendarCreateEvent_EventType(t *testing.T) {
+	var inserted []map[string]interface{}
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		inserted = append(inserted, body)
+		_ = json.NewEncoder(w).Encode(body)
+	})
+
+	baseArgs := func(extra map[string]interface{}) map[string]interface{} {
+		args := map[string]interface{}{
+			"summary":    "Away",
+			"start_time": time.Now().Add(time.Hour).Format(time.RFC3339),
+			"end_time":   time.Now().Add(9 * time.Hour).Format(time.RFC3339),
+		}
+		for k, v := range extra {
+			args[k] = v
+		}
+		return args
+	}
+
+	t.Run("out of office is sent with auto-decline", func(t *testing.T) {
+		result, err := srv.handleCalendarCreateEvent(context.Background(), createMockRequest("calendar_create_event", baseArgs(map[string]interface{}{
+			"event_type":      "outOfOffice",
+			"decline_message": "On leave",
+		})))
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+
+		require.Len(t, inserted, 1)
+		assert.Equal(t, "outOfOffice", inserted[0]["eventType"])
+		props, ok := inserted[0]["outOfOfficeProperties"].(map[string]interface{})
+		require.True(t, ok)
+		assert.Equal(t, "declineAllConflictingInvitations", props["autoDeclineMode"])
+		assert.Equal(t, "On leave", props["declineMessage"])
+	})
+
+	t.Run("unsupported type is rejected", func(t *testing.T) {
+		inserted = nil
+		result, err := srv.handleCalendarCreateEvent(context.Background(), createMockRequest("calendar_create_event", baseArgs(map[string]interface{}{
+			"event_type": "birthday",
+		})))
+		require.NoError(t, err)
+		assert.True(t, result.IsError)
+		assert.Empty(t, inserted, "no API write should happen for an unsupported type")
+	})
+}
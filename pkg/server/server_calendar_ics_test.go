@@ -0,0 +1,54 @@
+// ABOUTME: Tests for the calendar_export_ics tool
+// ABOUTME: Verifies export by event ID and by time range, and that one of them is required
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandleCalendarExportICS(t *testing.T) {
+	var paths []string
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		event := `{"id": "evt-1", "summary": "Standup", "start": {"dateTime": "2025-03-03T10:00:00Z"}, "end": {"dateTime": "2025-03-03T10:15:00Z"}}`
+		if strings.HasSuffix(r.URL.Path, "/events") {
+			_, _ = w.Write([]byte(`{"items": [` + event + `, {"id": "evt-2", "summary": "Offsite", "start": {"date": "2025-03-04"}, "end": {"date": "2025-03-05"}}]}`))
+			return
+		}
+		_, _ = w.Write([]byte(event))
+	})
+	ctx := context.Background()
+
+	export := func(args map[string]interface{}) ExportICSResponse {
+		result, err := srv.handleCalendarExportICS(ctx, createMockRequest("calendar_export_ics", args))
+		require.NoError(t, err)
+		require.False(t, result.IsError, result.Content)
+		var resp ExportICSResponse
+		require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &resp))
+		return resp
+	}
+
+	resp := export(map[string]interface{}{"event_ids": []interface{}{"evt-1"}})
+	assert.Equal(t, 1, resp.Count)
+	assert.Contains(t, resp.ICS, "SUMMARY:Standup\r\n")
+	assert.Contains(t, paths[0], "/events/evt-1")
+
+	resp = export(map[string]interface{}{"time_min": "2025-03-03T00:00:00Z", "time_max": "2025-03-10T00:00:00Z"})
+	assert.Equal(t, 2, resp.Count)
+	assert.Contains(t, resp.ICS, "DTSTART;VALUE=DATE:20250304\r\n")
+
+	result, err := srv.handleCalendarExportICS(ctx, createMockRequest("calendar_export_ics", map[string]interface{}{
+		"time_min": "2025-03-03T00:00:00Z",
+	}))
+	require.NoError(t, err)
+	assert.True(t, result.IsError)
+}
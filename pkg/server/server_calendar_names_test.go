@@ -0,0 +1,55 @@
+// ABOUTME: Tests for resolving attendee names in calendar_get_event
+// ABOUTME: Verifies known attendees get contact names and unknown ones keep their email
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	googlecalendar "google.golang.org/api/calendar/v3"
+)
+
+func TestHandleCalendarGetEvent_ResolveNames(t *testing.T) {
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/events/evt1"):
+			_, _ = w.Write([]byte(`{"id": "evt1", "attendees": [
+				{"email": "jane@acme.com"},
+				{"email": "stranger@example.com"},
+				{"email": "named@acme.com", "displayName": "Already Named"}
+			]}`))
+		case strings.HasSuffix(r.URL.Path, ":searchContacts") && r.URL.Query().Get("query") == "jane@acme.com":
+			_, _ = w.Write([]byte(`{"results": [{"person": {"names": [{"displayName": "Jane Smith"}], "emailAddresses": [{"value": "jane@acme.com"}]}}]}`))
+		case strings.HasSuffix(r.URL.Path, ":searchContacts"):
+			_, _ = w.Write([]byte(`{}`))
+		default:
+			// Lookup failures, like a missing directory, keep the email
+			http.Error(w, `{"error": {"code": 500, "message": "boom"}}`, http.StatusInternalServerError)
+		}
+	})
+
+	getEvent := func(args map[string]interface{}) *googlecalendar.Event {
+		result, err := srv.handleCalendarGetEvent(context.Background(), createMockRequest("calendar_get_event", args))
+		require.NoError(t, err)
+		require.False(t, result.IsError, result.Content)
+		var event googlecalendar.Event
+		require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &event))
+		return &event
+	}
+
+	event := getEvent(map[string]interface{}{"event_id": "evt1", "resolve_names": true})
+	require.Len(t, event.Attendees, 3)
+	assert.Equal(t, "Jane Smith", event.Attendees[0].DisplayName)
+	assert.Equal(t, "stranger@example.com", event.Attendees[1].DisplayName)
+	assert.Equal(t, "Already Named", event.Attendees[2].DisplayName)
+
+	event = getEvent(map[string]interface{}{"event_id": "evt1"})
+	assert.Empty(t, event.Attendees[0].DisplayName, "names are only resolved on request")
+}
@@ -0,0 +1,87 @@
+// ABOUTME: Tests for calendar event extended properties
+// ABOUTME: Validates writing properties on create, merging on update, and find-by-property
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandleCalendarEvents_ExtendedProperties(t *testing.T) {
+	var written map[string]interface{}
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost, http.MethodPut:
+			written = nil
+			_ = json.NewDecoder(r.Body).Decode(&written)
+			_ = json.NewEncoder(w).Encode(written)
+		default:
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"id":                 "evt-1",
+				"summary":            "Synced",
+				"extendedProperties": map[string]interface{}{"private": map[string]string{"source": "jira"}},
+			})
+		}
+	})
+
+	props := map[string]interface{}{"private": map[string]interface{}{"ticket": "OPS-12"}}
+
+	t.Run("create writes properties", func(t *testing.T) {
+		result, err := srv.handleCalendarCreateEvent(context.Background(), createMockRequest("calendar_create_event", map[string]interface{}{
+			"summary":             "Incident review",
+			"start_time":          time.Now().Add(time.Hour).Format(time.RFC3339),
+			"end_time":            time.Now().Add(2 * time.Hour).Format(time.RFC3339),
+			"extended_properties": props,
+		}))
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+
+		ext := written["extendedProperties"].(map[string]interface{})
+		assert.Equal(t, map[string]interface{}{"ticket": "OPS-12"}, ext["private"])
+	})
+
+	t.Run("update merges with existing properties", func(t *testing.T) {
+		result, err := srv.handleCalendarUpdateEvent(context.Background(), createMockRequest("calendar_update_event", map[string]interface{}{
+			"event_id":            "evt-1",
+			"extended_properties": props,
+		}))
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+
+		ext := written["extendedProperties"].(map[string]interface{})
+		assert.Equal(t, map[string]interface{}{"source": "jira", "ticket": "OPS-12"}, ext["private"])
+	})
+
+	t.Run("non-string value is rejected", func(t *testing.T) {
+		written = nil
+		result, err := srv.handleCalendarUpdateEvent(context.Background(), createMockRequest("calendar_update_event", map[string]interface{}{
+			"event_id":            "evt-1",
+			"extended_properties": map[string]interface{}{"shared": map[string]interface{}{"count": float64(3)}},
+		}))
+		require.NoError(t, err)
+		assert.True(t, result.IsError)
+		assert.Nil(t, written)
+	})
+}
+
+func TestHandleCalendarFindByProperty(t *testing.T) {
+	var filters []string
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		filters = r.URL.Query()["privateExtendedProperty"]
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"items": []map[string]string{{"id": "evt-1"}}})
+	})
+
+	result, err := srv.handleCalendarFindByProperty(context.Background(), createMockRequest("calendar_find_by_property", map[string]interface{}{
+		"extended_properties": map[string]interface{}{"private": map[string]interface{}{"ticket": "OPS-12"}},
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+	assert.Equal(t, []string{"ticket=OPS-12"}, filters)
+}
@@ -0,0 +1,60 @@
+// ABOUTME: Tests for the calendar_reschedule_event tool
+// ABOUTME: Verifies offsets are validated up front and notification settings reach the update
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	googlecalendar "google.golang.org/api/calendar/v3"
+)
+
+func TestHandleCalendarRescheduleEvent(t *testing.T) {
+	var requests int
+	var updated *googlecalendar.Event
+	var sendUpdates string
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		switch r.Method {
+		case http.MethodGet:
+			_ = json.NewEncoder(w).Encode(&googlecalendar.Event{
+				Id:    "evt-1",
+				Start: &googlecalendar.EventDateTime{DateTime: "2025-01-06T14:00:00-08:00", TimeZone: "America/Los_Angeles"},
+				End:   &googlecalendar.EventDateTime{DateTime: "2025-01-06T15:00:00-08:00", TimeZone: "America/Los_Angeles"},
+			})
+		case http.MethodPut:
+			sendUpdates = r.URL.Query().Get("sendUpdates")
+			updated = &googlecalendar.Event{}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(updated))
+			_ = json.NewEncoder(w).Encode(updated)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+
+	result, err := srv.handleCalendarRescheduleEvent(context.Background(), createMockRequest("calendar_reschedule_event", map[string]interface{}{
+		"event_id": "evt-1",
+		"offset":   "half an hour",
+	}))
+	require.NoError(t, err)
+	require.True(t, result.IsError)
+	assert.Contains(t, result.Content[0].(mcp.TextContent).Text, "invalid offset")
+	assert.Zero(t, requests, "a bad offset is rejected before fetching the event")
+
+	result, err = srv.handleCalendarRescheduleEvent(context.Background(), createMockRequest("calendar_reschedule_event", map[string]interface{}{
+		"event_id":           "evt-1",
+		"offset":             "-1h",
+		"send_notifications": false,
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError, result.Content)
+	assert.Equal(t, "2025-01-06T13:00:00-08:00", updated.Start.DateTime)
+	assert.Equal(t, "2025-01-06T14:00:00-08:00", updated.End.DateTime)
+	assert.Equal(t, "none", sendUpdates)
+}
@@ -0,0 +1,79 @@
+// ABOUTME: Tests for the calendar_find_room tool and room booking in calendar_create_event
+// ABOUTME: Verifies busy rooms are left out of free_rooms and booked rooms are resource attendees
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	googlecalendar "google.golang.org/api/calendar/v3"
+)
+
+func TestHandleCalendarFindRoom(t *testing.T) {
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"calendars": {
+			"boardroom@resource.calendar.google.com": {"busy": [{"start": "2025-01-06T14:00:00Z", "end": "2025-01-06T15:00:00Z"}]},
+			"huddle@resource.calendar.google.com": {"busy": []}
+		}}`))
+	})
+
+	args := map[string]interface{}{
+		"rooms":      []interface{}{"boardroom@resource.calendar.google.com", "huddle@resource.calendar.google.com"},
+		"start_time": "2025-01-06T14:30:00Z",
+		"end_time":   "2025-01-06T15:00:00Z",
+	}
+	result, err := srv.handleCalendarFindRoom(context.Background(), createMockRequest("calendar_find_room", args))
+	require.NoError(t, err)
+	require.False(t, result.IsError, result.Content)
+
+	var resp FindRoomResponse
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &resp))
+	assert.Equal(t, []string{"huddle@resource.calendar.google.com"}, resp.FreeRooms)
+	require.Len(t, resp.Rooms, 2)
+	assert.False(t, resp.Rooms[0].Free)
+
+	args["end_time"] = "3pm"
+	result, err = srv.handleCalendarFindRoom(context.Background(), createMockRequest("calendar_find_room", args))
+	require.NoError(t, err)
+	assert.True(t, result.IsError)
+}
+
+func TestHandleCalendarCreateEvent_RoomEmail(t *testing.T) {
+	var inserted googlecalendar.Event
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&inserted))
+		_ = json.NewEncoder(w).Encode(inserted) // The room hasn't answered yet
+	})
+
+	args := map[string]interface{}{
+		"summary":    "Planning",
+		"start_time": "2025-01-06T14:00:00Z",
+		"end_time":   "2025-01-06T15:00:00Z",
+		"room_email": "huddle@resource.calendar.google.com",
+	}
+	result, err := srv.handleCalendarCreateEvent(context.Background(), createMockRequest("calendar_create_event", args))
+	require.NoError(t, err)
+	require.False(t, result.IsError, result.Content)
+
+	require.Len(t, inserted.Attendees, 1)
+	assert.True(t, inserted.Attendees[0].Resource)
+	assert.Equal(t, "huddle@resource.calendar.google.com", inserted.Attendees[0].Email)
+
+	var resp RoomBookingResponse
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &resp))
+	assert.Equal(t, "huddle@resource.calendar.google.com", resp.RoomEmail)
+	assert.False(t, resp.RoomAccepted)
+	require.NotNil(t, resp.Event)
+	assert.Equal(t, "Planning", resp.Event.Summary)
+
+	args["room_email"] = "huddle"
+	result, err = srv.handleCalendarCreateEvent(context.Background(), createMockRequest("calendar_create_event", args))
+	require.NoError(t, err)
+	assert.True(t, result.IsError)
+}
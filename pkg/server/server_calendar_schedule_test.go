@@ -0,0 +1,58 @@
+// ABOUTME: Tests for the calendar_validate_schedule tool
+// ABOUTME: Verifies slot parsing and that external and internal conflicts come back without writes
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	googlecalendar "google.golang.org/api/calendar/v3"
+)
+
+func TestHandleCalendarValidateSchedule(t *testing.T) {
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodGet, r.Method, "validation must not write")
+		_ = json.NewEncoder(w).Encode(&googlecalendar.Events{Items: []*googlecalendar.Event{{
+			Id:      "review",
+			Summary: "Quarterly review",
+			Start:   &googlecalendar.EventDateTime{DateTime: "2025-02-03T15:00:00Z"},
+			End:     &googlecalendar.EventDateTime{DateTime: "2025-02-03T16:00:00Z"},
+		}}})
+	})
+
+	result, err := srv.handleCalendarValidateSchedule(context.Background(), createMockRequest("calendar_validate_schedule", map[string]interface{}{
+		"slots": []interface{}{
+			map[string]interface{}{"start": "2025-02-03T14:30:00Z", "end": "2025-02-03T15:30:00Z", "summary": "Onboarding"},
+			map[string]interface{}{"start": "2025-02-03T17:00:00Z", "end": "2025-02-03T18:00:00Z", "summary": "Workshop A"},
+			map[string]interface{}{"start": "2025-02-03T17:30:00Z", "end": "2025-02-03T18:30:00Z", "summary": "Workshop B"},
+		},
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError, result.Content)
+
+	var resp ValidateScheduleResponse
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &resp))
+	assert.Equal(t, 3, resp.Slots)
+	assert.False(t, resp.Clear)
+	require.Len(t, resp.Conflicts, 2)
+	assert.Equal(t, "external", resp.Conflicts[0].Type)
+	assert.Equal(t, "Onboarding", resp.Conflicts[0].Summary)
+	assert.Equal(t, "review", resp.Conflicts[0].EventID)
+	assert.Equal(t, "internal", resp.Conflicts[1].Type)
+	assert.Equal(t, 1, resp.Conflicts[1].Slot)
+	require.NotNil(t, resp.Conflicts[1].OtherSlot)
+	assert.Equal(t, 2, *resp.Conflicts[1].OtherSlot)
+
+	result, err = srv.handleCalendarValidateSchedule(context.Background(), createMockRequest("calendar_validate_schedule", map[string]interface{}{
+		"slots": []interface{}{map[string]interface{}{"start": "tomorrow", "end": "2025-02-03T18:00:00Z"}},
+	}))
+	require.NoError(t, err)
+	require.True(t, result.IsError)
+	assert.Contains(t, result.Content[0].(mcp.TextContent).Text, "invalid slots[0].start")
+}
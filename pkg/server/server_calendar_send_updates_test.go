@@ -0,0 +1,119 @@
+// ABOUTME: Tests for calendar invite notification modes
+// ABOUTME: Validates send_updates forwarding, legacy send_notifications mapping, and enum validation
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordSendUpdates returns a handler that records the sendUpdates query parameter
+// of every event write and echoes back a minimal event
+func recordSendUpdates(mu *sync.Mutex, seen *[]string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost || r.Method == http.MethodPut {
+			mu.Lock()
+			*seen = append(*seen, r.URL.Query().Get("sendUpdates"))
+			mu.Unlock()
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"id":      "evt-1",
+			"summary": "Planning",
+			"start":   map[string]string{"dateTime": "2025-01-06T09:00:00Z"},
+			"end":     map[string]string{"dateTime": "2025-01-06T10:00:00Z"},
+		})
+	}
+}
+
+func TestHandleCalendarCreateEvent_SendUpdates(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     map[string]interface{}
+		expected string
+	}{
+		{name: "externalOnly forwarded", args: map[string]interface{}{"send_updates": "externalOnly"}, expected: "externalOnly"},
+		{name: "send_updates overrides legacy flag", args: map[string]interface{}{"send_updates": "none", "send_notifications": true}, expected: "none"},
+		{name: "legacy true maps to all", args: map[string]interface{}{"send_notifications": true}, expected: "all"},
+		{name: "legacy false maps to none", args: map[string]interface{}{"send_notifications": false}, expected: "none"},
+		{name: "default notifies all", args: map[string]interface{}{}, expected: "all"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			var seen []string
+			srv := newTestServer(t, recordSendUpdates(&mu, &seen))
+
+			args := map[string]interface{}{
+				"summary":    "Planning",
+				"start_time": time.Now().Add(time.Hour).Format(time.RFC3339),
+				"end_time":   time.Now().Add(2 * time.Hour).Format(time.RFC3339),
+			}
+			for k, v := range tt.args {
+				args[k] = v
+			}
+
+			result, err := srv.handleCalendarCreateEvent(context.Background(), createMockRequest("calendar_create_event", args))
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+			assert.Equal(t, []string{tt.expected}, seen)
+		})
+	}
+}
+
+func TestHandleCalendarUpdateEvent_SendUpdates(t *testing.T) {
+	var mu sync.Mutex
+	var seen []string
+	srv := newTestServer(t, recordSendUpdates(&mu, &seen))
+
+	result, err := srv.handleCalendarUpdateEvent(context.Background(), createMockRequest("calendar_update_event", map[string]interface{}{
+		"event_id":     "evt-1",
+		"summary":      "Planning (moved)",
+		"send_updates": "externalOnly",
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+	assert.Equal(t, []string{"externalOnly"}, seen)
+}
+
+func TestHandleCalendarCancelEvent_NotifiesAll(t *testing.T) {
+	var mu sync.Mutex
+	var seen []string
+	srv := newTestServer(t, recordSendUpdates(&mu, &seen))
+
+	result, err := srv.handleCalendarCancelEvent(context.Background(), createMockRequest("calendar_cancel_event", map[string]interface{}{
+		"event_id": "evt-1",
+		"note":     "Rescheduling next week",
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+	assert.Equal(t, []string{"all"}, seen)
+
+	result, err = srv.handleCalendarCancelEvent(context.Background(), createMockRequest("calendar_cancel_event", map[string]interface{}{}))
+	require.NoError(t, err)
+	assert.True(t, result.IsError)
+}
+
+func TestHandleCalendarCreateEvent_InvalidSendUpdates(t *testing.T) {
+	var mu sync.Mutex
+	var seen []string
+	srv := newTestServer(t, recordSendUpdates(&mu, &seen))
+
+	result, err := srv.handleCalendarCreateEvent(context.Background(), createMockRequest("calendar_create_event", map[string]interface{}{
+		"summary":      "Planning",
+		"start_time":   time.Now().Add(time.Hour).Format(time.RFC3339),
+		"end_time":     time.Now().Add(2 * time.Hour).Format(time.RFC3339),
+		"send_updates": "everyone",
+	}))
+	require.NoError(t, err)
+	assert.True(t, result.IsError)
+	assert.Empty(t, seen, "no API write should happen for an invalid mode")
+}
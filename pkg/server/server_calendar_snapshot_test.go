@@ -0,0 +1,69 @@
+// ABOUTME: Tests for the calendar_snapshot and calendar_diff tools
+// ABOUTME: Verifies a snapshot passed back through calendar_diff classifies changed events
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/harper/gsuite-mcp/pkg/calendar"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandleCalendarSnapshotAndDiff(t *testing.T) {
+	items := `[{"id": "evt-1", "summary": "Standup", "updated": "2025-03-01T09:00:00Z", "start": {"dateTime": "2025-03-03T10:00:00Z"}, "end": {"dateTime": "2025-03-03T10:15:00Z"}},
+		{"id": "evt-2", "summary": "Review", "updated": "2025-03-01T09:00:00Z", "start": {"date": "2025-03-04"}, "end": {"date": "2025-03-05"}}]`
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"items": ` + items + `}`))
+	})
+	ctx := context.Background()
+
+	snapshot := func() map[string]interface{} {
+		result, err := srv.handleCalendarSnapshot(ctx, createMockRequest("calendar_snapshot", map[string]interface{}{
+			"time_min": "2025-03-03T00:00:00Z",
+			"time_max": "2025-03-10T00:00:00Z",
+		}))
+		require.NoError(t, err)
+		require.False(t, result.IsError, result.Content)
+		var snap map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &snap))
+		return snap
+	}
+
+	before := snapshot()
+	assert.NotEmpty(t, before["hash"])
+	assert.Len(t, before["events"], 2)
+
+	items = `[{"id": "evt-2", "summary": "Design review", "updated": "2025-03-02T12:00:00Z", "start": {"date": "2025-03-04"}, "end": {"date": "2025-03-05"}},
+		{"id": "evt-3", "summary": "1:1", "updated": "2025-03-02T12:30:00Z", "start": {"dateTime": "2025-03-05T15:00:00Z"}, "end": {"dateTime": "2025-03-05T15:30:00Z"}}]`
+	after := snapshot()
+	assert.NotEqual(t, before["hash"], after["hash"])
+
+	result, err := srv.handleCalendarDiff(ctx, createMockRequest("calendar_diff", map[string]interface{}{
+		"before": before,
+		"after":  after,
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError, result.Content)
+
+	var diff calendar.SnapshotDiff
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &diff))
+	require.Len(t, diff.Added, 1)
+	assert.Equal(t, "evt-3", diff.Added[0].ID)
+	require.Len(t, diff.Removed, 1)
+	assert.Equal(t, "evt-1", diff.Removed[0].ID)
+	require.Len(t, diff.Modified, 1)
+	assert.Equal(t, "Design review", diff.Modified[0].After.Summary)
+
+	result, err = srv.handleCalendarDiff(ctx, createMockRequest("calendar_diff", map[string]interface{}{
+		"before": "not a snapshot",
+		"after":  after,
+	}))
+	require.NoError(t, err)
+	assert.True(t, result.IsError)
+}
@@ -0,0 +1,56 @@
+// ABOUTME: Tests for the calendar_create_event source parameter
+// ABOUTME: Verifies the provenance link is written to the event and invalid URLs are rejected
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandleCalendarCreateEvent_Source(t *testing.T) {
+	var written map[string]interface{}
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		written = nil
+		_ = json.NewDecoder(r.Body).Decode(&written)
+		_ = json.NewEncoder(w).Encode(written)
+	})
+
+	args := func(source interface{}) map[string]interface{} {
+		return map[string]interface{}{
+			"summary":    "Incident review",
+			"start_time": time.Now().Add(time.Hour).Format(time.RFC3339),
+			"end_time":   time.Now().Add(2 * time.Hour).Format(time.RFC3339),
+			"source":     source,
+		}
+	}
+
+	result, err := srv.handleCalendarCreateEvent(context.Background(), createMockRequest("calendar_create_event", args(map[string]interface{}{
+		"title": "OPS-12",
+		"url":   "https://tracker.example.com/OPS-12",
+	})))
+	require.NoError(t, err)
+	require.False(t, result.IsError, result.Content)
+	assert.Equal(t, map[string]interface{}{"title": "OPS-12", "url": "https://tracker.example.com/OPS-12"}, written["source"])
+
+	for name, source := range map[string]interface{}{
+		"relative url":  map[string]interface{}{"url": "/OPS-12"},
+		"non-http url":  map[string]interface{}{"url": "javascript:alert(1)"},
+		"missing url":   map[string]interface{}{"title": "OPS-12"},
+		"not an object": "https://tracker.example.com/OPS-12",
+	} {
+		t.Run(name, func(t *testing.T) {
+			written = nil
+			result, err := srv.handleCalendarCreateEvent(context.Background(), createMockRequest("calendar_create_event", args(source)))
+			require.NoError(t, err)
+			assert.True(t, result.IsError)
+			assert.Nil(t, written, "nothing is created for an invalid source")
+		})
+	}
+}
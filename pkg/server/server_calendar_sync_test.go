@@ -0,0 +1,46 @@
+// ABOUTME: Tests for the calendar_sync_events tool
+// ABOUTME: Verifies changed events and tokens are returned and an expired token asks for a full sync
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandleCalendarSyncEvents(t *testing.T) {
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("syncToken") == "expired" {
+			w.WriteHeader(http.StatusGone)
+			_, _ = w.Write([]byte(`{"error": {"code": 410, "message": "Sync token is no longer valid, a full sync is required."}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"items": [{"id": "evt-1", "status": "cancelled"}], "nextSyncToken": "token-2"}`))
+	})
+
+	sync := func(args map[string]interface{}) SyncEventsResponse {
+		result, err := srv.handleCalendarSyncEvents(context.Background(), createMockRequest("calendar_sync_events", args))
+		require.NoError(t, err)
+		require.False(t, result.IsError, result.Content)
+		var resp SyncEventsResponse
+		require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &resp))
+		return resp
+	}
+
+	resp := sync(map[string]interface{}{"sync_token": "token-1"})
+	assert.Equal(t, 1, resp.Count)
+	assert.Equal(t, "cancelled", resp.Events[0].Status)
+	assert.Equal(t, "token-2", resp.NextSyncToken)
+	assert.False(t, resp.FullSyncRequired)
+
+	resp = sync(map[string]interface{}{"sync_token": "expired"})
+	assert.True(t, resp.FullSyncRequired)
+	assert.Empty(t, resp.NextSyncToken)
+	assert.Empty(t, resp.Events)
+}
@@ -0,0 +1,100 @@
+// ABOUTME: Tests for creating calendar events from meeting templates
+// ABOUTME: Validates agenda filling, template defaults, caller overrides, time checks, and skipping malformed files
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/harper/gsuite-mcp/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandleCalendarCreateEventFromTemplate(t *testing.T) {
+	var inserted []map[string]interface{}
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		inserted = append(inserted, body)
+		_ = json.NewEncoder(w).Encode(body)
+	})
+
+	create := func(args map[string]interface{}) (map[string]interface{}, bool) {
+		inserted = nil
+		result, err := srv.handleCalendarCreateEventFromTemplate(context.Background(), createMockRequest("calendar_create_event_from_template", args))
+		require.NoError(t, err)
+		if result.IsError || len(inserted) != 1 {
+			return nil, false
+		}
+		return inserted[0], true
+	}
+
+	t.Run("template defaults", func(t *testing.T) {
+		event, ok := create(map[string]interface{}{"template": "one_on_one", "start_time": "2025-03-03T10:00:00Z"})
+		require.True(t, ok)
+
+		assert.Equal(t, "1:1", event["summary"])
+		assert.Contains(t, event["description"], "Blockers and concerns")
+		assert.Equal(t, "2025-03-03T10:30:00Z", event["end"].(map[string]interface{})["dateTime"])
+		reminders := event["reminders"].(map[string]interface{})
+		assert.Equal(t, false, reminders["useDefault"])
+		assert.Equal(t, float64(10), reminders["overrides"].([]interface{})[0].(map[string]interface{})["minutes"])
+	})
+
+	t.Run("caller overrides win", func(t *testing.T) {
+		event, ok := create(map[string]interface{}{
+			"template":         "standup",
+			"start_time":       "2025-03-03T09:00:00Z",
+			"summary":          "Platform standup",
+			"duration_minutes": float64(25),
+			"description":      "Demo day prep",
+			"reminder_minutes": []interface{}{float64(1)},
+		})
+		require.True(t, ok)
+
+		assert.Equal(t, "Platform standup", event["summary"])
+		assert.Contains(t, event["description"], "Demo day prep")
+		assert.Contains(t, event["description"], "What I did yesterday")
+		assert.Equal(t, "2025-03-03T09:25:00Z", event["end"].(map[string]interface{})["dateTime"])
+		overrides := event["reminders"].(map[string]interface{})["overrides"].([]interface{})
+		require.Len(t, overrides, 1)
+		assert.Equal(t, float64(1), overrides[0].(map[string]interface{})["minutes"])
+	})
+
+	t.Run("end before start is rejected", func(t *testing.T) {
+		_, ok := create(map[string]interface{}{
+			"template":   "standup",
+			"start_time": "2025-03-03T09:00:00Z",
+			"end_time":   "2025-03-03T08:45:00Z",
+		})
+		assert.False(t, ok)
+		assert.Empty(t, inserted, "nothing reaches the API")
+	})
+
+	t.Run("unknown template is rejected", func(t *testing.T) {
+		_, ok := create(map[string]interface{}{"template": "offsite", "start_time": "2025-03-03T09:00:00Z"})
+		assert.False(t, ok)
+		assert.Empty(t, inserted)
+	})
+}
+
+func TestNewServer_SkipsMalformedTemplates(t *testing.T) {
+	t.Setenv("ISH_MODE", "true")
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "bad.txt"), []byte("duration_minutes: soon\n---\nAgenda\n"), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "retro.txt"), []byte("What went well?\n"), 0600))
+
+	cfg := config.Default()
+	cfg.TemplatesDir = dir
+	srv, err := NewServer(context.Background(), cfg)
+	require.NoError(t, err, "one bad file doesn't stop startup")
+
+	assert.Contains(t, srv.templates.Names(), "retro")
+	assert.NotContains(t, srv.templates.Names(), "bad")
+}
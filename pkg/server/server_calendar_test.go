@@ -16,7 +16,7 @@ import (
 func TestHandleCalendarUpdateEvent_WithNilStartField(t *testing.T) {
 	t.Setenv("ISH_MODE", "true")
 
-	srv, err := NewServer(context.Background())
+	srv, err := NewServer(context.Background(), nil)
 	require.NoError(t, err)
 
 	// First create an event to update
@@ -52,7 +52,7 @@ func TestHandleCalendarUpdateEvent_WithNilStartField(t *testing.T) {
 func TestHandleCalendarUpdateEvent_WithNilEndField(t *testing.T) {
 	t.Setenv("ISH_MODE", "true")
 
-	srv, err := NewServer(context.Background())
+	srv, err := NewServer(context.Background(), nil)
 	require.NoError(t, err)
 
 	eventID := "test-event-456"
@@ -74,7 +74,7 @@ func TestHandleCalendarUpdateEvent_WithNilEndField(t *testing.T) {
 func TestHandleCalendarUpdateEvent_WithBothNilStartAndEnd(t *testing.T) {
 	t.Setenv("ISH_MODE", "true")
 
-	srv, err := NewServer(context.Background())
+	srv, err := NewServer(context.Background(), nil)
 	require.NoError(t, err)
 
 	eventID := "test-event-789"
@@ -100,7 +100,7 @@ func TestHandleCalendarUpdateEvent_WithBothNilStartAndEnd(t *testing.T) {
 func TestHandleCalendarUpdateEvent_OnlySummary(t *testing.T) {
 	t.Setenv("ISH_MODE", "true")
 
-	srv, err := NewServer(context.Background())
+	srv, err := NewServer(context.Background(), nil)
 	require.NoError(t, err)
 
 	eventID := "test-event-summary"
@@ -121,7 +121,7 @@ func TestHandleCalendarUpdateEvent_OnlySummary(t *testing.T) {
 func TestHandleCalendarUpdateEvent_OnlyDescription(t *testing.T) {
 	t.Setenv("ISH_MODE", "true")
 
-	srv, err := NewServer(context.Background())
+	srv, err := NewServer(context.Background(), nil)
 	require.NoError(t, err)
 
 	eventID := "test-event-description"
@@ -142,7 +142,7 @@ func TestHandleCalendarUpdateEvent_OnlyDescription(t *testing.T) {
 func TestHandleCalendarUpdateEvent_OnlyStartTime(t *testing.T) {
 	t.Setenv("ISH_MODE", "true")
 
-	srv, err := NewServer(context.Background())
+	srv, err := NewServer(context.Background(), nil)
 	require.NoError(t, err)
 
 	eventID := "test-event-starttime"
@@ -164,7 +164,7 @@ func TestHandleCalendarUpdateEvent_OnlyStartTime(t *testing.T) {
 func TestHandleCalendarUpdateEvent_OnlyEndTime(t *testing.T) {
 	t.Setenv("ISH_MODE", "true")
 
-	srv, err := NewServer(context.Background())
+	srv, err := NewServer(context.Background(), nil)
 	require.NoError(t, err)
 
 	eventID := "test-event-endtime"
@@ -186,7 +186,7 @@ func TestHandleCalendarUpdateEvent_OnlyEndTime(t *testing.T) {
 func TestHandleCalendarUpdateEvent_InvalidStartTimeFormat(t *testing.T) {
 	t.Setenv("ISH_MODE", "true")
 
-	srv, err := NewServer(context.Background())
+	srv, err := NewServer(context.Background(), nil)
 	require.NoError(t, err)
 
 	eventID := "test-event-invalid-start"
@@ -209,7 +209,7 @@ func TestHandleCalendarUpdateEvent_InvalidStartTimeFormat(t *testing.T) {
 func TestHandleCalendarUpdateEvent_InvalidEndTimeFormat(t *testing.T) {
 	t.Setenv("ISH_MODE", "true")
 
-	srv, err := NewServer(context.Background())
+	srv, err := NewServer(context.Background(), nil)
 	require.NoError(t, err)
 
 	eventID := "test-event-invalid-end"
@@ -232,7 +232,7 @@ func TestHandleCalendarUpdateEvent_InvalidEndTimeFormat(t *testing.T) {
 func TestHandleCalendarUpdateEvent_MissingEventID(t *testing.T) {
 	t.Setenv("ISH_MODE", "true")
 
-	srv, err := NewServer(context.Background())
+	srv, err := NewServer(context.Background(), nil)
 	require.NoError(t, err)
 
 	// Try to update without event_id
@@ -251,7 +251,7 @@ func TestHandleCalendarUpdateEvent_MissingEventID(t *testing.T) {
 func TestHandleCalendarUpdateEvent_AllFieldsUpdate(t *testing.T) {
 	t.Setenv("ISH_MODE", "true")
 
-	srv, err := NewServer(context.Background())
+	srv, err := NewServer(context.Background(), nil)
 	require.NoError(t, err)
 
 	eventID := "test-event-all-fields"
@@ -279,7 +279,7 @@ func TestHandleCalendarUpdateEvent_AllFieldsUpdate(t *testing.T) {
 func TestHandleCalendarUpdateEvent_EmptyStringFieldsIgnored(t *testing.T) {
 	t.Setenv("ISH_MODE", "true")
 
-	srv, err := NewServer(context.Background())
+	srv, err := NewServer(context.Background(), nil)
 	require.NoError(t, err)
 
 	eventID := "test-event-empty-strings"
@@ -302,7 +302,7 @@ func TestHandleCalendarUpdateEvent_EmptyStringFieldsIgnored(t *testing.T) {
 func TestHandleCalendarUpdateEvent_MalformedExistingEvent(t *testing.T) {
 	t.Setenv("ISH_MODE", "true")
 
-	srv, err := NewServer(context.Background())
+	srv, err := NewServer(context.Background(), nil)
 	require.NoError(t, err)
 
 	// In ISH mode, this will try to get a non-existent event
@@ -326,7 +326,7 @@ func TestHandleCalendarUpdateEvent_NilEventDateTime_StartOnly(t *testing.T) {
 	// has nil Start field and we're updating it
 	t.Setenv("ISH_MODE", "true")
 
-	srv, err := NewServer(context.Background())
+	srv, err := NewServer(context.Background(), nil)
 	require.NoError(t, err)
 
 	eventID := "test-event-nil-start-update"
@@ -352,7 +352,7 @@ func TestHandleCalendarUpdateEvent_NilEventDateTime_EndOnly(t *testing.T) {
 	// has nil End field and we're updating it
 	t.Setenv("ISH_MODE", "true")
 
-	srv, err := NewServer(context.Background())
+	srv, err := NewServer(context.Background(), nil)
 	require.NoError(t, err)
 
 	eventID := "test-event-nil-end-update"
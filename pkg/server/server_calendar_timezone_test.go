@@ -0,0 +1,34 @@
+// ABOUTME: Tests for the calendar_get_timezone tool
+// ABOUTME: Verifies the primary calendar's zone is returned
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandleCalendarGetTimezone(t *testing.T) {
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/calendarList/primary") {
+			_, _ = w.Write([]byte(`{"timeZone": "Asia/Tokyo"}`))
+			return
+		}
+		http.NotFound(w, r)
+	})
+
+	result, err := srv.handleCalendarGetTimezone(context.Background(), createMockRequest("calendar_get_timezone", nil))
+	require.NoError(t, err)
+	require.False(t, result.IsError, result.Content)
+
+	var resp CalendarTimezoneResponse
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &resp))
+	assert.Equal(t, CalendarTimezoneResponse{CalendarID: "primary", TimeZone: "Asia/Tokyo"}, resp)
+}
@@ -0,0 +1,76 @@
+// ABOUTME: Tests for event text limits on calendar updates
+// ABOUTME: Verifies only caller-supplied text is checked, so long existing descriptions still update
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/harper/gsuite-mcp/pkg/calendar"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	googlecalendar "google.golang.org/api/calendar/v3"
+)
+
+func TestHandleCalendarUpdateEvent_ExistingTextNotRevalidated(t *testing.T) {
+	longDescription := strings.Repeat("x", calendar.MaxDescriptionLength+100)
+	var updates int
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			_ = json.NewEncoder(w).Encode(&googlecalendar.Event{
+				Id:          "evt-1",
+				Summary:     "Imported",
+				Description: longDescription,
+				Start:       &googlecalendar.EventDateTime{DateTime: "2025-01-06T14:00:00Z"},
+				End:         &googlecalendar.EventDateTime{DateTime: "2025-01-06T15:00:00Z"},
+			})
+		case http.MethodPut:
+			updates++
+			var event googlecalendar.Event
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&event))
+			_ = json.NewEncoder(w).Encode(&event)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	ctx := context.Background()
+
+	result, err := srv.handleCalendarUpdateEvent(ctx, createMockRequest("calendar_update_event", map[string]interface{}{
+		"event_id":   "evt-1",
+		"start_time": "2025-01-06T16:00:00Z",
+		"end_time":   "2025-01-06T17:00:00Z",
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError, result.Content)
+
+	result, err = srv.handleCalendarRescheduleEvent(ctx, createMockRequest("calendar_reschedule_event", map[string]interface{}{
+		"event_id": "evt-1",
+		"offset":   "30m",
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError, result.Content)
+
+	result, err = srv.handleCalendarCancelEvent(ctx, createMockRequest("calendar_cancel_event", map[string]interface{}{
+		"event_id": "evt-1",
+		"note":     "Moved to next week",
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError, result.Content)
+	assert.Equal(t, 3, updates)
+
+	// Text the caller sets is still checked, before anything is fetched
+	result, err = srv.handleCalendarUpdateEvent(ctx, createMockRequest("calendar_update_event", map[string]interface{}{
+		"event_id": "evt-1",
+		"summary":  strings.Repeat("s", calendar.MaxSummaryLength+1),
+	}))
+	require.NoError(t, err)
+	require.True(t, result.IsError)
+	assert.Contains(t, result.Content[0].(mcp.TextContent).Text, "summary is 1025 characters")
+	assert.Equal(t, 3, updates)
+}
@@ -0,0 +1,101 @@
+// ABOUTME: Tests for structured results from delete tools
+// ABOUTME: Validates delete results (deleted flag, ID, type, summary) and clean not-found errors
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeleteHandlers_ReturnStructuredResult(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     map[string]interface{}
+		call     func(*Server, context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error)
+		id       string
+		itemType string
+		summary  string
+	}{
+		{
+			name:     "gmail_delete_message",
+			args:     map[string]interface{}{"message_id": "msg-1"},
+			call:     (*Server).handleGmailDeleteMessage,
+			id:       "msg-1",
+			itemType: "message",
+			summary:  "Message msg-1 deleted successfully",
+		},
+		{
+			name:     "calendar_delete_event",
+			args:     map[string]interface{}{"event_id": "evt-1"},
+			call:     (*Server).handleCalendarDeleteEvent,
+			id:       "evt-1",
+			itemType: "event",
+			summary:  "Event evt-1 deleted successfully",
+		},
+		{
+			name:     "people_delete_contact",
+			args:     map[string]interface{}{"resource_name": "people/c1", "confirm": true},
+			call:     (*Server).handlePeopleDeleteContact,
+			id:       "people/c1",
+			itemType: "contact",
+			summary:  "Contact people/c1 deleted successfully",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+				require.Equal(t, http.MethodDelete, r.Method)
+				w.WriteHeader(http.StatusNoContent)
+			})
+
+			result, err := tt.call(srv, context.Background(), createMockRequest(tt.name, tt.args))
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+			require.Len(t, result.Content, 2)
+
+			var resp DeleteResponse
+			require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &resp))
+			assert.True(t, resp.Deleted)
+			assert.Equal(t, tt.id, resp.ID)
+			assert.Equal(t, tt.itemType, resp.Type)
+			assert.Equal(t, resp, result.StructuredContent)
+
+			assert.Equal(t, tt.summary, result.Content[1].(mcp.TextContent).Text)
+		})
+	}
+}
+
+func TestHandlers_NotFoundMessage(t *testing.T) {
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"error": {"code": 404, "message": "Requested entity was not found."}}`))
+	})
+
+	tests := []struct {
+		name    string
+		handler func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error)
+		args    map[string]interface{}
+		want    string
+	}{
+		{"message", srv.handleGmailGetMessage, map[string]interface{}{"message_id": "m404"}, "message m404 not found"},
+		{"event", srv.handleCalendarDeleteEvent, map[string]interface{}{"event_id": "e404"}, "event e404 not found"},
+		{"contact", srv.handlePeopleGetContact, map[string]interface{}{"resource_name": "people/c404"}, "contact people/c404 not found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := tt.handler(context.Background(), createMockRequest("", tt.args))
+			require.NoError(t, err)
+			require.True(t, result.IsError)
+			assert.Equal(t, tt.want, result.Content[0].(mcp.TextContent).Text)
+		})
+	}
+}
@@ -0,0 +1,56 @@
+// ABOUTME: Tests for the gmail_awaiting_reply tool
+// ABOUTME: Verifies unanswered sent threads are returned and argument bounds are enforced
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/harper/gsuite-mcp/pkg/gmail"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandleGmailAwaitingReply(t *testing.T) {
+	sent := time.Now().Add(-72 * time.Hour).UnixMilli()
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/messages"):
+			_, _ = w.Write([]byte(`{"messages": [{"id": "m1", "threadId": "t1"}, {"id": "m2", "threadId": "t2"}]}`))
+		case strings.HasSuffix(r.URL.Path, "/threads/t1"):
+			_, _ = fmt.Fprintf(w, `{"id": "t1", "messages": [{"id": "m1", "internalDate": "%d", "labelIds": ["SENT"],
+				"payload": {"headers": [{"name": "To", "value": "jane@acme.com"}, {"name": "Subject", "value": "Proposal"}]}}]}`, sent)
+		case strings.HasSuffix(r.URL.Path, "/threads/t2"):
+			_, _ = fmt.Fprintf(w, `{"id": "t2", "messages": [{"id": "m2", "internalDate": "%d", "labelIds": ["SENT"]},
+				{"id": "m3", "internalDate": "%d", "labelIds": ["INBOX"]}]}`, sent, sent+1000)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+
+	result, err := srv.handleGmailAwaitingReply(context.Background(), createMockRequest("gmail_awaiting_reply", map[string]interface{}{"days": 7}))
+	require.NoError(t, err)
+	require.False(t, result.IsError, result.Content)
+
+	var report gmail.AwaitingReplyReport
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &report))
+	assert.Equal(t, 2, report.Checked)
+	require.Len(t, report.Threads, 1, "the thread with a reply is not awaiting one")
+	assert.Equal(t, "t1", report.Threads[0].ThreadID)
+	assert.Equal(t, "jane@acme.com", report.Threads[0].To)
+	assert.Equal(t, "Proposal", report.Threads[0].Subject)
+	assert.Equal(t, 3, report.Threads[0].DaysSinceSent)
+
+	for _, args := range []map[string]interface{}{{"days": 0}, {"days": 365}, {"max_messages": 0}} {
+		result, err := srv.handleGmailAwaitingReply(context.Background(), createMockRequest("gmail_awaiting_reply", args))
+		require.NoError(t, err)
+		assert.True(t, result.IsError, "args %v", args)
+	}
+}
@@ -0,0 +1,177 @@
+// ABOUTME: Tests for the gmail_message_to_contact tool
+// ABOUTME: Verifies new senders become contacts and known senders return the existing record
+
+package server
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/harper/gsuite-mcp/pkg/gmail"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// senderAPI fakes the Gmail and People endpoints gmail_message_to_contact uses.
+// known holds the emails that already have a contact; created records create calls.
+func senderAPI(t *testing.T, known map[string]bool, created *[]map[string]interface{}) http.HandlerFunc {
+	body := "Hi,\n\nLooking forward to it.\n\nBest regards,\nJane Smith\nHead of Partnerships at Acme Corp\n+1 (415) 555-0123\n"
+	return func(w http.ResponseWriter, r *http.Request) {
+		var resp interface{}
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/messages/m1"):
+			resp = map[string]interface{}{
+				"id": "m1",
+				"payload": map[string]interface{}{
+					"mimeType": "text/plain",
+					"headers":  []map[string]string{{"name": "From", "value": "Jane Smith <Jane.Smith@acme.com>"}},
+					"body":     map[string]string{"data": base64.URLEncoding.EncodeToString([]byte(body))},
+				},
+			}
+		case strings.HasSuffix(r.URL.Path, "people:searchContacts"):
+			query := r.URL.Query().Get("query")
+			if !known[query] {
+				resp = map[string]interface{}{}
+				break
+			}
+			resp = map[string]interface{}{"results": []map[string]interface{}{{
+				"person": map[string]interface{}{
+					"resourceName":   "people/c1",
+					"emailAddresses": []map[string]string{{"value": query}},
+				},
+			}}}
+		case strings.HasSuffix(r.URL.Path, "/people/c1"):
+			resp = map[string]interface{}{
+				"resourceName":   "people/c1",
+				"names":          []map[string]string{{"displayName": "Jane Smith"}},
+				"emailAddresses": []map[string]string{{"value": "jane.smith@acme.com"}},
+				"phoneNumbers":   []map[string]string{{"value": "+14155550123"}},
+			}
+		case strings.HasSuffix(r.URL.Path, "people:createContact"):
+			var person map[string]interface{}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&person))
+			*created = append(*created, person)
+			person["resourceName"] = "people/new"
+			resp = person
+		default:
+			http.NotFound(w, r)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}
+}
+
+func messageToContact(t *testing.T, srv *Server, args map[string]interface{}) MessageToContactResponse {
+	t.Helper()
+	result, err := srv.handleGmailMessageToContact(context.Background(), createMockRequest("gmail_message_to_contact", args))
+	require.NoError(t, err)
+	require.False(t, result.IsError, result.Content)
+
+	var resp MessageToContactResponse
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &resp))
+	return resp
+}
+
+func TestHandleGmailMessageToContact_CreatesNewSender(t *testing.T) {
+	var created []map[string]interface{}
+	srv := newTestServer(t, senderAPI(t, nil, &created))
+
+	resp := messageToContact(t, srv, map[string]interface{}{"message_id": "m1"})
+
+	assert.Equal(t, "created", resp.Status)
+	assert.Equal(t, "jane.smith@acme.com", resp.Email)
+	require.Len(t, created, 1)
+	require.NotNil(t, resp.Contact)
+	assert.Equal(t, "people/new", resp.Contact.ResourceName)
+	assert.Equal(t, "Jane", resp.Contact.Names[0].GivenName)
+	assert.Equal(t, "Smith", resp.Contact.Names[0].FamilyName)
+	assert.Equal(t, "jane.smith@acme.com", resp.Contact.EmailAddresses[0].Value)
+	assert.Equal(t, "+14155550123", resp.Contact.PhoneNumbers[0].Value)
+	assert.Equal(t, "Acme Corp", resp.Contact.Organizations[0].Name)
+	assert.Equal(t, "Head of Partnerships", resp.Contact.Organizations[0].Title)
+}
+
+func TestHandleGmailMessageToContact_NotesAndRelations(t *testing.T) {
+	var created []map[string]interface{}
+	srv := newTestServer(t, senderAPI(t, nil, &created))
+
+	props := srv.mcp.GetTool("gmail_message_to_contact").Tool.InputSchema.Properties
+	assert.Contains(t, props, "notes")
+	assert.Contains(t, props, "relations")
+
+	resp := messageToContact(t, srv, map[string]interface{}{
+		"message_id": "m1",
+		"notes":      "Met at the partner summit",
+		"relations":  []interface{}{map[string]interface{}{"person": "Sam Lee", "type": "assistant"}},
+	})
+
+	assert.Equal(t, "created", resp.Status)
+	require.NotNil(t, resp.Contact)
+	require.Len(t, resp.Contact.Biographies, 1)
+	assert.Equal(t, "Met at the partner summit", resp.Contact.Biographies[0].Value)
+	require.Len(t, resp.Contact.Relations, 1)
+	assert.Equal(t, "Sam Lee", resp.Contact.Relations[0].Person)
+	assert.Equal(t, "assistant", resp.Contact.Relations[0].Type)
+}
+
+func TestHandleGmailMessageToContact_ExistingSender(t *testing.T) {
+	var created []map[string]interface{}
+	srv := newTestServer(t, senderAPI(t, map[string]bool{"jane.smith@acme.com": true}, &created))
+
+	resp := messageToContact(t, srv, map[string]interface{}{"message_id": "m1"})
+
+	assert.Equal(t, "existing", resp.Status)
+	assert.Empty(t, created, "a known sender isn't duplicated")
+	require.NotNil(t, resp.Contact)
+	assert.Equal(t, "people/c1", resp.Contact.ResourceName)
+	assert.Equal(t, "+14155550123", resp.Contact.PhoneNumbers[0].Value, "the full record is returned")
+
+	// Opting out of the check creates the contact anyway
+	resp = messageToContact(t, srv, map[string]interface{}{"message_id": "m1", "skip_if_exists": false})
+	assert.Equal(t, "created", resp.Status)
+	assert.Len(t, created, 1)
+}
+
+func TestContactFromSender(t *testing.T) {
+	tests := []struct {
+		name      string
+		info      gmail.ContactInfo
+		given     string
+		family    string
+		withPhone bool
+	}{
+		{
+			name:  "last, first display name",
+			info:  gmail.ContactInfo{From: `"Smith, Jane" <jane@acme.com>`, Name: "Jane Smith", Phone: "+14155550123"},
+			given: "Jane", family: "Smith", withPhone: true,
+		},
+		{
+			name:  "bare address uses signature name",
+			info:  gmail.ContactInfo{From: "jane@acme.com", Name: "Jane Smith", Phone: "+14155550123"},
+			given: "Jane", family: "Smith", withPhone: true,
+		},
+		{
+			name:  "signature of someone else is ignored",
+			info:  gmail.ContactInfo{From: "Bob Jones <bob@acme.com>", Name: "Jane Smith", Phone: "+14155550123"},
+			given: "Bob", family: "Jones",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			person, _, err := contactFromSender(&tt.info)
+			require.NoError(t, err)
+			assert.Equal(t, tt.given, person.Names[0].GivenName)
+			assert.Equal(t, tt.family, person.Names[0].FamilyName)
+			assert.Equal(t, tt.withPhone, len(person.PhoneNumbers) > 0)
+		})
+	}
+
+	_, _, err := contactFromSender(&gmail.ContactInfo{MessageID: "m1"})
+	assert.Error(t, err)
+}
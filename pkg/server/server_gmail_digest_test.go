@@ -0,0 +1,67 @@
+// ABOUTME: Tests for the gmail_digest tool
+// ABOUTME: Verifies the day window reaches the Gmail query and argument bounds are enforced
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/harper/gsuite-mcp/pkg/gmail"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandleGmailDigest(t *testing.T) {
+	var query string
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/messages"):
+			query = r.URL.Query().Get("q")
+			_, _ = w.Write([]byte(`{"messages": [{"id": "m1"}]}`))
+		case strings.HasSuffix(r.URL.Path, "/messages/m1"):
+			_, _ = fmt.Fprintf(w, `{"id": "m1", "threadId": "t1", "internalDate": "%d", "labelIds": ["INBOX"],
+				"payload": {"headers": [{"name": "From", "value": "Jane <jane@acme.com>"}]}}`, time.Now().Add(-time.Hour).UnixMilli())
+		case strings.HasSuffix(r.URL.Path, "/labels"):
+			_, _ = w.Write([]byte(`{"labels": []}`))
+		default:
+			http.NotFound(w, r)
+		}
+	})
+
+	start := time.Now()
+	result, err := srv.handleGmailDigest(context.Background(), createMockRequest("gmail_digest", map[string]interface{}{"days": 3}))
+	require.NoError(t, err)
+	require.False(t, result.IsError, result.Content)
+
+	var after int64
+	_, err = fmt.Sscanf(query[strings.Index(query, "after:"):], "after:%d", &after)
+	require.NoError(t, err)
+	assert.InDelta(t, start.AddDate(0, 0, -3).Unix(), after, 5, "the window starts days ago")
+
+	var digest gmail.Digest
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &digest))
+	assert.Equal(t, 1, digest.MessageCount)
+	require.Len(t, digest.Domains, 1)
+	assert.Equal(t, "acme.com", digest.Domains[0].Domain)
+}
+
+func TestHandleGmailDigest_InvalidArguments(t *testing.T) {
+	srv := newTestServer(t, http.NotFound)
+
+	for _, args := range []map[string]interface{}{
+		{"days": 0},
+		{"days": 45},
+		{"max_messages": 1000},
+	} {
+		result, err := srv.handleGmailDigest(context.Background(), createMockRequest("gmail_digest", args))
+		require.NoError(t, err)
+		assert.True(t, result.IsError, "args %v", args)
+	}
+}
@@ -0,0 +1,61 @@
+// ABOUTME: Tests for gmail_create_draft's dedupe option
+// ABOUTME: Verifies the schema advertises it and a repeated create returns the existing draft
+
+package server
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandleGmailCreateDraft_Dedupe(t *testing.T) {
+	var creates int
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost:
+			creates++
+			_, _ = w.Write([]byte(`{"id": "draft-new"}`))
+		case strings.HasSuffix(r.URL.Path, "/drafts"):
+			_, _ = w.Write([]byte(`{"drafts": [{"id": "draft-1"}]}`))
+		case strings.HasSuffix(r.URL.Path, "/drafts/draft-1"):
+			body := base64.URLEncoding.EncodeToString([]byte("Numbers attached."))
+			_, _ = w.Write([]byte(`{"id": "draft-1", "message": {"id": "m1", "payload": {"mimeType": "text/plain",
+				"headers": [{"name": "To", "value": "bob@example.com"}, {"name": "Subject", "value": "Budget"}],
+				"body": {"data": "` + body + `"}}}}`))
+		default:
+			http.NotFound(w, r)
+		}
+	})
+
+	draftTool := srv.mcp.GetTool("gmail_create_draft")
+	require.NotNil(t, draftTool)
+	assert.Contains(t, draftTool.Tool.InputSchema.Properties, "dedupe")
+	assert.NotContains(t, srv.mcp.GetTool("gmail_send_message").Tool.InputSchema.Properties, "dedupe")
+
+	args := map[string]interface{}{
+		"to":      "bob@example.com",
+		"subject": "Budget",
+		"body":    "Numbers attached.",
+		"dedupe":  true,
+	}
+	result, err := srv.handleGmailCreateDraft(context.Background(), createMockRequest("gmail_create_draft", args))
+	require.NoError(t, err)
+	require.False(t, result.IsError, result.Content)
+	assert.Contains(t, result.Content[0].(mcp.TextContent).Text, `"draft-1"`)
+	require.Len(t, result.Content, 2)
+	assert.Contains(t, result.Content[1].(mcp.TextContent).Text, "an identical draft already exists (draft-1)")
+	assert.Zero(t, creates)
+
+	args["body"] = "Revised numbers attached."
+	result, err = srv.handleGmailCreateDraft(context.Background(), createMockRequest("gmail_create_draft", args))
+	require.NoError(t, err)
+	require.False(t, result.IsError, result.Content)
+	assert.Equal(t, 1, creates, "a different body creates a new draft")
+}
@@ -0,0 +1,134 @@
+// ABOUTME: Tests for the internal_domains guard on the send tools
+// ABOUTME: Verifies external recipients block sends, draft sends, and scheduling unless allow_external is passed
+
+package server
+
+import (
+	"context"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandleGmailSendMessage_InternalDomains(t *testing.T) {
+	var sends int
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/profile"):
+			_, _ = w.Write([]byte(`{"emailAddress": "me@example.com"}`))
+		case strings.HasSuffix(r.URL.Path, "/messages/send"):
+			sends++
+			_, _ = w.Write([]byte(`{"id": "sent-1"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	srv.internal = map[string]bool{"example.com": true}
+
+	args := map[string]interface{}{
+		"to":      "alice@example.com, Bob <bob@partner.io>",
+		"subject": "Q3 numbers",
+		"body":    "Attached",
+	}
+	result, err := srv.handleGmailSendMessage(context.Background(), createMockRequest("gmail_send_message", args))
+	require.NoError(t, err)
+	require.True(t, result.IsError)
+	text := result.Content[0].(mcp.TextContent).Text
+	assert.Contains(t, text, "bob@partner.io")
+	assert.NotContains(t, text, "alice@example.com")
+	assert.Contains(t, text, "allow_external=true")
+	assert.Zero(t, sends, "blocked sends must not reach the API")
+
+	args["allow_external"] = true
+	result, err = srv.handleGmailSendMessage(context.Background(), createMockRequest("gmail_send_message", args))
+	require.NoError(t, err)
+	require.False(t, result.IsError, result.Content)
+	assert.Equal(t, 1, sends)
+
+	// Internal-only mail needs no flag
+	result, err = srv.handleGmailSendMessage(context.Background(), createMockRequest("gmail_send_message", map[string]interface{}{
+		"to":      "Alice@Example.com",
+		"subject": "Lunch",
+		"body":    "Noon?",
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError, result.Content)
+	assert.Equal(t, 2, sends)
+}
+
+func TestHandleGmailSendDraft_InternalDomains(t *testing.T) {
+	var sends int
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/drafts/send"):
+			sends++
+			_, _ = w.Write([]byte(`{"id": "sent-1"}`))
+		case strings.HasSuffix(r.URL.Path, "/drafts/d-ext"):
+			_, _ = w.Write([]byte(`{"id": "d-ext", "message": {"id": "m1", "payload": {"mimeType": "text/plain", "headers": [
+				{"name": "To", "value": "alice@example.com"},
+				{"name": "Bcc", "value": "Eve <eve@rival.io>"}]}}}`))
+		case strings.HasSuffix(r.URL.Path, "/drafts/d-int"):
+			_, _ = w.Write([]byte(`{"id": "d-int", "message": {"id": "m2", "payload": {"mimeType": "text/plain", "headers": [
+				{"name": "To", "value": "alice@example.com"},
+				{"name": "Cc", "value": "bob@example.com"}]}}}`))
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	srv.internal = map[string]bool{"example.com": true}
+
+	args := map[string]interface{}{"draft_id": "d-ext"}
+	result, err := srv.handleGmailSendDraft(context.Background(), createMockRequest("gmail_send_draft", args))
+	require.NoError(t, err)
+	require.True(t, result.IsError)
+	text := result.Content[0].(mcp.TextContent).Text
+	assert.Contains(t, text, "eve@rival.io", "Bcc recipients are checked too")
+	assert.Contains(t, text, "allow_external=true")
+	assert.Zero(t, sends)
+
+	args["allow_external"] = true
+	result, err = srv.handleGmailSendDraft(context.Background(), createMockRequest("gmail_send_draft", args))
+	require.NoError(t, err)
+	require.False(t, result.IsError, result.Content)
+	assert.Equal(t, 1, sends)
+
+	result, err = srv.handleGmailSendDraft(context.Background(), createMockRequest("gmail_send_draft", map[string]interface{}{"draft_id": "d-int"}))
+	require.NoError(t, err)
+	require.False(t, result.IsError, result.Content)
+	assert.Equal(t, 2, sends)
+}
+
+func TestHandleGmailScheduleSend_InternalDomains(t *testing.T) {
+	t.Setenv("GSUITE_MCP_SCHEDULE_PATH", filepath.Join(t.TempDir(), "scheduled.json"))
+
+	var drafts int
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		drafts++
+		_, _ = w.Write([]byte(`{"id": "draft-1", "message": {"id": "m1"}}`))
+	})
+	srv.internal = map[string]bool{"example.com": true}
+
+	args := map[string]interface{}{
+		"to":      "partner@vendor.io",
+		"subject": "Contract",
+		"body":    "Attached",
+		"send_at": time.Now().Add(time.Hour).Format(time.RFC3339),
+	}
+	result, err := srv.handleGmailScheduleSend(context.Background(), createMockRequest("gmail_schedule_send", args))
+	require.NoError(t, err)
+	require.True(t, result.IsError)
+	assert.Contains(t, result.Content[0].(mcp.TextContent).Text, "partner@vendor.io")
+	assert.Zero(t, drafts, "a refused schedule creates no draft")
+
+	args["allow_external"] = true
+	result, err = srv.handleGmailScheduleSend(context.Background(), createMockRequest("gmail_schedule_send", args))
+	require.NoError(t, err)
+	require.False(t, result.IsError, result.Content)
+	assert.Equal(t, 1, drafts)
+}
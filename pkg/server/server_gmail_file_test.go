@@ -0,0 +1,72 @@
+// ABOUTME: Tests for the gmail_file_message tool
+// ABOUTME: Verifies the label is created when absent and the message is archived under it
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandleGmailFileMessage(t *testing.T) {
+	var createdBody, modifyBody string
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/labels") && r.Method == http.MethodPost:
+			createdBody = string(body)
+			_, _ = w.Write([]byte(`{"id": "Label_9", "name": "Projects/Acme", "type": "user"}`))
+		case strings.HasSuffix(r.URL.Path, "/labels"):
+			_, _ = w.Write([]byte(`{"labels": [{"id": "INBOX", "name": "INBOX"}, {"id": "Label_7", "name": "Projects"}]}`))
+		case strings.HasSuffix(r.URL.Path, "/messages/msg1/modify"):
+			modifyBody = string(body)
+			_, _ = w.Write([]byte(`{"id": "msg1", "labelIds": ["Label_9", "UNREAD"]}`))
+		default:
+			http.NotFound(w, r)
+		}
+	})
+
+	result, err := srv.handleGmailFileMessage(context.Background(), createMockRequest("gmail_file_message", map[string]interface{}{
+		"message_id": "msg1",
+		"label":      "Projects/Acme",
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError, result.Content)
+
+	assert.Contains(t, createdBody, `"name":"Projects/Acme"`)
+
+	var modify struct {
+		AddLabelIds    []string `json:"addLabelIds"`
+		RemoveLabelIds []string `json:"removeLabelIds"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(modifyBody), &modify))
+	assert.Equal(t, []string{"Label_9"}, modify.AddLabelIds)
+	assert.Equal(t, []string{"INBOX"}, modify.RemoveLabelIds)
+
+	var resp FileMessageResponse
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &resp))
+	assert.Equal(t, "Label_9", resp.LabelID)
+	assert.Equal(t, []string{"Projects/Acme"}, resp.CreatedLabels)
+	assert.Equal(t, []string{"Label_9", "UNREAD"}, resp.LabelIDs)
+}
+
+func TestHandleGmailFileMessage_RejectsInbox(t *testing.T) {
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected API call %s %s", r.Method, r.URL.Path)
+	})
+
+	result, err := srv.handleGmailFileMessage(context.Background(), createMockRequest("gmail_file_message", map[string]interface{}{
+		"message_id": "msg1",
+		"label":      "inbox",
+	}))
+	require.NoError(t, err)
+	assert.True(t, result.IsError)
+}
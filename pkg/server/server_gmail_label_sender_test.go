@@ -0,0 +1,97 @@
+// ABOUTME: Tests for the gmail_label_by_sender tool
+// ABOUTME: Verifies the sender query, label creation, the batch modify, and argument checks
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandleGmailLabelBySender(t *testing.T) {
+	var query string
+	var maxResults string
+	var created []string
+	var batches []map[string][]string
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/labels") && r.Method == http.MethodPost:
+			var label struct {
+				Name string `json:"name"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&label)
+			created = append(created, label.Name)
+			_, _ = w.Write([]byte(`{"id": "Label_9", "name": "Important/Boss"}`))
+		case strings.HasSuffix(r.URL.Path, "/labels"):
+			labels := `{"id": "INBOX", "name": "INBOX"}, {"id": "Label_1", "name": "Important"}`
+			if len(created) > 0 {
+				labels += `, {"id": "Label_9", "name": "Important/Boss"}`
+			}
+			_, _ = w.Write([]byte(`{"labels": [` + labels + `]}`))
+		case strings.HasSuffix(r.URL.Path, "/messages"):
+			query = r.URL.Query().Get("q")
+			maxResults = r.URL.Query().Get("maxResults")
+			_, _ = w.Write([]byte(`{"messages": [{"id": "m1"}, {"id": "m2"}]}`))
+		case strings.HasSuffix(r.URL.Path, "/messages/batchModify"):
+			var body map[string][]string
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			batches = append(batches, body)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+
+	result, err := srv.handleGmailLabelBySender(context.Background(), createMockRequest("gmail_label_by_sender", map[string]interface{}{
+		"sender":       "The Boss <boss@example.com>",
+		"label":        "Important/Boss",
+		"max_messages": 25,
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError, result.Content)
+
+	assert.Equal(t, "from:boss@example.com", query)
+	assert.Equal(t, "25", maxResults)
+	assert.Equal(t, []string{"Important/Boss"}, created, "the missing child label is created under the existing parent")
+	require.Len(t, batches, 1)
+	assert.Equal(t, []string{"m1", "m2"}, batches[0]["ids"])
+	assert.Equal(t, []string{"Label_9"}, batches[0]["addLabelIds"])
+	assert.Empty(t, batches[0]["removeLabelIds"], "messages stay in the inbox")
+
+	var resp LabelBySenderResponse
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &resp))
+	assert.Equal(t, "added", resp.Action)
+	assert.Equal(t, 2, resp.Messages)
+	assert.False(t, resp.CapReached)
+
+	// Removing uses the existing label and never creates one
+	result, err = srv.handleGmailLabelBySender(context.Background(), createMockRequest("gmail_label_by_sender", map[string]interface{}{
+		"sender": "boss@example.com",
+		"label":  "important/boss",
+		"remove": true,
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError, result.Content)
+	require.Len(t, batches, 2)
+	assert.Equal(t, []string{"Label_9"}, batches[1]["removeLabelIds"])
+	assert.Len(t, created, 1)
+
+	for _, args := range []map[string]interface{}{
+		{"sender": "", "label": "Important"},
+		{"sender": "boss", "label": "Important"},
+		{"sender": "boss@example.com", "label": "Important", "max_messages": 501},
+		{"sender": "boss@example.com", "label": "Nope", "remove": true},
+	} {
+		result, err := srv.handleGmailLabelBySender(context.Background(), createMockRequest("gmail_label_by_sender", args))
+		require.NoError(t, err)
+		assert.True(t, result.IsError, "args %v", args)
+	}
+	assert.Len(t, batches, 2)
+}
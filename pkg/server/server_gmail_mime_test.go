@@ -0,0 +1,56 @@
+// ABOUTME: End-to-end tests of the MIME the Gmail tools send
+// ABOUTME: Asserts on the raw message recorded by the ish-mode fake client rather than the unit builders
+
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/mail"
+	"strings"
+	"testing"
+
+	"github.com/harper/gsuite-mcp/pkg/auth"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGmailTools_SentMIME(t *testing.T) {
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"id": "m1", "threadId": "t1", "message": {"id": "m1"}}`))
+	})
+	sent := auth.SentMessages(srv.client)
+	require.NotNil(t, sent)
+	ctx := context.Background()
+
+	result, err := srv.handleGmailSendMessage(ctx, createMockRequest("gmail_send_message", map[string]interface{}{
+		"to":       "Jane Smith <jane@example.com>",
+		"subject":  "Quarterly numbers",
+		"body":     "Hi Jane,\n\nNumbers attached.",
+		"priority": "high",
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError, result.Content)
+
+	msg, err := mail.ReadMessage(strings.NewReader(sent.LastSentRaw()))
+	require.NoError(t, err)
+	assert.Equal(t, "Jane Smith <jane@example.com>", msg.Header.Get("To"))
+	assert.Equal(t, "Quarterly numbers", msg.Header.Get("Subject"))
+	assert.Equal(t, "High", msg.Header.Get("Importance"))
+	assert.Contains(t, sent.LastSentRaw(), "Hi Jane,")
+	assert.Contains(t, sent.LastSentRaw(), "Numbers attached.")
+
+	result, err = srv.handleGmailCreateDraft(ctx, createMockRequest("gmail_create_draft", map[string]interface{}{
+		"to":      "bob@example.com",
+		"subject": "Draft only",
+		"body":    "Not sent yet",
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError, result.Content)
+
+	require.Len(t, sent.SentRaws(), 2)
+	msg, err = mail.ReadMessage(strings.NewReader(sent.LastSentRaw()))
+	require.NoError(t, err)
+	assert.Equal(t, "Draft only", msg.Header.Get("Subject"))
+	assert.Empty(t, msg.Header.Get("Importance"))
+}
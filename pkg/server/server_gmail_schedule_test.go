@@ -0,0 +1,85 @@
+// ABOUTME: Tests for the gmail_schedule_send tool
+// ABOUTME: Verifies drafts are created and queued, and invalid send times are rejected before any API call
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/harper/gsuite-mcp/pkg/gmail"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandleGmailScheduleSend_QueuesDraft(t *testing.T) {
+	queuePath := filepath.Join(t.TempDir(), "scheduled.json")
+	t.Setenv("GSUITE_MCP_SCHEDULE_PATH", queuePath)
+
+	var paths []string
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.Method+" "+r.URL.Path)
+		_, _ = w.Write([]byte(`{"id": "draft-1", "message": {"id": "m1"}}`))
+	})
+
+	sendAt := time.Now().Add(24 * time.Hour).UTC().Truncate(time.Second)
+	request := createMockRequest("gmail_schedule_send", map[string]interface{}{
+		"to":      "bob@example.com",
+		"subject": "Tomorrow",
+		"body":    "See you",
+		"send_at": sendAt.Format(time.RFC3339),
+	})
+
+	result, err := srv.handleGmailScheduleSend(context.Background(), request)
+	require.NoError(t, err)
+	require.False(t, result.IsError, result.Content)
+
+	require.Len(t, paths, 1)
+	assert.True(t, strings.HasPrefix(paths[0], "POST ") && strings.HasSuffix(paths[0], "/drafts"), paths[0])
+
+	var resp ScheduleSendResponse
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &resp))
+	assert.Equal(t, "draft-1", resp.DraftID)
+	assert.Contains(t, resp.Message, "send-scheduled")
+
+	queued, err := gmail.NewScheduleQueue(queuePath).List()
+	require.NoError(t, err)
+	require.Len(t, queued, 1)
+	assert.Equal(t, "draft-1", queued[0].DraftID)
+	assert.True(t, sendAt.Equal(queued[0].SendAt))
+	assert.Equal(t, "bob@example.com", queued[0].To)
+}
+
+func TestHandleGmailScheduleSend_InvalidSendAt(t *testing.T) {
+	t.Setenv("GSUITE_MCP_SCHEDULE_PATH", filepath.Join(t.TempDir(), "scheduled.json"))
+
+	called := false
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	})
+
+	for name, sendAt := range map[string]string{
+		"past":        time.Now().Add(-time.Hour).Format(time.RFC3339),
+		"unparseable": "tomorrow at 9",
+	} {
+		t.Run(name, func(t *testing.T) {
+			request := createMockRequest("gmail_schedule_send", map[string]interface{}{
+				"to":      "bob@example.com",
+				"subject": "Hi",
+				"body":    "Body",
+				"send_at": sendAt,
+			})
+
+			result, err := srv.handleGmailScheduleSend(context.Background(), request)
+			require.NoError(t, err)
+			assert.True(t, result.IsError)
+		})
+	}
+	assert.False(t, called, "no draft is created for an invalid send_at")
+}
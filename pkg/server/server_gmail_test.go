@@ -1,12 +1,20 @@
 // ABOUTME: Tests for Gmail-specific MCP server handlers
-// ABOUTME: Validates Gmail label array parameter handling and edge cases
+// ABOUTME: Validates Gmail label array parameter handling, hydration, and edge cases
 
 package server
 
 import (
 	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/harper/gsuite-mcp/pkg/gmail"
+	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -14,7 +22,7 @@ import (
 func TestHandleGmailModifyLabels_InvalidArrayParameters(t *testing.T) {
 	t.Setenv("ISH_MODE", "true")
 
-	srv, err := NewServer(context.Background())
+	srv, err := NewServer(context.Background(), nil)
 	require.NoError(t, err)
 
 	tests := []struct {
@@ -68,7 +76,7 @@ func TestHandleGmailModifyLabels_InvalidArrayParameters(t *testing.T) {
 func TestHandleGmailModifyLabels_MixedTypeArrays(t *testing.T) {
 	t.Setenv("ISH_MODE", "true")
 
-	srv, err := NewServer(context.Background())
+	srv, err := NewServer(context.Background(), nil)
 	require.NoError(t, err)
 
 	tests := []struct {
@@ -139,7 +147,7 @@ func TestHandleGmailModifyLabels_MixedTypeArrays(t *testing.T) {
 func TestHandleGmailModifyLabels_EmptyVsNullArrays(t *testing.T) {
 	t.Setenv("ISH_MODE", "true")
 
-	srv, err := NewServer(context.Background())
+	srv, err := NewServer(context.Background(), nil)
 	require.NoError(t, err)
 
 	tests := []struct {
@@ -211,7 +219,7 @@ func TestHandleGmailModifyLabels_EmptyVsNullArrays(t *testing.T) {
 func TestHandleGmailModifyLabels_LargeLabelArrays(t *testing.T) {
 	t.Setenv("ISH_MODE", "true")
 
-	srv, err := NewServer(context.Background())
+	srv, err := NewServer(context.Background(), nil)
 	require.NoError(t, err)
 
 	tests := []struct {
@@ -272,7 +280,7 @@ func TestHandleGmailModifyLabels_LargeLabelArrays(t *testing.T) {
 func TestHandleGmailModifyLabels_SpecialCharactersInLabels(t *testing.T) {
 	t.Setenv("ISH_MODE", "true")
 
-	srv, err := NewServer(context.Background())
+	srv, err := NewServer(context.Background(), nil)
 	require.NoError(t, err)
 
 	tests := []struct {
@@ -347,7 +355,7 @@ func TestHandleGmailModifyLabels_SpecialCharactersInLabels(t *testing.T) {
 func TestHandleGmailModifyLabels_DuplicateLabels(t *testing.T) {
 	t.Setenv("ISH_MODE", "true")
 
-	srv, err := NewServer(context.Background())
+	srv, err := NewServer(context.Background(), nil)
 	require.NoError(t, err)
 
 	tests := []struct {
@@ -419,7 +427,7 @@ func TestHandleGmailModifyLabels_DuplicateLabels(t *testing.T) {
 func TestHandleGmailModifyLabels_NonArrayTypes(t *testing.T) {
 	t.Setenv("ISH_MODE", "true")
 
-	srv, err := NewServer(context.Background())
+	srv, err := NewServer(context.Background(), nil)
 	require.NoError(t, err)
 
 	tests := []struct {
@@ -486,7 +494,7 @@ func TestHandleGmailModifyLabels_NonArrayTypes(t *testing.T) {
 func TestHandleGmailListMessages_WithHydrate(t *testing.T) {
 	t.Setenv("ISH_MODE", "true")
 
-	srv, err := NewServer(context.Background())
+	srv, err := NewServer(context.Background(), nil)
 	require.NoError(t, err)
 
 	tests := []struct {
@@ -542,7 +550,7 @@ func TestHandleGmailListMessages_WithHydrate(t *testing.T) {
 func TestHandleGmailModifyLabels_SuccessfulCases(t *testing.T) {
 	t.Setenv("ISH_MODE", "true")
 
-	srv, err := NewServer(context.Background())
+	srv, err := NewServer(context.Background(), nil)
 	require.NoError(t, err)
 
 	tests := []struct {
@@ -606,3 +614,400 @@ func TestHandleGmailModifyLabels_SuccessfulCases(t *testing.T) {
 		})
 	}
 }
+
+func TestHandleGmailListMessages_HydrationOrderAndPartialFailure(t *testing.T) {
+	ids := []string{"m1", "m2", "m3", "m4", "m5"}
+	position := make(map[string]int)
+	for i, id := range ids {
+		position[id] = i
+	}
+	var formats sync.Map
+
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/messages") {
+			list := make([]map[string]string, len(ids))
+			for i, id := range ids {
+				list[i] = map[string]string{"id": id, "threadId": "t-" + id}
+			}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"messages": list})
+			return
+		}
+
+		id := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+		formats.Store(id, r.URL.Query().Get("format"))
+
+		// Finish later messages first so completion order differs from list order
+		time.Sleep(time.Duration(len(ids)-position[id]) * 5 * time.Millisecond)
+
+		if id == "m3" {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error": {"code": 404, "message": "not found"}}`))
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"id":       id,
+			"threadId": "t-" + id,
+			"snippet":  "snippet " + id,
+			"payload": map[string]interface{}{
+				"headers": []map[string]string{{"name": "Subject", "value": "Subject " + id}},
+			},
+		})
+	})
+
+	request := createMockRequest("gmail_list_messages", map[string]interface{}{
+		"hydrate": true,
+	})
+
+	result, err := srv.handleGmailListMessages(context.Background(), request)
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var resp ListMessagesResponse
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &resp))
+	require.Len(t, resp.Messages, len(ids))
+
+	for i, id := range ids {
+		msg := resp.Messages[i]
+		assert.Equal(t, id, msg.ID, "results must keep list order")
+		assert.Equal(t, "t-"+id, msg.ThreadID)
+		if id == "m3" {
+			assert.Empty(t, msg.Subject, "failed fetch should fall back to basic info")
+			continue
+		}
+		assert.Equal(t, "Subject "+id, msg.Subject)
+
+		format, _ := formats.Load(id)
+		assert.Equal(t, "metadata", format)
+	}
+}
+
+func TestHandleGmailListMessages_DateRange(t *testing.T) {
+	var queries []string
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.Query().Get("q"))
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"messages": []interface{}{}})
+	})
+	srv.loc = time.UTC
+
+	tests := []struct {
+		name     string
+		args     map[string]interface{}
+		expected string
+	}{
+		{
+			name:     "RFC3339 bounds merge with query",
+			args:     map[string]interface{}{"query": "from:boss@example.com", "after": "2024-01-01T00:00:00Z", "before": "2024-02-01T00:00:00Z"},
+			expected: "from:boss@example.com after:1704067200 before:1706745600",
+		},
+		{
+			name:     "date-only uses server timezone midnight",
+			args:     map[string]interface{}{"after": "2024-01-01"},
+			expected: "after:1704067200",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			queries = nil
+			result, err := srv.handleGmailListMessages(context.Background(), createMockRequest("gmail_list_messages", tt.args))
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+			assert.Equal(t, []string{tt.expected}, queries)
+		})
+	}
+
+	invalid := []map[string]interface{}{
+		{"after": "last tuesday"},
+		{"after": "2024-02-01", "before": "2024-01-01"},
+	}
+	for _, args := range invalid {
+		queries = nil
+		result, err := srv.handleGmailListMessages(context.Background(), createMockRequest("gmail_list_messages", args))
+		require.NoError(t, err)
+		assert.True(t, result.IsError, args)
+		assert.Empty(t, queries, "invalid dates must not reach the API")
+	}
+}
+
+func TestHandleGmailMessages_ResolveLabels(t *testing.T) {
+	var mu sync.Mutex
+	labelCalls := 0
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/labels"):
+			mu.Lock()
+			labelCalls++
+			mu.Unlock()
+			_, _ = w.Write([]byte(`{"labels": [{"id": "INBOX", "name": "INBOX"}, {"id": "Label_42", "name": "Receipts"}]}`))
+		case strings.HasSuffix(r.URL.Path, "/messages"):
+			_, _ = w.Write([]byte(`{"messages": [{"id": "m1", "threadId": "t1"}, {"id": "m2", "threadId": "t2"}]}`))
+		default:
+			id := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+			_, _ = w.Write([]byte(`{"id": "` + id + `", "threadId": "t", "labelIds": ["INBOX", "Label_42"]}`))
+		}
+	})
+
+	list := createMockRequest("gmail_list_messages", map[string]interface{}{
+		"hydrate":        true,
+		"resolve_labels": true,
+	})
+	result, err := srv.handleGmailListMessages(context.Background(), list)
+	require.NoError(t, err)
+	require.False(t, result.IsError, result.Content)
+
+	var resp ListMessagesResponse
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &resp))
+	require.Len(t, resp.Messages, 2)
+	for _, msg := range resp.Messages {
+		assert.Equal(t, []string{"INBOX", "Label_42"}, msg.LabelIDs)
+		assert.Equal(t, []string{"INBOX", "Receipts"}, msg.Labels)
+	}
+
+	get := createMockRequest("gmail_get_message", map[string]interface{}{
+		"message_id":     "m1",
+		"resolve_labels": true,
+	})
+	result, err = srv.handleGmailGetMessage(context.Background(), get)
+	require.NoError(t, err)
+	require.False(t, result.IsError, result.Content)
+
+	var msg map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &msg))
+	assert.Equal(t, []interface{}{"INBOX", "Label_42"}, msg["labelIds"])
+	assert.Equal(t, []interface{}{"INBOX", "Receipts"}, msg["labels"])
+	assert.Contains(t, msg, "parsed", "the decoded view sits next to the API message")
+
+	assert.Equal(t, 1, labelCalls, "label list is fetched once per session")
+}
+
+func TestHandleGmailGetMessage_UndecodableBody(t *testing.T) {
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"id": "m1", "threadId": "t1", "payload": {"mimeType": "text/plain",
+			"headers": [{"name": "Subject", "value": "Garbled"}], "body": {"data": "!!not base64!!"}}}`))
+	})
+
+	result, err := srv.handleGmailGetMessage(context.Background(), createMockRequest("gmail_get_message", map[string]interface{}{
+		"message_id": "m1",
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError, result.Content)
+
+	var msg map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &msg))
+	assert.Equal(t, "m1", msg["id"], "the raw message is still returned")
+	assert.NotContains(t, msg, "parsed")
+	require.Len(t, result.Content, 2)
+	assert.Contains(t, result.Content[1].(mcp.TextContent).Text, "warning: unable to decode message")
+}
+
+func TestHandleGmailSearchDrafts(t *testing.T) {
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/drafts") {
+			_, _ = w.Write([]byte(`{"drafts": [{"id": "d1"}, {"id": "d2"}]}`))
+			return
+		}
+		id := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+		subject := map[string]string{"d1": "Contract redlines", "d2": "Lunch?"}[id]
+		_, _ = w.Write([]byte(`{"id": "` + id + `", "message": {"id": "m-` + id + `", "payload": {"headers": [{"name": "Subject", "value": "` + subject + `"}]}}}`))
+	})
+
+	search := func(args map[string]interface{}) SearchDraftsResponse {
+		result, err := srv.handleGmailSearchDrafts(context.Background(), createMockRequest("gmail_search_drafts", args))
+		require.NoError(t, err)
+		require.False(t, result.IsError, result.Content)
+		var resp SearchDraftsResponse
+		require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &resp))
+		return resp
+	}
+
+	resp := search(map[string]interface{}{"query": "REDLINES"})
+	require.Equal(t, 1, resp.Count)
+	assert.Equal(t, "d1", resp.Drafts[0].DraftID)
+	assert.Equal(t, 2, resp.Scanned)
+	assert.False(t, resp.CapReached)
+
+	resp = search(map[string]interface{}{"query": "budget", "max_scan": 2})
+	assert.Equal(t, 0, resp.Count)
+	assert.NotNil(t, resp.Drafts)
+	assert.True(t, resp.CapReached)
+
+	for _, args := range []map[string]interface{}{{"query": "  "}, {"query": "x", "max_scan": 0}} {
+		result, err := srv.handleGmailSearchDrafts(context.Background(), createMockRequest("gmail_search_drafts", args))
+		require.NoError(t, err)
+		assert.True(t, result.IsError, "args %v", args)
+	}
+}
+
+func TestHandleGmailSignature(t *testing.T) {
+	var patchedPath, patchedBody string
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/profile"):
+			_, _ = w.Write([]byte(`{"emailAddress": "me@example.com"}`))
+		case r.Method == http.MethodPatch:
+			body, _ := io.ReadAll(r.Body)
+			patchedPath, patchedBody = r.URL.Path, string(body)
+			_, _ = w.Write([]byte(`{"sendAsEmail": "support@example.com", "signature": "<p>Support</p>"}`))
+		default:
+			_, _ = w.Write([]byte(`{"sendAsEmail": "me@example.com", "isPrimary": true, "signature": "<b>Me</b>"}`))
+		}
+	})
+	ctx := context.Background()
+
+	result, err := srv.handleGmailGetSignature(ctx, createMockRequest("gmail_get_signature", map[string]interface{}{}))
+	require.NoError(t, err)
+	require.False(t, result.IsError, result.Content)
+	var sig gmail.Signature
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &sig))
+	assert.Equal(t, gmail.Signature{SendAsEmail: "me@example.com", IsPrimary: true, Signature: "<b>Me</b>"}, sig)
+
+	result, err = srv.handleGmailSetSignature(ctx, createMockRequest("gmail_set_signature", map[string]interface{}{
+		"send_as_email": "support@example.com",
+		"signature":     "<p>Support</p>",
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError, result.Content)
+	assert.True(t, strings.HasSuffix(patchedPath, "/settings/sendAs/support@example.com"), patchedPath)
+	assert.JSONEq(t, `{"signature": "<p>Support</p>"}`, patchedBody)
+
+	result, err = srv.handleGmailSetSignature(ctx, createMockRequest("gmail_set_signature", map[string]interface{}{"signature": ""}))
+	require.NoError(t, err)
+	assert.True(t, result.IsError)
+}
+
+func TestHandleGmailFixDraftThreading_RequiresBothIDs(t *testing.T) {
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected API call %s", r.URL.Path)
+	})
+
+	for _, args := range []map[string]interface{}{{"draft_id": "draft-1"}, {"original_message_id": "orig-1"}} {
+		result, err := srv.handleGmailFixDraftThreading(context.Background(), createMockRequest("gmail_fix_draft_threading", args))
+		require.NoError(t, err)
+		assert.True(t, result.IsError, "args %v", args)
+	}
+}
+
+func TestHandleGmailThreadParticipants(t *testing.T) {
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/threads/thread-9") {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error": {"code": 404, "message": "Requested entity was not found."}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"id": "thread-9", "messages": [
+			{"id": "m1", "payload": {"headers": [{"name": "From", "value": "Alice <alice@example.com>"}, {"name": "To", "value": "bob@example.com"}]}},
+			{"id": "m2", "payload": {"headers": [{"name": "From", "value": "Bob <BOB@example.com>"}, {"name": "To", "value": "Alice <alice@example.com>"}]}}
+		]}`))
+	})
+
+	result, err := srv.handleGmailThreadParticipants(context.Background(), createMockRequest("gmail_thread_participants", map[string]interface{}{"thread_id": "thread-9"}))
+	require.NoError(t, err)
+	require.False(t, result.IsError, result.Content)
+
+	var resp gmail.ThreadParticipants
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &resp))
+	assert.Equal(t, 2, resp.MessageCount)
+	require.Len(t, resp.Participants, 2)
+	assert.Equal(t, "bob@example.com", resp.Participants[1].Email)
+	assert.Equal(t, "Bob", resp.Participants[1].Name)
+
+	result, err = srv.handleGmailThreadParticipants(context.Background(), createMockRequest("gmail_thread_participants", map[string]interface{}{"thread_id": "gone"}))
+	require.NoError(t, err)
+	require.True(t, result.IsError)
+	assert.Contains(t, result.Content[0].(mcp.TextContent).Text, "thread gone not found")
+}
+
+func TestHandleGmailGetProfile(t *testing.T) {
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.True(t, strings.HasSuffix(r.URL.Path, "/users/me/profile"), r.URL.Path)
+		_, _ = w.Write([]byte(`{"emailAddress": "me@example.com", "messagesTotal": 1234, "threadsTotal": 567, "historyId": "98765"}`))
+	})
+
+	getProfile := func() ProfileResponse {
+		result, err := srv.handleGmailGetProfile(context.Background(), createMockRequest("gmail_get_profile", map[string]interface{}{}))
+		require.NoError(t, err)
+		require.False(t, result.IsError, result.Content)
+		var resp ProfileResponse
+		require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &resp))
+		return resp
+	}
+
+	simulated := getProfile()
+	assert.True(t, simulated.Simulated)
+	assert.NotEmpty(t, simulated.EmailAddress)
+
+	// Leaving ISH mode after construction sends the call to the test API
+	t.Setenv("ISH_MODE", "false")
+	assert.Equal(t, ProfileResponse{
+		EmailAddress:  "me@example.com",
+		MessagesTotal: 1234,
+		ThreadsTotal:  567,
+		HistoryID:     98765,
+	}, getProfile())
+}
+
+func TestHandleGmailListMessages_GroupByThread(t *testing.T) {
+	listed := []map[string]string{
+		{"id": "m1", "threadId": "tA"},
+		{"id": "m2", "threadId": "tB"},
+		{"id": "m3", "threadId": "tA"},
+		{"id": "m4", "threadId": "tC"},
+	}
+	dates := map[string]string{"m1": "1000", "m2": "3000", "m3": "2000", "m4": "500"}
+
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/messages") {
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"messages": listed})
+			return
+		}
+		id := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+		for _, msg := range listed {
+			if msg["id"] == id {
+				_ = json.NewEncoder(w).Encode(map[string]interface{}{"id": id, "threadId": msg["threadId"], "internalDate": dates[id]})
+				return
+			}
+		}
+		http.NotFound(w, r)
+	})
+
+	threadOrder := func(resp ListThreadsResponse) []string {
+		var order []string
+		for _, thread := range resp.Threads {
+			order = append(order, thread.ThreadID)
+		}
+		return order
+	}
+
+	result, err := srv.handleGmailListMessages(context.Background(), createMockRequest("gmail_list_messages", map[string]interface{}{
+		"hydrate":         true,
+		"group_by_thread": true,
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var resp ListThreadsResponse
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &resp))
+	assert.Equal(t, []string{"tB", "tA", "tC"}, threadOrder(resp), "threads are ordered by their newest message")
+	assert.Equal(t, 3, resp.Count)
+	assert.Equal(t, 4, resp.MessageCount)
+	require.Len(t, resp.Threads[1].Messages, 2)
+	assert.Equal(t, "m1", resp.Threads[1].Messages[0].ID)
+	assert.Equal(t, "m3", resp.Threads[1].Messages[1].ID)
+	assert.Equal(t, 2, resp.Threads[1].Count)
+
+	// Without hydration there are no dates, so threads keep list order
+	result, err = srv.handleGmailListMessages(context.Background(), createMockRequest("gmail_list_messages", map[string]interface{}{
+		"group_by_thread": true,
+	}))
+	require.NoError(t, err)
+	resp = ListThreadsResponse{}
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &resp))
+	assert.Equal(t, []string{"tA", "tB", "tC"}, threadOrder(resp))
+
+	// The flag off keeps the flat list
+	result, err = srv.handleGmailListMessages(context.Background(), createMockRequest("gmail_list_messages", nil))
+	require.NoError(t, err)
+	var flat ListMessagesResponse
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &flat))
+	assert.Len(t, flat.Messages, 4)
+}
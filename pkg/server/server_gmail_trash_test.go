@@ -0,0 +1,215 @@
+// ABOUTME: Tests for the gmail_trash_by_query, gmail_modify_labels_by_query, and gmail_recover_from_trash tools
+// ABOUTME: Verifies the message caps and that matches move via batch modify, never deleted
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandleGmailTrashByQuery(t *testing.T) {
+	var requests []string
+	var listMax string
+	var batch struct {
+		IDs    []string `json:"ids"`
+		Add    []string `json:"addLabelIds"`
+		Remove []string `json:"removeLabelIds"`
+	}
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		switch {
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/messages"):
+			listMax = r.URL.Query().Get("maxResults")
+			_, _ = w.Write([]byte(`{"messages": [{"id": "m1"}, {"id": "m2"}, {"id": "m3"}]}`))
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/messages/batchModify"):
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&batch))
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+
+	result, err := srv.handleGmailTrashByQuery(context.Background(), createMockRequest("gmail_trash_by_query", map[string]interface{}{
+		"query":        "from:newsletter@example.com older_than:1y",
+		"max_messages": 3,
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError, result.Content)
+
+	assert.Equal(t, "3", listMax)
+	assert.Equal(t, []string{"m1", "m2", "m3"}, batch.IDs)
+	assert.Equal(t, []string{"TRASH"}, batch.Add)
+	assert.Equal(t, []string{"INBOX"}, batch.Remove)
+	for _, req := range requests {
+		assert.NotContains(t, req, "DELETE", "messages are never permanently deleted")
+		assert.NotContains(t, req, "batchDelete")
+	}
+
+	var resp TrashByQueryResponse
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &resp))
+	assert.Equal(t, "from:newsletter@example.com older_than:1y", resp.Query)
+	assert.Equal(t, 3, resp.Trashed)
+	assert.True(t, resp.CapReached)
+}
+
+func TestHandleGmailTrashByQuery_Limits(t *testing.T) {
+	called := false
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	})
+
+	tests := map[string]map[string]interface{}{
+		"empty query":       {"query": "  ", "max_messages": 10},
+		"missing cap":       {"query": "label:old"},
+		"zero cap":          {"query": "label:old", "max_messages": 0},
+		"cap above ceiling": {"query": "label:old", "max_messages": maxTrashByQuery + 1},
+	}
+	for name, args := range tests {
+		t.Run(name, func(t *testing.T) {
+			result, err := srv.handleGmailTrashByQuery(context.Background(), createMockRequest("gmail_trash_by_query", args))
+			require.NoError(t, err)
+			assert.True(t, result.IsError)
+		})
+	}
+	assert.False(t, called, "nothing is listed or trashed when the arguments are rejected")
+
+	result, err := srv.handleGmailTrashByQuery(context.Background(), createMockRequest("gmail_trash_by_query", map[string]interface{}{
+		"query": "label:old", "max_messages": maxTrashByQuery + 1,
+	}))
+	require.NoError(t, err)
+	assert.Contains(t, result.Content[0].(mcp.TextContent).Text, fmt.Sprint(maxTrashByQuery))
+}
+
+func TestHandleGmailModifyLabelsByQuery(t *testing.T) {
+	var listQuery string
+	var batch struct {
+		IDs    []string `json:"ids"`
+		Add    []string `json:"addLabelIds"`
+		Remove []string `json:"removeLabelIds"`
+	}
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/messages"):
+			listQuery = r.URL.Query().Get("q")
+			_, _ = w.Write([]byte(`{"messages": [{"id": "m1"}, {"id": "m2"}]}`))
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/messages/batchModify"):
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&batch))
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+
+	result, err := srv.handleGmailModifyLabelsByQuery(context.Background(), createMockRequest("gmail_modify_labels_by_query", map[string]interface{}{
+		"query":         "category:promotions -is:starred",
+		"remove_labels": []interface{}{"INBOX"},
+		"max_messages":  50,
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError, result.Content)
+
+	assert.Equal(t, "category:promotions -is:starred", listQuery)
+	assert.Equal(t, []string{"m1", "m2"}, batch.IDs)
+	assert.Empty(t, batch.Add)
+	assert.Equal(t, []string{"INBOX"}, batch.Remove)
+
+	var resp ModifyByQueryResponse
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &resp))
+	assert.Equal(t, 2, resp.Modified)
+	assert.False(t, resp.CapReached)
+}
+
+func TestHandleGmailModifyLabelsByQuery_Limits(t *testing.T) {
+	called := false
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	})
+
+	tests := map[string]map[string]interface{}{
+		"empty query":       {"query": " ", "remove_labels": []interface{}{"INBOX"}, "max_messages": 10},
+		"no labels":         {"query": "label:old", "max_messages": 10},
+		"adds trash":        {"query": "label:old", "add_labels": []interface{}{"TRASH"}, "max_messages": 10},
+		"missing cap":       {"query": "label:old", "remove_labels": []interface{}{"INBOX"}},
+		"cap above ceiling": {"query": "label:old", "remove_labels": []interface{}{"INBOX"}, "max_messages": maxModifyByQuery + 1},
+	}
+	for name, args := range tests {
+		t.Run(name, func(t *testing.T) {
+			result, err := srv.handleGmailModifyLabelsByQuery(context.Background(), createMockRequest("gmail_modify_labels_by_query", args))
+			require.NoError(t, err)
+			assert.True(t, result.IsError)
+		})
+	}
+	assert.False(t, called, "nothing is listed or changed when the arguments are rejected")
+}
+
+func TestHandleGmailRecoverFromTrash(t *testing.T) {
+	var listQuery string
+	var batches int
+	var batch struct {
+		IDs    []string `json:"ids"`
+		Add    []string `json:"addLabelIds"`
+		Remove []string `json:"removeLabelIds"`
+	}
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/messages"):
+			listQuery = r.URL.Query().Get("q")
+			_, _ = w.Write([]byte(`{"messages": [{"id": "m1"}, {"id": "m2"}]}`))
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/messages/batchModify"):
+			batches++
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&batch))
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+
+	result, err := srv.handleGmailRecoverFromTrash(context.Background(), createMockRequest("gmail_recover_from_trash", map[string]interface{}{
+		"query":        "from:boss@example.com OR from:hr@example.com",
+		"max_messages": 10,
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError, result.Content)
+
+	assert.Equal(t, "in:trash (from:boss@example.com OR from:hr@example.com)", listQuery)
+	assert.Equal(t, 1, batches)
+	assert.Equal(t, []string{"m1", "m2"}, batch.IDs)
+	assert.Equal(t, []string{"INBOX"}, batch.Add)
+	assert.Equal(t, []string{"TRASH"}, batch.Remove)
+
+	var resp RecoverResponse
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &resp))
+	assert.Equal(t, 2, resp.Recovered)
+	assert.False(t, resp.CapReached)
+
+	result, err = srv.handleGmailRecoverFromTrash(context.Background(), createMockRequest("gmail_recover_from_trash", map[string]interface{}{
+		"from":         "spam",
+		"max_messages": 2,
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError, result.Content)
+	assert.Equal(t, "in:spam", listQuery)
+	assert.Equal(t, []string{"SPAM"}, batch.Remove)
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &resp))
+	assert.True(t, resp.CapReached)
+
+	for name, args := range map[string]map[string]interface{}{
+		"missing cap":       {},
+		"cap above ceiling": {"max_messages": maxRecoverMessages + 1},
+		"unknown source":    {"from": "archive", "max_messages": 5},
+	} {
+		result, err := srv.handleGmailRecoverFromTrash(context.Background(), createMockRequest("gmail_recover_from_trash", args))
+		require.NoError(t, err)
+		assert.True(t, result.IsError, name)
+	}
+	assert.Equal(t, 2, batches, "rejected arguments change nothing")
+}
@@ -0,0 +1,121 @@
+// ABOUTME: Tests for the people_interaction_history tool
+// ABOUTME: Verifies mail and meetings with the contact are gathered and the last interaction is the newest of both
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandlePeopleInteractionHistory(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Second)
+	received := now.Add(-72 * time.Hour)
+	sent := now.Add(-96 * time.Hour)
+	met := now.Add(-24 * time.Hour)
+	upcoming := now.Add(48 * time.Hour)
+
+	var mailQuery, eventQuery string
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/messages"):
+			mailQuery = r.URL.Query().Get("q")
+			_, _ = w.Write([]byte(`{"messages": [{"id": "m1", "threadId": "t1"}, {"id": "m2", "threadId": "t2"}, {"id": "m3", "threadId": "t3"}]}`))
+		case strings.HasSuffix(r.URL.Path, "/profile"):
+			_, _ = w.Write([]byte(`{"emailAddress": "me@example.com"}`))
+		case strings.HasSuffix(r.URL.Path, "/messages/m1"):
+			fmt.Fprintf(w, `{"id": "m1", "threadId": "t1", "internalDate": "%d", "payload": {"headers": [
+				{"name": "From", "value": "Alice <Alice@acme.com>"}, {"name": "Subject", "value": "Contract"}]}}`, received.UnixMilli())
+		case strings.HasSuffix(r.URL.Path, "/messages/m2"):
+			fmt.Fprintf(w, `{"id": "m2", "threadId": "t2", "internalDate": "%d", "payload": {"headers": [
+				{"name": "From", "value": "Me <ME@example.com>"}, {"name": "Subject", "value": "Intro"}]}}`, sent.UnixMilli())
+		case strings.HasSuffix(r.URL.Path, "/messages/m3"):
+			fmt.Fprintf(w, `{"id": "m3", "threadId": "t3", "internalDate": "%d", "payload": {"headers": [
+				{"name": "From", "value": "bob@example.com"}, {"name": "Cc", "value": "alice@acme.com"}, {"name": "Subject", "value": "FYI"}]}}`, sent.Add(-time.Hour).UnixMilli())
+		case strings.HasSuffix(r.URL.Path, "/events"):
+			eventQuery = r.URL.Query().Get("q")
+			fmt.Fprintf(w, `{"items": [
+				{"id": "e1", "summary": "Kickoff", "start": {"dateTime": %q}, "end": {"dateTime": %q}, "attendees": [{"email": "alice@acme.com"}]},
+				{"id": "e2", "summary": "Prep for alice@acme.com call", "start": {"dateTime": %q}, "end": {"dateTime": %q}},
+				{"id": "e3", "summary": "Review", "start": {"dateTime": %q}, "end": {"dateTime": %q}, "organizer": {"email": "alice@acme.com"}}
+			]}`, met.Format(time.RFC3339), met.Add(time.Hour).Format(time.RFC3339),
+				met.Add(time.Hour).Format(time.RFC3339), met.Add(2*time.Hour).Format(time.RFC3339),
+				upcoming.Format(time.RFC3339), upcoming.Add(time.Hour).Format(time.RFC3339))
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	srv.loc = time.UTC
+
+	result, err := srv.handlePeopleInteractionHistory(context.Background(), createMockRequest("people_interaction_history", map[string]interface{}{
+		"email": "alice@acme.com",
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError, result.Content)
+	require.Len(t, result.Content, 1, "no warnings when both sources answer")
+
+	var history InteractionHistory
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &history))
+	assert.Equal(t, "from:alice@acme.com OR to:alice@acme.com", mailQuery)
+	assert.Equal(t, "alice@acme.com", eventQuery)
+
+	require.Len(t, history.Emails, 3)
+	assert.Equal(t, "received", history.Emails[0].Direction)
+	assert.Equal(t, "Contract", history.Emails[0].Subject)
+	assert.Equal(t, "sent", history.Emails[1].Direction)
+	assert.Equal(t, "other", history.Emails[2].Direction, "a third party's message isn't ours")
+
+	require.Len(t, history.PastMeetings, 1, "events that only mention the contact are left out")
+	assert.Equal(t, "e1", history.PastMeetings[0].EventID)
+	require.Len(t, history.UpcomingMeetings, 1)
+	assert.Equal(t, "e3", history.UpcomingMeetings[0].EventID)
+
+	assert.Equal(t, met.Format(time.RFC3339), history.LastInteraction, "the meeting is newer than any mail")
+	assert.Equal(t, "meeting", history.LastInteractionSource)
+}
+
+func TestHandlePeopleInteractionHistory_NoHistory(t *testing.T) {
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/messages"):
+			_, _ = w.Write([]byte(`{}`))
+		case strings.HasSuffix(r.URL.Path, "/events"):
+			_, _ = w.Write([]byte(`{"items": []}`))
+		default:
+			http.NotFound(w, r)
+		}
+	})
+
+	result, err := srv.handlePeopleInteractionHistory(context.Background(), createMockRequest("people_interaction_history", map[string]interface{}{
+		"email": "stranger@example.com",
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError, result.Content)
+
+	var history InteractionHistory
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &history))
+	assert.Empty(t, history.Emails)
+	assert.Empty(t, history.PastMeetings)
+	assert.Empty(t, history.UpcomingMeetings)
+	assert.Empty(t, history.LastInteraction)
+	assert.Contains(t, result.Content[0].(mcp.TextContent).Text, `"emails":[]`, "empty lists, not null")
+
+	for _, args := range []map[string]interface{}{
+		{"email": "not an address"},
+		{"email": "a@example.com", "max_emails": maxHistoryItems + 1},
+		{"email": "a@example.com", "days_back": 0},
+	} {
+		result, err := srv.handlePeopleInteractionHistory(context.Background(), createMockRequest("people_interaction_history", args))
+		require.NoError(t, err)
+		assert.True(t, result.IsError, args)
+	}
+}
@@ -0,0 +1,760 @@
+// ABOUTME: Tests for People-specific MCP server handlers
+// ABOUTME: Validates contact creation, notes, deletion safeguards, resource name checks, search options, group members, and vCard import/export
+
+package server
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/harper/gsuite-mcp/pkg/people"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	googlepeople "google.golang.org/api/people/v1"
+)
+
+func TestHandlePeopleDeleteContact_UnconfirmedDoesNotDelete(t *testing.T) {
+	var methods []string
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		methods = append(methods, r.Method)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"resourceName":   "people/c12345",
+			"names":          []map[string]string{{"displayName": "Jane Doe"}},
+			"emailAddresses": []map[string]string{{"value": "jane@example.com"}},
+		})
+	})
+
+	request := createMockRequest("people_delete_contact", map[string]interface{}{
+		"resource_name": "people/c12345",
+	})
+
+	result, err := srv.handlePeopleDeleteContact(context.Background(), request)
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	assert.Equal(t, []string{http.MethodGet}, methods, "unconfirmed delete must only read the contact")
+
+	text := result.Content[0].(mcp.TextContent).Text
+	var resp DeleteContactPreviewResponse
+	require.NoError(t, json.Unmarshal([]byte(text), &resp))
+	assert.False(t, resp.Deleted)
+	assert.Equal(t, "Jane Doe", resp.Contact.Name)
+	assert.Equal(t, []string{"jane@example.com"}, resp.Contact.Emails)
+	assert.Contains(t, resp.Message, "confirm=true")
+}
+
+func TestHandlePeopleDeleteContact_ConfirmedDeletes(t *testing.T) {
+	var methods []string
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		methods = append(methods, r.Method)
+		_, _ = w.Write([]byte("{}"))
+	})
+
+	request := createMockRequest("people_delete_contact", map[string]interface{}{
+		"resource_name": "people/c12345",
+		"confirm":       true,
+	})
+
+	result, err := srv.handlePeopleDeleteContact(context.Background(), request)
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	assert.Equal(t, []string{http.MethodDelete}, methods)
+}
+
+func TestHandlePeopleDeleteContact_MalformedResourceName(t *testing.T) {
+	called := false
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	})
+
+	for _, name := range []string{"people/me", "c12345", "people/", "people/../admin", "contacts/c12345"} {
+		t.Run(name, func(t *testing.T) {
+			request := createMockRequest("people_delete_contact", map[string]interface{}{
+				"resource_name": name,
+				"confirm":       true,
+			})
+
+			result, err := srv.handlePeopleDeleteContact(context.Background(), request)
+			require.NoError(t, err)
+			assert.True(t, result.IsError)
+		})
+	}
+
+	assert.False(t, called, "malformed resource names must be rejected before any API call")
+}
+
+func TestHandlePeopleSearchContacts_FieldsAndNameSort(t *testing.T) {
+	var readMask string
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		readMask = r.URL.Query().Get("readMask")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"results": []map[string]interface{}{
+				{"person": map[string]interface{}{"resourceName": "people/c3", "names": []map[string]string{{"displayName": "Charlie"}}}},
+				{"person": map[string]interface{}{"resourceName": "people/c1", "names": []map[string]string{{"displayName": "alice"}}}},
+				{"person": map[string]interface{}{"resourceName": "people/c2", "names": []map[string]string{{"displayName": "Bob"}}}},
+			},
+		})
+	})
+
+	request := createMockRequest("people_search_contacts", map[string]interface{}{
+		"query":  "example",
+		"fields": "names, organizations",
+		"sort":   "name",
+	})
+
+	result, err := srv.handlePeopleSearchContacts(context.Background(), request)
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	assert.Equal(t, "names,organizations", readMask)
+
+	var resp struct {
+		Contacts []struct {
+			ResourceName string `json:"resourceName"`
+		} `json:"contacts"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &resp))
+	require.Len(t, resp.Contacts, 3)
+	assert.Equal(t, "people/c1", resp.Contacts[0].ResourceName)
+	assert.Equal(t, "people/c2", resp.Contacts[1].ResourceName)
+	assert.Equal(t, "people/c3", resp.Contacts[2].ResourceName)
+}
+
+func TestHandlePeopleSearchContacts_NameSortAddsNames(t *testing.T) {
+	var readMask string
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		readMask = r.URL.Query().Get("readMask")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"results": []map[string]interface{}{
+				{"person": map[string]interface{}{"resourceName": "people/c2", "names": []map[string]string{{"displayName": "Bob"}}}},
+				{"person": map[string]interface{}{"resourceName": "people/c1", "names": []map[string]string{{"displayName": "Alice"}}}},
+			},
+		})
+	})
+
+	result, err := srv.handlePeopleSearchContacts(context.Background(), createMockRequest("people_search_contacts", map[string]interface{}{
+		"query":  "example",
+		"fields": "emailAddresses",
+		"sort":   "name",
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError, result.Content)
+	assert.Equal(t, "emailAddresses,names", readMask)
+	assert.Less(t, strings.Index(result.Content[0].(mcp.TextContent).Text, "people/c1"), strings.Index(result.Content[0].(mcp.TextContent).Text, "people/c2"))
+
+	_, err = srv.handlePeopleSearchContacts(context.Background(), createMockRequest("people_search_contacts", map[string]interface{}{
+		"query":  "example",
+		"fields": "emailAddresses",
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, "emailAddresses", readMask, "relevance order leaves the mask alone")
+}
+
+func TestHandlePeopleSearchContacts_SearchOther(t *testing.T) {
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "people:searchContacts"):
+			_, _ = w.Write([]byte(`{"results": [{"person": {"resourceName": "people/c1", "emailAddresses": [{"value": "jane@acme.com"}]}}]}`))
+		case strings.HasSuffix(r.URL.Path, "otherContacts:search"):
+			_, _ = w.Write([]byte(`{"results": [
+				{"person": {"resourceName": "otherContacts/o1", "emailAddresses": [{"value": "Jane@Acme.com"}]}},
+				{"person": {"resourceName": "otherContacts/o2", "emailAddresses": [{"value": "jane.doe@example.com"}]}}
+			]}`))
+		default:
+			http.NotFound(w, r)
+		}
+	})
+
+	result, err := srv.handlePeopleSearchContacts(context.Background(), createMockRequest("people_search_contacts", map[string]interface{}{
+		"query":        "jane",
+		"search_other": true,
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError, result.Content)
+
+	var resp struct {
+		Contacts []struct {
+			Source string `json:"source"`
+			Person struct {
+				ResourceName string `json:"resourceName"`
+			} `json:"person"`
+		} `json:"contacts"`
+		Count int `json:"count"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &resp))
+	require.Equal(t, 2, resp.Count, "the other-contact matching a saved contact's email is dropped")
+	assert.Equal(t, "contact", resp.Contacts[0].Source)
+	assert.Equal(t, "people/c1", resp.Contacts[0].Person.ResourceName)
+	assert.Equal(t, "other", resp.Contacts[1].Source)
+	assert.Equal(t, "otherContacts/o2", resp.Contacts[1].Person.ResourceName)
+}
+
+func TestHandlePeopleSearchContacts_SearchOtherFails(t *testing.T) {
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "people:searchContacts"):
+			_, _ = w.Write([]byte(`{"results": [{"person": {"resourceName": "people/c1", "emailAddresses": [{"value": "jane@acme.com"}]}}]}`))
+		case strings.HasSuffix(r.URL.Path, "otherContacts:search"):
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"error": {"code": 403, "message": "Request had insufficient authentication scopes.", "status": "PERMISSION_DENIED"}}`))
+		default:
+			http.NotFound(w, r)
+		}
+	})
+
+	result, err := srv.handlePeopleSearchContacts(context.Background(), createMockRequest("people_search_contacts", map[string]interface{}{
+		"query":        "jane",
+		"search_other": true,
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError, "saved contacts are still returned")
+	require.Len(t, result.Content, 2)
+
+	var resp ListContactsResponse
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &resp))
+	assert.Equal(t, 1, resp.Count)
+	warning := result.Content[1].(mcp.TextContent).Text
+	assert.Contains(t, warning, "unable to search other contacts")
+	assert.Contains(t, warning, "contacts.other.readonly")
+}
+
+func TestHandlePeopleSearchContacts_InvalidOptions(t *testing.T) {
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected API call: %s", r.URL.Path)
+	})
+
+	for name, args := range map[string]map[string]interface{}{
+		"bad sort":  {"query": "x", "sort": "newest"},
+		"bad field": {"query": "x", "fields": "names,shoeSize"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			result, err := srv.handlePeopleSearchContacts(context.Background(), createMockRequest("people_search_contacts", args))
+			require.NoError(t, err)
+			assert.True(t, result.IsError)
+		})
+	}
+}
+
+func TestHandlePeopleCreateContact_InferOrganization(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     map[string]interface{}
+		expected []string
+	}{
+		{
+			name:     "corporate domain inferred",
+			args:     map[string]interface{}{"email": "jane@acme-corp.com", "infer_org_from_email": true},
+			expected: []string{"Acme-corp"},
+		},
+		{
+			name:     "free-mail domain skipped",
+			args:     map[string]interface{}{"email": "jane@gmail.com", "infer_org_from_email": true},
+			expected: nil,
+		},
+		{
+			name:     "explicit organization wins",
+			args:     map[string]interface{}{"email": "jane@acme-corp.com", "organization": "Acme Holdings", "infer_org_from_email": true},
+			expected: []string{"Acme Holdings"},
+		},
+		{
+			name:     "inference off by default",
+			args:     map[string]interface{}{"email": "jane@acme-corp.com"},
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var organizations []string
+			srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+				var person struct {
+					Organizations []struct {
+						Name string `json:"name"`
+					} `json:"organizations"`
+				}
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&person))
+				for _, org := range person.Organizations {
+					organizations = append(organizations, org.Name)
+				}
+				_ = json.NewEncoder(w).Encode(map[string]interface{}{"resourceName": "people/c1"})
+			})
+
+			args := map[string]interface{}{"given_name": "Jane"}
+			for k, v := range tt.args {
+				args[k] = v
+			}
+
+			result, err := srv.handlePeopleCreateContact(context.Background(), createMockRequest("people_create_contact", args))
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+			assert.Equal(t, tt.expected, organizations)
+		})
+	}
+}
+
+func TestHandlePeopleUpdateContact_NotesRoundTrip(t *testing.T) {
+	stored := map[string]interface{}{
+		"resourceName": "people/c1",
+		"etag":         "etag-1",
+		"names":        []map[string]string{{"givenName": "Jane"}},
+	}
+	var updateMask, readMask string
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPatch {
+			updateMask = r.URL.Query().Get("updatePersonFields")
+			stored = map[string]interface{}{}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&stored))
+		} else {
+			readMask = r.URL.Query().Get("personFields")
+		}
+		_ = json.NewEncoder(w).Encode(stored)
+	})
+	ctx := context.Background()
+
+	result, err := srv.handlePeopleUpdateContact(ctx, createMockRequest("people_update_contact", map[string]interface{}{
+		"resource_name": "people/c1",
+		"notes":         "Met at GopherCon, interested in consulting",
+		"relations": []interface{}{
+			map[string]interface{}{"person": "Sam Lee", "type": "Assistant"},
+			map[string]interface{}{"person": "Alex Kim", "type": "domesticPartner"},
+		},
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError, result.Content)
+	assert.Equal(t, "biographies,relations", updateMask)
+
+	result, err = srv.handlePeopleGetContact(ctx, createMockRequest("people_get_contact", map[string]interface{}{
+		"resource_name": "people/c1",
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError, result.Content)
+	assert.Contains(t, readMask, "biographies")
+	assert.Contains(t, readMask, "relations")
+
+	var person struct {
+		Biographies []struct {
+			Value       string `json:"value"`
+			ContentType string `json:"contentType"`
+		} `json:"biographies"`
+		Relations []struct {
+			Person string `json:"person"`
+			Type   string `json:"type"`
+		} `json:"relations"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &person))
+	require.Len(t, person.Biographies, 1)
+	assert.Equal(t, "Met at GopherCon, interested in consulting", person.Biographies[0].Value)
+	assert.Equal(t, "TEXT_PLAIN", person.Biographies[0].ContentType)
+	require.Len(t, person.Relations, 2)
+	assert.Equal(t, "Sam Lee", person.Relations[0].Person)
+	assert.Equal(t, "assistant", person.Relations[0].Type)
+	assert.Equal(t, "domesticPartner", person.Relations[1].Type, "camelCase API types keep their casing")
+
+	result, err = srv.handlePeopleUpdateContact(ctx, createMockRequest("people_update_contact", map[string]interface{}{
+		"resource_name": "people/c1",
+		"relations":     []interface{}{map[string]interface{}{"type": "manager"}},
+	}))
+	require.NoError(t, err)
+	assert.True(t, result.IsError, "a relation needs a person")
+}
+
+func TestHandlePeopleGetContact_Photo(t *testing.T) {
+	var photoURL string
+	var photoFetches int
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/photos/custom":
+			photoFetches++
+			w.Header().Set("Content-Type", "image/jpeg")
+			_, _ = w.Write([]byte("jpeg-bytes"))
+		case "/v1/people/c2":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"resourceName": "people/c2",
+				"photos":       []map[string]interface{}{{"url": photoURL + "/photos/letter", "default": true}},
+			})
+		default:
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"resourceName": "people/c1",
+				"names":        []map[string]string{{"displayName": "Jane Doe"}},
+				"photos":       []map[string]interface{}{{"url": photoURL + "/photos/custom", "metadata": map[string]bool{"primary": true}}},
+			})
+		}
+	})
+	photoURL = os.Getenv("ISH_BASE_URL")
+	ctx := context.Background()
+
+	get := func(args map[string]interface{}) (map[string]interface{}, *mcp.CallToolResult) {
+		result, err := srv.handlePeopleGetContact(ctx, createMockRequest("people_get_contact", args))
+		require.NoError(t, err)
+		require.False(t, result.IsError, result.Content)
+		var contact map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &contact))
+		return contact, result
+	}
+
+	contact, _ := get(map[string]interface{}{"resource_name": "people/c1"})
+	assert.Equal(t, "people/c1", contact["resourceName"], "the person's own fields are kept")
+	photo := contact["photo"].(map[string]interface{})
+	assert.Equal(t, photoURL+"/photos/custom", photo["url"])
+	assert.Equal(t, false, photo["default"])
+	assert.NotContains(t, photo, "content_base64")
+	assert.Zero(t, photoFetches, "photos are only downloaded on request")
+
+	contact, _ = get(map[string]interface{}{"resource_name": "people/c1", "include_photo": true})
+	photo = contact["photo"].(map[string]interface{})
+	assert.Equal(t, base64.StdEncoding.EncodeToString([]byte("jpeg-bytes")), photo["content_base64"])
+	assert.Equal(t, "image/jpeg", photo["mime_type"])
+
+	contact, result := get(map[string]interface{}{"resource_name": "people/c2", "include_photo": true})
+	photo = contact["photo"].(map[string]interface{})
+	assert.Equal(t, true, photo["default"], "a default avatar is not a custom photo")
+	assert.NotContains(t, photo, "content_base64")
+	assert.Len(t, result.Content, 1)
+	assert.Equal(t, 1, photoFetches)
+}
+
+func TestHandlePeopleExportVCard(t *testing.T) {
+	var query map[string][]string
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"responses": []map[string]interface{}{{
+				"requestedResourceName": "people/c12345",
+				"person": map[string]interface{}{
+					"resourceName": "people/c12345",
+					"names":        []map[string]string{{"displayName": "Jane Doe", "givenName": "Jane", "familyName": "Doe"}},
+					"emailAddresses": []map[string]string{
+						{"value": "jane@work.example.com", "type": "work"},
+						{"value": "jane@home.example.com", "type": "home"},
+					},
+				},
+			}},
+		})
+	})
+
+	request := createMockRequest("people_export_vcard", map[string]interface{}{
+		"resource_names": []interface{}{"people/c12345"},
+		"version":        "4.0",
+	})
+
+	result, err := srv.handlePeopleExportVCard(context.Background(), request)
+	require.NoError(t, err)
+	require.False(t, result.IsError, result.Content)
+
+	assert.Equal(t, []string{"people/c12345"}, query["resourceNames"])
+
+	var resp ExportVCardResponse
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &resp))
+	assert.Equal(t, "4.0", resp.Version)
+	assert.Equal(t, 1, resp.Count)
+	assert.Contains(t, resp.VCard, "FN:Jane Doe\r\n")
+	assert.Contains(t, resp.VCard, "EMAIL;TYPE=work:jane@work.example.com\r\n")
+	assert.Contains(t, resp.VCard, "EMAIL;TYPE=home:jane@home.example.com\r\n")
+}
+
+func TestHandlePeopleExportVCard_InvalidInput(t *testing.T) {
+	called := false
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	})
+
+	for name, args := range map[string]map[string]interface{}{
+		"no contacts":  {"resource_names": []interface{}{}},
+		"bad version":  {"resource_names": []interface{}{"people/c1"}, "version": "2.1"},
+		"bad resource": {"resource_names": []interface{}{"people/me"}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			result, err := srv.handlePeopleExportVCard(context.Background(), createMockRequest("people_export_vcard", args))
+			require.NoError(t, err)
+			assert.True(t, result.IsError)
+		})
+	}
+	assert.False(t, called, "invalid input must not reach the API")
+}
+
+func TestHandlePeopleImportVCard_SkipsDuplicates(t *testing.T) {
+	var created []string
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "people:searchContacts"):
+			results := []map[string]interface{}{}
+			if r.URL.Query().Get("query") == "existing@example.com" {
+				results = append(results, map[string]interface{}{"person": map[string]interface{}{
+					"resourceName":   "people/c1",
+					"emailAddresses": []map[string]string{{"value": "Existing@example.com"}},
+				}})
+			}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"results": results})
+		case strings.HasSuffix(r.URL.Path, "people:createContact"):
+			var person map[string]interface{}
+			_ = json.NewDecoder(r.Body).Decode(&person)
+			email := person["emailAddresses"].([]interface{})[0].(map[string]interface{})["value"].(string)
+			created = append(created, email)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"resourceName": "people/new" + strconv.Itoa(len(created))})
+		default:
+			http.NotFound(w, r)
+		}
+	})
+
+	vcard := strings.Join([]string{
+		"BEGIN:VCARD", "VERSION:3.0", "FN:New Person", "EMAIL:new@example.com", "END:VCARD",
+		"BEGIN:VCARD", "VERSION:3.0", "FN:Old Person", "EMAIL:existing@example.com", "END:VCARD",
+		"BEGIN:VCARD", "VERSION:3.0", "FN:New Again", "EMAIL:NEW@example.com", "END:VCARD",
+		"BEGIN:VCARD", "VERSION:3.0", "FN:Broken", "BDAY:never", "END:VCARD",
+	}, "\r\n")
+
+	request := createMockRequest("people_import_vcard", map[string]interface{}{
+		"vcard":           vcard,
+		"skip_duplicates": true,
+	})
+
+	result, err := srv.handlePeopleImportVCard(context.Background(), request)
+	require.NoError(t, err)
+	require.False(t, result.IsError, result.Content)
+
+	var resp ImportVCardResponse
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &resp))
+	assert.Equal(t, 1, resp.Created)
+	assert.Equal(t, 2, resp.Skipped)
+	assert.Equal(t, 1, resp.Failed)
+	assert.Equal(t, []string{"new@example.com"}, created)
+
+	require.Len(t, resp.Results, 4)
+	assert.Equal(t, ImportVCardResult{Index: 1, Name: "New Person", Status: "created", ResourceName: "people/new1"}, resp.Results[0])
+	assert.Equal(t, "skipped", resp.Results[1].Status)
+	assert.Equal(t, "people/c1", resp.Results[1].ResourceName)
+	assert.Equal(t, "skipped", resp.Results[2].Status)
+	assert.Contains(t, resp.Results[2].Reason, "earlier card")
+	assert.Equal(t, "failed", resp.Results[3].Status)
+	assert.Contains(t, resp.Results[3].Reason, "invalid BDAY")
+}
+
+func TestHandlePeopleImportVCard_NoCards(t *testing.T) {
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected API call: %s", r.URL.Path)
+	})
+
+	request := createMockRequest("people_import_vcard", map[string]interface{}{"vcard": "not a vcard"})
+	result, err := srv.handlePeopleImportVCard(context.Background(), request)
+	require.NoError(t, err)
+	assert.True(t, result.IsError)
+}
+
+func TestHandlePeopleListContacts_Fields(t *testing.T) {
+	var personFields []string
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		personFields = append(personFields, r.URL.Query().Get("personFields"))
+		_, _ = w.Write([]byte(`{"connections": [{"resourceName": "people/c1"}]}`))
+	})
+
+	result, err := srv.handlePeopleListContacts(context.Background(), createMockRequest("people_list_contacts", map[string]interface{}{}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	result, err = srv.handlePeopleListContacts(context.Background(), createMockRequest("people_list_contacts", map[string]interface{}{
+		"fields": "names, emailAddresses, organizations",
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	assert.Equal(t, []string{"names,emailAddresses", "names,emailAddresses,organizations"}, personFields)
+
+	result, err = srv.handlePeopleListContacts(context.Background(), createMockRequest("people_list_contacts", map[string]interface{}{
+		"fields": "names,shoeSize",
+	}))
+	require.NoError(t, err)
+	assert.True(t, result.IsError)
+	assert.Len(t, personFields, 2, "invalid fields are rejected before any API call")
+}
+
+func TestHandlePeopleExportCSV_AllContacts(t *testing.T) {
+	var path string
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"connections": []map[string]interface{}{{
+				"resourceName":   "people/c1",
+				"names":          []map[string]string{{"displayName": "Jane Doe", "givenName": "Jane", "familyName": "Doe"}},
+				"emailAddresses": []map[string]string{{"value": "jane@work.example.com"}, {"value": "jane@home.example.com"}},
+			}},
+		})
+	})
+
+	result, err := srv.handlePeopleExportCSV(context.Background(), createMockRequest("people_export_csv", map[string]interface{}{}))
+	require.NoError(t, err)
+	require.False(t, result.IsError, result.Content)
+
+	assert.True(t, strings.HasSuffix(path, "/people/me/connections"), "no resource names exports the whole contact list")
+
+	var resp ExportCSVResponse
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &resp))
+	assert.Equal(t, 1, resp.Count)
+	assert.Contains(t, resp.CSV, "Jane Doe,Jane,,Doe,,,jane@work.example.com;jane@home.example.com,,,\n")
+}
+
+func TestHandlePeopleListDirectory(t *testing.T) {
+	var readMask, pageSize string
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		readMask, pageSize = r.URL.Query().Get("readMask"), r.URL.Query().Get("pageSize")
+		_, _ = w.Write([]byte(`{"people": [{"resourceName": "people/d1"}, {"resourceName": "people/d2"}], "nextPageToken": "page2"}`))
+	})
+
+	result, err := srv.handlePeopleListDirectory(context.Background(), createMockRequest("people_list_directory", map[string]interface{}{
+		"fields":      "names, organizations",
+		"max_results": 2,
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError, result.Content)
+	assert.Equal(t, "names,organizations", readMask)
+	assert.Equal(t, "2", pageSize)
+
+	var resp ListDirectoryResponse
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &resp))
+	assert.Equal(t, 2, resp.Count)
+	assert.Equal(t, "page2", resp.NextPageToken)
+	assert.Empty(t, resp.NextSyncToken)
+
+	for _, args := range []map[string]interface{}{{"max_results": 0}, {"fields": "shoeSize"}} {
+		result, err := srv.handlePeopleListDirectory(context.Background(), createMockRequest("people_list_directory", args))
+		require.NoError(t, err)
+		assert.True(t, result.IsError, "args %v", args)
+	}
+}
+
+func TestHandlePeopleUpdateContact_ClearFields(t *testing.T) {
+	var updateMask string
+	var sent map[string]interface{}
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPatch {
+			updateMask = r.URL.Query().Get("updatePersonFields")
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&sent))
+		}
+		_, _ = w.Write([]byte(`{
+			"resourceName": "people/c1",
+			"etag": "etag-1",
+			"names": [{"givenName": "Jane"}],
+			"emailAddresses": [{"value": "jane@acme.com"}],
+			"phoneNumbers": [{"value": "555-0100"}],
+			"organizations": [{"name": "Wrong Corp"}]
+		}`))
+	})
+	ctx := context.Background()
+
+	result, err := srv.handlePeopleUpdateContact(ctx, createMockRequest("people_update_contact", map[string]interface{}{
+		"resource_name": "people/c1",
+		"given_name":    "Janet",
+		"clear_fields":  []interface{}{"phoneNumbers", "organizations"},
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError, result.Content)
+
+	assert.Equal(t, "names,phoneNumbers,organizations", updateMask)
+	assert.Equal(t, []interface{}{}, sent["phoneNumbers"])
+	assert.Equal(t, []interface{}{}, sent["organizations"])
+	assert.Len(t, sent["emailAddresses"], 1, "untouched fields are preserved")
+	assert.Equal(t, "Janet", sent["names"].([]interface{})[0].(map[string]interface{})["givenName"])
+
+	for name, args := range map[string]map[string]interface{}{
+		"unknown field":     {"clear_fields": []interface{}{"nicknames"}},
+		"set and cleared":   {"phone": "555-0199", "clear_fields": []interface{}{"phoneNumbers"}},
+		"names not allowed": {"clear_fields": []interface{}{"names"}},
+	} {
+		args["resource_name"] = "people/c1"
+		result, err := srv.handlePeopleUpdateContact(ctx, createMockRequest("people_update_contact", args))
+		require.NoError(t, err)
+		assert.True(t, result.IsError, name)
+	}
+}
+
+func TestHandlePeopleBatchCreate(t *testing.T) {
+	var created []string
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, ":searchContacts"):
+			if r.URL.Query().Get("query") == "old@example.com" {
+				_, _ = w.Write([]byte(`{"results": [{"person": {"resourceName": "people/c-old", "emailAddresses": [{"value": "old@example.com"}]}}]}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{}`))
+		case strings.HasSuffix(r.URL.Path, ":batchCreateContacts"):
+			var req googlepeople.BatchCreateContactsRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			resp := googlepeople.BatchCreateContactsResponse{}
+			for _, contact := range req.Contacts {
+				name := contact.ContactPerson.Names[0].GivenName
+				created = append(created, name)
+				resp.CreatedPeople = append(resp.CreatedPeople, &googlepeople.PersonResponse{Person: &googlepeople.Person{ResourceName: "people/" + name}})
+			}
+			_ = json.NewEncoder(w).Encode(resp)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+
+	result, err := srv.handlePeopleBatchCreate(context.Background(), createMockRequest("people_batch_create", map[string]interface{}{
+		"contacts": []interface{}{
+			map[string]interface{}{"given_name": "ann", "email": "ann@example.com"},
+			map[string]interface{}{"given_name": "old", "email": "old@example.com"},
+			map[string]interface{}{"given_name": "ann2", "email": "ANN@example.com"},
+			map[string]interface{}{"given_name": "bob", "organization": "Acme"},
+		},
+		"skip_existing": true,
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError, result.Content)
+
+	var resp BatchCreateResponse
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &resp))
+	assert.Equal(t, []string{"ann", "bob"}, created, "duplicates never reach the API")
+	assert.Equal(t, 2, resp.Created)
+	assert.Equal(t, 2, resp.Skipped)
+	require.Len(t, resp.Results, 4)
+	assert.Equal(t, ImportVCardResult{Index: 1, Name: "ann", Status: "created", ResourceName: "people/ann"}, resp.Results[0])
+	assert.Equal(t, "people/c-old", resp.Results[1].ResourceName)
+	assert.Equal(t, "skipped", resp.Results[1].Status)
+	assert.Contains(t, resp.Results[2].Reason, "duplicate of an earlier contact")
+	assert.Equal(t, ImportVCardResult{Index: 4, Name: "bob", Status: "created", ResourceName: "people/bob"}, resp.Results[3])
+
+	result, err = srv.handlePeopleBatchCreate(context.Background(), createMockRequest("people_batch_create", map[string]interface{}{
+		"contacts": []interface{}{map[string]interface{}{"email": "noname@example.com"}},
+	}))
+	require.NoError(t, err)
+	assert.True(t, result.IsError, "every contact needs a given name")
+}
+
+func TestHandlePeopleListGroupMembers(t *testing.T) {
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/contactGroups"):
+			_, _ = w.Write([]byte(`{"contactGroups": [{"resourceName": "contactGroups/c1", "name": "Clients"}]}`))
+		case strings.HasSuffix(r.URL.Path, "/contactGroups/c1"):
+			_, _ = w.Write([]byte(`{"resourceName": "contactGroups/c1", "name": "Clients", "memberCount": 1, "memberResourceNames": ["people/p1"]}`))
+		case strings.HasSuffix(r.URL.Path, "people:batchGet"):
+			_, _ = w.Write([]byte(`{"responses": [{"person": {"resourceName": "people/p1"}}]}`))
+		default:
+			http.NotFound(w, r)
+		}
+	})
+
+	result, err := srv.handlePeopleListGroupMembers(context.Background(), createMockRequest("people_list_group_members", map[string]interface{}{"group": "Clients"}))
+	require.NoError(t, err)
+	require.False(t, result.IsError, result.Content)
+
+	var resp people.GroupMembers
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &resp))
+	assert.Equal(t, "contactGroups/c1", resp.Group)
+	require.Len(t, resp.Contacts, 1)
+	assert.Equal(t, "people/p1", resp.Contacts[0].ResourceName)
+
+	result, err = srv.handlePeopleListGroupMembers(context.Background(), createMockRequest("people_list_group_members", map[string]interface{}{"group": "Friends"}))
+	require.NoError(t, err)
+	assert.True(t, result.IsError)
+}
@@ -0,0 +1,87 @@
+// ABOUTME: Tests for the gsuite_selftest tool
+// ABOUTME: Verifies one entry per service, simulated success in ISH mode, per-service failures, and probes shared with auth_status
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandleSelfTest_ISHMode(t *testing.T) {
+	called := false
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	})
+
+	result, err := srv.handleSelfTest(context.Background(), createMockRequest("gsuite_selftest", nil))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var resp SelfTestResponse
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &resp))
+	assert.True(t, resp.OK)
+	assert.True(t, resp.Simulated)
+	require.Len(t, resp.Services, 3)
+	for i, name := range []string{"gmail", "calendar", "people"} {
+		assert.Equal(t, name, resp.Services[i].Service)
+		assert.True(t, resp.Services[i].OK)
+	}
+	assert.False(t, called, "simulated runs make no API calls")
+}
+
+func TestRunSelfTest_ReportsEachService(t *testing.T) {
+	var calendarQuery string
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.Contains(r.URL.Path, "/calendars/"):
+			calendarQuery = r.URL.RawQuery
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"error": {"code": 403, "message": "Calendar API has not been used in project"}}`))
+		case strings.HasSuffix(r.URL.Path, "/connections"):
+			_, _ = w.Write([]byte(`{"connections": []}`))
+		default:
+			_, _ = w.Write([]byte(`{"messages": []}`))
+		}
+	})
+
+	resp := srv.runSelfTest(context.Background())
+
+	assert.False(t, resp.OK)
+	assert.False(t, resp.Simulated)
+	require.Len(t, resp.Services, 3)
+	assert.True(t, resp.Services[0].OK, "gmail")
+	assert.False(t, resp.Services[1].OK, "calendar")
+	assert.Contains(t, resp.Services[1].Error, "Calendar API has not been used")
+	assert.True(t, resp.Services[2].OK, "people")
+	assert.Contains(t, calendarQuery, "timeMax=", "the event read is bounded to the next day")
+	assert.Contains(t, calendarQuery, "maxResults=1")
+	for _, svc := range resp.Services {
+		assert.GreaterOrEqual(t, svc.LatencyMS, int64(0))
+	}
+}
+
+func TestServiceProbes_SharedByAuthStatusAndSelfTest(t *testing.T) {
+	var paths []string
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		_, _ = w.Write([]byte(`{}`))
+	})
+
+	srv.runSelfTest(context.Background())
+	selfTestPaths := paths
+	paths = nil
+	resp := authStatus(t, srv, map[string]interface{}{"detailed": true})
+
+	assert.Equal(t, selfTestPaths, paths, "both checks make the same reads")
+	for _, p := range srv.serviceProbes() {
+		assert.Contains(t, resp.Services, p.service)
+	}
+}
@@ -5,8 +5,12 @@ package server
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
+	"github.com/harper/gsuite-mcp/pkg/config"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -25,10 +29,25 @@ func createMockRequest(name string, args map[string]interface{}) mcp.CallToolReq
 	}
 }
 
+// newTestServer creates a Server in ish mode whose API calls are served by handler
+func newTestServer(t *testing.T, handler http.HandlerFunc) *Server {
+	t.Helper()
+
+	api := httptest.NewServer(handler)
+	t.Cleanup(api.Close)
+
+	t.Setenv("ISH_MODE", "true")
+	t.Setenv("ISH_BASE_URL", api.URL)
+
+	srv, err := NewServer(context.Background(), nil)
+	require.NoError(t, err)
+	return srv
+}
+
 func TestNewServer_WithIshMode(t *testing.T) {
 	t.Setenv("ISH_MODE", "true")
 
-	srv, err := NewServer(context.Background())
+	srv, err := NewServer(context.Background(), nil)
 
 	require.NoError(t, err)
 	assert.NotNil(t, srv)
@@ -37,7 +56,7 @@ func TestNewServer_WithIshMode(t *testing.T) {
 func TestServer_ListTools(t *testing.T) {
 	t.Setenv("ISH_MODE", "true")
 
-	srv, err := NewServer(context.Background())
+	srv, err := NewServer(context.Background(), nil)
 	require.NoError(t, err)
 
 	tools := srv.ListTools()
@@ -62,10 +81,102 @@ func TestServer_ListTools(t *testing.T) {
 	assert.True(t, toolNames["people_get_contact"])
 }
 
+func TestNewServer_DisabledToolsFromConfig(t *testing.T) {
+	t.Setenv("ISH_MODE", "true")
+
+	cfg := config.Default()
+	cfg.DisabledTools = []string{"gmail_send_message", "people_delete_contact"}
+
+	srv, err := NewServer(context.Background(), cfg)
+	require.NoError(t, err)
+
+	toolNames := make(map[string]bool)
+	for _, tool := range srv.ListTools() {
+		toolNames[tool.Name] = true
+	}
+	assert.False(t, toolNames["gmail_send_message"])
+	assert.False(t, toolNames["people_delete_contact"])
+	assert.True(t, toolNames["gmail_list_messages"])
+}
+
+func TestNewServer_DraftOnly(t *testing.T) {
+	var posts []string
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			posts = append(posts, r.URL.Path)
+		}
+		_, _ = w.Write([]byte(`{"id": "draft-1", "emailAddress": "me@example.com"}`))
+	}))
+	t.Cleanup(api.Close)
+	t.Setenv("ISH_MODE", "true")
+	t.Setenv("ISH_BASE_URL", api.URL)
+
+	cfg := config.Default()
+	cfg.DraftOnly = true
+
+	srv, err := NewServer(context.Background(), cfg)
+	require.NoError(t, err)
+
+	toolNames := make(map[string]bool)
+	for _, tool := range srv.ListTools() {
+		toolNames[tool.Name] = true
+	}
+	for _, name := range []string{"gmail_send_message", "gmail_send_draft", "gmail_schedule_send"} {
+		assert.False(t, toolNames[name], name)
+	}
+	assert.True(t, toolNames["gmail_create_draft"])
+
+	result, err := srv.handleGmailCreateDraft(context.Background(), createMockRequest("gmail_create_draft", map[string]interface{}{
+		"to":      "bob@example.com",
+		"subject": "Hi",
+		"body":    "Hello",
+	}))
+	require.NoError(t, err)
+	assert.False(t, result.IsError, result.Content)
+
+	result, err = srv.handleCalendarEmailAgenda(context.Background(), createMockRequest("calendar_email_agenda", map[string]interface{}{
+		"send": true,
+	}))
+	require.NoError(t, err)
+	require.True(t, result.IsError)
+	assert.Contains(t, result.Content[0].(mcp.TextContent).Text, "draft-only mode")
+
+	assert.Equal(t, []string{"/gmail/v1/users/me/drafts"}, posts, "only the draft reached the API")
+}
+
+func TestNewServer_HTTPClientTimeout(t *testing.T) {
+	t.Setenv("ISH_MODE", "true")
+
+	t.Run("default", func(t *testing.T) {
+		srv, err := NewServer(context.Background(), nil)
+		require.NoError(t, err)
+		assert.Equal(t, config.DefaultHTTPTimeout, srv.client.Timeout)
+	})
+
+	t.Run("configured", func(t *testing.T) {
+		cfg := config.Default()
+		cfg.HTTPTimeout = "5s"
+
+		srv, err := NewServer(context.Background(), cfg)
+		require.NoError(t, err)
+		assert.Equal(t, 5*time.Second, srv.client.Timeout)
+	})
+}
+
+func TestNewServer_InvalidConfig(t *testing.T) {
+	t.Setenv("ISH_MODE", "true")
+
+	cfg := config.Default()
+	cfg.Timezone = "Nowhere/Special"
+
+	_, err := NewServer(context.Background(), cfg)
+	assert.Error(t, err)
+}
+
 func TestServer_HandleGmailListMessages(t *testing.T) {
 	t.Setenv("ISH_MODE", "true")
 
-	srv, err := NewServer(context.Background())
+	srv, err := NewServer(context.Background(), nil)
 	require.NoError(t, err)
 
 	// Create a mock request
@@ -85,7 +196,7 @@ func TestServer_HandleGmailListMessages(t *testing.T) {
 func TestServer_HandleGmailSendMessage(t *testing.T) {
 	t.Setenv("ISH_MODE", "true")
 
-	srv, err := NewServer(context.Background())
+	srv, err := NewServer(context.Background(), nil)
 	require.NoError(t, err)
 
 	request := createMockRequest("gmail_send_message", map[string]interface{}{
@@ -104,7 +215,7 @@ func TestServer_HandleGmailSendMessage(t *testing.T) {
 func TestServer_HandleCalendarListEvents(t *testing.T) {
 	t.Setenv("ISH_MODE", "true")
 
-	srv, err := NewServer(context.Background())
+	srv, err := NewServer(context.Background(), nil)
 	require.NoError(t, err)
 
 	request := createMockRequest("calendar_list_events", map[string]interface{}{
@@ -121,7 +232,7 @@ func TestServer_HandleCalendarListEvents(t *testing.T) {
 func TestServer_HandlePeopleListContacts(t *testing.T) {
 	t.Setenv("ISH_MODE", "true")
 
-	srv, err := NewServer(context.Background())
+	srv, err := NewServer(context.Background(), nil)
 	require.NoError(t, err)
 
 	request := createMockRequest("people_list_contacts", map[string]interface{}{
@@ -138,7 +249,7 @@ func TestServer_HandlePeopleListContacts(t *testing.T) {
 func TestServer_HandlePeopleSearchContacts(t *testing.T) {
 	t.Setenv("ISH_MODE", "true")
 
-	srv, err := NewServer(context.Background())
+	srv, err := NewServer(context.Background(), nil)
 	require.NoError(t, err)
 
 	request := createMockRequest("people_search_contacts", map[string]interface{}{
@@ -156,7 +267,7 @@ func TestServer_HandlePeopleSearchContacts(t *testing.T) {
 func TestServer_HandlePeopleGetContact(t *testing.T) {
 	t.Setenv("ISH_MODE", "true")
 
-	srv, err := NewServer(context.Background())
+	srv, err := NewServer(context.Background(), nil)
 	require.NoError(t, err)
 
 	request := createMockRequest("people_get_contact", map[string]interface{}{
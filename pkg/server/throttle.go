@@ -0,0 +1,169 @@
+// ABOUTME: Concurrency and rate limiting for API-backed MCP handlers
+// ABOUTME: Queues tool and resource calls so parallel agents don't trip per-user quotas
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// defaultMaxConcurrency bounds simultaneous in-flight handlers
+	defaultMaxConcurrency = 4
+	// defaultRateLimit caps handler starts per second
+	defaultRateLimit = 10.0
+)
+
+// throttle combines a semaphore bounding in-flight calls with a token bucket
+// capping how many calls may start per second. Callers block rather than fail.
+type throttle struct {
+	sem chan struct{}
+
+	mu     sync.Mutex
+	rate   float64 // tokens added per second; 0 disables rate limiting
+	burst  float64 // bucket capacity
+	tokens float64
+	last   time.Time
+}
+
+// newThrottle creates a throttle. maxConcurrency <= 0 disables the semaphore and
+// ratePerSecond <= 0 disables the token bucket.
+func newThrottle(maxConcurrency int, ratePerSecond float64) *throttle {
+	t := &throttle{}
+	if maxConcurrency > 0 {
+		t.sem = make(chan struct{}, maxConcurrency)
+	}
+	if ratePerSecond > 0 {
+		t.rate = ratePerSecond
+		t.burst = ratePerSecond
+		if t.burst < 1 {
+			t.burst = 1
+		}
+		t.tokens = t.burst
+		t.last = time.Now()
+	}
+	return t
+}
+
+// newThrottleFromEnv reads GSUITE_MCP_MAX_CONCURRENCY and GSUITE_MCP_RATE_LIMIT.
+// Unset or unparseable values fall back to the defaults; 0 disables that limit.
+func newThrottleFromEnv() *throttle {
+	maxConcurrency := defaultMaxConcurrency
+	if v := os.Getenv("GSUITE_MCP_MAX_CONCURRENCY"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n >= 0 {
+			maxConcurrency = n
+		}
+	}
+
+	rate := defaultRateLimit
+	if v := os.Getenv("GSUITE_MCP_RATE_LIMIT"); v != "" {
+		if f, err := strconv.ParseFloat(v, 64); err == nil && f >= 0 {
+			rate = f
+		}
+	}
+
+	return newThrottle(maxConcurrency, rate)
+}
+
+// acquire waits for a rate token and a concurrency slot, or until ctx is done.
+// Every successful acquire must be paired with release.
+func (t *throttle) acquire(ctx context.Context) error {
+	if err := t.waitForToken(ctx); err != nil {
+		return err
+	}
+
+	if t.sem == nil {
+		return nil
+	}
+
+	select {
+	case t.sem <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// release frees the concurrency slot taken by acquire
+func (t *throttle) release() {
+	if t.sem != nil {
+		<-t.sem
+	}
+}
+
+// waitForToken blocks until the token bucket has a token to spend
+func (t *throttle) waitForToken(ctx context.Context) error {
+	if t.rate == 0 {
+		return nil
+	}
+
+	for {
+		t.mu.Lock()
+		now := time.Now()
+		t.tokens += now.Sub(t.last).Seconds() * t.rate
+		if t.tokens > t.burst {
+			t.tokens = t.burst
+		}
+		t.last = now
+
+		if t.tokens >= 1 {
+			t.tokens--
+			t.mu.Unlock()
+			return nil
+		}
+		wait := time.Duration((1 - t.tokens) / t.rate * float64(time.Second))
+		t.mu.Unlock()
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+	}
+}
+
+// unthrottledTools check or change the credentials rather than read account
+// data. They skip the queue so the server can be diagnosed while it is busy.
+var unthrottledTools = map[string]bool{
+	"auth_status":     true,
+	"auth_info":       true,
+	"auth_init":       true,
+	"auth_complete":   true,
+	"auth_revoke":     true,
+	"gsuite_selftest": true,
+}
+
+// throttleTools wraps tool handlers so they queue on the server's throttle
+func (s *Server) throttleTools(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		if unthrottledTools[request.Params.Name] {
+			return next(ctx, request)
+		}
+		if err := s.throttle.acquire(ctx); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("request cancelled while waiting for rate limiter: %v", err)), nil
+		}
+		defer s.throttle.release()
+		return next(ctx, request)
+	}
+}
+
+// throttleResources wraps resource handlers so they queue on the server's throttle
+func (s *Server) throttleResources(next server.ResourceHandlerFunc) server.ResourceHandlerFunc {
+	return func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		if err := s.throttle.acquire(ctx); err != nil {
+			return nil, fmt.Errorf("request cancelled while waiting for rate limiter: %w", err)
+		}
+		defer s.throttle.release()
+		return next(ctx, request)
+	}
+}
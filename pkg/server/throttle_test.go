@@ -0,0 +1,161 @@
+// ABOUTME: Tests for handler concurrency and rate limiting
+// ABOUTME: Validates serialization, queueing, auth tool exemptions, context cancellation, and env configuration
+
+package server
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestThrottleTools_SerializesWithConcurrencyOne(t *testing.T) {
+	s := &Server{throttle: newThrottle(1, 0)}
+
+	var inFlight, maxInFlight int32
+	handler := s.throttleTools(func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		current := atomic.AddInt32(&inFlight, 1)
+		for {
+			prev := atomic.LoadInt32(&maxInFlight)
+			if current <= prev || atomic.CompareAndSwapInt32(&maxInFlight, prev, current) {
+				break
+			}
+		}
+		time.Sleep(50 * time.Millisecond)
+		atomic.AddInt32(&inFlight, -1)
+		return mcp.NewToolResultText("ok"), nil
+	})
+
+	start := time.Now()
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result, err := handler(context.Background(), createMockRequest("test", nil))
+			assert.NoError(t, err)
+			assert.False(t, result.IsError)
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(1), maxInFlight, "handlers should never overlap")
+	assert.GreaterOrEqual(t, time.Since(start), 100*time.Millisecond)
+}
+
+func TestThrottleTools_AuthToolsSkipQueue(t *testing.T) {
+	s := &Server{throttle: newThrottle(1, 0)}
+	handler := s.throttleTools(func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultText("ok"), nil
+	})
+
+	// Hold the only slot, as a long-running API call would
+	require.NoError(t, s.throttle.acquire(context.Background()))
+	defer s.throttle.release()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	for _, name := range []string{"auth_status", "gsuite_selftest"} {
+		result, err := handler(ctx, createMockRequest(name, nil))
+		require.NoError(t, err)
+		assert.False(t, result.IsError, name)
+	}
+
+	result, err := handler(ctx, createMockRequest("gmail_list_messages", nil))
+	require.NoError(t, err)
+	assert.True(t, result.IsError, "data tools still wait for a slot")
+}
+
+func TestThrottleTools_AllowsConfiguredConcurrency(t *testing.T) {
+	s := &Server{throttle: newThrottle(2, 0)}
+
+	release := make(chan struct{})
+	var inFlight int32
+	handler := s.throttleTools(func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		atomic.AddInt32(&inFlight, 1)
+		<-release
+		return mcp.NewToolResultText("ok"), nil
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _ = handler(context.Background(), createMockRequest("test", nil))
+		}()
+	}
+
+	assert.Eventually(t, func() bool { return atomic.LoadInt32(&inFlight) == 2 }, time.Second, 5*time.Millisecond)
+	close(release)
+	wg.Wait()
+}
+
+func TestThrottle_AcquireRespectsContext(t *testing.T) {
+	th := newThrottle(1, 0)
+	require.NoError(t, th.acquire(context.Background()))
+	defer th.release()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	err := th.acquire(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestThrottle_TokenBucketLimitsRate(t *testing.T) {
+	th := newThrottle(0, 20)
+
+	start := time.Now()
+	// Burst of 20 is immediate; the next 5 must wait ~50ms each
+	for i := 0; i < 25; i++ {
+		require.NoError(t, th.acquire(context.Background()))
+		th.release()
+	}
+
+	assert.GreaterOrEqual(t, time.Since(start), 200*time.Millisecond)
+}
+
+func TestNewThrottleFromEnv(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		t.Setenv("GSUITE_MCP_MAX_CONCURRENCY", "")
+		t.Setenv("GSUITE_MCP_RATE_LIMIT", "")
+
+		th := newThrottleFromEnv()
+		assert.Equal(t, defaultMaxConcurrency, cap(th.sem))
+		assert.Equal(t, defaultRateLimit, th.rate)
+	})
+
+	t.Run("overrides", func(t *testing.T) {
+		t.Setenv("GSUITE_MCP_MAX_CONCURRENCY", "1")
+		t.Setenv("GSUITE_MCP_RATE_LIMIT", "2.5")
+
+		th := newThrottleFromEnv()
+		assert.Equal(t, 1, cap(th.sem))
+		assert.Equal(t, 2.5, th.rate)
+	})
+
+	t.Run("zero disables", func(t *testing.T) {
+		t.Setenv("GSUITE_MCP_MAX_CONCURRENCY", "0")
+		t.Setenv("GSUITE_MCP_RATE_LIMIT", "0")
+
+		th := newThrottleFromEnv()
+		assert.Nil(t, th.sem)
+		assert.Zero(t, th.rate)
+	})
+
+	t.Run("invalid falls back to defaults", func(t *testing.T) {
+		t.Setenv("GSUITE_MCP_MAX_CONCURRENCY", "lots")
+		t.Setenv("GSUITE_MCP_RATE_LIMIT", "-3")
+
+		th := newThrottleFromEnv()
+		assert.Equal(t, defaultMaxConcurrency, cap(th.sem))
+		assert.Equal(t, defaultRateLimit, th.rate)
+	})
+}
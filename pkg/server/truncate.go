@@ -0,0 +1,114 @@
+// ABOUTME: Size cap for tool results so oversized JSON doesn't break MCP clients
+// ABOUTME: Trims the largest list in a result until it fits and flags what was omitted
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// truncatedItemsKey holds a bare JSON array result once it has been wrapped
+// in an object to carry the truncation flags
+const truncatedItemsKey = "items"
+
+// limitToolResults wraps tool handlers so JSON results larger than the
+// configured max_result_bytes are trimmed by limitResult
+func (s *Server) limitToolResults(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		result, err := next(ctx, request)
+		if err != nil || s.maxResultBytes <= 0 {
+			return result, err
+		}
+		return limitResult(result, s.maxResultBytes), nil
+	}
+}
+
+// limitResult returns result unchanged if its first text fits in maxBytes or
+// isn't JSON with a list to trim. Otherwise the largest list (the result
+// itself if it is an array, else its largest array field) keeps as many
+// leading items as fit, and truncated, omitted_items, and truncation_note are
+// added. Array results are wrapped as {"items": [...]} to carry those fields.
+// Content after the JSON, such as warnings, is kept as is.
+func limitResult(result *mcp.CallToolResult, maxBytes int) *mcp.CallToolResult {
+	if result == nil || result.IsError || len(result.Content) == 0 {
+		return result
+	}
+	text, ok := result.Content[0].(mcp.TextContent)
+	if !ok || len(text.Text) <= maxBytes {
+		return result
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader([]byte(text.Text)))
+	decoder.UseNumber() // Keep IDs and history IDs exact
+	var decoded interface{}
+	if err := decoder.Decode(&decoded); err != nil {
+		return result
+	}
+
+	var obj map[string]interface{}
+	var field string
+	switch v := decoded.(type) {
+	case []interface{}:
+		obj = map[string]interface{}{truncatedItemsKey: v}
+		field = truncatedItemsKey
+	case map[string]interface{}:
+		obj = v
+		field = largestList(v)
+	}
+	if field == "" {
+		return result
+	}
+
+	items := obj[field].([]interface{})
+	encode := func(keep int) []byte {
+		obj[field] = items[:keep]
+		obj["truncated"] = true
+		obj["omitted_items"] = len(items) - keep
+		obj["truncation_note"] = fmt.Sprintf("result exceeded %d bytes, so only the first %d of %d %s are included; narrow the request to see the rest", maxBytes, keep, len(items), field)
+		data, _ := json.Marshal(obj)
+		return data
+	}
+
+	// Find the most items that fit; with none kept the result may still be too
+	// big, but it's the best that can be done without dropping other fields
+	lo, hi := 0, len(items)
+	for lo < hi {
+		mid := (lo + hi + 1) / 2
+		if len(encode(mid)) <= maxBytes {
+			lo = mid
+		} else {
+			hi = mid - 1
+		}
+	}
+	data := encode(lo)
+
+	return &mcp.CallToolResult{
+		Result:            result.Result,
+		Content:           append([]mcp.Content{mcp.TextContent{Type: text.Type, Text: string(data)}}, result.Content[1:]...),
+		StructuredContent: obj,
+	}
+}
+
+// largestList returns the key of obj's array field with the longest
+// encoding, or "" if it has no non-empty arrays
+func largestList(obj map[string]interface{}) string {
+	var best string
+	bestSize := 0
+	for key, value := range obj {
+		list, ok := value.([]interface{})
+		if !ok || len(list) == 0 {
+			continue
+		}
+		data, _ := json.Marshal(list)
+		if len(data) > bestSize || (len(data) == bestSize && key < best) {
+			best, bestSize = key, len(data)
+		}
+	}
+	return best
+}
@@ -0,0 +1,117 @@
+// ABOUTME: Tests for trimming oversized tool results
+// ABOUTME: Covers list truncation with the omitted-item flags, trailing warnings, and untouched small results
+
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLimitResult_TruncatesLargestList(t *testing.T) {
+	messages := make([]map[string]string, 200)
+	for i := range messages {
+		messages[i] = map[string]string{"id": fmt.Sprintf("msg%03d", i), "snippet": "a reasonably long snippet of message text"}
+	}
+	result, err := mcp.NewToolResultJSON(map[string]interface{}{
+		"query":    "in:inbox",
+		"labels":   []string{"INBOX"},
+		"messages": messages,
+	})
+	require.NoError(t, err)
+
+	limited := limitResult(result, 4096)
+	text := limited.Content[0].(mcp.TextContent).Text
+	assert.LessOrEqual(t, len(text), 4096)
+
+	var got struct {
+		Query        string              `json:"query"`
+		Labels       []string            `json:"labels"`
+		Messages     []map[string]string `json:"messages"`
+		Truncated    bool                `json:"truncated"`
+		OmittedItems int                 `json:"omitted_items"`
+		Note         string              `json:"truncation_note"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(text), &got))
+	assert.True(t, got.Truncated)
+	assert.Equal(t, "in:inbox", got.Query)
+	assert.Equal(t, []string{"INBOX"}, got.Labels)
+	require.NotEmpty(t, got.Messages)
+	assert.Equal(t, "msg000", got.Messages[0]["id"])
+	assert.Equal(t, 200, len(got.Messages)+got.OmittedItems)
+	assert.Contains(t, got.Note, fmt.Sprintf("first %d of 200 messages", len(got.Messages)))
+
+	structured, ok := limited.StructuredContent.(map[string]interface{})
+	require.True(t, ok)
+	assert.Equal(t, true, structured["truncated"])
+}
+
+func TestLimitResult_WrapsTopLevelArray(t *testing.T) {
+	events := make([]map[string]string, 100)
+	for i := range events {
+		events[i] = map[string]string{"id": fmt.Sprintf("event%03d", i), "summary": "Weekly sync with the team"}
+	}
+	result, err := mcp.NewToolResultJSON(events)
+	require.NoError(t, err)
+
+	limited := limitResult(result, 1024)
+	text := limited.Content[0].(mcp.TextContent).Text
+	assert.LessOrEqual(t, len(text), 1024)
+
+	var got struct {
+		Items        []map[string]string `json:"items"`
+		Truncated    bool                `json:"truncated"`
+		OmittedItems int                 `json:"omitted_items"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(text), &got))
+	assert.True(t, got.Truncated)
+	assert.Equal(t, 100, len(got.Items)+got.OmittedItems)
+}
+
+func TestLimitResult_KeepsWarnings(t *testing.T) {
+	messages := make([]string, 200)
+	for i := range messages {
+		messages[i] = fmt.Sprintf("message %03d with some padding", i)
+	}
+	result, err := mcp.NewToolResultJSON(map[string]interface{}{"messages": messages})
+	require.NoError(t, err)
+	result = withWarnings(result, []string{"label lookup failed"})
+
+	limited := limitResult(result, 1024)
+	require.Len(t, limited.Content, 2)
+	text := limited.Content[0].(mcp.TextContent).Text
+	assert.LessOrEqual(t, len(text), 1024)
+	assert.Contains(t, text, `"truncated":true`)
+	assert.Equal(t, "warning: label lookup failed", limited.Content[1].(mcp.TextContent).Text)
+}
+
+func TestLimitResult_SmallResultUntouched(t *testing.T) {
+	result, err := mcp.NewToolResultJSON(map[string]interface{}{"messages": []string{"a", "b"}})
+	require.NoError(t, err)
+
+	limited := limitResult(result, 4096)
+	assert.Same(t, result, limited)
+	assert.NotContains(t, limited.Content[0].(mcp.TextContent).Text, "truncated")
+}
+
+func TestLimitResult_KeepsLargeIDsExact(t *testing.T) {
+	result := mcp.NewToolResultText(`{"history_id": 18446744073709551615, "items": ["aaaaaaaaaa", "bbbbbbbbbb", "cccccccccc"]}`)
+
+	limited := limitResult(result, 80)
+	text := limited.Content[0].(mcp.TextContent).Text
+	assert.Contains(t, text, `"history_id":18446744073709551615`)
+	assert.Contains(t, text, `"truncated":true`)
+}
+
+func TestLimitResult_ErrorsAndNonJSONUntouched(t *testing.T) {
+	errResult := mcp.NewToolResultError(string(make([]byte, 500)))
+	assert.Same(t, errResult, limitResult(errResult, 100))
+
+	textResult := mcp.NewToolResultText(fmt.Sprintf("%0500d", 0))
+	assert.Same(t, textResult, limitResult(textResult, 100))
+}
@@ -0,0 +1,161 @@
+// ABOUTME: Tool argument validation against each tool's declared input schema
+// ABOUTME: Rejects mistyped arguments with an error naming the field instead of silently dropping them
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// validateToolArgs wraps tool handlers so arguments whose JSON type doesn't
+// match the tool's InputSchema are rejected before the handler runs
+func (s *Server) validateToolArgs(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		if tool := s.mcp.GetTool(request.Params.Name); tool != nil {
+			if err := validateArguments(tool.Tool.InputSchema, request.GetArguments()); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+		}
+		return next(ctx, request)
+	}
+}
+
+// validateArguments checks each supplied argument against its property schema.
+// Arguments the schema doesn't declare, and null values, are left to the handler.
+func validateArguments(schema mcp.ToolInputSchema, args map[string]interface{}) error {
+	for name, value := range args {
+		if value == nil {
+			continue
+		}
+		prop, ok := schema.Properties[name]
+		if !ok {
+			continue
+		}
+		if err := validateValue(name, propertySchema(prop), value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fieldSchema is the subset of a JSON schema property that validation checks
+type fieldSchema struct {
+	Type  string
+	Items string   // Element type for arrays
+	Enum  []string // Allowed values for strings
+}
+
+// propertySchema reads a property declared as map[string]string or map[string]interface{}
+func propertySchema(prop interface{}) fieldSchema {
+	switch p := prop.(type) {
+	case map[string]string:
+		return fieldSchema{Type: p["type"]}
+	case map[string]interface{}:
+		field := fieldSchema{}
+		field.Type, _ = p["type"].(string)
+		switch items := p["items"].(type) {
+		case map[string]string:
+			field.Items = items["type"]
+		case map[string]interface{}:
+			field.Items, _ = items["type"].(string)
+		}
+		if enum, ok := p["enum"].([]string); ok {
+			field.Enum = enum
+		}
+		return field
+	}
+	return fieldSchema{}
+}
+
+// validateValue checks a single value against a field schema
+func validateValue(name string, field fieldSchema, value interface{}) error {
+	if field.Type == "" {
+		return nil
+	}
+	if !matchesType(field.Type, value) {
+		return fmt.Errorf("invalid argument %q: expected %s, got %s", name, field.Type, jsonType(value))
+	}
+
+	if field.Type == "array" && field.Items != "" {
+		for i, item := range value.([]interface{}) {
+			if !matchesType(field.Items, item) {
+				return fmt.Errorf("invalid argument %q: element %d: expected %s, got %s", name, i, field.Items, jsonType(item))
+			}
+		}
+	}
+
+	if field.Type == "string" && len(field.Enum) > 0 {
+		for _, allowed := range field.Enum {
+			if value == allowed {
+				return nil
+			}
+		}
+		return fmt.Errorf("invalid argument %q: must be one of %v (got %q)", name, field.Enum, value)
+	}
+	return nil
+}
+
+// matchesType reports whether a decoded JSON value has the given schema type
+func matchesType(schemaType string, value interface{}) bool {
+	switch schemaType {
+	case "string":
+		_, ok := value.(string)
+		return ok
+	case "boolean":
+		_, ok := value.(bool)
+		return ok
+	case "number":
+		_, ok := toFloat(value)
+		return ok
+	case "integer":
+		f, ok := toFloat(value)
+		return ok && f == math.Trunc(f)
+	case "array":
+		_, ok := value.([]interface{})
+		return ok
+	case "object":
+		_, ok := value.(map[string]interface{})
+		return ok
+	}
+	return true
+}
+
+// toFloat converts the numeric types a JSON decoder or Go caller may supply
+func toFloat(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	}
+	return 0, false
+}
+
+// jsonType names a value's JSON type for error messages
+func jsonType(value interface{}) string {
+	switch value.(type) {
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	}
+	if _, ok := toFloat(value); ok {
+		return "number"
+	}
+	return fmt.Sprintf("%T", value)
+}
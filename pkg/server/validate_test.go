@@ -0,0 +1,78 @@
+// ABOUTME: Tests for tool argument validation against input schemas
+// ABOUTME: Validates type, array element, and enum checks plus the middleware wiring
+
+package server
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateArguments(t *testing.T) {
+	schema := mcp.ToolInputSchema{
+		Type: "object",
+		Properties: map[string]interface{}{
+			"query":       map[string]string{"type": "string"},
+			"max_results": map[string]string{"type": "integer"},
+			"hydrate":     map[string]interface{}{"type": "boolean"},
+			"labels":      map[string]interface{}{"type": "array", "items": map[string]string{"type": "string"}},
+			"mode":        map[string]interface{}{"type": "string", "enum": []string{"all", "none"}},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		args    map[string]interface{}
+		wantErr string
+	}{
+		{name: "valid", args: map[string]interface{}{"query": "is:unread", "max_results": float64(10), "hydrate": true, "labels": []interface{}{"INBOX"}, "mode": "all"}},
+		{name: "null and undeclared args are ignored", args: map[string]interface{}{"query": nil, "extra": 5}},
+		{name: "number for string", args: map[string]interface{}{"query": float64(42)}, wantErr: `invalid argument "query": expected string, got number`},
+		{name: "fractional integer", args: map[string]interface{}{"max_results": 2.5}, wantErr: `invalid argument "max_results": expected integer, got number`},
+		{name: "string for boolean", args: map[string]interface{}{"hydrate": "yes"}, wantErr: `invalid argument "hydrate": expected boolean, got string`},
+		{name: "string for array", args: map[string]interface{}{"labels": "INBOX"}, wantErr: `invalid argument "labels": expected array, got string`},
+		{name: "bad array element", args: map[string]interface{}{"labels": []interface{}{"INBOX", float64(1)}}, wantErr: `invalid argument "labels": element 1: expected string, got number`},
+		{name: "value outside enum", args: map[string]interface{}{"mode": "some"}, wantErr: `invalid argument "mode": must be one of [all none]`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateArguments(schema, tt.args)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestValidateToolArgs_RejectsBeforeHandler(t *testing.T) {
+	var apiCalls int
+	srv := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		apiCalls++
+		_, _ = w.Write([]byte(`{}`))
+	})
+
+	handler := srv.validateToolArgs(srv.handleGmailListMessages)
+	result, err := handler(context.Background(), createMockRequest("gmail_list_messages", map[string]interface{}{
+		"query": float64(123),
+	}))
+	require.NoError(t, err)
+	require.True(t, result.IsError)
+	assert.Contains(t, result.Content[0].(mcp.TextContent).Text, `invalid argument "query": expected string, got number`)
+	assert.Zero(t, apiCalls, "a mistyped argument must not reach the API")
+
+	result, err = handler(context.Background(), createMockRequest("gmail_list_messages", map[string]interface{}{
+		"query": "is:unread",
+	}))
+	require.NoError(t, err)
+	assert.False(t, result.IsError)
+	assert.Equal(t, 1, apiCalls)
+}
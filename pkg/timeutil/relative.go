@@ -0,0 +1,76 @@
+// ABOUTME: Shared parsing of short and relative time expressions used by tool arguments
+// ABOUTME: Resolves today, tomorrow, next_week, weekday names, +Nd, RFC3339, and YYYY-MM-DD in a timezone
+
+package timeutil
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Expressions describes the forms ParseRelativeTime accepts, for tool and
+// prompt argument descriptions
+const Expressions = "today, tomorrow, next_week, a weekday name, +Nd, RFC3339, or YYYY-MM-DD"
+
+// weekdays maps lowercased day names to their time.Weekday
+var weekdays = map[string]time.Weekday{
+	"sunday":    time.Sunday,
+	"monday":    time.Monday,
+	"tuesday":   time.Tuesday,
+	"wednesday": time.Wednesday,
+	"thursday":  time.Thursday,
+	"friday":    time.Friday,
+	"saturday":  time.Saturday,
+}
+
+// ParseRelativeTime resolves expr against now in loc. RFC3339 timestamps are
+// returned as given. Everything else means midnight in loc on the day it
+// names: a YYYY-MM-DD date, today, tomorrow, next_week (seven days from
+// today), +Nd (N days from today), or a weekday name (its next occurrence
+// after today, so "monday" on a Monday is a week away). Names are
+// case-insensitive.
+func ParseRelativeTime(expr string, now time.Time, loc *time.Location) (time.Time, error) {
+	value := strings.ToLower(strings.TrimSpace(expr))
+	if value == "" {
+		return time.Time{}, fmt.Errorf("empty time expression")
+	}
+
+	if t, err := time.Parse(time.RFC3339, strings.TrimSpace(expr)); err == nil {
+		return t, nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02", value, loc); err == nil {
+		return t, nil
+	}
+
+	now = now.In(loc)
+	days := func(n int) time.Time {
+		return time.Date(now.Year(), now.Month(), now.Day()+n, 0, 0, 0, 0, loc)
+	}
+
+	switch value {
+	case "today":
+		return days(0), nil
+	case "tomorrow":
+		return days(1), nil
+	case "next_week":
+		return days(7), nil
+	}
+
+	if day, ok := weekdays[value]; ok {
+		ahead := (int(day) - int(now.Weekday()) + 7) % 7
+		if ahead == 0 {
+			ahead = 7
+		}
+		return days(ahead), nil
+	}
+
+	if strings.HasPrefix(value, "+") && strings.HasSuffix(value, "d") {
+		if n, err := strconv.ParseUint(value[1:len(value)-1], 10, 16); err == nil {
+			return days(int(n)), nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("unrecognized time %q: expected %s", expr, Expressions)
+}
@@ -0,0 +1,63 @@
+// ABOUTME: Tests for relative time expression parsing
+// ABOUTME: Checks each supported expression resolves to the expected instant in a fixed timezone
+
+package timeutil
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRelativeTime(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	// Wednesday 2026-03-04 23:30 in New York is already Thursday in UTC
+	now := time.Date(2026, 3, 5, 4, 30, 0, 0, time.UTC)
+	midnight := func(day int) time.Time { return time.Date(2026, 3, day, 0, 0, 0, 0, loc) }
+
+	tests := []struct {
+		expr string
+		want time.Time
+	}{
+		{"today", midnight(4)},
+		{" Tomorrow ", midnight(5)},
+		{"next_week", midnight(11)},
+		{"+3d", midnight(7)},
+		{"+0d", midnight(4)},
+		{"friday", midnight(6)},
+		{"Monday", midnight(9)},
+		{"wednesday", midnight(11)}, // Today's weekday means a week out
+		{"2026-03-20", midnight(20)},
+		{"2026-03-20T15:00:00Z", time.Date(2026, 3, 20, 15, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			got, err := ParseRelativeTime(tt.expr, now, loc)
+			require.NoError(t, err)
+			assert.True(t, tt.want.Equal(got), "got %s, want %s", got, tt.want)
+		})
+	}
+}
+
+func TestParseRelativeTime_AcrossDST(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	// Clocks spring forward on 2026-03-08, so that day is 23 hours long
+	now := time.Date(2026, 3, 7, 12, 0, 0, 0, loc)
+
+	got, err := ParseRelativeTime("+2d", now, loc)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 3, 9, 0, 0, 0, 0, loc), got)
+	assert.Equal(t, 47*time.Hour, got.Sub(time.Date(2026, 3, 7, 0, 0, 0, 0, loc)))
+}
+
+func TestParseRelativeTime_Invalid(t *testing.T) {
+	now := time.Date(2026, 3, 4, 12, 0, 0, 0, time.UTC)
+	for _, expr := range []string{"", "someday", "+d", "+-2d", "+3w", "2026-13-01", "next week"} {
+		_, err := ParseRelativeTime(expr, now, time.UTC)
+		assert.Error(t, err, expr)
+	}
+}
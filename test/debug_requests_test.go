@@ -73,7 +73,7 @@ func TestDebugRequests(t *testing.T) {
 		svc, err := gmail.NewService(ctx, client)
 		require.NoError(t, err)
 
-		_, _ = svc.SendMessage(ctx, "test@example.com", "Subject", "Body", "")
+		_, _ = svc.SendMessage(ctx, "test@example.com", "Subject", "Body", "", nil)
 	})
 
 	t.Run("Calendar ListEvents", func(t *testing.T) {
@@ -85,7 +85,7 @@ func TestDebugRequests(t *testing.T) {
 
 		now := time.Now()
 		tomorrow := now.Add(24 * time.Hour)
-		_, _ = svc.ListEvents(ctx, 10, now, tomorrow)
+		_, _ = svc.ListEvents(ctx, 10, now, tomorrow, nil)
 	})
 
 	t.Run("Calendar CreateEvent", func(t *testing.T) {
@@ -97,7 +97,7 @@ func TestDebugRequests(t *testing.T) {
 
 		start := time.Now().Add(24 * time.Hour)
 		end := start.Add(1 * time.Hour)
-		_, _ = svc.CreateEvent(ctx, "Meeting", "Description", start, end, []string{}, []string{}, false)
+		_, _ = svc.CreateEvent(ctx, "Meeting", "Description", start, end, []string{}, []string{}, calendar.SendUpdatesNone, nil)
 	})
 
 	t.Run("People ListContacts", func(t *testing.T) {
@@ -107,7 +107,7 @@ func TestDebugRequests(t *testing.T) {
 		svc, err := people.NewService(ctx, client)
 		require.NoError(t, err)
 
-		_, _ = svc.ListContacts(ctx, 10)
+		_, _ = svc.ListContacts(ctx, 10, "")
 	})
 
 	t.Run("People SearchContacts", func(t *testing.T) {
@@ -117,7 +117,7 @@ func TestDebugRequests(t *testing.T) {
 		svc, err := people.NewService(ctx, client)
 		require.NoError(t, err)
 
-		_, _ = svc.SearchContacts(ctx, "John", 5)
+		_, _ = svc.SearchContacts(ctx, "John", 5, "")
 	})
 
 	t.Log("\n=== CAPTURED REQUESTS ===")
@@ -88,7 +88,7 @@ func TestGmailOperationsIntegration(t *testing.T) {
 	})
 
 	t.Run("SendMessage", func(t *testing.T) {
-		msg, err := svc.SendMessage(ctx, "recipient@example.com", "Test Subject", "Test Body", "")
+		msg, err := svc.SendMessage(ctx, "recipient@example.com", "Test Subject", "Test Body", "", nil)
 		if err != nil {
 			t.Logf("Note: Send message failed (expected without ish server): %v", err)
 			return
@@ -138,7 +138,7 @@ func TestCalendarOperationsIntegration(t *testing.T) {
 	tomorrow := now.Add(24 * time.Hour)
 
 	t.Run("ListEvents", func(t *testing.T) {
-		events, err := svc.ListEvents(ctx, 10, time.Time{}, time.Time{})
+		events, err := svc.ListEvents(ctx, 10, time.Time{}, time.Time{}, nil)
 		if err != nil {
 			t.Logf("Note: List events failed (expected without ish server): %v", err)
 			return
@@ -147,7 +147,7 @@ func TestCalendarOperationsIntegration(t *testing.T) {
 	})
 
 	t.Run("ListEventsWithTimeRange", func(t *testing.T) {
-		events, err := svc.ListEvents(ctx, 10, now, tomorrow)
+		events, err := svc.ListEvents(ctx, 10, now, tomorrow, nil)
 		if err != nil {
 			t.Logf("Note: List events with time range failed (expected without ish server): %v", err)
 			return
@@ -159,7 +159,7 @@ func TestCalendarOperationsIntegration(t *testing.T) {
 		startTime := now.Add(2 * time.Hour)
 		endTime := startTime.Add(1 * time.Hour)
 
-		event, err := svc.CreateEvent(ctx, "Integration Test Event", "Testing event creation", startTime, endTime, []string{}, []string{}, false)
+		event, err := svc.CreateEvent(ctx, "Integration Test Event", "Testing event creation", startTime, endTime, []string{}, []string{}, calendar.SendUpdatesNone, nil)
 		if err != nil {
 			t.Logf("Note: Create event failed (expected without ish server): %v", err)
 			return
@@ -173,7 +173,7 @@ func TestCalendarOperationsIntegration(t *testing.T) {
 
 	t.Run("GetEvent", func(t *testing.T) {
 		// First list to get an event ID
-		events, err := svc.ListEvents(ctx, 1, time.Time{}, time.Time{})
+		events, err := svc.ListEvents(ctx, 1, time.Time{}, time.Time{}, nil)
 		if err != nil {
 			t.Logf("Note: List events failed (expected without ish server): %v", err)
 			return
@@ -208,7 +208,7 @@ func TestPeopleOperationsIntegration(t *testing.T) {
 	}
 
 	t.Run("ListContacts", func(t *testing.T) {
-		contacts, err := svc.ListContacts(ctx, 10)
+		contacts, err := svc.ListContacts(ctx, 10, "")
 		if err != nil {
 			t.Errorf("Failed to list contacts: %v", err)
 		}
@@ -216,7 +216,7 @@ func TestPeopleOperationsIntegration(t *testing.T) {
 	})
 
 	t.Run("SearchContacts", func(t *testing.T) {
-		contacts, err := svc.SearchContacts(ctx, "test", 10)
+		contacts, err := svc.SearchContacts(ctx, "test", 10, "")
 		if err != nil {
 			t.Logf("Note: Search contacts failed (expected without ish server): %v", err)
 			return
@@ -226,7 +226,7 @@ func TestPeopleOperationsIntegration(t *testing.T) {
 
 	t.Run("GetPerson", func(t *testing.T) {
 		// First list to get a person resource name
-		contacts, err := svc.ListContacts(ctx, 1)
+		contacts, err := svc.ListContacts(ctx, 1, "")
 		if err != nil {
 			t.Fatalf("Failed to list contacts: %v", err)
 		}
@@ -251,7 +251,7 @@ func TestServerToolHandlers(t *testing.T) {
 	defer cleanup()
 
 	ctx := context.Background()
-	srv, err := server.NewServer(ctx)
+	srv, err := server.NewServer(ctx, nil)
 	if err != nil {
 		t.Fatalf("Failed to create server: %v", err)
 	}
@@ -371,7 +371,7 @@ func TestEndToEndWorkflow(t *testing.T) {
 
 	t.Run("CompleteWorkflow", func(t *testing.T) {
 		// Create server
-		srv, err := server.NewServer(ctx)
+		srv, err := server.NewServer(ctx, nil)
 		if err != nil {
 			t.Fatalf("Failed to create server: %v", err)
 		}
@@ -402,7 +402,7 @@ func TestEndToEndWorkflow(t *testing.T) {
 		if err != nil {
 			t.Errorf("Failed to create Calendar service: %v", err)
 		} else {
-			events, err := calSvc.ListEvents(ctx, 1, time.Time{}, time.Time{})
+			events, err := calSvc.ListEvents(ctx, 1, time.Time{}, time.Time{}, nil)
 			if err != nil {
 				t.Logf("Note: List events failed (expected without ish server): %v", err)
 			} else {
@@ -415,7 +415,7 @@ func TestEndToEndWorkflow(t *testing.T) {
 		if err != nil {
 			t.Errorf("Failed to create People service: %v", err)
 		} else {
-			contacts, err := peopleSvc.ListContacts(ctx, 1)
+			contacts, err := peopleSvc.ListContacts(ctx, 1, "")
 			if err != nil {
 				t.Errorf("Failed to list contacts: %v", err)
 			}
@@ -55,7 +55,8 @@ func TestScenario_EmailTriage(t *testing.T) {
 			"customer@example.com",
 			"Re: Your inquiry",
 			"Thank you for reaching out. We'll get back to you soon.",
-			"")
+			"",
+			nil)
 
 		if err != nil {
 			t.Logf("Send reply failed: %v", err)
@@ -82,7 +83,7 @@ func TestScenario_MeetingScheduling(t *testing.T) {
 	nextWeek := now.Add(7 * 24 * time.Hour)
 
 	t.Run("Check availability for next week", func(t *testing.T) {
-		events, err := calendarSvc.ListEvents(ctx, 50, tomorrow, nextWeek)
+		events, err := calendarSvc.ListEvents(ctx, 50, tomorrow, nextWeek, nil)
 
 		if err != nil {
 			t.Logf("Check availability failed: %v", err)
@@ -103,7 +104,7 @@ func TestScenario_MeetingScheduling(t *testing.T) {
 			meetingEnd,
 			[]string{},
 			[]string{},
-			false)
+			calendar.SendUpdatesNone, nil)
 
 		if err != nil {
 			t.Logf("Schedule meeting failed: %v", err)
@@ -126,7 +127,7 @@ func TestScenario_MeetingScheduling(t *testing.T) {
 		todayStart := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
 		todayEnd := todayStart.Add(24 * time.Hour)
 
-		events, err := calendarSvc.ListEvents(ctx, 20, todayStart, todayEnd)
+		events, err := calendarSvc.ListEvents(ctx, 20, todayStart, todayEnd, nil)
 
 		if err != nil {
 			t.Logf("List today's meetings failed: %v", err)
@@ -148,7 +149,7 @@ func TestScenario_ContactManagement(t *testing.T) {
 	require.NoError(t, err, "Failed to create People service")
 
 	t.Run("List recent contacts", func(t *testing.T) {
-		contacts, err := peopleSvc.ListContacts(ctx, 20)
+		contacts, err := peopleSvc.ListContacts(ctx, 20, "")
 
 		if err != nil {
 			t.Logf("List contacts failed: %v", err)
@@ -159,7 +160,7 @@ func TestScenario_ContactManagement(t *testing.T) {
 	})
 
 	t.Run("Search for specific person", func(t *testing.T) {
-		results, err := peopleSvc.SearchContacts(ctx, "John", 5)
+		results, err := peopleSvc.SearchContacts(ctx, "John", 5, "")
 
 		if err != nil {
 			t.Logf("Search contacts failed: %v", err)
@@ -170,7 +171,7 @@ func TestScenario_ContactManagement(t *testing.T) {
 	})
 
 	t.Run("Search by email domain", func(t *testing.T) {
-		results, err := peopleSvc.SearchContacts(ctx, "example.com", 10)
+		results, err := peopleSvc.SearchContacts(ctx, "example.com", 10, "")
 
 		if err != nil {
 			t.Logf("Search by domain failed: %v", err)
@@ -205,7 +206,7 @@ func TestScenario_FullDayWorkflow(t *testing.T) {
 		todayStart := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
 		todayEnd := todayStart.Add(24 * time.Hour)
 
-		events, err := calendarSvc.ListEvents(ctx, 20, todayStart, todayEnd)
+		events, err := calendarSvc.ListEvents(ctx, 20, todayStart, todayEnd, nil)
 		if err != nil {
 			t.Logf("Calendar check: %v", err)
 		} else {
@@ -223,7 +224,7 @@ func TestScenario_FullDayWorkflow(t *testing.T) {
 
 		// 3. Look up contact for meeting
 		t.Log("Step 3: Looking up meeting attendee...")
-		contacts, err := peopleSvc.SearchContacts(ctx, "Sarah", 5)
+		contacts, err := peopleSvc.SearchContacts(ctx, "Sarah", 5, "")
 		if err != nil {
 			t.Logf("Contact lookup: %v", err)
 		} else {
@@ -237,7 +238,8 @@ func TestScenario_FullDayWorkflow(t *testing.T) {
 			"boss@example.com",
 			"Re: Urgent: Project status",
 			"The project is on track. Will send detailed update by EOD.",
-			"")
+			"",
+			nil)
 
 		if err != nil {
 			t.Logf("Send response: %v", err)
@@ -259,7 +261,7 @@ func TestScenario_FullDayWorkflow(t *testing.T) {
 			meetingEnd,
 			[]string{},
 			[]string{},
-			false)
+			calendar.SendUpdatesNone, nil)
 
 		if err != nil {
 			t.Logf("Schedule meeting: %v", err)
@@ -276,7 +278,7 @@ func TestScenario_MCPServerIntegration(t *testing.T) {
 
 	ctx := context.Background()
 
-	srv, err := server.NewServer(ctx)
+	srv, err := server.NewServer(ctx, nil)
 	require.NoError(t, err, "Failed to create MCP server")
 
 	t.Run("Verify all tools registered", func(t *testing.T) {